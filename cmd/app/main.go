@@ -36,6 +36,7 @@ func main() {
 }
 
 func run(addr string, container *di.Container) {
+	container.GreetingScheduler.Start()
 	if err := container.Router.Run(addr); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
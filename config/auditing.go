@@ -0,0 +1,87 @@
+package config
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/security"
+	"gorm.io/gorm"
+	"reflect"
+)
+
+// Columns of the auditors of entities embedding domain.AuditingEntity
+const (
+	createdByColumn = "created_by"
+	updatedByColumn = "updated_by"
+)
+
+// registerAuditingCallbacks records the authenticated user of the statement context as creator and modifier
+// of the entities embedding domain.AuditingEntity, or domain.SystemAuditor without one. Like the update time,
+// the auditors are not recorded for statements skipping hooks, e.g. UpdateColumn.
+func registerAuditingCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:before_create").Before("gorm:create").
+		Register("auditing:create", auditCreate); err != nil {
+		return err
+	}
+	return db.Callback().Update().After("gorm:before_update").Before("gorm:update").
+		Register("auditing:update", auditUpdate)
+}
+
+// auditCreate records the authenticated user as creator and modifier of new entities.
+// Without one, a creator set by the caller is kept.
+func auditCreate(db *gorm.DB) {
+
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.SkipHooks {
+		return
+	}
+	createdBy := db.Statement.Schema.LookUpField(createdByColumn)
+	updatedBy := db.Statement.Schema.LookUpField(updatedByColumn)
+	if createdBy == nil || updatedBy == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	auditor, authenticated := currentAuditor(db)
+	audit := func(entity reflect.Value) {
+		if !entity.IsValid() {
+			return
+		}
+		creator, _ := createdBy.ValueOf(ctx, entity)
+		if authenticated || creator == "" {
+			creator = auditor
+		}
+		if err := createdBy.Set(ctx, entity, creator); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		_ = db.AddError(updatedBy.Set(ctx, entity, creator))
+	}
+
+	switch entities := reflect.Indirect(db.Statement.ReflectValue); entities.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < entities.Len(); i++ {
+			audit(reflect.Indirect(entities.Index(i)))
+		}
+	case reflect.Struct:
+		audit(entities)
+	}
+}
+
+// auditUpdate records the authenticated user as modifier, also for updates with a map of columns
+func auditUpdate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.SkipHooks {
+		return
+	}
+	if db.Statement.Schema.LookUpField(updatedByColumn) == nil {
+		return
+	}
+	auditor, _ := currentAuditor(db)
+	db.Statement.SetColumn(updatedByColumn, auditor, true)
+}
+
+// currentAuditor returns the ID of the authenticated user of the statement context,
+// or domain.SystemAuditor without one
+func currentAuditor(db *gorm.DB) (string, bool) {
+	if claims, ok := security.ClaimsFromContext(db.Statement.Context); ok && claims.UserID != "" {
+		return claims.UserID, true
+	}
+	return domain.SystemAuditor, false
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+)
+
+// newAuditedDB opens an in-memory database of tags with the auditing callbacks
+func newAuditedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, registerAuditingCallbacks(db))
+	require.NoError(t, db.AutoMigrate(&domain.Tag{}))
+	return db
+}
+
+// contextOf returns a context carrying the claims of a user
+func contextOf(userID string) context.Context {
+	return security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: userID})
+}
+
+// findTag reads a tag back from the database
+func findTag(t *testing.T, db *gorm.DB, id uint) domain.Tag {
+	t.Helper()
+	var tag domain.Tag
+	require.NoError(t, db.First(&tag, id).Error)
+	return tag
+}
+
+func TestAuditingCallbacks_Create(t *testing.T) {
+	db := newAuditedDB(t)
+
+	// The authenticated user creates and modifies the new entity, whatever the caller set
+	tag := domain.Tag{Name: "christmas", AuditingEntity: domain.AuditingEntity{CreatedBy: "2"}}
+	require.NoError(t, db.WithContext(contextOf("1")).Create(&tag).Error)
+	assert.Equal(t, "1", tag.CreatedBy)
+	assert.Equal(t, "1", tag.UpdatedBy)
+	assert.Equal(t, "1", findTag(t, db, tag.ID).CreatedBy)
+
+	// Every entity of a batch is audited
+	tags := []*domain.Tag{{Name: "winter"}, {Name: "summer"}}
+	require.NoError(t, db.WithContext(contextOf("3")).Create(&tags).Error)
+	for _, tag := range tags {
+		stored := findTag(t, db, tag.ID)
+		assert.Equal(t, "3", stored.CreatedBy)
+		assert.Equal(t, "3", stored.UpdatedBy)
+	}
+}
+
+func TestAuditingCallbacks_Update(t *testing.T) {
+	db := newAuditedDB(t)
+	tag := domain.Tag{Name: "christmas"}
+	require.NoError(t, db.WithContext(contextOf("1")).Create(&tag).Error)
+
+	// Updates with a column record the modifier and keep the creator
+	require.NoError(t, db.WithContext(contextOf("2")).Model(&domain.Tag{ID: tag.ID}).Update("name", "xmas").Error)
+	stored := findTag(t, db, tag.ID)
+	assert.Equal(t, "xmas", stored.Name)
+	assert.Equal(t, "1", stored.CreatedBy)
+	assert.Equal(t, "2", stored.UpdatedBy)
+
+	// So do updates with a map of columns and with the entity
+	require.NoError(t, db.WithContext(contextOf("3")).Model(&domain.Tag{}).Where("id = ?", tag.ID).
+		Updates(map[string]any{"name": "noel"}).Error)
+	assert.Equal(t, "3", findTag(t, db, tag.ID).UpdatedBy)
+
+	stored.Name = "yule"
+	require.NoError(t, db.WithContext(contextOf("4")).Save(&stored).Error)
+	stored = findTag(t, db, tag.ID)
+	assert.Equal(t, "1", stored.CreatedBy)
+	assert.Equal(t, "4", stored.UpdatedBy)
+
+	// Statements skipping hooks are not audited
+	require.NoError(t, db.WithContext(contextOf("5")).Model(&domain.Tag{ID: tag.ID}).UpdateColumn("name", "jul").Error)
+	assert.Equal(t, "4", findTag(t, db, tag.ID).UpdatedBy)
+}
+
+func TestAuditingCallbacks_SystemAuditor(t *testing.T) {
+	db := newAuditedDB(t)
+
+	// Without an authenticated user, the system creates the entity
+	tag := domain.Tag{Name: "christmas"}
+	require.NoError(t, db.Create(&tag).Error)
+	stored := findTag(t, db, tag.ID)
+	assert.Equal(t, domain.SystemAuditor, stored.CreatedBy)
+	assert.Equal(t, domain.SystemAuditor, stored.UpdatedBy)
+
+	// A creator set by the caller is kept
+	imported := domain.Tag{Name: "winter", AuditingEntity: domain.AuditingEntity{CreatedBy: "2"}}
+	require.NoError(t, db.Create(&imported).Error)
+	stored = findTag(t, db, imported.ID)
+	assert.Equal(t, "2", stored.CreatedBy)
+	assert.Equal(t, "2", stored.UpdatedBy)
+
+	// Updates without an authenticated user are made by the system
+	require.NoError(t, db.WithContext(contextOf("1")).Model(&domain.Tag{ID: tag.ID}).Update("name", "xmas").Error)
+	require.NoError(t, db.Model(&domain.Tag{ID: imported.ID}).Update("name", "snow").Error)
+	assert.Equal(t, "1", findTag(t, db, tag.ID).UpdatedBy)
+	assert.Equal(t, domain.SystemAuditor, findTag(t, db, imported.ID).UpdatedBy)
+}
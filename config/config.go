@@ -1,18 +1,57 @@
 package config
 
 import (
+	"gin-samples/internal/security"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
+	Profile       string
 	ServerPort    string
 	TokenDuration time.Duration
 	TokenIssuer   string
+	JweKeys       JweKeyConfig
+	AuthCookie    security.CookieOptions
+	DPoP          DPoPConfig
+	AuthProviders AuthProvidersConfig
+	// TrustedProxies are the IPs and CIDR ranges of the reverse proxies whose forwarding headers are honoured
+	TrustedProxies []string
+	AuditSink      string // Destination of security events: db or log
+	CursorSecret   string // HMAC secret of the pagination cursors; random when empty
+	DefaultLocale  string // Locale of greetings created without one and the fallback of the language negotiation
+	// IfMatchRequired rejects updates and deletes without an If-Match header with 428 Precondition Required
+	IfMatchRequired bool
+	// CacheControl maps route patterns to the Cache-Control policy of their GET responses
+	CacheControl      map[string]string
+	GreetingScheduler GreetingSchedulerConfig
+}
+
+// GreetingSchedulerConfig configures the publishing and expiry of scheduled greetings
+type GreetingSchedulerConfig struct {
+	Interval  time.Duration // Time between two scheduler runs; the scheduler is disabled when zero
+	Retention time.Duration // Time expired greetings are kept before they are purged; never purged when zero
+}
+
+// AuthProvidersConfig configures the authentication provider chain
+type AuthProvidersConfig struct {
+	Order         []string // Enabled providers in the order they are consulted: db, htpasswd, static
+	HtpasswdFile  string
+	HtpasswdRoles []string // Roles granted to every htpasswd user
+	UsersFile     string   // YAML users file for the static provider
+}
+
+// DPoPConfig configures sender-constrained tokens (RFC 9449)
+type DPoPConfig struct {
+	Enabled     bool
+	ProofMaxAge time.Duration // Maximum clock difference accepted for the proof "iat" claim
 }
 
 func LoadConfig() *Config {
@@ -29,9 +68,42 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
+		Profile:       env,
 		ServerPort:    getEnv("SERVER_PORT", "8080"),
 		TokenDuration: parseDuration("TOKEN_DURATION", "3600s"),
 		TokenIssuer:   getEnv("TOKEN_ISSUER", "https://susimsek.github.io"),
+		JweKeys:       loadJweKeyConfig(),
+		AuthCookie: security.CookieOptions{
+			Enabled:        parseBool("AUTH_COOKIE_ENABLED", false),
+			Name:           getEnv("AUTH_COOKIE_NAME", "access_token"),
+			CSRFCookieName: getEnv("AUTH_CSRF_COOKIE_NAME", "XSRF-TOKEN"),
+			CSRFHeaderName: getEnv("AUTH_CSRF_HEADER_NAME", "X-XSRF-TOKEN"),
+			Path:           getEnv("AUTH_COOKIE_PATH", "/"),
+			Domain:         getEnv("AUTH_COOKIE_DOMAIN", ""),
+			Secure:         parseBool("AUTH_COOKIE_SECURE", true),
+			SameSite:       parseSameSite("AUTH_COOKIE_SAME_SITE", "Strict"),
+		},
+		DPoP: DPoPConfig{
+			Enabled:     parseBool("DPOP_ENABLED", false),
+			ProofMaxAge: parseDuration("DPOP_PROOF_MAX_AGE", "60s"),
+		},
+		AuthProviders: AuthProvidersConfig{
+			Order:         parseList("AUTH_PROVIDERS", "db"),
+			HtpasswdFile:  getEnv("AUTH_HTPASSWD_FILE", filepath.Join("resources", "config", ".htpasswd")),
+			HtpasswdRoles: parseList("AUTH_HTPASSWD_ROLES", "ROLE_ADMIN"),
+			UsersFile:     getEnv("AUTH_USERS_FILE", filepath.Join("resources", "config", "users.yaml")),
+		},
+		TrustedProxies:  parseList("TRUSTED_PROXIES", ""),
+		AuditSink:       getEnv("AUDIT_SINK", "db"),
+		CursorSecret:    getEnv("CURSOR_SECRET", ""),
+		DefaultLocale:   getEnv("DEFAULT_LOCALE", "en"),
+		IfMatchRequired: parseBool("IF_MATCH_REQUIRED", false),
+		CacheControl: parsePolicies("CACHE_CONTROL_POLICIES",
+			"/api/hello/:id=private, no-cache;/api/hello/all=private, no-cache"),
+		GreetingScheduler: GreetingSchedulerConfig{
+			Interval:  parseDuration("GREETING_SCHEDULER_INTERVAL", "1m"),
+			Retention: parseDuration("GREETING_EXPIRED_RETENTION", "720h"),
+		},
 	}
 }
 
@@ -52,3 +124,62 @@ func parseDuration(key, defaultValue string) time.Duration {
 	}
 	return duration
 }
+
+// parseBool parses a boolean from the environment or uses a default.
+func parseBool(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean for %s: %s, using default: %t. Error: %v", key, value, defaultValue, err)
+		return defaultValue
+	}
+	return parsed
+}
+
+// parseList parses a comma separated list from the environment or uses a default.
+func parseList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parsePolicies parses "route=policy" entries separated by semicolons from the environment or uses a default.
+// Policies may contain commas and equal signs, e.g. "/api/hello/:id=private, max-age=60".
+func parsePolicies(key, defaultValue string) map[string]string {
+	policies := make(map[string]string)
+	for _, entry := range strings.Split(getEnv(key, defaultValue), ";") {
+		route, policy, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || strings.TrimSpace(route) == "" {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				log.Printf("Invalid policy for %s: %s, expected route=policy", key, entry)
+			}
+			continue
+		}
+		policies[strings.TrimSpace(route)] = strings.TrimSpace(policy)
+	}
+	return policies
+}
+
+// parseSameSite parses a cookie SameSite mode (Strict, Lax or None) from the environment or uses a default.
+// Unknown values fall back to Strict.
+func parseSameSite(key, defaultValue string) http.SameSite {
+	value := getEnv(key, defaultValue)
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		log.Printf("Invalid SameSite mode for %s: %s, using Strict", key, value)
+		return http.SameSiteStrictMode
+	}
+}
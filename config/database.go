@@ -38,6 +38,9 @@ func (r *RealDatabaseConfig) InitDB() *gorm.DB {
 	if err != nil {
 		log.Fatalf("Failed to initialize GORM: %v", err)
 	}
+	if err := registerAuditingCallbacks(gormDB); err != nil {
+		log.Fatalf("Failed to register auditing callbacks: %v", err)
+	}
 
 	return gormDB
 }
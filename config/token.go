@@ -1,14 +1,41 @@
 package config
 
 import (
+	"fmt"
 	"gin-samples/internal/util"
 	"log"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
+// sampleKeyFingerprints contains the SHA-256 fingerprints of the sample public keys bundled under resources/keys
+var sampleKeyFingerprints = map[string]bool{
+	"f0b81d89169fdf2a414e5e36a3661be07a1f648901d701be7e04097401301b33": true, // sign
+	"3120e26e9aeda853953d6acb1e4b1b73ce6b48aaf6cb8fd14b4a16eb165b8ef1": true, // enc
+}
+
+// KeySource describes where a PEM encoded key is loaded from.
+// Inline PEM takes precedence over a file path.
+type KeySource struct {
+	Name   string // Environment variable name, used in log messages
+	Inline string // Inline PEM content
+	Path   string // Path of the PEM file
+}
+
+// JweKeyConfig holds the key sources for the signing and encryption key pairs
+type JweKeyConfig struct {
+	SignPrivateKey KeySource
+	SignPublicKey  KeySource
+	EncPrivateKey  KeySource
+	EncPublicKey   KeySource
+	ReloadInterval time.Duration // Polling interval for file based key sources
+}
+
 // JweTokenInitializer interface for initializing JWE Key Pairs
 type JweTokenInitializer interface {
-	InitJweKeyPair() (*util.RSAKeyPair, *util.RSAKeyPair)
+	InitJweKeyPair(cfg *Config) (*util.RSAKeyPairHolder, *util.RSAKeyPairHolder)
 }
 
 // RealJweTokenConfig is the production implementation
@@ -17,28 +44,162 @@ type RealJweTokenConfig struct{}
 // JweTokenConfig is the default implementation for production
 var JweTokenConfig JweTokenInitializer = &RealJweTokenConfig{}
 
-// InitJweKeyPair loads RSA key pairs for signing and encryption from files
-func (r *RealJweTokenConfig) InitJweKeyPair() (*util.RSAKeyPair, *util.RSAKeyPair) {
-	// Paths for signing keys
-	signPrivateKeyPath := filepath.Join("resources", "keys", "sign", "private_key.pem")
-	signPublicKeyPath := filepath.Join("resources", "keys", "sign", "public_key.pem")
+// InitJweKeyPair loads RSA key pairs for signing and encryption from the configured key sources.
+// File based sources are watched, so rotated keys are picked up without a restart.
+func (r *RealJweTokenConfig) InitJweKeyPair(cfg *Config) (*util.RSAKeyPairHolder, *util.RSAKeyPairHolder) {
+	keys := cfg.JweKeys
 
-	// Paths for encryption keys
-	encPrivateKeyPath := filepath.Join("resources", "keys", "enc", "private_key.pem")
-	encPublicKeyPath := filepath.Join("resources", "keys", "enc", "public_key.pem")
+	signKeyPair, encKeyPair, err := loadJweKeyPairs(keys, cfg.Profile)
+	if err != nil {
+		log.Fatalf("Failed to load JWE key pairs: %v", err)
+	}
 
+	signHolder := util.NewRSAKeyPairHolder(signKeyPair)
+	encHolder := util.NewRSAKeyPairHolder(encKeyPair)
+
+	// Replaced keys are retained until the tokens issued with them have expired
+	if keys.ReloadInterval > 0 {
+		go watchKeyPair(signHolder, keys.SignPrivateKey, keys.SignPublicKey, keys.ReloadInterval, cfg.TokenDuration, cfg.Profile)
+		go watchKeyPair(encHolder, keys.EncPrivateKey, keys.EncPublicKey, keys.ReloadInterval, cfg.TokenDuration, cfg.Profile)
+	}
+
+	log.Println("JWE Key Pairs loaded successfully!")
+	return signHolder, encHolder
+}
+
+// loadJweKeyPairs loads the signing and encryption key pairs, refusing the bundled sample keys in production
+func loadJweKeyPairs(keys JweKeyConfig, profile string) (*util.RSAKeyPair, *util.RSAKeyPair, error) {
 	// Load signing key pair
-	signKeyPair, err := util.LoadRSAKeyPair(signPrivateKeyPath, signPublicKeyPath)
+	signKeyPair, err := loadKeyPair(keys.SignPrivateKey, keys.SignPublicKey)
 	if err != nil {
-		log.Fatalf("Failed to load signing RSA key pair: %v", err)
+		return nil, nil, fmt.Errorf("signing key pair: %w", err)
 	}
 
 	// Load encryption key pair
-	encKeyPair, err := util.LoadRSAKeyPair(encPrivateKeyPath, encPublicKeyPath)
+	encKeyPair, err := loadKeyPair(keys.EncPrivateKey, keys.EncPublicKey)
 	if err != nil {
-		log.Fatalf("Failed to load encryption RSA key pair: %v", err)
+		return nil, nil, fmt.Errorf("encryption key pair: %w", err)
 	}
 
-	log.Println("JWE Key Pairs loaded successfully!")
-	return signKeyPair, encKeyPair
+	// Never run production with the keys committed to the repository
+	if profile == "prod" && (isSampleKeyPair(signKeyPair) || isSampleKeyPair(encKeyPair)) {
+		return nil, nil, fmt.Errorf("the bundled sample keys must not be used in the %q profile", profile)
+	}
+	return signKeyPair, encKeyPair, nil
+}
+
+// loadJweKeyConfig builds the key sources from the environment, falling back to the bundled sample keys
+func loadJweKeyConfig() JweKeyConfig {
+	keysDir := filepath.Join("resources", "keys")
+	return JweKeyConfig{
+		SignPrivateKey: loadKeySource("JWE_SIGN_PRIVATE_KEY", filepath.Join(keysDir, "sign", "private_key.pem")),
+		SignPublicKey:  loadKeySource("JWE_SIGN_PUBLIC_KEY", filepath.Join(keysDir, "sign", "public_key.pem")),
+		EncPrivateKey:  loadKeySource("JWE_ENC_PRIVATE_KEY", filepath.Join(keysDir, "enc", "private_key.pem")),
+		EncPublicKey:   loadKeySource("JWE_ENC_PUBLIC_KEY", filepath.Join(keysDir, "enc", "public_key.pem")),
+		ReloadInterval: parseDuration("JWE_KEY_RELOAD_INTERVAL", "30s"),
+	}
+}
+
+// loadKeySource resolves a key source from <key> (inline PEM), <key>_FILE (secret file) or <key>_PATH
+func loadKeySource(key, defaultPath string) KeySource {
+	if inline := getEnv(key, ""); inline != "" {
+		// Allow single line values with escaped newlines, as commonly used in environment variables
+		return KeySource{Name: key, Inline: strings.ReplaceAll(inline, `\n`, "\n")}
+	}
+	if path := getEnv(key+"_FILE", ""); path != "" {
+		return KeySource{Name: key + "_FILE", Path: path}
+	}
+	return KeySource{Name: key + "_PATH", Path: getEnv(key+"_PATH", defaultPath)}
+}
+
+// read returns the PEM content of the key source
+func (s KeySource) read() ([]byte, error) {
+	if s.Inline != "" {
+		return []byte(s.Inline), nil
+	}
+	if s.Path == "" {
+		return nil, fmt.Errorf("%s: no key configured", s.Name)
+	}
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Name, err)
+	}
+	return content, nil
+}
+
+// stamp returns a value that changes whenever the underlying key file changes
+func (s KeySource) stamp() string {
+	if s.Path == "" {
+		return ""
+	}
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+}
+
+func loadKeyPair(privateKey, publicKey KeySource) (*util.RSAKeyPair, error) {
+	privateKeyPEM, err := privateKey.read()
+	if err != nil {
+		return nil, err
+	}
+	publicKeyPEM, err := publicKey.read()
+	if err != nil {
+		return nil, err
+	}
+	return util.ParseRSAKeyPair(privateKeyPEM, publicKeyPEM)
+}
+
+func isSampleKeyPair(keyPair *util.RSAKeyPair) bool {
+	fingerprint, err := util.PublicKeyFingerprint(keyPair.PublicKey)
+	return err == nil && sampleKeyFingerprints[fingerprint]
+}
+
+// watchKeyPair polls the file based key sources and rotates the key pair in the holder when they change,
+// retaining the replaced key pair for the retention.
+// Polling is used instead of file system notifications because mounted secrets are usually replaced through symlink swaps.
+func watchKeyPair(holder *util.RSAKeyPairHolder, privateKey, publicKey KeySource, interval, retention time.Duration,
+	profile string) {
+	if privateKey.Path == "" && publicKey.Path == "" {
+		return
+	}
+
+	lastStamp := keyPairStamp(privateKey, publicKey)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		lastStamp = reloadKeyPair(holder, privateKey, publicKey, lastStamp, retention, profile)
+	}
+}
+
+// keyPairStamp returns a value that changes whenever one of the key files changes
+func keyPairStamp(privateKey, publicKey KeySource) string {
+	return privateKey.stamp() + "|" + publicKey.stamp()
+}
+
+// reloadKeyPair rotates the key pair in the holder if the key files changed since lastStamp, retaining the replaced
+// key pair for the retention, and returns the stamp to compare the next poll with
+func reloadKeyPair(holder *util.RSAKeyPairHolder, privateKey, publicKey KeySource, lastStamp string,
+	retention time.Duration, profile string) string {
+	currentStamp := keyPairStamp(privateKey, publicKey)
+	if currentStamp == lastStamp {
+		return lastStamp
+	}
+
+	keyPair, err := loadKeyPair(privateKey, publicKey)
+	if err != nil {
+		// Keep serving with the previous keys; the files may be in the middle of being replaced
+		log.Printf("Failed to reload RSA key pair from %s/%s: %v", privateKey.Name, publicKey.Name, err)
+		return lastStamp
+	}
+	if profile == "prod" && isSampleKeyPair(keyPair) {
+		log.Printf("Ignoring reloaded RSA key pair from %s/%s: sample keys are not allowed", privateKey.Name, publicKey.Name)
+		return currentStamp
+	}
+
+	holder.Rotate(keyPair, time.Now(), retention)
+	log.Printf("RSA key pair reloaded from %s/%s", privateKey.Name, publicKey.Name)
+	return currentStamp
 }
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// sampleKeysDir is the directory of the sample keys bundled with the repository, relative to this package
+var sampleKeysDir = filepath.Join("..", "resources", "keys")
+
+// newKeyPairPEM generates an RSA key pair and returns its PEM encoded private and public keys
+func newKeyPairPEM(t *testing.T) (string, string) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return string(privatePEM), string(publicPEM)
+}
+
+// writeKeyFile writes a key file and moves its modification time forward, so that every write changes its stamp
+func writeKeyFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// sampleKeySources returns the key sources of the bundled sample keys
+func sampleKeySources() JweKeyConfig {
+	return JweKeyConfig{
+		SignPrivateKey: KeySource{Name: "sign_private", Path: filepath.Join(sampleKeysDir, "sign", "private_key.pem")},
+		SignPublicKey:  KeySource{Name: "sign_public", Path: filepath.Join(sampleKeysDir, "sign", "public_key.pem")},
+		EncPrivateKey:  KeySource{Name: "enc_private", Path: filepath.Join(sampleKeysDir, "enc", "private_key.pem")},
+		EncPublicKey:   KeySource{Name: "enc_public", Path: filepath.Join(sampleKeysDir, "enc", "public_key.pem")},
+	}
+}
+
+func TestLoadKeySource(t *testing.T) {
+	const key = "JWE_TEST_KEY"
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected KeySource
+	}{
+		{
+			name:     "Default path",
+			env:      map[string]string{},
+			expected: KeySource{Name: key + "_PATH", Path: "default.pem"},
+		},
+		{
+			name:     "Path",
+			env:      map[string]string{key + "_PATH": "/keys/key.pem"},
+			expected: KeySource{Name: key + "_PATH", Path: "/keys/key.pem"},
+		},
+		{
+			name:     "Secret file takes precedence over path",
+			env:      map[string]string{key + "_FILE": "/run/secrets/key", key + "_PATH": "/keys/key.pem"},
+			expected: KeySource{Name: key + "_FILE", Path: "/run/secrets/key"},
+		},
+		{
+			name: "Inline PEM takes precedence over files",
+			env: map[string]string{key: "-----BEGIN PUBLIC KEY-----\nabc\n-----END PUBLIC KEY-----",
+				key + "_FILE": "/run/secrets/key"},
+			expected: KeySource{Name: key, Inline: "-----BEGIN PUBLIC KEY-----\nabc\n-----END PUBLIC KEY-----"},
+		},
+		{
+			name:     "Inline PEM with escaped newlines",
+			env:      map[string]string{key: `-----BEGIN PUBLIC KEY-----\nabc\n-----END PUBLIC KEY-----`},
+			expected: KeySource{Name: key, Inline: "-----BEGIN PUBLIC KEY-----\nabc\n-----END PUBLIC KEY-----"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, suffix := range []string{"", "_FILE", "_PATH"} {
+				t.Setenv(key+suffix, "")
+				require.NoError(t, os.Unsetenv(key+suffix))
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			assert.Equal(t, tt.expected, loadKeySource(key, "default.pem"))
+		})
+	}
+}
+
+func TestLoadKeyPair(t *testing.T) {
+	privatePEM, publicPEM := newKeyPairPEM(t)
+	_, otherPublicPEM := newKeyPairPEM(t)
+
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "private_key.pem")
+	publicPath := filepath.Join(dir, "public_key.pem")
+	writeKeyFile(t, privatePath, privatePEM, time.Now())
+	writeKeyFile(t, publicPath, publicPEM, time.Now())
+
+	tests := []struct {
+		name          string
+		privateKey    KeySource
+		publicKey     KeySource
+		expectedError string
+	}{
+		{
+			name:       "Inline PEM",
+			privateKey: KeySource{Name: "PRIVATE", Inline: privatePEM},
+			publicKey:  KeySource{Name: "PUBLIC", Inline: publicPEM},
+		},
+		{
+			name:       "Secret files",
+			privateKey: KeySource{Name: "PRIVATE_FILE", Path: privatePath},
+			publicKey:  KeySource{Name: "PUBLIC_FILE", Path: publicPath},
+		},
+		{
+			name:       "Inline PEM and path",
+			privateKey: KeySource{Name: "PRIVATE", Inline: privatePEM},
+			publicKey:  KeySource{Name: "PUBLIC_PATH", Path: publicPath},
+		},
+		{
+			name:          "Missing file",
+			privateKey:    KeySource{Name: "PRIVATE_PATH", Path: filepath.Join(dir, "missing.pem")},
+			publicKey:     KeySource{Name: "PUBLIC_PATH", Path: publicPath},
+			expectedError: "PRIVATE_PATH",
+		},
+		{
+			name:          "No key configured",
+			privateKey:    KeySource{Name: "PRIVATE_PATH"},
+			publicKey:     KeySource{Name: "PUBLIC_PATH", Path: publicPath},
+			expectedError: "PRIVATE_PATH: no key configured",
+		},
+		{
+			name:          "Invalid PEM",
+			privateKey:    KeySource{Name: "PRIVATE", Inline: "not a key"},
+			publicKey:     KeySource{Name: "PUBLIC", Inline: publicPEM},
+			expectedError: "failed to decode PEM block containing private key",
+		},
+		{
+			name:          "Mismatched keys",
+			privateKey:    KeySource{Name: "PRIVATE", Inline: privatePEM},
+			publicKey:     KeySource{Name: "PUBLIC", Inline: otherPublicPEM},
+			expectedError: "public key does not match private key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyPair, err := loadKeyPair(tt.privateKey, tt.publicKey)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, keyPair)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, keyPair.PrivateKey)
+			assert.NotNil(t, keyPair.PublicKey)
+		})
+	}
+}
+
+func TestLoadJweKeyPairs(t *testing.T) {
+	privatePEM, publicPEM := newKeyPairPEM(t)
+	generated := KeySource{Name: "PRIVATE", Inline: privatePEM}
+	generatedPublic := KeySource{Name: "PUBLIC", Inline: publicPEM}
+
+	withGeneratedSignKeys := sampleKeySources()
+	withGeneratedSignKeys.SignPrivateKey = generated
+	withGeneratedSignKeys.SignPublicKey = generatedPublic
+
+	generatedOnly := JweKeyConfig{
+		SignPrivateKey: generated,
+		SignPublicKey:  generatedPublic,
+		EncPrivateKey:  generated,
+		EncPublicKey:   generatedPublic,
+	}
+
+	tests := []struct {
+		name          string
+		keys          JweKeyConfig
+		profile       string
+		expectedError string
+	}{
+		{name: "Sample keys outside production", keys: sampleKeySources(), profile: "dev"},
+		{name: "Sample keys in production", keys: sampleKeySources(), profile: "prod",
+			expectedError: "the bundled sample keys must not be used"},
+		{name: "Sample encryption keys in production", keys: withGeneratedSignKeys, profile: "prod",
+			expectedError: "the bundled sample keys must not be used"},
+		{name: "Own keys in production", keys: generatedOnly, profile: "prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signKeyPair, encKeyPair, err := loadJweKeyPairs(tt.keys, tt.profile)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, signKeyPair)
+			assert.NotNil(t, encKeyPair)
+		})
+	}
+}
+
+func TestReloadKeyPair(t *testing.T) {
+	initialPrivatePEM, initialPublicPEM := newKeyPairPEM(t)
+	rotatedPrivatePEM, rotatedPublicPEM := newKeyPairPEM(t)
+	samplePrivatePEM, err := os.ReadFile(filepath.Join(sampleKeysDir, "sign", "private_key.pem"))
+	require.NoError(t, err)
+	samplePublicPEM, err := os.ReadFile(filepath.Join(sampleKeysDir, "sign", "public_key.pem"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		privatePEM  string
+		publicPEM   string
+		profile     string
+		expectSwap  bool
+		expectStamp bool
+	}{
+		{name: "Rotated keys are loaded", privatePEM: rotatedPrivatePEM, publicPEM: rotatedPublicPEM,
+			expectSwap: true, expectStamp: true},
+		{name: "Half replaced keys are retried", privatePEM: rotatedPrivatePEM, publicPEM: initialPublicPEM},
+		{name: "Sample keys are ignored in production", privatePEM: string(samplePrivatePEM),
+			publicPEM: string(samplePublicPEM), profile: "prod", expectStamp: true},
+		{name: "Sample keys are loaded outside production", privatePEM: string(samplePrivatePEM),
+			publicPEM: string(samplePublicPEM), profile: "dev", expectSwap: true, expectStamp: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			privateKey := KeySource{Name: "PRIVATE_FILE", Path: filepath.Join(dir, "private_key.pem")}
+			publicKey := KeySource{Name: "PUBLIC_FILE", Path: filepath.Join(dir, "public_key.pem")}
+			initialTime := time.Now().Add(-time.Hour)
+			writeKeyFile(t, privateKey.Path, initialPrivatePEM, initialTime)
+			writeKeyFile(t, publicKey.Path, initialPublicPEM, initialTime)
+
+			initial, err := loadKeyPair(privateKey, publicKey)
+			require.NoError(t, err)
+			holder := util.NewRSAKeyPairHolder(initial)
+			lastStamp := keyPairStamp(privateKey, publicKey)
+
+			// Unchanged files are not reloaded
+			assert.Equal(t, lastStamp, reloadKeyPair(holder, privateKey, publicKey, lastStamp, time.Hour, tt.profile))
+			assert.Same(t, initial, holder.Get())
+
+			rotationTime := time.Now()
+			writeKeyFile(t, privateKey.Path, tt.privatePEM, rotationTime)
+			writeKeyFile(t, publicKey.Path, tt.publicPEM, rotationTime)
+
+			stamp := reloadKeyPair(holder, privateKey, publicKey, lastStamp, time.Hour, tt.profile)
+
+			if tt.expectStamp {
+				assert.Equal(t, keyPairStamp(privateKey, publicKey), stamp)
+			} else {
+				assert.Equal(t, lastStamp, stamp)
+			}
+			if tt.expectSwap {
+				assert.NotSame(t, initial, holder.Get())
+				assert.NotEqual(t, initial.PublicKey, holder.Get().PublicKey)
+				// The replaced key pair is retained until the tokens issued with it have expired
+				assert.Equal(t, []*util.RSAKeyPair{holder.Get(), initial}, holder.KeyPairs(time.Now()))
+				assert.Equal(t, []*util.RSAKeyPair{holder.Get()}, holder.KeyPairs(time.Now().Add(time.Hour)))
+			} else {
+				assert.Same(t, initial, holder.Get())
+			}
+		})
+	}
+}
@@ -65,4 +65,28 @@ func registerCustomTranslations(validate *validator.Validate, trans ut.Translato
 		t, _ := ut.T("numeric", fe.Field())
 		return t
 	})
+
+	// One Of
+	_ = validate.RegisterTranslation("oneof", trans, func(ut ut.Translator) error {
+		return ut.Add("oneof", "Field must be one of [{1}]", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("oneof", fe.Field(), fe.Param())
+		return t
+	})
+
+	// BCP 47 Language Tag
+	_ = validate.RegisterTranslation("bcp47_language_tag", trans, func(ut ut.Translator) error {
+		return ut.Add("bcp47_language_tag", "Field must be a valid BCP 47 language tag", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("bcp47_language_tag", fe.Field())
+		return t
+	})
+
+	// IANA Time Zone
+	_ = validate.RegisterTranslation("timezone", trans, func(ut ut.Translator) error {
+		return ut.Add("timezone", "Field must be a valid IANA time zone", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("timezone", fe.Field())
+		return t
+	})
 }
@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/auth/login": {
-            "post": {
-                "description": "Validates user credentials and returns a JWT token",
+        "/api/admin/hello/daily/{date}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pins a greeting message as greeting of the day for a date, replacing the greeting pinned before. A pinned greeting which is not live on the date is skipped.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,17 +39,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "admin"
                 ],
-                "summary": "Authenticate user and generate token",
+                "summary": "Pin the greeting of the day",
                 "parameters": [
                     {
-                        "description": "Login Input",
+                        "type": "string",
+                        "example": "2025-01-05",
+                        "description": "Date in the format 2006-01-02",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Greeting to pin",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginInput"
+                            "$ref": "#/definitions/dto.GreetingPinInput"
                         }
                     }
                 ],
@@ -52,7 +65,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.TokenResponse"
+                            "$ref": "#/definitions/dto.DailyGreetingResponse"
                         }
                     },
                     "400": {
@@ -67,6 +80,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -74,16 +99,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/hello": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a greeting message",
+                "description": "Removes the greeting message pinned for a date, so the greeting of the day is chosen again",
                 "consumes": [
                     "application/json"
                 ],
@@ -91,14 +114,119 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "hello"
+                    "admin"
+                ],
+                "summary": "Unpin the greeting of the day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-01-05",
+                        "description": "Date in the format 2006-01-02",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/hello/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams all greeting messages matching the filters, ordered by ID, as JSON, NDJSON or CSV depending on the Accept header. The export can be imported again.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export greeting messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message contains (case-insensitive)",
+                        "name": "message",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created after (RFC 3339)",
+                        "name": "createdAfter",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get a greeting message",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GreetingResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GreetingResponse"
+                            }
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "Suggested file name of the export"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
                     "401": {
@@ -113,6 +241,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -120,40 +254,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/hello/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new greeting",
+                "description": "Imports greeting messages from an uploaded JSON, NDJSON or CSV file, e.g. an export. The message, locale, publishAt, expireAt and tags of each row are read; CSV files need a header line. Overwritten greetings keep the schedule times and tags a row omits. The format is taken from the content type of the file, or else from its extension. Rows are validated like single greetings; failed rows do not prevent the other rows from being imported. The report lists the outcome of every row with its line number.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "hello"
+                    "admin"
                 ],
-                "summary": "Create a new greeting message",
+                "summary": "Import greeting messages",
                 "parameters": [
                     {
-                        "description": "Greeting Input",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.GreetingInput"
-                        }
+                        "type": "file",
+                        "description": "JSON, NDJSON or CSV file of at most 10 MiB and 10000 rows",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Report the outcome without saving anything",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "overwrite",
+                            "fail"
+                        ],
+                        "type": "string",
+                        "default": "skip",
+                        "description": "Handling of greetings whose message already exists in their locale",
+                        "name": "onDuplicate",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GreetingResponse"
+                            "$ref": "#/definitions/dto.GreetingImportReport"
                         }
                     },
                     "400": {
@@ -168,8 +321,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
@@ -183,14 +342,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/hello/all": {
+        "/api/admin/hello/moderation": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns all greeting messages",
+                "description": "Returns a page of greeting messages waiting for moderation, the longest waiting first by default. Links to the neighbouring pages are returned in the Link header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -198,25 +357,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "hello"
+                    "admin"
+                ],
+                "summary": "List the moderation queue",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based page index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort criteria in the format property[,asc|desc]; properties: id, message, createdAt, updatedAt",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all greeting messages",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.GreetingResponse"
+                            "$ref": "#/definitions/dto.PagedResponse-dto_GreetingResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, prev, next and last pages"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -226,14 +429,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/hello/{id}": {
-            "get": {
+        "/api/admin/hello/moderation/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single greeting message by its ID",
+                "description": "Approves a greeting message waiting for moderation, which makes it visible to everyone",
                 "consumes": [
                     "application/json"
                 ],
@@ -241,9 +444,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "hello"
+                    "admin"
                 ],
-                "summary": "Get a greeting by ID",
+                "summary": "Approve a greeting message",
                 "parameters": [
                     {
                         "type": "integer",
@@ -258,6 +461,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
                         }
                     },
                     "400": {
@@ -272,12 +481,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -285,14 +506,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/hello/moderation/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates a greeting message by its ID",
+                "description": "Rejects a greeting message waiting for moderation with a reason for its owner, who may change and submit it again",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,9 +523,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "hello"
+                    "admin"
                 ],
-                "summary": "Update a greeting message by ID",
+                "summary": "Reject a greeting message",
                 "parameters": [
                     {
                         "type": "integer",
@@ -312,12 +535,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Greeting Input",
+                        "description": "Reason of the rejection",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.GreetingInput"
+                            "$ref": "#/definitions/dto.GreetingRejectionInput"
                         }
                     }
                 ],
@@ -326,6 +549,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
                         }
                     },
                     "400": {
@@ -340,12 +569,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -353,14 +594,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/hello/tags/merge": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a greeting message by its ID",
+                "description": "Tags the greeting messages tagged with the source tags with the target tag instead, and deletes the source tags. The greetings tagged with a source tag get a new version. Tags are shared by all tenants, so only super-admins merge them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -368,21 +611,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "hello"
+                    "admin"
                 ],
-                "summary": "Delete a greeting message by ID",
+                "summary": "Merge tags",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Greeting ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Tags to merge",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagMergeInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -396,6 +644,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -411,9 +665,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/health/liveness": {
-            "get": {
-                "description": "Returns the liveness status of the application",
+        "/api/admin/hello/tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renames a tag of greeting messages. The greetings tagged with it get a new version. Fails with a conflict when another tag has the name; merge the tags instead. Tags are shared by all tenants, so only super-admins rename them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -421,22 +680,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "Rename a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagInput"
+                        }
+                    }
                 ],
-                "summary": "Check if the application is alive",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthStatus"
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     }
                 }
             }
         },
-        "/health/readiness": {
+        "/api/admin/hello/trash": {
             "get": {
-                "description": "Returns the readiness status of the application",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of deleted greeting messages, most recently deleted first. Links to the neighbouring pages are returned in the Link header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,39 +762,4218 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "List the greeting trash",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based page index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort criteria in the format property[,asc|desc]; properties: id, message, createdAt, updatedAt, deletedAt",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Check if the application is ready",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.HealthStatus"
+                            "$ref": "#/definitions/dto.PagedResponse-dto_GreetingResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.GreetingInput": {
-            "description": "Input dto for creating a new greeting",
+        },
+        "/api/admin/hello/trash/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently deletes a greeting message in the trash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Purge a deleted greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/hello/trash/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a greeting message out of the trash. Fails with a conflict when another greeting took over its message in the meantime.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Restore a deleted greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/hello/{id}/comments/{commentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a comment of any user along with the replies to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove any comment on a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/security-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns security events filtered by user, type and time range, newest first. Admins only see the events of the users of their tenant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Query the security audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id or attempted username",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "LOGIN_SUCCESS",
+                            "LOGIN_FAILURE",
+                            "TOKEN_REJECTED",
+                            "ACCESS_DENIED",
+                            "ADMIN_ACTION"
+                        ],
+                        "type": "string",
+                        "description": "Event type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive start of the time range (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive end of the time range (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of events",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SecurityEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of the users of the caller's tenant using keyset pagination. Follow the next and prev cursors to scroll through the users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor of the requested page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort criterion in the format property[,asc|desc] for the first page; properties: id, username, email, createdAt",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CursorPagedResponse-dto_UserResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the prev and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/login": {
+            "post": {
+                "description": "Validates user credentials and returns a JWT token.\nIn cookie token mode the token is set in an HttpOnly cookie instead and only the CSRF token is returned.\nWhen a DPoP proof is sent, the issued token is bound to its key and must be used with the DPoP scheme.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Authenticate user and generate token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DPoP proof JWT (RFC 9449)",
+                        "name": "DPoP",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Login Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout": {
+            "post": {
+                "description": "Clears the access token and CSRF cookies set by the cookie token mode",
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Log out the current user",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/hello": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a greeting message in the best matching language, e.g. pt-BR falls back to pt and then to the default locale. The most recently updated live template of that language is rendered for the caller; without one, a static message is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get a greeting message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "pt-BR, en;q=0.8",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Europe/Istanbul",
+                        "description": "IANA time zone of the caller",
+                        "name": "timeZone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Locale of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new greeting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Create a new greeting message",
+                "parameters": [
+                    {
+                        "description": "Greeting Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of greeting messages matching the filters, in the best matching locale having greetings. Links to the neighbouring pages are returned in the Link header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get a page of greeting messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "pt-BR, en;q=0.8",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based page index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort criteria in the format property[,asc|desc]; properties: id, message, createdAt, updatedAt",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message contains (case-insensitive)",
+                        "name": "message",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created after (RFC 3339)",
+                        "name": "createdAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag names",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether greetings need any or all of the tags",
+                        "name": "tagMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached representation",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PagedResponse-dto_GreetingResponse"
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Locale of the listed greetings"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the page content"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/all/cursor": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of greeting messages in the best matching locale having greetings, using keyset pagination. Follow the next and prev cursors to scroll; pages do not shift when greetings are added.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Scroll through greeting messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "pt-BR, en;q=0.8",
+                        "description": "Preferred languages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the requested page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort criterion in the format property[,asc|desc] for the first page; properties: id, message, createdAt, updatedAt",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CursorPagedResponse-dto_GreetingResponse"
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Locale of the listed greetings"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the prev and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/bulk": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Validates and updates up to 100 greetings in one transaction. An item carrying a version only updates that version of the greeting. In all-or-nothing mode, any failed item rolls back every item (status 424 for the others); in best-effort mode, the other items are still updated. The outcome of every item is returned in request order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Update greeting messages in bulk",
+                "parameters": [
+                    {
+                        "enum": [
+                            "all-or-nothing",
+                            "best-effort"
+                        ],
+                        "type": "string",
+                        "default": "all-or-nothing",
+                        "description": "Bulk mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Greeting updates",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BulkGreetingUpdate"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkResponse-dto_GreetingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Validates and creates up to 100 greetings in one transaction. In all-or-nothing mode, any failed item rolls back every item (status 424 for the others); in best-effort mode, the other items are still created. The outcome of every item is returned in request order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Create greeting messages in bulk",
+                "parameters": [
+                    {
+                        "enum": [
+                            "all-or-nothing",
+                            "best-effort"
+                        ],
+                        "type": "string",
+                        "default": "all-or-nothing",
+                        "description": "Bulk mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Greetings to create",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GreetingInput"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkResponse-dto_GreetingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves up to 100 greetings to the trash in one transaction. An item carrying a version only deletes that version of the greeting. In all-or-nothing mode, any failed item rolls back every item (status 424 for the others); in best-effort mode, the other items are still deleted. The outcome of every item is returned in request order, without data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Delete greeting messages in bulk",
+                "parameters": [
+                    {
+                        "enum": [
+                            "all-or-nothing",
+                            "best-effort"
+                        ],
+                        "type": "string",
+                        "default": "all-or-nothing",
+                        "description": "Bulk mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Greeting deletes",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BulkGreetingDelete"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkResponse-dto_GreetingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/daily": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the greeting message of the current day in the time zone of the caller. The greeting pinned by an admin for the date comes first; otherwise, a live greeting is chosen by the date, so all callers get the same greeting during the day. Templated messages are rendered for the caller.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get the greeting of the day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Europe/Istanbul",
+                        "description": "IANA time zone of the caller, which decides the date",
+                        "name": "timeZone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DailyGreetingResponse"
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Locale of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/random": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a live greeting message picked at random, optionally among the greetings in a locale or with tags. Templated messages are rendered for the caller.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get a random greeting message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "en",
+                        "description": "Locale of the greeting",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag names",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether the greeting needs any or all of the tags",
+                        "name": "tagMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Europe/Istanbul",
+                        "description": "IANA time zone of the caller",
+                        "name": "timeZone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Locale of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over greeting messages, most relevant first. Matches words and \"quoted phrases\"; a word ending with * matches as a prefix. Links to the neighbouring pages are returned in the Link header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Search greeting messages",
+                "parameters": [
+                    {
+                        "maxLength": 200,
+                        "type": "string",
+                        "description": "Words and phrases to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based page index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PagedResponse-dto_GreetingSearchResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of the tags of greeting messages with the number of greetings tagged with each, not counting the trash. Only the tags and greetings of the caller's tenant are listed and counted. Links to the neighbouring pages are returned in the Link header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get a page of tags",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based page index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort criteria in the format property[,asc|desc]; properties: name, count, createdAt",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PagedResponse-dto_TagResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/top": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ranks the greeting messages by the number of reactions they received in a period ending now, most reactions first. Greetings without reactions in the period are not ranked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "List the top greeting messages",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "year",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "week",
+                        "description": "Period whose reactions are counted",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "laugh",
+                            "wow",
+                            "sad",
+                            "celebrate"
+                        ],
+                        "type": "string",
+                        "description": "Only count reactions of the type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of greetings",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TopGreetingResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single greeting message by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get a greeting by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached representation",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time the cached representation was last modified",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting with a hash of its content"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time of the last update of the greeting, its reactions or its comments"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a greeting message by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Update a greeting message by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Greeting Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the greeting version the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a greeting message to the trash, from where an admin can restore or purge it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Delete a greeting message by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the greeting version the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to a greeting message. The patched greeting is validated like a full update; removing the locale resets it to the default locale.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Patch a greeting message by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch, or an array of JSON Patch operations on /message and /locale",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the greeting version the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archives a greeting message, which hides it from everyone but its owner and admins. Only its owner and admins may archive it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Archive a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of the comments on a greeting message, oldest first by default. Replies carry the ID of the comment they answer, and parentId lists the direct replies to one comment. Links to the neighbouring pages are returned in the Link header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List the comments on a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Zero-based page index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Sort criteria in the format property[,asc|desc]; properties: id, createdAt, updatedAt",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Only list the direct replies to the comment",
+                        "name": "parentId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PagedResponse-dto_CommentResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a comment of the caller to a greeting message, or a reply to one of its comments when parentId is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/comments/{commentId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the text of a comment. Only the author of the comment can edit it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Edit a comment on a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment Update Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a comment along with the replies to it. Only the author of the comment can delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment on a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/reactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the number of reactions per type to a greeting message and the types the caller reacted with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Get the reactions to a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReactionSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/reactions/{type}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a reaction of the caller to a greeting message. A user reacts at most once per type, so reacting again has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "React to a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "laugh",
+                            "wow",
+                            "sad",
+                            "celebrate"
+                        ],
+                        "type": "string",
+                        "description": "Reaction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReactionSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a reaction of the caller from a greeting message. Removing a missing reaction has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Remove a reaction from a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "like",
+                            "love",
+                            "laugh",
+                            "wow",
+                            "sad",
+                            "celebrate"
+                        ],
+                        "type": "string",
+                        "description": "Reaction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReactionSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/render": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renders the placeholders of a greeting message, e.g. {{.FirstName}} or {{timeOfDay}}, for the authenticated user at the request time. Messages without placeholders are returned as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Render a greeting for the caller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Europe/Istanbul",
+                        "description": "IANA time zone of the caller",
+                        "name": "timeZone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RenderedGreetingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the recorded changes of a greeting message with the old and new values, most recent first. Greetings the user cannot see are not found; admins list the revisions of greetings in the trash as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "List the revisions of a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page index (zero-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PagedResponse-dto_GreetingRevisionResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first, previous, next and last pages (RFC 8288)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the fields whose values after the two revisions differ. A value is null when the revision deleted the greeting. Greetings the user cannot see are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Compare two revisions of a greeting message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingRevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/revisions/{revision}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the message and locale of a greeting back to their values after the revision. The rollback is recorded as a new revision. Revisions which deleted the greeting cannot be rolled back to; deleted greetings are restored from the trash instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Roll back a greeting message to a revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to roll back to",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the greeting version the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/hello/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submits a draft or rejected greeting message for moderation. Only its owner and admins may submit it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hello"
+                ],
+                "summary": "Submit a greeting message for moderation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Greeting ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the greeting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/liveness": {
+            "get": {
+                "description": "Returns the liveness status of the application",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Check if the application is alive",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/readiness": {
+            "get": {
+                "description": "Returns the readiness status of the application",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Check if the application is ready",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthStatus"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.BulkGreetingDelete": {
+            "description": "Input dto for one greeting of a bulk delete",
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "description": "ID of the greeting to delete",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "version": {
+                    "description": "Version the delete is based on; the delete fails if the greeting has changed since",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.BulkGreetingUpdate": {
+            "description": "Input dto for one greeting of a bulk update",
+            "type": "object",
+            "required": [
+                "id",
+                "message",
+                "tags"
+            ],
+            "properties": {
+                "draft": {
+                    "description": "Draft keeps a created greeting as a draft instead of submitting it for moderation; updates ignore it",
+                    "type": "boolean",
+                    "example": false
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, after PublishAt; it never expires when omitted",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "id": {
+                    "description": "ID of the greeting to update",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message; the default locale when omitted",
+                    "type": "string",
+                    "example": "pt-BR"
+                },
+                "message": {
+                    "description": "Message is the greeting text to be created",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Hello, World!"
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live; it is live from its creation when omitted",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, which are stored in lowercase and created when missing.\nUpdates keep the tags when omitted, and remove them when empty.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                },
+                "version": {
+                    "description": "Version the update is based on; the update fails if the greeting has changed since",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.BulkItemResult-dto_GreetingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data holds the resulting resource of a successful item, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GreetingResponse"
+                        }
+                    ]
+                },
+                "index": {
+                    "description": "Index is the position of the item in the request",
+                    "type": "integer",
+                    "example": 0
+                },
+                "problem": {
+                    "description": "Problem describes why the item failed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Status is the HTTP status the item would have had as a single request",
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "dto.BulkResponse-dto_GreetingResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "Failed is the number of items which were not applied",
+                    "type": "integer",
+                    "example": 0
+                },
+                "mode": {
+                    "description": "Mode is the mode the operation was applied in",
+                    "type": "string",
+                    "example": "all-or-nothing"
+                },
+                "results": {
+                    "description": "Results holds the outcome of every item",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkItemResult-dto_GreetingResponse"
+                    }
+                },
+                "succeeded": {
+                    "description": "Succeeded is the number of applied items",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.CommentInput": {
+            "description": "Comment input dto",
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "description": "Body is the text of the comment",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 1,
+                    "example": "Nice greeting!"
+                },
+                "parentId": {
+                    "description": "ParentID is the ID of the comment replied to; a top-level comment when omitted",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "dto.CommentResponse": {
+            "description": "Comment response dto",
+            "type": "object",
+            "properties": {
+                "authorId": {
+                    "description": "AuthorID is the user ID of the user who wrote the comment",
+                    "type": "string",
+                    "example": "2"
+                },
+                "body": {
+                    "description": "Body is the text of the comment",
+                    "type": "string",
+                    "example": "Nice greeting!"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the comment was written",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who wrote the comment, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "greetingId": {
+                    "description": "GreetingID is the ID of the greeting commented on",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID of the comment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "parentId": {
+                    "description": "ParentID is the ID of the comment replied to, absent for top-level comments",
+                    "type": "integer",
+                    "example": 1
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the comment was last edited",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the comment, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "dto.CommentUpdateInput": {
+            "description": "Comment update input dto",
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "description": "Body is the new text of the comment",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 1,
+                    "example": "Nice greeting, thanks!"
+                }
+            }
+        },
+        "dto.CursorPagedResponse-dto_GreetingResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GreetingResponse"
+                    }
+                },
+                "next": {
+                    "description": "Next is the cursor of the following page, absent on the last page",
+                    "type": "string",
+                    "example": "eyJjIjoiaWQiLCJ2IjoyMCwiaWQiOjIwfQ.c2lnbmF0dXJl"
+                },
+                "prev": {
+                    "description": "Prev is the cursor of the preceding page, absent on the first page",
+                    "type": "string",
+                    "example": "eyJjIjoiaWQiLCJ2IjoxLCJpZCI6MSwiYiI6dHJ1ZX0.c2lnbmF0dXJl"
+                },
+                "size": {
+                    "description": "Size is the requested number of elements per page",
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "dto.CursorPagedResponse-dto_UserResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserResponse"
+                    }
+                },
+                "next": {
+                    "description": "Next is the cursor of the following page, absent on the last page",
+                    "type": "string",
+                    "example": "eyJjIjoiaWQiLCJ2IjoyMCwiaWQiOjIwfQ.c2lnbmF0dXJl"
+                },
+                "prev": {
+                    "description": "Prev is the cursor of the preceding page, absent on the first page",
+                    "type": "string",
+                    "example": "eyJjIjoiaWQiLCJ2IjoxLCJpZCI6MSwiYiI6dHJ1ZX0.c2lnbmF0dXJl"
+                },
+                "size": {
+                    "description": "Size is the requested number of elements per page",
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "dto.DailyGreetingResponse": {
+            "description": "Greeting of the day response dto",
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "message"
+            ],
+            "properties": {
+                "commentCount": {
+                    "description": "CommentCount is the number of comments on the greeting including replies, absent for greetings without comments",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the greeting was created",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who created the greeting, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "date": {
+                    "description": "Date is the calendar date the greeting was chosen for, in the requested time zone",
+                    "type": "string",
+                    "example": "2025-01-05"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is the timestamp when the greeting was moved to the trash, absent for other greetings",
+                    "type": "string",
+                    "example": "2025-01-06T09:00:00Z"
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, absent for greetings which never expire",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "id": {
+                    "description": "ID of the greeting",
+                    "type": "integer",
+                    "example": 1
+                },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message",
+                    "type": "string",
+                    "example": "en"
+                },
+                "message": {
+                    "description": "Message is the greeting text",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Hello, World!"
+                },
+                "ownerId": {
+                    "description": "OwnerID is the user ID of the user who created the greeting",
+                    "type": "string",
+                    "example": "2"
+                },
+                "pin": {
+                    "description": "Pin holds the auditing details of the pin, only returned when pinning a greeting",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GreetingPinResponse"
+                        }
+                    ]
+                },
+                "pinned": {
+                    "description": "Pinned tells whether an admin pinned the greeting for the date instead of it being chosen",
+                    "type": "boolean",
+                    "example": false
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live, absent for greetings live from their creation",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "reactions": {
+                    "description": "Reactions holds the number of reactions per type, e.g. like; absent for greetings without reactions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "like": 2,
+                        "love": 1
+                    }
+                },
+                "rejectionReason": {
+                    "description": "RejectionReason is the reason given by the moderator, present for rejected greetings",
+                    "type": "string",
+                    "example": "Contains a link"
+                },
+                "status": {
+                    "description": "Status is the moderation state: DRAFT, PENDING, APPROVED, REJECTED or ARCHIVED",
+                    "type": "string",
+                    "example": "APPROVED"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, sorted by name; absent for greetings without tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                },
+                "tenantId": {
+                    "description": "TenantID is the organisation owning the greeting",
+                    "type": "string",
+                    "example": "default"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the greeting was last updated",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the greeting, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                },
+                "version": {
+                    "description": "Version is incremented by every update and sent as the ETag",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.GreetingFieldChange": {
+            "description": "Changed field of a greeting",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field is the name of the changed field",
+                    "type": "string",
+                    "example": "message"
+                },
+                "from": {
+                    "description": "From is the value at the revision compared from, null if the greeting did not exist then",
+                    "type": "string",
+                    "example": "Hello, World!"
+                },
+                "to": {
+                    "description": "To is the value at the revision compared to, null if the greeting did not exist then",
+                    "type": "string",
+                    "example": "Hello, Revisions!"
+                }
+            }
+        },
+        "dto.GreetingImportReport": {
+            "description": "Summary of a greeting import",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Created is the number of created greetings",
+                    "type": "integer",
+                    "example": 1
+                },
+                "dryRun": {
+                    "description": "DryRun tells whether the import was only simulated",
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "description": "Failed is the number of rows which could not be imported",
+                    "type": "integer",
+                    "example": 1
+                },
+                "onDuplicate": {
+                    "description": "OnDuplicate is the handling applied to duplicate greetings",
+                    "type": "string",
+                    "example": "skip"
+                },
+                "overwritten": {
+                    "description": "Overwritten is the number of overwritten greetings",
+                    "type": "integer",
+                    "example": 0
+                },
+                "rows": {
+                    "description": "Rows holds the outcome of every row in file order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GreetingImportRowResult"
+                    }
+                },
+                "skipped": {
+                    "description": "Skipped is the number of skipped duplicates",
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "description": "Total is the number of rows in the file",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.GreetingImportRowResult": {
+            "description": "Outcome of one imported row",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the created or overwritten greeting, absent in a dry run",
+                    "type": "integer",
+                    "example": 7
+                },
+                "line": {
+                    "description": "Line is the line of the file the row starts at",
+                    "type": "integer",
+                    "example": 2
+                },
+                "outcome": {
+                    "description": "Outcome is one of created, overwritten, skipped and failed",
+                    "type": "string",
+                    "example": "created"
+                },
+                "problem": {
+                    "description": "Problem describes why the row failed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ProblemDetail"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.GreetingInput": {
+            "description": "Input dto for creating a new greeting",
+            "type": "object",
+            "required": [
+                "message",
+                "tags"
+            ],
+            "properties": {
+                "draft": {
+                    "description": "Draft keeps a created greeting as a draft instead of submitting it for moderation; updates ignore it",
+                    "type": "boolean",
+                    "example": false
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, after PublishAt; it never expires when omitted",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message; the default locale when omitted",
+                    "type": "string",
+                    "example": "pt-BR"
+                },
+                "message": {
+                    "description": "Message is the greeting text to be created",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Hello, World!"
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live; it is live from its creation when omitted",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, which are stored in lowercase and created when missing.\nUpdates keep the tags when omitted, and remove them when empty.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                }
+            }
+        },
+        "dto.GreetingPinInput": {
+            "description": "Greeting pin input dto",
+            "type": "object",
+            "required": [
+                "greetingId"
+            ],
+            "properties": {
+                "greetingId": {
+                    "description": "GreetingID is the ID of the greeting to pin",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "dto.GreetingPinResponse": {
+            "description": "Greeting pin dto",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when a greeting was first pinned for the date",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the admin who first pinned a greeting for the date",
+                    "type": "string",
+                    "example": "1"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the pin was last changed",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the admin who last pinned a greeting for the date",
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "dto.GreetingRejectionInput": {
+            "description": "Input dto for rejecting a greeting",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason tells the owner why the greeting was rejected",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Contains a link"
+                }
+            }
+        },
+        "dto.GreetingResponse": {
+            "description": "Greeting dto",
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "message"
+            ],
+            "properties": {
+                "commentCount": {
+                    "description": "CommentCount is the number of comments on the greeting including replies, absent for greetings without comments",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the greeting was created",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who created the greeting, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is the timestamp when the greeting was moved to the trash, absent for other greetings",
+                    "type": "string",
+                    "example": "2025-01-06T09:00:00Z"
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, absent for greetings which never expire",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "id": {
+                    "description": "ID of the greeting",
+                    "type": "integer",
+                    "example": 1
+                },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message",
+                    "type": "string",
+                    "example": "en"
+                },
+                "message": {
+                    "description": "Message is the greeting text",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Hello, World!"
+                },
+                "ownerId": {
+                    "description": "OwnerID is the user ID of the user who created the greeting",
+                    "type": "string",
+                    "example": "2"
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live, absent for greetings live from their creation",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "reactions": {
+                    "description": "Reactions holds the number of reactions per type, e.g. like; absent for greetings without reactions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "like": 2,
+                        "love": 1
+                    }
+                },
+                "rejectionReason": {
+                    "description": "RejectionReason is the reason given by the moderator, present for rejected greetings",
+                    "type": "string",
+                    "example": "Contains a link"
+                },
+                "status": {
+                    "description": "Status is the moderation state: DRAFT, PENDING, APPROVED, REJECTED or ARCHIVED",
+                    "type": "string",
+                    "example": "APPROVED"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, sorted by name; absent for greetings without tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                },
+                "tenantId": {
+                    "description": "TenantID is the organisation owning the greeting",
+                    "type": "string",
+                    "example": "default"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the greeting was last updated",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the greeting, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                },
+                "version": {
+                    "description": "Version is incremented by every update and sent as the ETag",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.GreetingRevisionDiffResponse": {
+            "description": "Greeting revision diff dto",
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes lists the fields whose values differ, empty when both revisions have the same values",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GreetingFieldChange"
+                    }
+                },
+                "from": {
+                    "description": "From is the revision compared from",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GreetingRevisionResponse"
+                        }
+                    ]
+                },
+                "to": {
+                    "description": "To is the revision compared to",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GreetingRevisionResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.GreetingRevisionResponse": {
+            "description": "Greeting revision dto",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the kind of change",
+                    "type": "string",
+                    "enum": [
+                        "CREATE",
+                        "UPDATE",
+                        "DELETE",
+                        "RESTORE",
+                        "ROLLBACK"
+                    ],
+                    "example": "UPDATE"
+                },
+                "changedAt": {
+                    "description": "ChangedAt is the timestamp of the change",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "changedBy": {
+                    "description": "ChangedBy is the user id of the user who made the change",
+                    "type": "string",
+                    "example": "1"
+                },
+                "new": {
+                    "description": "New holds the values after the change, null if the change deleted the greeting",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GreetingState"
+                        }
+                    ]
+                },
+                "old": {
+                    "description": "Old holds the values before the change, null if the greeting did not exist",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.GreetingState"
+                        }
+                    ]
+                },
+                "revision": {
+                    "description": "Revision is the sequence number of the revision within the greeting, starting at 1",
+                    "type": "integer",
+                    "example": 2
+                },
+                "rolledBackTo": {
+                    "description": "RolledBackTo is the revision restored by a rollback, absent for other changes",
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "description": "Version is the version of the greeting after the change",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.GreetingSearchResponse": {
+            "description": "Greeting search result dto",
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "message"
+            ],
+            "properties": {
+                "commentCount": {
+                    "description": "CommentCount is the number of comments on the greeting including replies, absent for greetings without comments",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the greeting was created",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who created the greeting, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is the timestamp when the greeting was moved to the trash, absent for other greetings",
+                    "type": "string",
+                    "example": "2025-01-06T09:00:00Z"
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, absent for greetings which never expire",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "id": {
+                    "description": "ID of the greeting",
+                    "type": "integer",
+                    "example": 1
+                },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message",
+                    "type": "string",
+                    "example": "en"
+                },
+                "message": {
+                    "description": "Message is the greeting text",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Hello, World!"
+                },
+                "ownerId": {
+                    "description": "OwnerID is the user ID of the user who created the greeting",
+                    "type": "string",
+                    "example": "2"
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live, absent for greetings live from their creation",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "reactions": {
+                    "description": "Reactions holds the number of reactions per type, e.g. like; absent for greetings without reactions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "like": 2,
+                        "love": 1
+                    }
+                },
+                "rejectionReason": {
+                    "description": "RejectionReason is the reason given by the moderator, present for rejected greetings",
+                    "type": "string",
+                    "example": "Contains a link"
+                },
+                "score": {
+                    "description": "Score is the relevance of the result, higher is more relevant",
+                    "type": "number",
+                    "example": 1.52
+                },
+                "snippet": {
+                    "description": "Snippet is an HTML escaped excerpt of the message with the matched terms wrapped in \u003cmark\u003e tags",
+                    "type": "string",
+                    "example": "\u003cmark\u003eGood\u003c/mark\u003e \u003cmark\u003emorning\u003c/mark\u003e, World \u0026amp; all!"
+                },
+                "status": {
+                    "description": "Status is the moderation state: DRAFT, PENDING, APPROVED, REJECTED or ARCHIVED",
+                    "type": "string",
+                    "example": "APPROVED"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, sorted by name; absent for greetings without tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                },
+                "tenantId": {
+                    "description": "TenantID is the organisation owning the greeting",
+                    "type": "string",
+                    "example": "default"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the greeting was last updated",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the greeting, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                },
+                "version": {
+                    "description": "Version is incremented by every update and sent as the ETag",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.GreetingState": {
+            "description": "Greeting values recorded by a revision",
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message",
+                    "type": "string",
+                    "example": "en"
+                },
+                "message": {
+                    "description": "Message is the greeting text",
+                    "type": "string",
+                    "example": "Hello, World!"
+                }
+            }
+        },
+        "dto.HealthStatus": {
+            "description": "Health status dto",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "Status indicates the health of the application",
+                    "type": "string",
+                    "example": "UP"
+                }
+            }
+        },
+        "dto.LoginInput": {
+            "description": "Login request DTO containing username and password",
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "description": "Password is the password of the user",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4,
+                    "example": "password"
+                },
+                "username": {
+                    "description": "Username is the username of the user",
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "admin"
+                }
+            }
+        },
+        "dto.PageMetadata": {
+            "description": "Pagination metadata",
+            "type": "object",
+            "properties": {
+                "number": {
+                    "description": "Number is the zero-based index of the page",
+                    "type": "integer",
+                    "example": 0
+                },
+                "size": {
+                    "description": "Size is the requested number of elements per page",
+                    "type": "integer",
+                    "example": 20
+                },
+                "totalElements": {
+                    "description": "TotalElements is the number of elements matching the query",
+                    "type": "integer",
+                    "example": 42
+                },
+                "totalPages": {
+                    "description": "TotalPages is the number of pages for the requested size",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.PagedResponse-dto_CommentResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CommentResponse"
+                    }
+                },
+                "page": {
+                    "description": "Page holds the pagination metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.PagedResponse-dto_GreetingResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GreetingResponse"
+                    }
+                },
+                "page": {
+                    "description": "Page holds the pagination metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.PagedResponse-dto_GreetingRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GreetingRevisionResponse"
+                    }
+                },
+                "page": {
+                    "description": "Page holds the pagination metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.PagedResponse-dto_GreetingSearchResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GreetingSearchResponse"
+                    }
+                },
+                "page": {
+                    "description": "Page holds the pagination metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.PagedResponse-dto_TagResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content holds the elements of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TagResponse"
+                    }
+                },
+                "page": {
+                    "description": "Page holds the pagination metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageMetadata"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.ProblemDetail": {
+            "description": "Represents a structured error response for the API",
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "Detail provides a more detailed explanation of the error",
+                    "type": "string",
+                    "example": "One or more fields failed validation"
+                },
+                "error": {
+                    "description": "Error is a machine-readable error code",
+                    "type": "string",
+                    "example": "invalid_request"
+                },
+                "instance": {
+                    "description": "Instance is a URI that identifies the specific occurrence of the error",
+                    "type": "string",
+                    "example": "/api/hello"
+                },
+                "status": {
+                    "description": "HTTP status code for the error",
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "description": "Title is a short, human-readable summary of the error",
+                    "type": "string",
+                    "example": "Validation Error"
+                },
+                "type": {
+                    "description": "Type of the error, usually a URI identifying the error type",
+                    "type": "string",
+                    "example": "about:blank"
+                },
+                "violations": {
+                    "description": "Violations is a list of validation errors (optional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Violation"
+                    }
+                }
+            }
+        },
+        "dto.ReactionSummaryResponse": {
+            "description": "Greeting reactions dto",
             "type": "object",
-            "required": [
-                "message"
-            ],
             "properties": {
-                "message": {
-                    "description": "Message is the greeting text to be created",
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "Hello, World!"
+                "greetingId": {
+                    "description": "GreetingID is the ID of the greeting reacted to",
+                    "type": "integer",
+                    "example": 1
+                },
+                "mine": {
+                    "description": "Mine lists the types the caller reacted with, sorted by name",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "like"
+                    ]
+                },
+                "reactions": {
+                    "description": "Reactions holds the number of reactions per type; types without reactions are absent",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "like": 2,
+                        "love": 1
+                    }
                 }
             }
         },
-        "dto.GreetingResponse": {
-            "description": "Greeting dto",
+        "dto.RenderedGreetingResponse": {
+            "description": "Rendered greeting response dto",
             "type": "object",
             "required": [
                 "createdAt",
@@ -484,16 +4981,41 @@ const docTemplate = `{
                 "message"
             ],
             "properties": {
+                "commentCount": {
+                    "description": "CommentCount is the number of comments on the greeting including replies, absent for greetings without comments",
+                    "type": "integer",
+                    "example": 3
+                },
                 "createdAt": {
                     "description": "CreatedAt is the timestamp when the greeting was created",
                     "type": "string",
                     "example": "2025-01-05T10:00:00Z"
                 },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who created the greeting, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is the timestamp when the greeting was moved to the trash, absent for other greetings",
+                    "type": "string",
+                    "example": "2025-01-06T09:00:00Z"
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, absent for greetings which never expire",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
                 "id": {
                     "description": "ID of the greeting",
                     "type": "integer",
                     "example": 1
                 },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message",
+                    "type": "string",
+                    "example": "en"
+                },
                 "message": {
                     "description": "Message is the greeting text",
                     "type": "string",
@@ -501,91 +5023,225 @@ const docTemplate = `{
                     "minLength": 3,
                     "example": "Hello, World!"
                 },
+                "ownerId": {
+                    "description": "OwnerID is the user ID of the user who created the greeting",
+                    "type": "string",
+                    "example": "2"
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live, absent for greetings live from their creation",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "reactions": {
+                    "description": "Reactions holds the number of reactions per type, e.g. like; absent for greetings without reactions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "like": 2,
+                        "love": 1
+                    }
+                },
+                "rejectionReason": {
+                    "description": "RejectionReason is the reason given by the moderator, present for rejected greetings",
+                    "type": "string",
+                    "example": "Contains a link"
+                },
+                "renderedAt": {
+                    "description": "RenderedAt is the time the message was rendered for, in the requested time zone",
+                    "type": "string",
+                    "example": "2025-01-05T15:04:05+03:00"
+                },
+                "status": {
+                    "description": "Status is the moderation state: DRAFT, PENDING, APPROVED, REJECTED or ARCHIVED",
+                    "type": "string",
+                    "example": "APPROVED"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, sorted by name; absent for greetings without tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                },
+                "template": {
+                    "description": "Template is the message before rendering",
+                    "type": "string",
+                    "example": "Good {{timeOfDay}}, {{.FirstName}}!"
+                },
+                "tenantId": {
+                    "description": "TenantID is the organisation owning the greeting",
+                    "type": "string",
+                    "example": "default"
+                },
                 "updatedAt": {
                     "description": "UpdatedAt is the timestamp when the greeting was last updated",
                     "type": "string",
                     "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the greeting, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                },
+                "version": {
+                    "description": "Version is incremented by every update and sent as the ETag",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "dto.HealthStatus": {
-            "description": "Health status dto",
+        "dto.SecurityEventResponse": {
+            "description": "Security event dto",
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
+                "authority": {
+                    "description": "Authority required by a denied request",
+                    "type": "string",
+                    "example": "ROLE_ADMIN"
+                },
+                "clientIp": {
+                    "description": "ClientIP is the IP address of the client",
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "id": {
+                    "description": "ID of the event",
+                    "type": "integer",
+                    "example": 1
+                },
+                "method": {
+                    "description": "Method is the HTTP method of the request",
+                    "type": "string",
+                    "example": "POST"
+                },
+                "occurredAt": {
+                    "description": "OccurredAt is the time the event occurred",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "path": {
+                    "description": "Path of the request",
+                    "type": "string",
+                    "example": "/api/auth/login"
+                },
+                "principal": {
+                    "description": "Principal is the user id or the attempted username",
+                    "type": "string",
+                    "example": "admin"
+                },
+                "reason": {
+                    "description": "Reason of a failure or rejection",
+                    "type": "string",
+                    "example": "bad credentials"
+                },
                 "status": {
-                    "description": "Status indicates the health of the application",
+                    "description": "Status is the HTTP response status",
+                    "type": "integer",
+                    "example": 401
+                },
+                "tenantId": {
+                    "description": "TenantID is the tenant of the user, absent when the user is unknown",
                     "type": "string",
-                    "example": "UP"
+                    "example": "default"
+                },
+                "type": {
+                    "description": "Type of the event",
+                    "type": "string",
+                    "enum": [
+                        "LOGIN_SUCCESS",
+                        "LOGIN_FAILURE",
+                        "TOKEN_REJECTED",
+                        "ACCESS_DENIED",
+                        "ADMIN_ACTION"
+                    ],
+                    "example": "LOGIN_FAILURE"
                 }
             }
         },
-        "dto.LoginInput": {
-            "description": "Login request DTO containing username and password",
+        "dto.TagInput": {
+            "description": "Input dto for renaming a tag",
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "name"
             ],
             "properties": {
-                "password": {
-                    "description": "Password is the password of the user",
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 4,
-                    "example": "password"
-                },
-                "username": {
-                    "description": "Username is the username of the user",
+                "name": {
+                    "description": "Name is the new name of the tag, stored in lowercase",
                     "type": "string",
                     "maxLength": 50,
-                    "minLength": 3,
-                    "example": "admin"
+                    "example": "xmas"
                 }
             }
         },
-        "dto.ProblemDetail": {
-            "description": "Represents a structured error response for the API",
+        "dto.TagMergeInput": {
+            "description": "Input dto for merging tags",
             "type": "object",
+            "required": [
+                "sourceIds",
+                "targetId"
+            ],
             "properties": {
-                "detail": {
-                    "description": "Detail provides a more detailed explanation of the error",
-                    "type": "string",
-                    "example": "One or more fields failed validation"
+                "sourceIds": {
+                    "description": "SourceIDs are the IDs of the tags to merge, which are deleted",
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
                 },
-                "error": {
-                    "description": "Error is a machine-readable error code",
+                "targetId": {
+                    "description": "TargetID is the ID of the tag the greetings of the source tags are tagged with instead",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "dto.TagResponse": {
+            "description": "Tag dto",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of greetings tagged with the tag, not counting the trash",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the tag was first used",
                     "type": "string",
-                    "example": "invalid_request"
+                    "example": "2025-01-05T10:00:00Z"
                 },
-                "instance": {
-                    "description": "Instance is a URI that identifies the specific occurrence of the error",
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who first used the tag, absent for tags created before auditing",
                     "type": "string",
-                    "example": "/api/hello"
+                    "example": "1"
                 },
-                "status": {
-                    "description": "HTTP status code for the error",
+                "id": {
+                    "description": "ID of the tag",
                     "type": "integer",
-                    "example": 400
+                    "example": 1
                 },
-                "title": {
-                    "description": "Title is a short, human-readable summary of the error",
+                "name": {
+                    "description": "Name of the tag in lowercase",
                     "type": "string",
-                    "example": "Validation Error"
+                    "example": "christmas"
                 },
-                "type": {
-                    "description": "Type of the error, usually a URI identifying the error type",
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last renamed the tag, or who created it",
                     "type": "string",
-                    "example": "about:blank"
-                },
-                "violations": {
-                    "description": "Violations is a list of validation errors (optional)",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.Violation"
-                    }
+                    "example": "1"
                 }
             }
         },
@@ -593,13 +5249,12 @@ const docTemplate = `{
             "description": "JWT token response DTO",
             "type": "object",
             "required": [
-                "accessToken",
                 "accessTokenExpiresIn",
                 "tokenType"
             ],
             "properties": {
                 "accessToken": {
-                    "description": "AccessToken is the JWT access token",
+                    "description": "AccessToken is the JWT access token, omitted when the token is delivered in an HttpOnly cookie",
                     "type": "string",
                     "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
@@ -608,6 +5263,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 3600
                 },
+                "csrfToken": {
+                    "description": "CsrfToken must be echoed in the CSRF header on unsafe requests when using the cookie token mode",
+                    "type": "string",
+                    "example": "hTz4Yb1x0Jb6m6pQ2ZQ0cVbJkqZ2m1Qk3p8b3n0fL5E"
+                },
                 "tokenType": {
                     "description": "TokenType is the type of the token",
                     "type": "string",
@@ -615,6 +5275,197 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TopGreetingResponse": {
+            "description": "Top greeting dto",
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "message"
+            ],
+            "properties": {
+                "commentCount": {
+                    "description": "CommentCount is the number of comments on the greeting including replies, absent for greetings without comments",
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the greeting was created",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who created the greeting, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is the timestamp when the greeting was moved to the trash, absent for other greetings",
+                    "type": "string",
+                    "example": "2025-01-06T09:00:00Z"
+                },
+                "expireAt": {
+                    "description": "ExpireAt is the time the greeting disappears, absent for greetings which never expire",
+                    "type": "string",
+                    "example": "2025-02-06T08:00:00Z"
+                },
+                "id": {
+                    "description": "ID of the greeting",
+                    "type": "integer",
+                    "example": 1
+                },
+                "locale": {
+                    "description": "Locale is the BCP 47 language tag of the message",
+                    "type": "string",
+                    "example": "en"
+                },
+                "message": {
+                    "description": "Message is the greeting text",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Hello, World!"
+                },
+                "ownerId": {
+                    "description": "OwnerID is the user ID of the user who created the greeting",
+                    "type": "string",
+                    "example": "2"
+                },
+                "publishAt": {
+                    "description": "PublishAt is the time the greeting goes live, absent for greetings live from their creation",
+                    "type": "string",
+                    "example": "2025-01-06T08:00:00Z"
+                },
+                "reactions": {
+                    "description": "Reactions holds the number of reactions per type, e.g. like; absent for greetings without reactions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "like": 2,
+                        "love": 1
+                    }
+                },
+                "rejectionReason": {
+                    "description": "RejectionReason is the reason given by the moderator, present for rejected greetings",
+                    "type": "string",
+                    "example": "Contains a link"
+                },
+                "score": {
+                    "description": "Score is the number of reactions counted for the ranking",
+                    "type": "integer",
+                    "example": 5
+                },
+                "status": {
+                    "description": "Status is the moderation state: DRAFT, PENDING, APPROVED, REJECTED or ARCHIVED",
+                    "type": "string",
+                    "example": "APPROVED"
+                },
+                "tags": {
+                    "description": "Tags are the names of the tags of the greeting, sorted by name; absent for greetings without tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "christmas",
+                        "campaign-2025"
+                    ]
+                },
+                "tenantId": {
+                    "description": "TenantID is the organisation owning the greeting",
+                    "type": "string",
+                    "example": "default"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the greeting was last updated",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the greeting, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                },
+                "version": {
+                    "description": "Version is incremented by every update and sent as the ETag",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.UserResponse": {
+            "description": "User dto",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is the timestamp when the user was created",
+                    "type": "string",
+                    "example": "2025-01-05T10:00:00Z"
+                },
+                "createdBy": {
+                    "description": "CreatedBy is the user ID of the user who created the user, absent for records created before auditing",
+                    "type": "string",
+                    "example": "1"
+                },
+                "email": {
+                    "description": "Email of the user",
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "enabled": {
+                    "description": "Enabled tells whether the user can log in",
+                    "type": "boolean",
+                    "example": true
+                },
+                "firstName": {
+                    "description": "FirstName of the user",
+                    "type": "string",
+                    "example": "Admin"
+                },
+                "id": {
+                    "description": "ID of the user",
+                    "type": "string",
+                    "example": "a1b2c3d4-e5f6-7890-abcd-ef1234567890"
+                },
+                "lastName": {
+                    "description": "LastName of the user",
+                    "type": "string",
+                    "example": "User"
+                },
+                "roles": {
+                    "description": "Roles granted to the user",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ROLE_ADMIN"
+                    ]
+                },
+                "tenantId": {
+                    "description": "TenantID is the organisation of the user",
+                    "type": "string",
+                    "example": "default"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the timestamp when the user was last updated",
+                    "type": "string",
+                    "example": "2025-01-05T12:00:00Z"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the user ID of the user who last modified the user, or system for changes by scheduled jobs",
+                    "type": "string",
+                    "example": "1"
+                },
+                "username": {
+                    "description": "Username of the user",
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
         "dto.Violation": {
             "description": "Represents a single validation error for a field",
             "type": "object",
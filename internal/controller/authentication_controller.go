@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"gin-samples/internal/domain"
 	"gin-samples/internal/dto"
 	customError "gin-samples/internal/error"
+	"gin-samples/internal/security"
 	"gin-samples/internal/service"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
@@ -13,29 +15,39 @@ import (
 
 type AuthenticationController interface {
 	Login(c *gin.Context)
+	Logout(c *gin.Context)
 }
 
 type authenticationControllerImpl struct {
-	authService service.AuthenticationService
-	validator   *validator.Validate
-	trans       ut.Translator
+	authService   service.AuthenticationService
+	validator     *validator.Validate
+	trans         ut.Translator
+	cookieOptions security.CookieOptions
+	dpopVerifier  security.DPoPVerifier
 }
 
 // NewAuthenticationController creates a new instance of AuthenticationController
-func NewAuthenticationController(authService service.AuthenticationService, validator *validator.Validate, trans ut.Translator) AuthenticationController {
+// dpopVerifier may be nil, in which case DPoP proofs are ignored and plain Bearer tokens are issued.
+func NewAuthenticationController(authService service.AuthenticationService, validator *validator.Validate, trans ut.Translator,
+	cookieOptions security.CookieOptions, dpopVerifier security.DPoPVerifier) AuthenticationController {
 	return &authenticationControllerImpl{
-		authService: authService,
-		validator:   validator,
-		trans:       trans,
+		authService:   authService,
+		validator:     validator,
+		trans:         trans,
+		cookieOptions: cookieOptions,
+		dpopVerifier:  dpopVerifier,
 	}
 }
 
 // Login godoc
 // @Summary Authenticate user and generate token
-// @Description Validates user credentials and returns a JWT token
+// @Description Validates user credentials and returns a JWT token.
+// @Description In cookie token mode the token is set in an HttpOnly cookie instead and only the CSRF token is returned.
+// @Description When a DPoP proof is sent, the issued token is bound to its key and must be used with the DPoP scheme.
 // @Tags authentication
 // @Accept json
 // @Produce json
+// @Param DPoP header string false "DPoP proof JWT (RFC 9449)"
 // @Param input body dto.LoginInput true "Login Input"
 // @Success 200 {object} dto.TokenResponse
 // @Failure 400 {object} dto.ProblemDetail
@@ -58,13 +70,51 @@ func (a *authenticationControllerImpl) Login(c *gin.Context) {
 		return
 	}
 
+	// Verify the optional DPoP proof to bind the token to the client's key
+	var dpopJkt string
+	if proof := c.GetHeader(security.DPoPProofHeader); proof != "" && a.dpopVerifier != nil {
+		jkt, err := a.dpopVerifier.Verify(proof, c.Request.Method, a.dpopVerifier.RequestURL(c.Request), "")
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		dpopJkt = jkt
+	}
+
 	// Authenticate the user
-	tokenResponse, err := a.authService.Authenticate(input)
+	tokenResponse, principal, err := a.authService.Authenticate(input, dpopJkt)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	// Let the security audit middleware record the successful login under the user ID,
+	// like every other event of the authenticated user
+	c.Set("securityEvent", domain.SecurityEvent{
+		Type:      domain.SecurityEventLoginSuccess,
+		Principal: principal.UserID,
+		TenantID:  domain.TenantOrDefault(principal.TenantID),
+	})
+
+	// In cookie token mode, keep the access token out of reach of JavaScript
+	if a.cookieOptions.Enabled {
+		security.SetTokenCookies(c.Writer, a.cookieOptions,
+			tokenResponse.AccessToken, tokenResponse.CsrfToken, int(tokenResponse.AccessTokenExpiresIn))
+		tokenResponse.AccessToken = ""
+		tokenResponse.TokenType = "Cookie"
+	}
+
 	// Return the token response
 	c.JSON(http.StatusOK, tokenResponse)
 }
+
+// Logout godoc
+// @Summary Log out the current user
+// @Description Clears the access token and CSRF cookies set by the cookie token mode
+// @Tags authentication
+// @Success 204 "No Content"
+// @Router /api/auth/logout [post]
+func (a *authenticationControllerImpl) Logout(c *gin.Context) {
+	security.ClearTokenCookies(c.Writer, a.cookieOptions)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"bytes"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	"gin-samples/internal/security"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// MockAuthenticationService simulates the AuthenticationService
+type MockAuthenticationService struct {
+	mock.Mock
+}
+
+func (m *MockAuthenticationService) Authenticate(input dto.LoginInput,
+	dpopJkt string) (dto.TokenResponse, *service.AuthenticatedPrincipal, error) {
+	args := m.Called(input, dpopJkt)
+	principal, _ := args.Get(1).(*service.AuthenticatedPrincipal)
+	return args.Get(0).(dto.TokenResponse), principal, args.Error(2)
+}
+
+// testCookieOptions are the cookie token mode options used by the authentication controller tests
+var testCookieOptions = security.CookieOptions{
+	Enabled:        true,
+	Name:           "access_token",
+	CSRFCookieName: "XSRF-TOKEN",
+	CSRFHeaderName: "X-XSRF-TOKEN",
+	Path:           "/api",
+	Domain:         "example.com",
+	Secure:         true,
+	SameSite:       http.SameSiteStrictMode,
+}
+
+// findCookie returns the cookie with the given name set by the response
+func findCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestAuthenticationController_Login(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	input := dto.LoginInput{Username: "admin", Password: "password"}
+	tokenResponse := dto.TokenResponse{
+		AccessToken:          "access-token",
+		TokenType:            "Bearer",
+		AccessTokenExpiresIn: 3600,
+		CsrfToken:            "csrf-token",
+	}
+	principal := &service.AuthenticatedPrincipal{UserID: "1e7d07a7-896e-41a7-bb47-8ccedb9c9fc3", Username: "admin"}
+
+	tests := []struct {
+		name          string
+		cookieOptions security.CookieOptions
+		expectedBody  string
+	}{
+		{
+			name:          "Bearer mode",
+			cookieOptions: security.CookieOptions{},
+			expectedBody: `{"accessToken": "access-token", "tokenType": "Bearer", "accessTokenExpiresIn": 3600,
+				"csrfToken": "csrf-token"}`,
+		},
+		{
+			name:          "Cookie mode",
+			cookieOptions: testCookieOptions,
+			expectedBody:  `{"tokenType": "Cookie", "accessTokenExpiresIn": 3600, "csrfToken": "csrf-token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Mock Service
+			mockService := new(MockAuthenticationService)
+			mockService.On("Authenticate", input, "").Return(tokenResponse, principal, nil)
+
+			// Controller Setup
+			controller := NewAuthenticationController(mockService, validator.New(), nil, tt.cookieOptions, nil)
+			router := gin.Default()
+
+			// Capture the security event handed to the security audit middleware
+			var event domain.SecurityEvent
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				value, _ := c.Get("securityEvent")
+				event, _ = value.(domain.SecurityEvent)
+			})
+			router.POST("/api/auth/login", controller.Login)
+
+			req, _ := http.NewRequest("POST", "/api/auth/login",
+				bytes.NewBufferString(`{"username": "admin", "password": "password"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, domain.SecurityEvent{
+				Type:      domain.SecurityEventLoginSuccess,
+				Principal: "1e7d07a7-896e-41a7-bb47-8ccedb9c9fc3",
+				TenantID:  domain.DefaultTenant,
+			}, event)
+
+			if !tt.cookieOptions.Enabled {
+				assert.Empty(t, w.Result().Cookies())
+				return
+			}
+
+			// The access token is only readable by the browser, the CSRF token also by JavaScript
+			accessCookie := findCookie(w, "access_token")
+			if assert.NotNil(t, accessCookie) {
+				assert.Equal(t, "access-token", accessCookie.Value)
+				assert.True(t, accessCookie.HttpOnly)
+				assert.True(t, accessCookie.Secure)
+				assert.Equal(t, http.SameSiteStrictMode, accessCookie.SameSite)
+				assert.Equal(t, "/api", accessCookie.Path)
+				assert.Equal(t, "example.com", accessCookie.Domain)
+				assert.Equal(t, 3600, accessCookie.MaxAge)
+			}
+			csrfCookie := findCookie(w, "XSRF-TOKEN")
+			if assert.NotNil(t, csrfCookie) {
+				assert.Equal(t, "csrf-token", csrfCookie.Value)
+				assert.False(t, csrfCookie.HttpOnly)
+				assert.True(t, csrfCookie.Secure)
+				assert.Equal(t, http.SameSiteStrictMode, csrfCookie.SameSite)
+				assert.Equal(t, 3600, csrfCookie.MaxAge)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticationController_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Controller Setup
+	controller := NewAuthenticationController(new(MockAuthenticationService), validator.New(), nil, testCookieOptions, nil)
+	router := gin.Default()
+	router.POST("/api/auth/logout", controller.Logout)
+
+	req, _ := http.NewRequest("POST", "/api/auth/logout", nil)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: "access-token"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Both cookies are expired with the attributes they were set with
+	for _, name := range []string{"access_token", "XSRF-TOKEN"} {
+		cookie := findCookie(w, name)
+		if assert.NotNil(t, cookie, name) {
+			assert.Empty(t, cookie.Value)
+			assert.Negative(t, cookie.MaxAge)
+			assert.Equal(t, "/api", cookie.Path)
+			assert.Equal(t, "example.com", cookie.Domain)
+			assert.True(t, cookie.Secure)
+		}
+	}
+	assert.True(t, findCookie(w, "access_token").HttpOnly)
+}
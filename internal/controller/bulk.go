@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"fmt"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/middleware"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+// bindBulk reads the mode of a bulk operation and the JSON array of its items
+func bindBulk[I any](c *gin.Context, validate *validator.Validate) ([]I, string, error) {
+	var query dto.BulkQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return nil, "", &customError.MessageNotReadableError{Detail: err.Error()}
+	}
+	if err := validate.Struct(query); err != nil {
+		return nil, "", err
+	}
+
+	var items []I
+	if err := c.ShouldBindJSON(&items); err != nil {
+		return nil, "", &customError.MessageNotReadableError{Detail: err.Error()}
+	}
+
+	if len(items) == 0 || len(items) > dto.MaxBulkItems {
+		code := "max"
+		if len(items) == 0 {
+			code = "min"
+		}
+		return nil, "", customError.ConstraintViolationError{
+			Violations: []dto.Violation{{
+				Code:          code,
+				Field:         "items",
+				RejectedValue: fmt.Sprintf("%d", len(items)),
+				Message:       fmt.Sprintf("A bulk operation must have between 1 and %d items", dto.MaxBulkItems),
+			}},
+		}
+	}
+	return items, query.Mode, nil
+}
+
+// requireItemVersion fails items without a version when conditional updates are required (see middleware.ItemVersionMiddleware)
+func requireItemVersion(c *gin.Context, version *uint) error {
+	if version != nil || !c.GetBool(middleware.ItemVersionRequiredKey) {
+		return nil
+	}
+	return customError.ConstraintViolationError{
+		Violations: []dto.Violation{{
+			Code:          "required",
+			Field:         "version",
+			RejectedValue: "",
+			Message:       "The version the change is based on is required",
+		}},
+	}
+}
+
+// renderBulk writes the outcome of every item as a 207 Multi-Status response. Applied items have the
+// successStatus and their value as data, unless successStatus is 204; failed items have a problem detail.
+func renderBulk[R any](c *gin.Context, trans ut.Translator, mode string, results []service.BulkResult[R], successStatus int) {
+	response := dto.BulkResponse[R]{
+		Mode:    mode,
+		Results: make([]dto.BulkItemResult[R], len(results)),
+	}
+	for i, result := range results {
+		item := dto.BulkItemResult[R]{Index: i, Status: successStatus}
+		if result.Err != nil {
+			problem := middleware.ProblemDetailFor(c, result.Err, trans)
+			item.Status = problem.Status
+			item.Problem = &problem
+			response.Failed++
+		} else {
+			if successStatus != http.StatusNoContent {
+				value := result.Value
+				item.Data = &value
+			}
+			response.Succeeded++
+		}
+		response.Results[i] = item
+	}
+
+	c.JSON(http.StatusMultiStatus, response)
+}
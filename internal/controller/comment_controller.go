@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type CommentController interface {
+	GetComments(c *gin.Context)
+	CreateComment(c *gin.Context)
+	UpdateComment(c *gin.Context)
+	DeleteComment(c *gin.Context)
+	RemoveComment(c *gin.Context)
+}
+
+type commentControllerImpl struct {
+	commentService service.CommentService
+	validator      *validator.Validate
+}
+
+// NewCommentController creates a new instance of CommentController
+func NewCommentController(commentService service.CommentService, validator *validator.Validate) CommentController {
+	return &commentControllerImpl{
+		commentService: commentService,
+		validator:      validator,
+	}
+}
+
+// GetComments godoc
+// @Summary List the comments on a greeting message
+// @Description Returns a page of the comments on a greeting message, oldest first by default. Replies carry the ID of the comment they answer, and parentId lists the direct replies to one comment. Links to the neighbouring pages are returned in the Link header.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param page query int false "Zero-based page index" default(0) minimum(0)
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query []string false "Sort criteria in the format property[,asc|desc]; properties: id, createdAt, updatedAt" collectionFormat(multi)
+// @Param parentId query int false "Only list the direct replies to the comment" minimum(1)
+// @Success 200 {object} dto.PagedResponse[dto.CommentResponse]
+// @Header 200 {string} Link "Links to the first, prev, next and last pages"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/comments [get]
+func (cc *commentControllerImpl) GetComments(c *gin.Context) {
+	greetingID, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var query dto.CommentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := cc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	comments, err := cc.commentService.GetComments(c.Request.Context(), greetingID, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setPageLinks(c, comments.Page)
+	c.JSON(http.StatusOK, comments)
+}
+
+// CreateComment godoc
+// @Summary Comment on a greeting message
+// @Description Adds a comment of the caller to a greeting message, or a reply to one of its comments when parentId is given
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param input body dto.CommentInput true "Comment Input"
+// @Success 201 {object} dto.CommentResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/comments [post]
+func (cc *commentControllerImpl) CreateComment(c *gin.Context) {
+	greetingID, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input dto.CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := cc.validator.Struct(input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	comment, err := cc.commentService.CreateComment(c.Request.Context(), greetingID, input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment on a greeting message
+// @Description Changes the text of a comment. Only the author of the comment can edit it.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param commentId path int true "Comment ID"
+// @Param input body dto.CommentUpdateInput true "Comment Update Input"
+// @Success 200 {object} dto.CommentResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/comments/{commentId} [put]
+func (cc *commentControllerImpl) UpdateComment(c *gin.Context) {
+	greetingID, commentID, err := parseCommentPath(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input dto.CommentUpdateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := cc.validator.Struct(input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	comment, err := cc.commentService.UpdateComment(c.Request.Context(), greetingID, commentID, input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment on a greeting message
+// @Description Deletes a comment along with the replies to it. Only the author of the comment can delete it.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param commentId path int true "Comment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/comments/{commentId} [delete]
+func (cc *commentControllerImpl) DeleteComment(c *gin.Context) {
+	greetingID, commentID, err := parseCommentPath(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := cc.commentService.DeleteComment(c.Request.Context(), greetingID, commentID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveComment godoc
+// @Summary Remove any comment on a greeting message
+// @Description Deletes a comment of any user along with the replies to it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param commentId path int true "Comment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/{id}/comments/{commentId} [delete]
+func (cc *commentControllerImpl) RemoveComment(c *gin.Context) {
+	greetingID, commentID, err := parseCommentPath(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := cc.commentService.RemoveComment(c.Request.Context(), greetingID, commentID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseCommentPath parses the greeting and comment ID path parameters, reporting an invalid ID
+// as a ConstraintViolationError
+func parseCommentPath(c *gin.Context) (uint, uint, error) {
+	greetingID, err := parseGreetingID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	commentID, err := parsePathNumber(c, "commentId", "Comment ID")
+	if err != nil {
+		return 0, 0, err
+	}
+	return greetingID, commentID, nil
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// MockCommentService simulates the CommentService
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) GetComments(_ context.Context, greetingID uint,
+	query dto.CommentQuery) (dto.PagedResponse[dto.CommentResponse], error) {
+	args := m.Called(greetingID, query)
+	return args.Get(0).(dto.PagedResponse[dto.CommentResponse]), args.Error(1)
+}
+
+func (m *MockCommentService) CreateComment(_ context.Context, greetingID uint,
+	input dto.CommentInput) (dto.CommentResponse, error) {
+	args := m.Called(greetingID, input)
+	return args.Get(0).(dto.CommentResponse), args.Error(1)
+}
+
+func (m *MockCommentService) UpdateComment(_ context.Context, greetingID, id uint,
+	input dto.CommentUpdateInput) (dto.CommentResponse, error) {
+	args := m.Called(greetingID, id, input)
+	return args.Get(0).(dto.CommentResponse), args.Error(1)
+}
+
+func (m *MockCommentService) DeleteComment(_ context.Context, greetingID, id uint) error {
+	args := m.Called(greetingID, id)
+	return args.Error(0)
+}
+
+func (m *MockCommentService) RemoveComment(_ context.Context, greetingID, id uint) error {
+	args := m.Called(greetingID, id)
+	return args.Error(0)
+}
+
+func TestCommentController_GetComments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	parentID := uint(5)
+	createdAt := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	// Mock Service
+	mockService := new(MockCommentService)
+	mockService.On("GetComments", uint(1), dto.CommentQuery{Page: 0, Size: 1, ParentID: &parentID}).
+		Return(dto.PagedResponse[dto.CommentResponse]{
+			Content: []dto.CommentResponse{{
+				ID:         6,
+				GreetingID: 1,
+				ParentID:   &parentID,
+				AuthorID:   "3",
+				Body:       "Agreed",
+				CreatedAt:  createdAt,
+				UpdatedAt:  createdAt,
+			}},
+			Page: dto.PageMetadata{Number: 0, Size: 1, TotalElements: 2, TotalPages: 2},
+		}, nil)
+
+	// Controller Setup
+	controller := NewCommentController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/hello/:id/comments", controller.GetComments)
+
+	// Replies to a comment
+	req, _ := http.NewRequest("GET", "/api/hello/1/comments?size=1&parentId=5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"content": [{
+			"id": 6,
+			"greetingId": 1,
+			"parentId": 5,
+			"authorId": "3",
+			"body": "Agreed",
+			"createdAt": "2025-01-05T10:00:00Z",
+			"updatedAt": "2025-01-05T10:00:00Z"
+		}],
+		"page": {"number": 0, "size": 1, "totalElements": 2, "totalPages": 2}
+	}`, w.Body.String())
+	assert.Contains(t, w.Header().Get("Link"), `rel="next"`)
+
+	// Invalid page size
+	req, _ = http.NewRequest("GET", "/api/hello/1/comments?size=0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if assert.Len(t, errs, 1) {
+		assert.ErrorAs(t, errs[0].Err, new(validator.ValidationErrors))
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCommentController_ChangeComments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	parentID := uint(5)
+	createdAt := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	reply := dto.CommentResponse{
+		ID:         6,
+		GreetingID: 1,
+		ParentID:   &parentID,
+		AuthorID:   "3",
+		Body:       "Agreed",
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+	editedReply := reply
+	editedReply.Body = "Agreed, thanks!"
+
+	// Mock Service
+	mockService := new(MockCommentService)
+	mockService.On("CreateComment", uint(1), dto.CommentInput{Body: "Agreed", ParentID: &parentID}).Return(reply, nil)
+	mockService.On("UpdateComment", uint(1), uint(6), dto.CommentUpdateInput{Body: "Agreed, thanks!"}).
+		Return(editedReply, nil)
+	mockService.On("DeleteComment", uint(1), uint(6)).Return(nil)
+	mockService.On("DeleteComment", uint(1), uint(7)).
+		Return(&customError.AccessDeniedError{Message: "Only the author of the comment can change it"})
+	mockService.On("RemoveComment", uint(1), uint(7)).Return(nil)
+
+	// Controller Setup
+	controller := NewCommentController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.POST("/api/hello/:id/comments", controller.CreateComment)
+	router.PUT("/api/hello/:id/comments/:commentId", controller.UpdateComment)
+	router.DELETE("/api/hello/:id/comments/:commentId", controller.DeleteComment)
+	router.DELETE("/api/admin/hello/:id/comments/:commentId", controller.RemoveComment)
+
+	// Created reply
+	req, _ := http.NewRequest("POST", "/api/hello/1/comments", bytes.NewBufferString(`{"body": "Agreed", "parentId": 5}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"parentId":5`)
+
+	// Empty comments are rejected
+	req, _ = http.NewRequest("POST", "/api/hello/1/comments", bytes.NewBufferString(`{"body": ""}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if assert.Len(t, errs, 1) {
+		assert.ErrorAs(t, errs[0].Err, new(validator.ValidationErrors))
+	}
+
+	// Edited comment
+	req, _ = http.NewRequest("PUT", "/api/hello/1/comments/6", bytes.NewBufferString(`{"body": "Agreed, thanks!"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"body":"Agreed, thanks!"`)
+
+	// Invalid comment ID
+	req, _ = http.NewRequest("PUT", "/api/hello/1/comments/abc", bytes.NewBufferString(`{"body": "Agreed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var constraintErr customError.ConstraintViolationError
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &constraintErr) {
+		assert.Equal(t, "commentId", constraintErr.Violations[0].Field)
+	}
+
+	// Deleted own comment
+	req, _ = http.NewRequest("DELETE", "/api/hello/1/comments/6", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Comments of other users are only removed by admins
+	req, _ = http.NewRequest("DELETE", "/api/hello/1/comments/7", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if assert.Len(t, errs, 1) {
+		assert.ErrorAs(t, errs[0].Err, new(*customError.AccessDeniedError))
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/admin/hello/1/comments/7", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// notModified reports whether the representation cached by the client is still current. Following RFC 9110,
+// If-None-Match is compared weakly and takes precedence; If-Modified-Since is only evaluated without it.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have a resolution of one second
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// contentDigest returns a short hash of a JSON representation
+func contentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+// renderVersioned sends a version of a resource with a strong ETag of its version and content, e.g. "1-3.<digest>",
+// and its Last-Modified time, or 304 Not Modified when the client's copy is current. The digest covers data which
+// changes without a new version, like reaction and comment counts; the version still serves as If-Match
+// precondition, see ifMatchPrecondition. lastModified must likewise account for such data.
+func renderVersioned(c *gin.Context, id any, version uint, lastModified time.Time, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	renderWithETag(c, fmt.Sprintf(`"%v-%d.%s"`, id, version, contentDigest(body)), lastModified, body)
+}
+
+// renderWithContentETag sends the payload with a strong ETag derived from its JSON representation,
+// or 304 Not Modified when the client's copy is current. It suits listings, which have no single version.
+func renderWithContentETag(c *gin.Context, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	renderWithETag(c, `"`+contentDigest(body)+`"`, time.Time{}, body)
+}
+
+// renderWithETag sends the JSON body with its validators, or 304 Not Modified when the client's copy is current.
+// A zero lastModified omits the Last-Modified header.
+func renderWithETag(c *gin.Context, etag string, lastModified time.Time, body []byte) {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
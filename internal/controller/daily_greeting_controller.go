@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"fmt"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"time"
+)
+
+type DailyGreetingController interface {
+	GetDailyGreeting(c *gin.Context)
+	PinDailyGreeting(c *gin.Context)
+	UnpinDailyGreeting(c *gin.Context)
+}
+
+type dailyGreetingControllerImpl struct {
+	dailyGreetingService service.DailyGreetingService
+	validator            *validator.Validate
+}
+
+// NewDailyGreetingController creates a new instance of DailyGreetingController
+func NewDailyGreetingController(dailyGreetingService service.DailyGreetingService,
+	validator *validator.Validate) DailyGreetingController {
+	return &dailyGreetingControllerImpl{
+		dailyGreetingService: dailyGreetingService,
+		validator:            validator,
+	}
+}
+
+// GetDailyGreeting godoc
+// @Summary Get the greeting of the day
+// @Description Returns the greeting message of the current day in the time zone of the caller. The greeting pinned by an admin for the date comes first; otherwise, a live greeting is chosen by the date, so all callers get the same greeting during the day. Templated messages are rendered for the caller.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param timeZone query string false "IANA time zone of the caller, which decides the date" example(Europe/Istanbul)
+// @Success 200 {object} dto.DailyGreetingResponse
+// @Header 200 {string} Content-Language "Locale of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/daily [get]
+func (dc *dailyGreetingControllerImpl) GetDailyGreeting(c *gin.Context) {
+	var query dto.GreetingRenderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := dc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greeting, err := dc.dailyGreetingService.GetDailyGreeting(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Language", greeting.Locale)
+	c.JSON(http.StatusOK, greeting)
+}
+
+// PinDailyGreeting godoc
+// @Summary Pin the greeting of the day
+// @Description Pins a greeting message as greeting of the day for a date, replacing the greeting pinned before. A pinned greeting which is not live on the date is skipped.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date path string true "Date in the format 2006-01-02" example(2025-01-05)
+// @Param input body dto.GreetingPinInput true "Greeting to pin"
+// @Success 200 {object} dto.DailyGreetingResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/daily/{date} [put]
+func (dc *dailyGreetingControllerImpl) PinDailyGreeting(c *gin.Context) {
+	date, err := parsePathDate(c, "date")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input dto.GreetingPinInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := dc.validator.Struct(input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greeting, err := dc.dailyGreetingService.PinDailyGreeting(c.Request.Context(), date, input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, greeting)
+}
+
+// UnpinDailyGreeting godoc
+// @Summary Unpin the greeting of the day
+// @Description Removes the greeting message pinned for a date, so the greeting of the day is chosen again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param date path string true "Date in the format 2006-01-02" example(2025-01-05)
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/daily/{date} [delete]
+func (dc *dailyGreetingControllerImpl) UnpinDailyGreeting(c *gin.Context) {
+	date, err := parsePathDate(c, "date")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := dc.dailyGreetingService.UnpinDailyGreeting(c.Request.Context(), date); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parsePathDate parses a path parameter holding a calendar date in the format 2006-01-02, reporting an invalid date
+// as a ConstraintViolationError
+func parsePathDate(c *gin.Context, param string) (string, error) {
+	value := c.Param(param)
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		return "", customError.ConstraintViolationError{
+			Violations: []dto.Violation{{
+				Code:          "datetime",
+				Field:         param,
+				RejectedValue: value,
+				Message:       fmt.Sprintf("%s must be a valid date in the format 2006-01-02", param),
+			}},
+		}
+	}
+	return value, nil
+}
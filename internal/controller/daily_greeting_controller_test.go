@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// MockDailyGreetingService simulates the DailyGreetingService
+type MockDailyGreetingService struct {
+	mock.Mock
+}
+
+func (m *MockDailyGreetingService) GetDailyGreeting(_ context.Context,
+	query dto.GreetingRenderQuery) (dto.DailyGreetingResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.DailyGreetingResponse), args.Error(1)
+}
+
+func (m *MockDailyGreetingService) PinDailyGreeting(_ context.Context, date string, input dto.GreetingPinInput) (dto.DailyGreetingResponse, error) {
+	args := m.Called(date, input)
+	return args.Get(0).(dto.DailyGreetingResponse), args.Error(1)
+}
+
+func (m *MockDailyGreetingService) UnpinDailyGreeting(_ context.Context, date string) error {
+	args := m.Called(date)
+	return args.Error(0)
+}
+
+func TestDailyGreetingController_GetDailyGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockDailyGreetingService)
+	query := dto.GreetingRenderQuery{TimeZone: "Asia/Tokyo"}
+	mockService.On("GetDailyGreeting", query).Return(dto.DailyGreetingResponse{
+		GreetingResponse: dto.GreetingResponse{
+			ID:        1,
+			Message:   "Hello, World!",
+			Locale:    "en",
+			Version:   1,
+			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		},
+		Date:   "2025-01-06",
+		Pinned: true,
+	}, nil)
+
+	// Controller Setup
+	controller := NewDailyGreetingController(mockService, validator.New())
+	router := gin.Default()
+	router.GET("/api/hello/daily", controller.GetDailyGreeting)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/hello/daily?timeZone=Asia/Tokyo", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	assert.JSONEq(t, `{
+		"id": 1,
+		"message": "Hello, World!",
+		"locale": "en",
+		"version": 1,
+		"createdAt": "2025-01-05T10:00:00Z",
+		"updatedAt": "2025-01-05T10:00:00Z",
+		"date": "2025-01-06",
+		"pinned": true
+	}`, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDailyGreetingController_PinDailyGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockDailyGreetingService)
+	mockService.On("PinDailyGreeting", "2025-01-06", dto.GreetingPinInput{GreetingID: 1}).
+		Return(dto.DailyGreetingResponse{
+			GreetingResponse: dto.GreetingResponse{ID: 1, Message: "Hello, World!", Locale: "en", Version: 1},
+			Date:             "2025-01-06",
+			Pinned:           true,
+		}, nil)
+	mockService.On("UnpinDailyGreeting", "2025-01-06").Return(nil)
+
+	// Controller Setup
+	controller := NewDailyGreetingController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.PUT("/api/admin/hello/daily/:date", controller.PinDailyGreeting)
+	router.DELETE("/api/admin/hello/daily/:date", controller.UnpinDailyGreeting)
+
+	// Pinned greeting
+	req, _ := http.NewRequest("PUT", "/api/admin/hello/daily/2025-01-06", bytes.NewBufferString(`{"greetingId":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pinned":true`)
+
+	// Unpinned greeting
+	req, _ = http.NewRequest("DELETE", "/api/admin/hello/daily/2025-01-06", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Invalid date
+	req, _ = http.NewRequest("PUT", "/api/admin/hello/daily/2025-02-30", bytes.NewBufferString(`{"greetingId":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var constraintErr customError.ConstraintViolationError
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &constraintErr) {
+		assert.Equal(t, "date", constraintErr.Violations[0].Field)
+		assert.Equal(t, "2025-02-30", constraintErr.Violations[0].RejectedValue)
+	}
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"fmt"
+	"gin-samples/internal/dto"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+)
+
+// entityTag returns the strong entity tag of a version of a resource
+func entityTag(id any, version uint) string {
+	return fmt.Sprintf(`"%v-%d"`, id, version)
+}
+
+// setETag sends the entity tag of the resource version in the response
+func setETag(c *gin.Context, id any, version uint) {
+	c.Header("ETag", entityTag(id, version))
+}
+
+// ifMatchPrecondition returns the versions of the resource accepted by the If-Match header,
+// or nil when the request is unconditional or matches any current version ("*"). The content digest of the
+// entity tags sent by reads, see renderVersioned, is ignored. Entity tags are compared strongly (RFC 9110),
+// so weak tags never match.
+func ifMatchPrecondition(c *gin.Context, id any) *dto.VersionPrecondition {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	precondition := &dto.VersionPrecondition{Versions: []uint{}}
+	prefix := fmt.Sprintf("%v-", id)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil
+		}
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		if value, found := strings.CutPrefix(tag[1:len(tag)-1], prefix); found {
+			value, _, _ = strings.Cut(value, ".")
+			if version, err := strconv.ParseUint(value, 10, 0); err == nil {
+				precondition.Versions = append(precondition.Versions, uint(version))
+			}
+		}
+	}
+	return precondition
+}
@@ -0,0 +1,429 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Media types of greeting exports and imports
+const (
+	MediaTypeJSON   = "application/json"
+	MediaTypeNDJSON = "application/x-ndjson"
+	MediaTypeCSV    = "text/csv"
+)
+
+// transferMediaTypes lists the export and import formats, the default first
+var transferMediaTypes = []string{MediaTypeJSON, MediaTypeNDJSON, MediaTypeCSV}
+
+// transferFileExtensions maps file extensions to the import formats, for uploads without a specific content type
+var transferFileExtensions = map[string]string{
+	".json":   MediaTypeJSON,
+	".ndjson": MediaTypeNDJSON,
+	".jsonl":  MediaTypeNDJSON,
+	".csv":    MediaTypeCSV,
+}
+
+// exportFileExtensions holds the file extension suggested for an export in each format
+var exportFileExtensions = map[string]string{
+	MediaTypeJSON:   "json",
+	MediaTypeNDJSON: "ndjson",
+	MediaTypeCSV:    "csv",
+}
+
+// Limits of a greeting import
+const (
+	MaxImportFileSize = 10 << 20
+	MaxImportRows     = 10000
+)
+
+// greetingCSVHeader holds the columns of a CSV export. Imports read message, locale, publishAt, expireAt and tags.
+// The tags are a comma separated list in one cell, quoted like CSV fields where needed.
+var greetingCSVHeader = []string{"id", "message", "locale", "version", "createdAt", "updatedAt", "publishAt", "expireAt", "tags"}
+
+// csvFormulaPrefixes are the leading characters which make spreadsheets evaluate a cell as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVCell prefixes a cell which a spreadsheet would evaluate as a formula with a quote, so that it is shown as text.
+// Cells starting with a quote are prefixed as well, so that unescapeCSVCell restores every exported message.
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes+"'", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVCell removes the quote escapeCSVCell prefixed a cell with
+func unescapeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes+"'", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// greetingImportRecord holds the fields read from an imported JSON or NDJSON row; other fields are ignored
+type greetingImportRecord struct {
+	Message   string     `json:"message"`
+	Locale    string     `json:"locale"`
+	PublishAt *time.Time `json:"publishAt"`
+	ExpireAt  *time.Time `json:"expireAt"`
+	Tags      []string   `json:"tags"`
+}
+
+// greetingEncoder streams greetings in an export format. Nothing is written before the first greeting,
+// so the response status can still change if the export fails early.
+type greetingEncoder interface {
+	Encode(greetings []dto.GreetingResponse) error
+	// Close completes the export, also when no greeting was encoded
+	Close() error
+}
+
+// newGreetingEncoder returns the encoder of the media type
+func newGreetingEncoder(mediaType string, w http.ResponseWriter) greetingEncoder {
+	switch mediaType {
+	case MediaTypeCSV:
+		return &csvGreetingEncoder{w: w, csv: csv.NewWriter(w)}
+	case MediaTypeNDJSON:
+		return &ndjsonGreetingEncoder{w: w, json: json.NewEncoder(w)}
+	default:
+		return &jsonGreetingEncoder{w: w}
+	}
+}
+
+// flush sends the written part of a streamed response to the client
+func flush(w http.ResponseWriter) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// jsonGreetingEncoder writes a JSON array, one greeting at a time
+type jsonGreetingEncoder struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (e *jsonGreetingEncoder) Encode(greetings []dto.GreetingResponse) error {
+	for _, greeting := range greetings {
+		element, err := json.Marshal(greeting)
+		if err != nil {
+			return err
+		}
+
+		separator := ","
+		if !e.started {
+			separator = "["
+			e.started = true
+		}
+		if _, err := io.WriteString(e.w, separator); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(element); err != nil {
+			return err
+		}
+	}
+	flush(e.w)
+	return nil
+}
+
+func (e *jsonGreetingEncoder) Close() error {
+	end := "]"
+	if !e.started {
+		end = "[]"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// ndjsonGreetingEncoder writes one JSON object per line
+type ndjsonGreetingEncoder struct {
+	w    http.ResponseWriter
+	json *json.Encoder
+}
+
+func (e *ndjsonGreetingEncoder) Encode(greetings []dto.GreetingResponse) error {
+	for _, greeting := range greetings {
+		if err := e.json.Encode(greeting); err != nil {
+			return err
+		}
+	}
+	flush(e.w)
+	return nil
+}
+
+func (e *ndjsonGreetingEncoder) Close() error {
+	return nil
+}
+
+// csvGreetingEncoder writes a header line and one line per greeting. Messages are escaped with escapeCSVCell,
+// as they are user content which must not run as formulas when the export is opened in a spreadsheet.
+type csvGreetingEncoder struct {
+	w       http.ResponseWriter
+	csv     *csv.Writer
+	started bool
+}
+
+func (e *csvGreetingEncoder) Encode(greetings []dto.GreetingResponse) error {
+	if !e.started {
+		e.started = true
+		if err := e.csv.Write(greetingCSVHeader); err != nil {
+			return err
+		}
+	}
+	for _, greeting := range greetings {
+		if err := e.csv.Write([]string{
+			strconv.FormatUint(uint64(greeting.ID), 10),
+			escapeCSVCell(greeting.Message),
+			greeting.Locale,
+			strconv.FormatUint(uint64(greeting.Version), 10),
+			greeting.CreatedAt.Format(time.RFC3339Nano),
+			greeting.UpdatedAt.Format(time.RFC3339Nano),
+			formatCSVTime(greeting.PublishAt),
+			formatCSVTime(greeting.ExpireAt),
+			escapeCSVCell(joinCSVTags(greeting.Tags)),
+		}); err != nil {
+			return err
+		}
+	}
+	e.csv.Flush()
+	flush(e.w)
+	return e.csv.Error()
+}
+
+func (e *csvGreetingEncoder) Close() error {
+	if !e.started {
+		return e.Encode(nil)
+	}
+	return nil
+}
+
+// formatCSVTime formats an optional time of a CSV cell, which is empty without a time
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// parseCSVTime parses an optional time of a CSV cell, returning nil for an empty cell
+func parseCSVTime(cell string) (*time.Time, error) {
+	if cell = strings.TrimSpace(cell); cell == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, cell)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// joinCSVTags joins tag names into one cell, quoting names which contain commas or quotes like CSV fields
+func joinCSVTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	var cell strings.Builder
+	writer := csv.NewWriter(&cell)
+	_ = writer.Write(tags) // Writing to a strings.Builder cannot fail
+	writer.Flush()
+	return strings.TrimSuffix(cell.String(), "\n")
+}
+
+// splitCSVTags splits a cell written by joinCSVTags into tag names, returning no names for an empty cell
+func splitCSVTags(cell string) ([]string, error) {
+	if strings.TrimSpace(cell) == "" {
+		return []string{}, nil
+	}
+	reader := csv.NewReader(strings.NewReader(cell))
+	reader.TrimLeadingSpace = true
+	return reader.Read()
+}
+
+// importMediaType returns the import format of an uploaded file, from its content type or else its extension
+func importMediaType(file *multipart.FileHeader) (string, error) {
+	contentType := file.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		for _, supported := range transferMediaTypes {
+			if mediaType == supported {
+				return mediaType, nil
+			}
+		}
+	}
+	if mediaType, found := transferFileExtensions[strings.ToLower(filepath.Ext(file.Filename))]; found {
+		return mediaType, nil
+	}
+	return "", &customError.UnsupportedMediaTypeError{MediaType: contentType, Supported: transferMediaTypes}
+}
+
+// decodeGreetingRows reads the rows of an import file. Rows which cannot be read are returned with an error;
+// a file which cannot be read at all, e.g. a CSV file without a message column, fails the import.
+func decodeGreetingRows(mediaType string, data []byte) ([]service.GreetingImportRow, error) {
+	switch mediaType {
+	case MediaTypeCSV:
+		return decodeCSVRows(data)
+	case MediaTypeNDJSON:
+		return decodeNDJSONRows(data)
+	default:
+		return decodeJSONRows(data)
+	}
+}
+
+// decodeCSVRows reads a CSV file whose header line names the message and, optionally, the locale, publishAt,
+// expireAt and tags columns. Without a tags column, the tags of overwritten greetings are kept.
+func decodeCSVRows(data []byte) ([]service.GreetingImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	messageColumn, found := columns["message"]
+	if !found {
+		return nil, errors.New("the CSV header has no message column")
+	}
+
+	var rows []service.GreetingImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, service.GreetingImportRow{
+				Line: parseErr.StartLine,
+				Err:  &customError.MessageNotReadableError{Detail: parseErr.Error()},
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CSV file: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := service.GreetingImportRow{Line: line}
+		if messageColumn >= len(record) {
+			row.Err = &customError.MessageNotReadableError{Detail: fmt.Sprintf("line %d has no message column", line)}
+		} else {
+			row.Input, row.Err = decodeCSVRecord(line, record, columns)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// decodeCSVRecord reads the greeting of a CSV line from the named columns. Missing columns are left empty.
+func decodeCSVRecord(line int, record []string, columns map[string]int) (dto.GreetingInput, error) {
+	cell := func(name string) (string, bool) {
+		i, found := columns[name]
+		if !found || i >= len(record) {
+			return "", false
+		}
+		return record[i], true
+	}
+
+	var input dto.GreetingInput
+	input.Message, _ = cell("message")
+	input.Message = unescapeCSVCell(input.Message)
+	input.Locale, _ = cell("locale")
+
+	invalid := func(column string, err error) error {
+		return &customError.MessageNotReadableError{Detail: fmt.Sprintf("line %d has an invalid %s: %v", line, column, err)}
+	}
+	var err error
+	publishAt, _ := cell("publishat")
+	if input.PublishAt, err = parseCSVTime(publishAt); err != nil {
+		return dto.GreetingInput{}, invalid("publishAt", err)
+	}
+	expireAt, _ := cell("expireat")
+	if input.ExpireAt, err = parseCSVTime(expireAt); err != nil {
+		return dto.GreetingInput{}, invalid("expireAt", err)
+	}
+	if tags, found := cell("tags"); found {
+		if input.Tags, err = splitCSVTags(unescapeCSVCell(tags)); err != nil {
+			return dto.GreetingInput{}, invalid("tags cell", err)
+		}
+	}
+	return input, nil
+}
+
+// decodeNDJSONRows reads one JSON object per line, ignoring blank lines
+func decodeNDJSONRows(data []byte) ([]service.GreetingImportRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxImportFileSize)
+
+	var rows []service.GreetingImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		rows = append(rows, decodeJSONRow(line, text))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the NDJSON file: %w", err)
+	}
+	return rows, nil
+}
+
+// decodeJSONRows reads a JSON array of objects, locating each object by the line it starts at
+func decodeJSONRows(data []byte) ([]service.GreetingImportRow, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("the JSON file must contain an array")
+	}
+
+	var rows []service.GreetingImportRow
+	line, counted := 1, 0
+	for decoder.More() {
+		// The element starts after the separator following the previous one
+		start := int(decoder.InputOffset())
+		for start < len(data) && strings.ContainsRune(", \t\r\n", rune(data[start])) {
+			start++
+		}
+		line += bytes.Count(data[counted:start], []byte("\n"))
+		counted = start
+
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return nil, fmt.Errorf("failed to read the JSON file at line %d: %w", line, err)
+		}
+		rows = append(rows, decodeJSONRow(line, element))
+	}
+	return rows, nil
+}
+
+// decodeJSONRow reads the greeting of a JSON object
+func decodeJSONRow(line int, data []byte) service.GreetingImportRow {
+	var record greetingImportRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return service.GreetingImportRow{
+			Line: line,
+			Err:  &customError.MessageNotReadableError{Detail: err.Error()},
+		}
+	}
+	return service.GreetingImportRow{
+		Line: line,
+		Input: dto.GreetingInput{Message: record.Message, Locale: record.Locale,
+			PublishAt: record.PublishAt, ExpireAt: record.ExpireAt, Tags: record.Tags},
+	}
+}
@@ -1,11 +1,15 @@
 package controller
 
 import (
+	"fmt"
 	"gin-samples/internal/dto"
 	customError "gin-samples/internal/error"
+	"gin-samples/internal/middleware"
 	"gin-samples/internal/service"
+	"gin-samples/internal/util"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -16,9 +20,22 @@ type HelloController interface {
 	Hello(c *gin.Context)
 	CreateGreeting(c *gin.Context)
 	GetAllGreetings(c *gin.Context)
+	GetGreetingsByCursor(c *gin.Context)
+	SearchGreetings(c *gin.Context)
 	GetGreetingByID(c *gin.Context)
+	RenderGreeting(c *gin.Context)
+	GetRandomGreeting(c *gin.Context)
 	UpdateGreeting(c *gin.Context)
+	PatchGreeting(c *gin.Context)
 	DeleteGreeting(c *gin.Context)
+	GetDeletedGreetings(c *gin.Context)
+	RestoreGreeting(c *gin.Context)
+	PurgeGreeting(c *gin.Context)
+	BulkCreateGreetings(c *gin.Context)
+	BulkUpdateGreetings(c *gin.Context)
+	BulkDeleteGreetings(c *gin.Context)
+	ExportGreetings(c *gin.Context)
+	ImportGreetings(c *gin.Context)
 }
 
 type helloControllerImpl struct {
@@ -39,18 +56,83 @@ func NewHelloController(service service.HelloService,
 
 // Hello godoc
 // @Summary Get a greeting message
-// @Description Returns a greeting message
+// @Description Returns a greeting message in the best matching language, e.g. pt-BR falls back to pt and then to the default locale. The most recently updated live template of that language is rendered for the caller; without one, a static message is returned.
 // @Tags hello
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Accept-Language header string false "Preferred languages" example(pt-BR, en;q=0.8)
+// @Param timeZone query string false "IANA time zone of the caller" example(Europe/Istanbul)
 // @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} Content-Language "Locale of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
 // @Failure 401 {object} dto.ProblemDetail
 // @Failure 403 {object} dto.ProblemDetail
 // @Failure 500 {object} dto.ProblemDetail
 // @Router /api/hello [get]
 func (h *helloControllerImpl) Hello(c *gin.Context) {
-	greeting := h.HelloService.GetGreeting()
+	var query dto.GreetingRenderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greeting, err := h.HelloService.GetGreeting(c.Request.Context(), acceptedLanguages(c), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Language", greeting.Locale)
+	c.JSON(http.StatusOK, greeting)
+}
+
+// GetRandomGreeting godoc
+// @Summary Get a random greeting message
+// @Description Returns a live greeting message picked at random, optionally among the greetings in a locale or with tags. Templated messages are rendered for the caller.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param locale query string false "Locale of the greeting" example(en)
+// @Param tag query []string false "Tag names" collectionFormat(multi)
+// @Param tagMatch query string false "Whether the greeting needs any or all of the tags" Enums(any, all) default(any)
+// @Param timeZone query string false "IANA time zone of the caller" example(Europe/Istanbul)
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} Content-Language "Locale of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/random [get]
+func (h *helloControllerImpl) GetRandomGreeting(c *gin.Context) {
+	var query dto.RandomGreetingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greeting, err := h.HelloService.GetRandomGreeting(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Language", greeting.Locale)
 	c.JSON(http.StatusOK, greeting)
 }
 
@@ -63,6 +145,7 @@ func (h *helloControllerImpl) Hello(c *gin.Context) {
 // @Security BearerAuth
 // @Param input body dto.GreetingInput true "Greeting Input"
 // @Success 201 {object} dto.GreetingResponse
+// @Header 201 {string} ETag "Version of the greeting"
 // @Failure 400 {object} dto.ProblemDetail
 // @Failure 401 {object} dto.ProblemDetail
 // @Failure 409 {object} dto.ProblemDetail
@@ -83,36 +166,165 @@ func (h *helloControllerImpl) CreateGreeting(c *gin.Context) {
 		return
 	}
 
-	newGreeting, err := h.HelloService.CreateGreeting(input)
+	newGreeting, err := h.HelloService.CreateGreeting(c.Request.Context(), input)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, newGreeting.ID, newGreeting.Version)
 	c.JSON(http.StatusCreated, newGreeting)
 }
 
 // GetAllGreetings godoc
-// @Summary Get all greeting messages
-// @Description Returns all greeting messages
+// @Summary Get a page of greeting messages
+// @Description Returns a page of greeting messages matching the filters, in the best matching locale having greetings. Links to the neighbouring pages are returned in the Link header.
 // @Tags hello
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} dto.GreetingResponse
+// @Param Accept-Language header string false "Preferred languages" example(pt-BR, en;q=0.8)
+// @Param page query int false "Zero-based page index" default(0) minimum(0)
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query []string false "Sort criteria in the format property[,asc|desc]; properties: id, message, createdAt, updatedAt" collectionFormat(multi)
+// @Param message query string false "Message contains (case-insensitive)"
+// @Param createdBefore query string false "Created before (RFC 3339)"
+// @Param createdAfter query string false "Created after (RFC 3339)"
+// @Param tag query []string false "Tag names" collectionFormat(multi)
+// @Param tagMatch query string false "Whether greetings need any or all of the tags" Enums(any, all) default(any)
+// @Param If-None-Match header string false "ETag of the cached representation"
+// @Success 200 {object} dto.PagedResponse[dto.GreetingResponse]
+// @Header 200 {string} Link "Links to the first, prev, next and last pages"
+// @Header 200 {string} Content-Language "Locale of the listed greetings"
+// @Header 200 {string} ETag "Hash of the page content"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} dto.ProblemDetail
 // @Failure 401 {object} dto.ProblemDetail
 // @Failure 500 {object} dto.ProblemDetail
 // @Router /api/hello/all [get]
 func (h *helloControllerImpl) GetAllGreetings(c *gin.Context) {
-	greetings, err := h.HelloService.GetAllGreetings()
+	var query dto.GreetingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	locale, err := h.HelloService.ResolveLocale(c.Request.Context(), acceptedLanguages(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
+	query.Locale = locale
+
+	greetings, err := h.HelloService.GetAllGreetings(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+	setPageLinks(c, greetings.Page)
+	renderWithContentETag(c, greetings)
+}
+
+// GetGreetingsByCursor godoc
+// @Summary Scroll through greeting messages
+// @Description Returns a page of greeting messages in the best matching locale having greetings, using keyset pagination. Follow the next and prev cursors to scroll; pages do not shift when greetings are added.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Accept-Language header string false "Preferred languages" example(pt-BR, en;q=0.8)
+// @Param cursor query string false "Cursor of the requested page"
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort criterion in the format property[,asc|desc] for the first page; properties: id, message, createdAt, updatedAt"
+// @Success 200 {object} dto.CursorPagedResponse[dto.GreetingResponse]
+// @Header 200 {string} Link "Links to the prev and next pages"
+// @Header 200 {string} Content-Language "Locale of the listed greetings"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/all/cursor [get]
+func (h *helloControllerImpl) GetGreetingsByCursor(c *gin.Context) {
+	var query dto.CursorQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
 
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	locale, err := h.HelloService.ResolveLocale(c.Request.Context(), acceptedLanguages(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greetings, err := h.HelloService.GetGreetingsByCursor(c.Request.Context(), query, locale)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Language", locale)
+
+	setCursorLinks(c, greetings.Next, greetings.Prev)
 	c.JSON(http.StatusOK, greetings)
 }
 
+// SearchGreetings godoc
+// @Summary Search greeting messages
+// @Description Full-text search over greeting messages, most relevant first. Matches words and "quoted phrases"; a word ending with * matches as a prefix. Links to the neighbouring pages are returned in the Link header.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Words and phrases to search for" maxLength(200)
+// @Param page query int false "Zero-based page index" default(0) minimum(0)
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Success 200 {object} dto.PagedResponse[dto.GreetingSearchResponse]
+// @Header 200 {string} Link "Links to the first, prev, next and last pages"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/search [get]
+func (h *helloControllerImpl) SearchGreetings(c *gin.Context) {
+	var query dto.GreetingSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results, err := h.HelloService.SearchGreetings(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setPageLinks(c, results.Page)
+	c.JSON(http.StatusOK, results)
+}
+
 // GetGreetingByID godoc
 // @Summary Get a greeting by ID
 // @Description Returns a single greeting message by its ID
@@ -121,7 +333,12 @@ func (h *helloControllerImpl) GetAllGreetings(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Greeting ID"
+// @Param If-None-Match header string false "ETag of the cached representation"
+// @Param If-Modified-Since header string false "Time the cached representation was last modified"
 // @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting with a hash of its content"
+// @Header 200 {string} Last-Modified "Time of the last update of the greeting, its reactions or its comments"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} dto.ProblemDetail
 // @Failure 401 {object} dto.ProblemDetail
 // @Failure 404 {object} dto.ProblemDetail
@@ -146,7 +363,51 @@ func (h *helloControllerImpl) GetGreetingByID(c *gin.Context) {
 		return
 	}
 
-	greeting, err := h.HelloService.GetGreetingByID(uint(id))
+	greeting, err := h.HelloService.GetGreetingByID(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderVersioned(c, greeting.ID, greeting.Version, greeting.LastModified, greeting)
+}
+
+// RenderGreeting godoc
+// @Summary Render a greeting for the caller
+// @Description Renders the placeholders of a greeting message, e.g. {{.FirstName}} or {{timeOfDay}}, for the authenticated user at the request time. Messages without placeholders are returned as they are.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param timeZone query string false "IANA time zone of the caller" example(Europe/Istanbul)
+// @Success 200 {object} dto.RenderedGreetingResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/render [get]
+func (h *helloControllerImpl) RenderGreeting(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var query dto.GreetingRenderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greeting, err := h.HelloService.RenderGreeting(c.Request.Context(), id, query)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -164,10 +425,16 @@ func (h *helloControllerImpl) GetGreetingByID(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Greeting ID"
 // @Param input body dto.GreetingInput true "Greeting Input"
+// @Param If-Match header string false "ETag of the greeting version the change is based on"
 // @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
 // @Failure 400 {object} dto.ProblemDetail
 // @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
 // @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 412 {object} dto.ProblemDetail
+// @Failure 428 {object} dto.ProblemDetail
 // @Failure 500 {object} dto.ProblemDetail
 // @Router /api/hello/{id} [put]
 func (h *helloControllerImpl) UpdateGreeting(c *gin.Context) {
@@ -203,27 +470,108 @@ func (h *helloControllerImpl) UpdateGreeting(c *gin.Context) {
 	}
 
 	// Call service to update the greeting
-	updatedGreeting, err := h.HelloService.UpdateGreeting(uint(id), input)
+	updatedGreeting, err := h.HelloService.UpdateGreeting(c.Request.Context(), uint(id), input, ifMatchPrecondition(c, id))
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, updatedGreeting.ID, updatedGreeting.Version)
 	c.JSON(http.StatusOK, updatedGreeting)
 }
 
+// PatchGreeting godoc
+// @Summary Patch a greeting message by ID
+// @Description Applies a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to a greeting message. The patched greeting is validated like a full update; removing the locale resets it to the default locale.
+// @Tags hello
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param patch body dto.GreetingInput true "Merge patch, or an array of JSON Patch operations on /message and /locale"
+// @Param If-Match header string false "ETag of the greeting version the change is based on"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 412 {object} dto.ProblemDetail
+// @Failure 415 {object} dto.ProblemDetail
+// @Failure 422 {object} dto.ProblemDetail
+// @Failure 428 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id} [patch]
+func (h *helloControllerImpl) PatchGreeting(c *gin.Context) {
+	// Parse and validate ID from path
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil || id < 1 {
+		violation := dto.Violation{
+			Code:          "min",
+			Field:         "id",
+			RejectedValue: idParam,
+			Message:       "ID must be a valid integer greater than or equal to 1",
+		}
+		constraintErr := customError.ConstraintViolationError{
+			Violations: []dto.Violation{violation},
+		}
+		_ = c.Error(constraintErr)
+		return
+	}
+
+	// Reject unsupported patch formats before looking up the greeting
+	if err := checkPatchMediaType(c); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{Detail: err.Error()})
+		return
+	}
+
+	// The patch is applied to the updatable fields of the greeting and validated like a full update
+	patch := func(document dto.GreetingInput) (dto.GreetingInput, error) {
+		var input dto.GreetingInput
+		if err := applyPatch(c.ContentType(), body, document, &input); err != nil {
+			return dto.GreetingInput{}, err
+		}
+		if err := h.Validator.Struct(input); err != nil {
+			return dto.GreetingInput{}, err
+		}
+		return input, nil
+	}
+
+	patchedGreeting, err := h.HelloService.PatchGreeting(c.Request.Context(), uint(id), patch, ifMatchPrecondition(c, id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, patchedGreeting.ID, patchedGreeting.Version)
+	c.JSON(http.StatusOK, patchedGreeting)
+}
+
 // DeleteGreeting godoc
 // @Summary Delete a greeting message by ID
-// @Description Deletes a greeting message by its ID
+// @Description Moves a greeting message to the trash, from where an admin can restore or purge it
 // @Tags hello
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Greeting ID"
+// @Param If-Match header string false "ETag of the greeting version the change is based on"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ProblemDetail
 // @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
 // @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 412 {object} dto.ProblemDetail
+// @Failure 428 {object} dto.ProblemDetail
 // @Failure 500 {object} dto.ProblemDetail
 // @Router /api/hello/{id} [delete]
 func (h *helloControllerImpl) DeleteGreeting(c *gin.Context) {
@@ -245,7 +593,7 @@ func (h *helloControllerImpl) DeleteGreeting(c *gin.Context) {
 	}
 
 	// Call service to delete the greeting
-	err = h.HelloService.DeleteGreeting(uint(id))
+	err = h.HelloService.DeleteGreeting(c.Request.Context(), uint(id), ifMatchPrecondition(c, id))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -254,3 +602,415 @@ func (h *helloControllerImpl) DeleteGreeting(c *gin.Context) {
 	// Return no content status
 	c.Status(http.StatusNoContent)
 }
+
+// BulkCreateGreetings godoc
+// @Summary Create greeting messages in bulk
+// @Description Validates and creates up to 100 greetings in one transaction. In all-or-nothing mode, any failed item rolls back every item (status 424 for the others); in best-effort mode, the other items are still created. The outcome of every item is returned in request order.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param mode query string false "Bulk mode" Enums(all-or-nothing, best-effort) default(all-or-nothing)
+// @Param input body []dto.GreetingInput true "Greetings to create"
+// @Success 207 {object} dto.BulkResponse[dto.GreetingResponse]
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/bulk [post]
+func (h *helloControllerImpl) BulkCreateGreetings(c *gin.Context) {
+	inputs, mode, err := bindBulk[dto.GreetingInput](c, h.Validator)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results, err := h.HelloService.BulkCreateGreetings(c.Request.Context(), inputs, mode, func(input dto.GreetingInput) error {
+		return h.Validator.Struct(input)
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderBulk(c, h.Trans, mode, results, http.StatusCreated)
+}
+
+// BulkUpdateGreetings godoc
+// @Summary Update greeting messages in bulk
+// @Description Validates and updates up to 100 greetings in one transaction. An item carrying a version only updates that version of the greeting. In all-or-nothing mode, any failed item rolls back every item (status 424 for the others); in best-effort mode, the other items are still updated. The outcome of every item is returned in request order.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param mode query string false "Bulk mode" Enums(all-or-nothing, best-effort) default(all-or-nothing)
+// @Param input body []dto.BulkGreetingUpdate true "Greeting updates"
+// @Success 207 {object} dto.BulkResponse[dto.GreetingResponse]
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/bulk [put]
+func (h *helloControllerImpl) BulkUpdateGreetings(c *gin.Context) {
+	updates, mode, err := bindBulk[dto.BulkGreetingUpdate](c, h.Validator)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results, err := h.HelloService.BulkUpdateGreetings(c.Request.Context(), updates, mode, func(update dto.BulkGreetingUpdate) error {
+		if err := h.Validator.Struct(update); err != nil {
+			return err
+		}
+		return requireItemVersion(c, update.Version)
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderBulk(c, h.Trans, mode, results, http.StatusOK)
+}
+
+// BulkDeleteGreetings godoc
+// @Summary Delete greeting messages in bulk
+// @Description Moves up to 100 greetings to the trash in one transaction. An item carrying a version only deletes that version of the greeting. In all-or-nothing mode, any failed item rolls back every item (status 424 for the others); in best-effort mode, the other items are still deleted. The outcome of every item is returned in request order, without data.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param mode query string false "Bulk mode" Enums(all-or-nothing, best-effort) default(all-or-nothing)
+// @Param input body []dto.BulkGreetingDelete true "Greeting deletes"
+// @Success 207 {object} dto.BulkResponse[dto.GreetingResponse]
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/bulk [delete]
+func (h *helloControllerImpl) BulkDeleteGreetings(c *gin.Context) {
+	deletes, mode, err := bindBulk[dto.BulkGreetingDelete](c, h.Validator)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results, err := h.HelloService.BulkDeleteGreetings(c.Request.Context(), deletes, mode, func(del dto.BulkGreetingDelete) error {
+		if err := h.Validator.Struct(del); err != nil {
+			return err
+		}
+		return requireItemVersion(c, del.Version)
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	renderBulk(c, h.Trans, mode, results, http.StatusNoContent)
+}
+
+// ExportGreetings godoc
+// @Summary Export greeting messages
+// @Description Streams all greeting messages matching the filters, ordered by ID, as JSON, NDJSON or CSV depending on the Accept header. The export can be imported again.
+// @Tags admin
+// @Produce json,application/x-ndjson,text/csv
+// @Security BearerAuth
+// @Param locale query string false "Locale"
+// @Param message query string false "Message contains (case-insensitive)"
+// @Param createdBefore query string false "Created before (RFC 3339)"
+// @Param createdAfter query string false "Created after (RFC 3339)"
+// @Success 200 {array} dto.GreetingResponse
+// @Header 200 {string} Content-Disposition "Suggested file name of the export"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 406 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/export [get]
+func (h *helloControllerImpl) ExportGreetings(c *gin.Context) {
+	var query dto.GreetingExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	mediaType := c.NegotiateFormat(transferMediaTypes...)
+	if mediaType == "" {
+		_ = c.Error(&customError.NotAcceptableError{Accept: c.GetHeader("Accept"), Supported: transferMediaTypes})
+		return
+	}
+
+	// The headers are sent with the first greeting, so errors before it are still reported as such
+	c.Header("Content-Type", mediaType+"; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="greetings.%s"`, exportFileExtensions[mediaType]))
+	c.Status(http.StatusOK)
+
+	encoder := newGreetingEncoder(mediaType, c.Writer)
+	err := h.HelloService.ExportGreetings(c.Request.Context(), query, encoder.Encode)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		// Once streaming has started, a failed export can only be cut short
+		_ = c.Error(err)
+		return
+	}
+	c.Writer.WriteHeaderNow()
+}
+
+// ImportGreetings godoc
+// @Summary Import greeting messages
+// @Description Imports greeting messages from an uploaded JSON, NDJSON or CSV file, e.g. an export. The message, locale, publishAt, expireAt and tags of each row are read; CSV files need a header line. Overwritten greetings keep the schedule times and tags a row omits. The format is taken from the content type of the file, or else from its extension. Rows are validated like single greetings; failed rows do not prevent the other rows from being imported. The report lists the outcome of every row with its line number.
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "JSON, NDJSON or CSV file of at most 10 MiB and 10000 rows"
+// @Param dryRun query bool false "Report the outcome without saving anything" default(false)
+// @Param onDuplicate query string false "Handling of greetings whose message already exists in their locale" Enums(skip, overwrite, fail) default(skip)
+// @Success 200 {object} dto.GreetingImportReport
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 415 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/import [post]
+func (h *helloControllerImpl) ImportGreetings(c *gin.Context) {
+	var query dto.GreetingImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rows, err := readImportFile(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results, err := h.HelloService.ImportGreetings(c.Request.Context(), rows, query, func(input dto.GreetingInput) error {
+		return h.Validator.Struct(input)
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	report := dto.GreetingImportReport{
+		DryRun:      query.DryRun,
+		OnDuplicate: query.OnDuplicate,
+		Total:       len(results),
+		Rows:        make([]dto.GreetingImportRowResult, len(results)),
+	}
+	for i, result := range results {
+		row := dto.GreetingImportRowResult{Line: result.Line, Outcome: result.Outcome, ID: result.ID}
+		switch result.Outcome {
+		case dto.ImportOutcomeCreated:
+			report.Created++
+		case dto.ImportOutcomeOverwritten:
+			report.Overwritten++
+		case dto.ImportOutcomeSkipped:
+			report.Skipped++
+		default:
+			problem := middleware.ProblemDetailFor(c, result.Err, h.Trans)
+			row.Problem = &problem
+			report.Failed++
+		}
+		report.Rows[i] = row
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// readImportFile reads the rows of the file uploaded for an import
+func readImportFile(c *gin.Context) ([]service.GreetingImportRow, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, &customError.MessageNotReadableError{Detail: err.Error()}
+	}
+	if fileHeader.Size > MaxImportFileSize {
+		return nil, customError.ConstraintViolationError{
+			Violations: []dto.Violation{{
+				Code:          "max",
+				Field:         "file",
+				RejectedValue: fmt.Sprintf("%d", fileHeader.Size),
+				Message:       fmt.Sprintf("The file must not exceed %d bytes", MaxImportFileSize),
+			}},
+		}
+	}
+
+	mediaType, err := importMediaType(fileHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the uploaded file: %w", err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the uploaded file: %w", err)
+	}
+
+	rows, err := decodeGreetingRows(mediaType, data)
+	if err != nil {
+		return nil, &customError.MessageNotReadableError{Detail: err.Error()}
+	}
+	if len(rows) > MaxImportRows {
+		return nil, customError.ConstraintViolationError{
+			Violations: []dto.Violation{{
+				Code:          "max",
+				Field:         "file",
+				RejectedValue: fmt.Sprintf("%d", len(rows)),
+				Message:       fmt.Sprintf("The file must not have more than %d rows", MaxImportRows),
+			}},
+		}
+	}
+	return rows, nil
+}
+
+// GetDeletedGreetings godoc
+// @Summary List the greeting trash
+// @Description Returns a page of deleted greeting messages, most recently deleted first. Links to the neighbouring pages are returned in the Link header.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Zero-based page index" default(0) minimum(0)
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query []string false "Sort criteria in the format property[,asc|desc]; properties: id, message, createdAt, updatedAt, deletedAt" collectionFormat(multi)
+// @Success 200 {object} dto.PagedResponse[dto.GreetingResponse]
+// @Header 200 {string} Link "Links to the first, prev, next and last pages"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/trash [get]
+func (h *helloControllerImpl) GetDeletedGreetings(c *gin.Context) {
+	var query dto.GreetingTrashQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := h.Validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greetings, err := h.HelloService.GetDeletedGreetings(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setPageLinks(c, greetings.Page)
+	c.JSON(http.StatusOK, greetings)
+}
+
+// RestoreGreeting godoc
+// @Summary Restore a deleted greeting message
+// @Description Moves a greeting message out of the trash. Fails with a conflict when another greeting took over its message in the meantime.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/trash/{id}/restore [post]
+func (h *helloControllerImpl) RestoreGreeting(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	restoredGreeting, err := h.HelloService.RestoreGreeting(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, restoredGreeting.ID, restoredGreeting.Version)
+	c.JSON(http.StatusOK, restoredGreeting)
+}
+
+// PurgeGreeting godoc
+// @Summary Purge a deleted greeting message
+// @Description Permanently deletes a greeting message in the trash
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/trash/{id} [delete]
+func (h *helloControllerImpl) PurgeGreeting(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.HelloService.PurgeGreeting(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseGreetingID parses the greeting ID path parameter, reporting an invalid ID as a ConstraintViolationError
+func parseGreetingID(c *gin.Context) (uint, error) {
+	return parsePathNumber(c, "id", "ID")
+}
+
+// parsePathNumber parses a path parameter holding a number of at least 1, reporting an invalid number
+// as a ConstraintViolationError
+func parsePathNumber(c *gin.Context, param, name string) (uint, error) {
+	value := c.Param(param)
+	number, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || number < 1 {
+		return 0, customError.ConstraintViolationError{
+			Violations: []dto.Violation{{
+				Code:          "min",
+				Field:         param,
+				RejectedValue: value,
+				Message:       fmt.Sprintf("%s must be a valid integer greater than or equal to 1", name),
+			}},
+		}
+	}
+	return uint(number), nil
+}
+
+// acceptedLanguages returns the languages of the Accept-Language header, most preferred first
+func acceptedLanguages(c *gin.Context) []string {
+	return util.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+}
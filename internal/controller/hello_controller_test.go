@@ -2,13 +2,21 @@ package controller
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/middleware"
+	"gin-samples/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"testing"
 	"time"
 )
@@ -18,64 +26,190 @@ type MockHelloService struct {
 	mock.Mock
 }
 
-func (m *MockHelloService) GetGreeting() dto.GreetingResponse {
-	args := m.Called()
-	return args.Get(0).(dto.GreetingResponse)
+func (m *MockHelloService) GetGreeting(_ context.Context, languages []string,
+	query dto.GreetingRenderQuery) (dto.GreetingResponse, error) {
+	args := m.Called(languages, query)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+func (m *MockHelloService) ResolveLocale(_ context.Context, languages []string) (string, error) {
+	args := m.Called(languages)
+	return args.String(0), args.Error(1)
 }
 
-func (m *MockHelloService) CreateGreeting(input dto.GreetingInput) (dto.GreetingResponse, error) {
+func (m *MockHelloService) CreateGreeting(_ context.Context, input dto.GreetingInput) (dto.GreetingResponse, error) {
 	args := m.Called(input)
 	return args.Get(0).(dto.GreetingResponse), args.Error(1)
 }
 
-func (m *MockHelloService) GetAllGreetings() ([]dto.GreetingResponse, error) {
-	args := m.Called()
-	return args.Get(0).([]dto.GreetingResponse), args.Error(1)
+func (m *MockHelloService) GetAllGreetings(_ context.Context, query dto.GreetingQuery) (dto.PagedResponse[dto.GreetingResponse], error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.PagedResponse[dto.GreetingResponse]), args.Error(1)
+}
+
+func (m *MockHelloService) GetGreetingsByCursor(_ context.Context, query dto.CursorQuery, locale string) (dto.CursorPagedResponse[dto.GreetingResponse], error) {
+	args := m.Called(query, locale)
+	return args.Get(0).(dto.CursorPagedResponse[dto.GreetingResponse]), args.Error(1)
+}
+
+func (m *MockHelloService) SearchGreetings(_ context.Context, query dto.GreetingSearchQuery) (dto.PagedResponse[dto.GreetingSearchResponse], error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.PagedResponse[dto.GreetingSearchResponse]), args.Error(1)
 }
 
-func (m *MockHelloService) GetGreetingByID(id uint) (dto.GreetingResponse, error) {
+func (m *MockHelloService) GetGreetingByID(_ context.Context, id uint) (dto.GreetingResponse, error) {
 	args := m.Called(id)
 	return args.Get(0).(dto.GreetingResponse), args.Error(1)
 }
 
-func (m *MockHelloService) UpdateGreeting(id uint, input dto.GreetingInput) (dto.GreetingResponse, error) {
-	args := m.Called(id, input)
+func (m *MockHelloService) RenderGreeting(_ context.Context, id uint,
+	query dto.GreetingRenderQuery) (dto.RenderedGreetingResponse, error) {
+	args := m.Called(id, query)
+	return args.Get(0).(dto.RenderedGreetingResponse), args.Error(1)
+}
+
+func (m *MockHelloService) UpdateGreeting(_ context.Context, id uint, input dto.GreetingInput,
+	precondition *dto.VersionPrecondition) (dto.GreetingResponse, error) {
+	args := m.Called(id, input, precondition)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+// PatchGreeting applies the patch to the greeting document the mock returns, like the service does
+func (m *MockHelloService) PatchGreeting(_ context.Context, id uint, patch service.GreetingPatch,
+	precondition *dto.VersionPrecondition) (dto.GreetingResponse, error) {
+	args := m.Called(id, precondition)
+	input, err := patch(args.Get(0).(dto.GreetingInput))
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+	return dto.GreetingResponse{ID: id, Message: input.Message, Locale: input.Locale, Version: 2}, nil
+}
+
+func (m *MockHelloService) DeleteGreeting(_ context.Context, id uint, precondition *dto.VersionPrecondition) error {
+	args := m.Called(id, precondition)
+	return args.Error(0)
+}
+
+func (m *MockHelloService) GetDeletedGreetings(_ context.Context, query dto.GreetingTrashQuery) (dto.PagedResponse[dto.GreetingResponse], error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.PagedResponse[dto.GreetingResponse]), args.Error(1)
+}
+
+func (m *MockHelloService) RestoreGreeting(_ context.Context, id uint) (dto.GreetingResponse, error) {
+	args := m.Called(id)
 	return args.Get(0).(dto.GreetingResponse), args.Error(1)
 }
 
-func (m *MockHelloService) DeleteGreeting(id uint) error {
+func (m *MockHelloService) PurgeGreeting(_ context.Context, id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+// BulkCreateGreetings fails the items rejected by validate, like the service does
+func (m *MockHelloService) BulkCreateGreetings(_ context.Context, inputs []dto.GreetingInput, mode string,
+	validate func(dto.GreetingInput) error) ([]service.BulkResult[dto.GreetingResponse], error) {
+	args := m.Called(inputs, mode)
+	results := args.Get(0).([]service.BulkResult[dto.GreetingResponse])
+	for i, input := range inputs {
+		if err := validate(input); err != nil {
+			results[i] = service.BulkResult[dto.GreetingResponse]{Err: err}
+		}
+	}
+	return results, args.Error(1)
+}
+
+// BulkUpdateGreetings fails the items rejected by validate, like the service does
+func (m *MockHelloService) BulkUpdateGreetings(_ context.Context, updates []dto.BulkGreetingUpdate, mode string,
+	validate func(dto.BulkGreetingUpdate) error) ([]service.BulkResult[dto.GreetingResponse], error) {
+	args := m.Called(updates, mode)
+	results := args.Get(0).([]service.BulkResult[dto.GreetingResponse])
+	for i, update := range updates {
+		if err := validate(update); err != nil {
+			results[i] = service.BulkResult[dto.GreetingResponse]{Err: err}
+		}
+	}
+	return results, args.Error(1)
+}
+
+// BulkDeleteGreetings fails the items rejected by validate, like the service does
+func (m *MockHelloService) BulkDeleteGreetings(_ context.Context, deletes []dto.BulkGreetingDelete, mode string,
+	validate func(dto.BulkGreetingDelete) error) ([]service.BulkResult[struct{}], error) {
+	args := m.Called(deletes, mode)
+	results := args.Get(0).([]service.BulkResult[struct{}])
+	for i, del := range deletes {
+		if err := validate(del); err != nil {
+			results[i] = service.BulkResult[struct{}]{Err: err}
+		}
+	}
+	return results, args.Error(1)
+}
+
+// ExportGreetings passes the greetings returned by the mock to write, one batch at a time
+func (m *MockHelloService) ExportGreetings(_ context.Context, query dto.GreetingExportQuery, write func([]dto.GreetingResponse) error) error {
+	args := m.Called(query)
+	for _, batch := range args.Get(0).([][]dto.GreetingResponse) {
+		if err := write(batch); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// ImportGreetings fails the rows rejected by validate, like the service does
+func (m *MockHelloService) ImportGreetings(_ context.Context, rows []service.GreetingImportRow, query dto.GreetingImportQuery,
+	validate func(dto.GreetingInput) error) ([]service.GreetingImportResult, error) {
+	args := m.Called(rows, query)
+	results := args.Get(0).([]service.GreetingImportResult)
+	for i, row := range rows {
+		if row.Err == nil {
+			row.Err = validate(row.Input)
+		}
+		if row.Err != nil {
+			results[i] = service.GreetingImportResult{Line: row.Line, Outcome: dto.ImportOutcomeFailed, Err: row.Err}
+		}
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockHelloService) GetRandomGreeting(_ context.Context,
+	query dto.RandomGreetingQuery) (dto.GreetingResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
 func TestHelloController_Hello(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	// Mock Service
 	mockService := new(MockHelloService)
-	mockService.On("GetGreeting").Return(dto.GreetingResponse{
+	mockService.On("GetGreeting", []string{"tr", "en"}, dto.GreetingRenderQuery{}).Return(dto.GreetingResponse{
 		ID:        1,
 		Message:   "Mock Hello",
+		Locale:    "tr",
 		CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
 		UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
-	})
+	}, nil)
 
 	// Controller Setup
-	controller := NewHelloController(mockService, nil, nil)
+	controller := NewHelloController(mockService, validator.New(), nil)
 	router := gin.Default()
 	router.GET("/api/hello", controller.Hello)
 
 	// Mock Request
 	req, _ := http.NewRequest("GET", "/api/hello", nil)
+	req.Header.Set("Accept-Language", "en;q=0.5, tr")
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	// Assertions
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "tr", w.Header().Get("Content-Language"))
 
 	expectedResponse := `{
 		"id": 1,
 		"message": "Mock Hello",
+		"locale": "tr",
+		"version": 0,
 		"createdAt": "2025-01-05T10:00:00Z",
 		"updatedAt": "2025-01-05T10:00:00Z"
 	}`
@@ -93,6 +227,8 @@ func TestHelloController_CreateGreeting(t *testing.T) {
 		Return(dto.GreetingResponse{
 			ID:        1,
 			Message:   "Hello, Test!",
+			Locale:    "en",
+			Version:   1,
 			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
 			UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
 		}, nil)
@@ -116,6 +252,8 @@ func TestHelloController_CreateGreeting(t *testing.T) {
 	expectedResponse := `{
 		"id": 1,
 		"message": "Hello, Test!",
+		"locale": "en",
+		"version": 1,
 		"createdAt": "2025-01-05T10:00:00Z",
 		"updatedAt": "2025-01-05T10:00:00Z"
 	}`
@@ -129,49 +267,169 @@ func TestHelloController_GetAllGreetings(t *testing.T) {
 
 	// Mock Service
 	mockService := new(MockHelloService)
-	mockService.On("GetAllGreetings").Return([]dto.GreetingResponse{
-		{
-			ID:        1,
-			Message:   "Mock Hello",
-			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
-			UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
-		},
-		{
-			ID:        2,
-			Message:   "Mock Hi",
-			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
-			UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+	mockService.On("ResolveLocale", []string(nil)).Return("en", nil)
+	mockService.On("GetAllGreetings", dto.GreetingQuery{
+		Page:     1,
+		Size:     2,
+		Sort:     []string{"createdAt,desc"},
+		Message:  "mock",
+		Locale:   "en",
+		TagMatch: dto.TagMatchAny,
+	}).Return(dto.PagedResponse[dto.GreetingResponse]{
+		Content: []dto.GreetingResponse{
+			{
+				ID:        1,
+				Message:   "Mock Hello",
+				Locale:    "en",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:        2,
+				Message:   "Mock Hi",
+				Locale:    "en",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			},
 		},
+		Page: dto.PageMetadata{Number: 1, Size: 2, TotalElements: 6, TotalPages: 3},
 	}, nil)
 
 	// Controller Setup
-	controller := NewHelloController(mockService, nil, nil)
+	controller := NewHelloController(mockService, validator.New(), nil)
 	router := gin.Default()
 	router.GET("/api/hello/all", controller.GetAllGreetings)
 
 	// Mock Request
-	req, _ := http.NewRequest("GET", "/api/hello/all", nil)
+	req, _ := http.NewRequest("GET", "/api/hello/all?page=1&size=2&sort=createdAt,desc&message=mock", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	// Assertions
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
 
-	expectedResponse := `[
-		{
-			"id": 1,
-			"message": "Mock Hello",
-			"createdAt": "2025-01-05T10:00:00Z",
-			"updatedAt": "2025-01-05T10:00:00Z"
-		},
-		{
-			"id": 2,
-			"message": "Mock Hi",
-			"createdAt": "2025-01-05T10:00:00Z",
-			"updatedAt": "2025-01-05T10:00:00Z"
-		}
-	]`
+	expectedResponse := `{
+		"content": [
+			{
+				"id": 1,
+				"message": "Mock Hello",
+				"locale": "en",
+				"version": 1,
+				"createdAt": "2025-01-05T10:00:00Z",
+				"updatedAt": "2025-01-05T10:00:00Z"
+			},
+			{
+				"id": 2,
+				"message": "Mock Hi",
+				"locale": "en",
+				"version": 1,
+				"createdAt": "2025-01-05T10:00:00Z",
+				"updatedAt": "2025-01-05T10:00:00Z"
+			}
+		],
+		"page": {"number": 1, "size": 2, "totalElements": 6, "totalPages": 3}
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	link := w.Header().Get("Link")
+	assert.Contains(t, link, `</api/hello/all?message=mock&page=0&size=2&sort=createdAt%2Cdesc>; rel="first"`)
+	assert.Contains(t, link, `page=0&size=2&sort=createdAt%2Cdesc>; rel="prev"`)
+	assert.Contains(t, link, `page=2&size=2&sort=createdAt%2Cdesc>; rel="next"`)
+	assert.Contains(t, link, `page=2&size=2&sort=createdAt%2Cdesc>; rel="last"`)
+
+	// The ETag is derived from the content, so an unchanged page is not sent again
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_GetAllGreetings_InvalidSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockHelloService)
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/hello/all", controller.GetAllGreetings)
+
+	req, _ := http.NewRequest("GET", "/api/hello/all?size=1000", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var validationErrs validator.ValidationErrors
+	assert.Len(t, errs, 1)
+	assert.ErrorAs(t, errs[0].Err, &validationErrs)
+	assert.Equal(t, "Size", validationErrs[0].Field())
+	mockService.AssertNotCalled(t, "GetAllGreetings", mock.Anything)
+}
+
+func TestHelloController_GetGreetingsByCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("ResolveLocale", []string(nil)).Return("en", nil)
+	mockService.On("GetGreetingsByCursor", dto.CursorQuery{Cursor: "current", Size: 1, Sort: "message"}, "en").
+		Return(dto.CursorPagedResponse[dto.GreetingResponse]{
+			Content: []dto.GreetingResponse{{
+				ID:        2,
+				Message:   "Mock Hi",
+				Locale:    "en",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			}},
+			Size: 1,
+			Next: "next",
+			Prev: "prev",
+		}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.GET("/api/hello/all/cursor", controller.GetGreetingsByCursor)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/hello/all/cursor?cursor=current&size=1&sort=message", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+
+	expectedResponse := `{
+		"content": [
+			{
+				"id": 2,
+				"message": "Mock Hi",
+				"locale": "en",
+				"version": 1,
+				"createdAt": "2025-01-05T10:00:00Z",
+				"updatedAt": "2025-01-05T10:00:00Z"
+			}
+		],
+		"size": 1,
+		"next": "next",
+		"prev": "prev"
+	}`
 	assert.JSONEq(t, expectedResponse, w.Body.String())
+	assert.Equal(t, `</api/hello/all/cursor?cursor=prev&size=1>; rel="prev", </api/hello/all/cursor?cursor=next&size=1>; rel="next"`,
+		w.Header().Get("Link"))
 
 	mockService.AssertExpectations(t)
 }
@@ -182,10 +440,13 @@ func TestHelloController_GetGreetingByID_Success(t *testing.T) {
 	// Mock Service
 	mockService := new(MockHelloService)
 	mockService.On("GetGreetingByID", uint(1)).Return(dto.GreetingResponse{
-		ID:        1,
-		Message:   "Mock Greeting",
-		CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
-		UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		ID:           1,
+		Message:      "Mock Greeting",
+		Locale:       "en",
+		Version:      1,
+		CreatedAt:    time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		LastModified: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
 	}, nil)
 
 	// Controller Setup
@@ -200,10 +461,14 @@ func TestHelloController_GetGreetingByID_Success(t *testing.T) {
 
 	// Assertions
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `^"1-1\.[\w-]+"$`, w.Header().Get("ETag"))
+	assert.Equal(t, "Sun, 05 Jan 2025 10:00:00 GMT", w.Header().Get("Last-Modified"))
 
 	expectedResponse := `{
 		"id": 1,
 		"message": "Mock Greeting",
+		"locale": "en",
+		"version": 1,
 		"createdAt": "2025-01-05T10:00:00Z",
 		"updatedAt": "2025-01-05T10:00:00Z"
 	}`
@@ -212,15 +477,163 @@ func TestHelloController_GetGreetingByID_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestHelloController_RenderGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	query := dto.GreetingRenderQuery{TimeZone: "Europe/Istanbul"}
+	renderedAt := time.Date(2025, 1, 5, 9, 0, 0, 0, time.FixedZone("+03", 3*60*60))
+	mockService.On("RenderGreeting", uint(1), query).Return(dto.RenderedGreetingResponse{
+		GreetingResponse: dto.GreetingResponse{
+			ID:        1,
+			Message:   "Good morning, John!",
+			Locale:    "en",
+			Version:   1,
+			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		},
+		Template:   "Good {{timeOfDay}}, {{.FirstName}}!",
+		RenderedAt: renderedAt,
+	}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.GET("/api/hello/:id/render", controller.RenderGreeting)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/hello/1/render?timeZone=Europe/Istanbul", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	expectedResponse := `{
+		"id": 1,
+		"message": "Good morning, John!",
+		"locale": "en",
+		"version": 1,
+		"createdAt": "2025-01-05T10:00:00Z",
+		"updatedAt": "2025-01-05T10:00:00Z",
+		"template": "Good {{timeOfDay}}, {{.FirstName}}!",
+		"renderedAt": "2025-01-05T09:00:00+03:00"
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_RenderGreeting_InvalidTimeZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockHelloService)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": c.Errors.Last().Error()})
+		}
+	})
+	router.GET("/api/hello/:id/render", controller.RenderGreeting)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/hello/1/render?timeZone=Mars/Olympus", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "RenderGreeting", mock.Anything, mock.Anything)
+}
+
+func TestHelloController_GetGreetingByID_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	greeting := dto.GreetingResponse{
+		ID:           1,
+		Message:      "Mock Greeting",
+		Version:      2,
+		UpdatedAt:    time.Date(2025, 1, 5, 10, 0, 0, 500, time.UTC),
+		LastModified: time.Date(2025, 1, 5, 10, 0, 0, 500, time.UTC),
+	}
+	mockService := new(MockHelloService)
+	mockService.On("GetGreetingByID", uint(1)).Return(greeting, nil).Once()
+
+	controller := NewHelloController(mockService, nil, nil)
+	router := gin.Default()
+	router.GET("/api/hello/:id", controller.GetGreetingByID)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/hello/1", nil)
+		if value != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	etag := get("", "").Header().Get("ETag")
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		expected int
+	}{
+		{name: "matching etag", header: "If-None-Match", value: `"1-1", W/` + etag, expected: http.StatusNotModified},
+		{name: "outdated etag", header: "If-None-Match", value: `"1-1"`, expected: http.StatusOK},
+		{name: "version without content", header: "If-None-Match", value: `"1-2"`, expected: http.StatusOK},
+		{name: "not modified since", header: "If-Modified-Since", value: "Sun, 05 Jan 2025 10:00:00 GMT", expected: http.StatusNotModified},
+		{name: "modified since", header: "If-Modified-Since", value: "Sun, 05 Jan 2025 09:59:59 GMT", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.On("GetGreetingByID", uint(1)).Return(greeting, nil).Once()
+			w := get(tt.header, tt.value)
+
+			assert.Equal(t, tt.expected, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			assert.Equal(t, "Sun, 05 Jan 2025 10:00:00 GMT", w.Header().Get("Last-Modified"))
+			if tt.expected == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+
+	// Reactions and comments do not change the version, but they change the content and the last modification
+	reacted := greeting
+	reacted.Reactions = map[string]int64{"like": 1}
+	reacted.CommentCount = 1
+	reacted.LastModified = time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+	mockService.On("GetGreetingByID", uint(1)).Return(reacted, nil).Twice()
+	w := get("If-None-Match", etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `^"1-2\.[\w-]+"$`, w.Header().Get("ETag"))
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	w = get("If-Modified-Since", "Sun, 05 Jan 2025 10:00:00 GMT")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Mon, 06 Jan 2025 08:00:00 GMT", w.Header().Get("Last-Modified"))
+}
+
 func TestHelloController_UpdateGreeting_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	// Mock Service
 	mockService := new(MockHelloService)
-	mockService.On("UpdateGreeting", uint(1), dto.GreetingInput{Message: "Updated Greeting"}).
+	mockService.On("UpdateGreeting", uint(1), dto.GreetingInput{Message: "Updated Greeting"},
+		&dto.VersionPrecondition{Versions: []uint{3}}).
 		Return(dto.GreetingResponse{
 			ID:        1,
 			Message:   "Updated Greeting",
+			Locale:    "en",
+			Version:   4,
 			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
 			UpdatedAt: time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC),
 		}, nil)
@@ -235,15 +648,20 @@ func TestHelloController_UpdateGreeting_Success(t *testing.T) {
 	body := []byte(`{"message": "Updated Greeting"}`)
 	req, _ := http.NewRequest("PUT", "/api/hello/1", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
+	// Weak tags and tags of other greetings never match; the content digest of read tags is ignored
+	req.Header.Set("If-Match", `W/"1-2", "2-5", "1-3.cmVhZA"`)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	// Assertions
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-4"`, w.Header().Get("ETag"))
 
 	expectedResponse := `{
 		"id": 1,
 		"message": "Updated Greeting",
+		"locale": "en",
+		"version": 4,
 		"createdAt": "2025-01-05T10:00:00Z",
 		"updatedAt": "2025-01-06T12:00:00Z"
 	}`
@@ -252,12 +670,137 @@ func TestHelloController_UpdateGreeting_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestHelloController_PatchGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	current := dto.GreetingInput{Message: "Hello", Locale: "en"}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    dto.GreetingInput
+	}{
+		{
+			name:        "merge patch",
+			contentType: MediaTypeMergePatch,
+			body:        `{"message": "Olá", "locale": "pt"}`,
+			expected:    dto.GreetingInput{Message: "Olá", Locale: "pt"},
+		},
+		{
+			name:        "merge patch removing the locale",
+			contentType: MediaTypeMergePatch,
+			body:        `{"locale": null}`,
+			expected:    dto.GreetingInput{Message: "Hello"},
+		},
+		{
+			name:        "json patch",
+			contentType: MediaTypeJSONPatch,
+			body:        `[{"op": "test", "path": "/message", "value": "Hello"}, {"op": "replace", "path": "/message", "value": "Hi there"}]`,
+			expected:    dto.GreetingInput{Message: "Hi there", Locale: "en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHelloService)
+			mockService.On("PatchGreeting", uint(1), &dto.VersionPrecondition{Versions: []uint{1}}).Return(current)
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+			router.PATCH("/api/hello/:id", controller.PatchGreeting)
+
+			req, _ := http.NewRequest("PATCH", "/api/hello/1", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("If-Match", `"1-1"`)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+
+			var actual dto.GreetingResponse
+			_ = json.Unmarshal(w.Body.Bytes(), &actual)
+			assert.Equal(t, tt.expected, dto.GreetingInput{Message: actual.Message, Locale: actual.Locale})
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHelloController_PatchGreeting_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expectedErr any
+	}{
+		{
+			name:        "unsupported media type",
+			contentType: "application/json",
+			body:        `{"message": "Hi there"}`,
+			expectedErr: new(*customError.UnsupportedMediaTypeError),
+		},
+		{
+			name:        "malformed json patch",
+			contentType: MediaTypeJSONPatch,
+			body:        `{"op": "replace"}`,
+			expectedErr: new(*customError.MessageNotReadableError),
+		},
+		{
+			name:        "failed json patch test",
+			contentType: MediaTypeJSONPatch,
+			body:        `[{"op": "test", "path": "/message", "value": "Bye"}]`,
+			expectedErr: new(*customError.UnprocessablePatchError),
+		},
+		{
+			name:        "read-only field",
+			contentType: MediaTypeMergePatch,
+			body:        `{"id": 2}`,
+			expectedErr: new(*customError.UnprocessablePatchError),
+		},
+		{
+			name:        "invalid patched document",
+			contentType: MediaTypeMergePatch,
+			body:        `{"message": "Hi"}`,
+			expectedErr: new(validator.ValidationErrors),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHelloService)
+			mockService.On("PatchGreeting", uint(1), (*dto.VersionPrecondition)(nil)).
+				Return(dto.GreetingInput{Message: "Hello", Locale: "en"})
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+
+			// Capture the errors passed to the error handling middleware
+			var errs []*gin.Error
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				errs = c.Errors
+			})
+			router.PATCH("/api/hello/:id", controller.PatchGreeting)
+
+			req, _ := http.NewRequest("PATCH", "/api/hello/1", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Len(t, errs, 1)
+			assert.ErrorAs(t, errs[0].Err, tt.expectedErr)
+		})
+	}
+}
+
 func TestHelloController_DeleteGreeting_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	// Mock Service
 	mockService := new(MockHelloService)
-	mockService.On("DeleteGreeting", uint(1)).Return(nil)
+	mockService.On("DeleteGreeting", uint(1), (*dto.VersionPrecondition)(nil)).Return(nil)
 
 	// Controller Setup
 	controller := NewHelloController(mockService, nil, nil)
@@ -274,3 +817,855 @@ func TestHelloController_DeleteGreeting_Success(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestHelloController_GetDeletedGreetings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	deletedAt := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	mockService := new(MockHelloService)
+	mockService.On("GetDeletedGreetings", dto.GreetingTrashQuery{Page: 0, Size: 20}).
+		Return(dto.PagedResponse[dto.GreetingResponse]{
+			Content: []dto.GreetingResponse{{
+				ID:        1,
+				Message:   "Hello, World!",
+				Locale:    "en",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				DeletedAt: &deletedAt,
+			}},
+			Page: dto.PageMetadata{Number: 0, Size: 20, TotalElements: 1, TotalPages: 1},
+		}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.GET("/api/admin/hello/trash", controller.GetDeletedGreetings)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/admin/hello/trash", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	expectedResponse := `{
+		"content": [{
+			"id": 1,
+			"message": "Hello, World!",
+			"locale": "en",
+			"version": 1,
+			"createdAt": "2025-01-05T10:00:00Z",
+			"updatedAt": "2025-01-05T10:00:00Z",
+			"deletedAt": "2025-01-06T09:00:00Z"
+		}],
+		"page": {"number": 0, "size": 20, "totalElements": 1, "totalPages": 1}
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_RestoreGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("RestoreGreeting", uint(1)).Return(dto.GreetingResponse{
+		ID:        1,
+		Message:   "Hello, World!",
+		Locale:    "en",
+		Version:   2,
+		CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+	}, nil)
+	mockService.On("RestoreGreeting", uint(2)).Return(dto.GreetingResponse{}, &customError.ResourceConflictError{
+		Resource: "Greeting",
+		Criteria: "message and locale",
+		Value:    "Hello, World! (en)",
+	})
+
+	// Controller Setup
+	controller := NewHelloController(mockService, nil, nil)
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.POST("/api/admin/hello/trash/:id/restore", controller.RestoreGreeting)
+
+	// Restored greeting
+	req, _ := http.NewRequest("POST", "/api/admin/hello/trash/1/restore", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+
+	expectedResponse := `{
+		"id": 1,
+		"message": "Hello, World!",
+		"locale": "en",
+		"version": 2,
+		"createdAt": "2025-01-05T10:00:00Z",
+		"updatedAt": "2025-01-06T10:00:00Z"
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	// Message taken over by another greeting
+	req, _ = http.NewRequest("POST", "/api/admin/hello/trash/2/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Len(t, errs, 1)
+	assert.ErrorAs(t, errs[0].Err, new(*customError.ResourceConflictError))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_PurgeGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("PurgeGreeting", uint(1)).Return(nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, nil, nil)
+	router := gin.Default()
+	router.DELETE("/api/admin/hello/trash/:id", controller.PurgeGreeting)
+
+	// Mock Request
+	req, _ := http.NewRequest("DELETE", "/api/admin/hello/trash/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_BulkCreateGreetings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	inputs := []dto.GreetingInput{
+		{Message: "Hello, Bulk!"},
+		{Message: "Hi"},
+		{Message: "Hello, World!"},
+	}
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("BulkCreateGreetings", inputs, dto.BulkModeBestEffort).
+		Return([]service.BulkResult[dto.GreetingResponse]{
+			{Value: dto.GreetingResponse{
+				ID:        3,
+				Message:   "Hello, Bulk!",
+				Locale:    "en",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			}},
+			{},
+			{Err: &customError.ResourceConflictError{
+				Resource: "Greeting",
+				Criteria: "message and locale",
+				Value:    "Hello, World! (en)",
+			}},
+		}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.POST("/api/hello/bulk", controller.BulkCreateGreetings)
+
+	// Mock Request
+	body, _ := json.Marshal(inputs)
+	req, _ := http.NewRequest("POST", "/api/hello/bulk?mode=best-effort", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+
+	var response dto.BulkResponse[dto.GreetingResponse]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dto.BulkModeBestEffort, response.Mode)
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	if assert.Len(t, response.Results, 3) {
+		assert.Equal(t, http.StatusCreated, response.Results[0].Status)
+		assert.Equal(t, "Hello, Bulk!", response.Results[0].Data.Message)
+		assert.Nil(t, response.Results[0].Problem)
+
+		assert.Equal(t, http.StatusBadRequest, response.Results[1].Status)
+		assert.Nil(t, response.Results[1].Data)
+		assert.Equal(t, "invalid_request", response.Results[1].Problem.Error)
+		assert.Len(t, response.Results[1].Problem.Violations, 1)
+
+		assert.Equal(t, 2, response.Results[2].Index)
+		assert.Equal(t, http.StatusConflict, response.Results[2].Status)
+		assert.Equal(t, "resource_conflict", response.Results[2].Problem.Error)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_BulkCreateGreetings_InvalidRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		url         string
+		body        string
+		expectedErr any
+	}{
+		{
+			name:        "unknown mode",
+			url:         "/api/hello/bulk?mode=sometimes",
+			body:        `[{"message": "Hello, Bulk!"}]`,
+			expectedErr: new(validator.ValidationErrors),
+		},
+		{
+			name:        "not an array",
+			url:         "/api/hello/bulk",
+			body:        `{"message": "Hello, Bulk!"}`,
+			expectedErr: new(*customError.MessageNotReadableError),
+		},
+		{
+			name:        "no items",
+			url:         "/api/hello/bulk",
+			body:        `[]`,
+			expectedErr: new(customError.ConstraintViolationError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHelloService)
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+
+			// Capture the errors passed to the error handling middleware
+			var errs []*gin.Error
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				errs = c.Errors
+			})
+			router.POST("/api/hello/bulk", controller.BulkCreateGreetings)
+
+			req, _ := http.NewRequest("POST", tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Len(t, errs, 1)
+			assert.ErrorAs(t, errs[0].Err, tt.expectedErr)
+			mockService.AssertNotCalled(t, "BulkCreateGreetings", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHelloController_BulkUpdateGreetings_VersionRequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	version := uint(1)
+	updates := []dto.BulkGreetingUpdate{
+		{ID: 1, Version: &version, GreetingInput: dto.GreetingInput{Message: "Hello, Bulk!"}},
+		{ID: 2, GreetingInput: dto.GreetingInput{Message: "Hello again!"}},
+	}
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("BulkUpdateGreetings", updates, dto.BulkModeAllOrNothing).
+		Return([]service.BulkResult[dto.GreetingResponse]{
+			{Err: &customError.FailedDependencyError{Index: 0}},
+			{},
+		}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ItemVersionRequiredKey, true)
+	})
+	router.PUT("/api/hello/bulk", controller.BulkUpdateGreetings)
+
+	// Mock Request
+	body, _ := json.Marshal(updates)
+	req, _ := http.NewRequest("PUT", "/api/hello/bulk", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+
+	var response dto.BulkResponse[dto.GreetingResponse]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 0, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	if assert.Len(t, response.Results, 2) {
+		assert.Equal(t, http.StatusFailedDependency, response.Results[0].Status)
+		assert.Equal(t, http.StatusBadRequest, response.Results[1].Status)
+		assert.Equal(t, "version", response.Results[1].Problem.Violations[0].Field)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_BulkDeleteGreetings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deletes := []dto.BulkGreetingDelete{{ID: 1}, {ID: 2}}
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("BulkDeleteGreetings", deletes, dto.BulkModeAllOrNothing).
+		Return([]service.BulkResult[struct{}]{{}, {}}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.DELETE("/api/hello/bulk", controller.BulkDeleteGreetings)
+
+	// Mock Request
+	req, _ := http.NewRequest("DELETE", "/api/hello/bulk", bytes.NewBufferString(`[{"id": 1}, {"id": 2}]`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+
+	expectedResponse := `{
+		"mode": "all-or-nothing",
+		"succeeded": 2,
+		"failed": 0,
+		"results": [
+			{"index": 0, "status": 204},
+			{"index": 1, "status": 204}
+		]
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_ExportGreetings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	publishAt, expireAt := createdAt.Add(24*time.Hour), createdAt.Add(48*time.Hour)
+	batches := [][]dto.GreetingResponse{
+		{{ID: 1, Message: "Hello, World!", Locale: "en", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt}},
+		{{ID: 2, Message: "Merhaba, Dünya!", Locale: "tr", Version: 2, CreatedAt: createdAt, UpdatedAt: createdAt,
+			PublishAt: &publishAt, ExpireAt: &expireAt, Tags: []string{"hello, world", "turkish"}}},
+		{{ID: 3, Message: "=HYPERLINK(\"https://example.com\")", Locale: "en", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+			{ID: 4, Message: "'quoted", Locale: "en", Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt}},
+	}
+
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+		expectedFilename    string
+		expectedBody        string
+	}{
+		{
+			name:                "json",
+			accept:              "*/*",
+			expectedContentType: "application/json; charset=utf-8",
+			expectedFilename:    "greetings.json",
+			expectedBody: `[{"id":1,"message":"Hello, World!","locale":"en","version":1,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z"},` +
+				`{"id":2,"message":"Merhaba, Dünya!","locale":"tr","version":2,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z",` +
+				`"publishAt":"2025-01-06T10:00:00Z","expireAt":"2025-01-07T10:00:00Z","tags":["hello, world","turkish"]},` +
+				`{"id":3,"message":"=HYPERLINK(\"https://example.com\")","locale":"en","version":1,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z"},` +
+				`{"id":4,"message":"'quoted","locale":"en","version":1,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z"}]`,
+		},
+		{
+			name:                "ndjson",
+			accept:              "application/x-ndjson",
+			expectedContentType: "application/x-ndjson; charset=utf-8",
+			expectedFilename:    "greetings.ndjson",
+			expectedBody: `{"id":1,"message":"Hello, World!","locale":"en","version":1,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z"}` + "\n" +
+				`{"id":2,"message":"Merhaba, Dünya!","locale":"tr","version":2,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z",` +
+				`"publishAt":"2025-01-06T10:00:00Z","expireAt":"2025-01-07T10:00:00Z","tags":["hello, world","turkish"]}` + "\n" +
+				`{"id":3,"message":"=HYPERLINK(\"https://example.com\")","locale":"en","version":1,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z"}` + "\n" +
+				`{"id":4,"message":"'quoted","locale":"en","version":1,"createdAt":"2025-01-05T10:00:00Z","updatedAt":"2025-01-05T10:00:00Z"}` + "\n",
+		},
+		{
+			name:                "csv",
+			accept:              "text/csv",
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedFilename:    "greetings.csv",
+			expectedBody: "id,message,locale,version,createdAt,updatedAt,publishAt,expireAt,tags\n" +
+				"1,\"Hello, World!\",en,1,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,,,\n" +
+				"2,\"Merhaba, Dünya!\",tr,2,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,2025-01-06T10:00:00Z,2025-01-07T10:00:00Z," +
+				"\"\"\"hello, world\"\",turkish\"\n" +
+				"3,\"'=HYPERLINK(\"\"https://example.com\"\")\",en,1,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,,,\n" +
+				"4,''quoted,en,1,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Mock Service
+			mockService := new(MockHelloService)
+			mockService.On("ExportGreetings", dto.GreetingExportQuery{Locale: "en"}).Return(batches, nil)
+
+			// Controller Setup
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+			router.GET("/api/admin/hello/export", controller.ExportGreetings)
+
+			// Mock Request
+			req, _ := http.NewRequest("GET", "/api/admin/hello/export?locale=en", nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assertions
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="`+tt.expectedFilename+`"`, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHelloController_ExportGreetings_Empty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for accept, expectedBody := range map[string]string{
+		MediaTypeJSON:   "[]",
+		MediaTypeNDJSON: "",
+		MediaTypeCSV:    "id,message,locale,version,createdAt,updatedAt,publishAt,expireAt,tags\n",
+	} {
+		t.Run(accept, func(t *testing.T) {
+			mockService := new(MockHelloService)
+			mockService.On("ExportGreetings", dto.GreetingExportQuery{}).Return([][]dto.GreetingResponse{}, nil)
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+			router.GET("/api/admin/hello/export", controller.ExportGreetings)
+
+			req, _ := http.NewRequest("GET", "/api/admin/hello/export", nil)
+			req.Header.Set("Accept", accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestHelloController_ExportGreetings_NotAcceptable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockHelloService)
+
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/admin/hello/export", controller.ExportGreetings)
+
+	req, _ := http.NewRequest("GET", "/api/admin/hello/export", nil)
+	req.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Len(t, errs, 1)
+	var notAcceptableErr *customError.NotAcceptableError
+	if assert.ErrorAs(t, errs[0].Err, &notAcceptableErr) {
+		assert.Equal(t, "application/xml", notAcceptableErr.Accept)
+	}
+	mockService.AssertNotCalled(t, "ExportGreetings", mock.Anything)
+}
+
+// newImportRequest builds a multipart request uploading content as a file with the given name and content type
+func newImportRequest(url, filename, contentType, content string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	part, _ := writer.CreatePart(header)
+	_, _ = part.Write([]byte(content))
+	_ = writer.Close()
+
+	req, _ := http.NewRequest("POST", url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestHelloController_ImportGreetings_EscapedCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Cells escaped by the export are read back as they were exported, other cells as they are
+	rows := []service.GreetingImportRow{
+		{Line: 2, Input: dto.GreetingInput{Message: "=1+1", Locale: "en"}},
+		{Line: 3, Input: dto.GreetingInput{Message: "'quoted", Locale: "en"}},
+		{Line: 4, Input: dto.GreetingInput{Message: "'Tis the season", Locale: "en"}},
+	}
+	query := dto.GreetingImportQuery{OnDuplicate: dto.OnDuplicateSkip}
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("ImportGreetings", rows, query).Return([]service.GreetingImportResult{
+		{Line: 2, Outcome: dto.ImportOutcomeCreated, ID: 1},
+		{Line: 3, Outcome: dto.ImportOutcomeCreated, ID: 2},
+		{Line: 4, Outcome: dto.ImportOutcomeCreated, ID: 3},
+	}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.POST("/api/admin/hello/import", controller.ImportGreetings)
+
+	// Mock Request
+	content := "message,locale\n" +
+		"'=1+1,en\n" +
+		"''quoted,en\n" +
+		"'Tis the season,en\n"
+	req := newImportRequest("/api/admin/hello/import", "greetings.csv", "", content)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_ImportGreetings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rows := []service.GreetingImportRow{
+		{Line: 2, Input: dto.GreetingInput{Message: "Hello, Import!", Locale: "en"}},
+		{Line: 3, Input: dto.GreetingInput{Message: "Hello, World!", Locale: "en"}},
+		{Line: 4, Input: dto.GreetingInput{Message: "Hi", Locale: "en"}},
+		{Line: 5, Input: dto.GreetingInput{Message: "Merhaba,\n Dünya!"}},
+	}
+	query := dto.GreetingImportQuery{OnDuplicate: dto.OnDuplicateOverwrite}
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	mockService.On("ImportGreetings", rows, query).Return([]service.GreetingImportResult{
+		{Line: 2, Outcome: dto.ImportOutcomeCreated, ID: 3},
+		{Line: 3, Outcome: dto.ImportOutcomeOverwritten, ID: 1},
+		{},
+		{Line: 5, Outcome: dto.ImportOutcomeFailed, Err: &customError.ResourceConflictError{
+			Resource: "Greeting",
+			Criteria: "message and locale",
+			Value:    "Merhaba,\n Dünya! (tr)",
+		}},
+	}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+	router.POST("/api/admin/hello/import", controller.ImportGreetings)
+
+	// Mock Request
+	content := "message,locale\n" +
+		"\"Hello, Import!\",en\n" +
+		"\"Hello, World!\",en\n" +
+		"Hi,en\n" +
+		"\"Merhaba,\n Dünya!\"\n"
+	req := newImportRequest("/api/admin/hello/import?onDuplicate=overwrite", "greetings.csv", "", content)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var report dto.GreetingImportReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.False(t, report.DryRun)
+	assert.Equal(t, dto.OnDuplicateOverwrite, report.OnDuplicate)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Overwritten)
+	assert.Equal(t, 0, report.Skipped)
+	assert.Equal(t, 2, report.Failed)
+	if assert.Len(t, report.Rows, 4) {
+		assert.Equal(t, uint(3), report.Rows[0].ID)
+		assert.Nil(t, report.Rows[0].Problem)
+
+		assert.Equal(t, 4, report.Rows[2].Line)
+		assert.Equal(t, dto.ImportOutcomeFailed, report.Rows[2].Outcome)
+		assert.Equal(t, "invalid_request", report.Rows[2].Problem.Error)
+
+		assert.Equal(t, 5, report.Rows[3].Line)
+		assert.Equal(t, "resource_conflict", report.Rows[3].Problem.Error)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHelloController_ImportGreetings_Formats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		filename    string
+		contentType string
+		content     string
+		failedLine  int
+	}{
+		{
+			name:        "json",
+			filename:    "greetings",
+			contentType: "application/json",
+			content:     "[\n  {\"id\": 1, \"message\": \"Hello, Import!\", \"locale\": \"en\"},\n  {\"message\": 42}\n]",
+			failedLine:  3,
+		},
+		{
+			name:       "ndjson",
+			filename:   "greetings.ndjson",
+			content:    "{\"id\": 1, \"message\": \"Hello, Import!\", \"locale\": \"en\"}\n\n{\"message\": 42}\n",
+			failedLine: 3,
+		},
+		{
+			name:        "csv with bom",
+			filename:    "greetings.txt",
+			contentType: "text/csv",
+			content:     "\ufeffid,locale,message\n1,en,\"Hello, Import!\"\n2,en,\"unterminated\n",
+			failedLine:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHelloService)
+			mockService.On("ImportGreetings", mock.Anything, dto.GreetingImportQuery{DryRun: true, OnDuplicate: dto.OnDuplicateSkip}).
+				Return([]service.GreetingImportResult{
+					{Line: 2, Outcome: dto.ImportOutcomeCreated},
+					{},
+				}, nil)
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+			router.POST("/api/admin/hello/import", controller.ImportGreetings)
+
+			req := newImportRequest("/api/admin/hello/import?dryRun=true", tt.filename, tt.contentType, tt.content)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			rows := mockService.Calls[0].Arguments.Get(0).([]service.GreetingImportRow)
+			if assert.Len(t, rows, 2) {
+				assert.Equal(t, dto.GreetingInput{Message: "Hello, Import!", Locale: "en"}, rows[0].Input)
+				assert.NoError(t, rows[0].Err)
+				assert.Equal(t, tt.failedLine, rows[1].Line)
+				assert.ErrorAs(t, rows[1].Err, new(*customError.MessageNotReadableError))
+			}
+
+			var report dto.GreetingImportReport
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.True(t, report.DryRun)
+			assert.Equal(t, 1, report.Created)
+			assert.Equal(t, 1, report.Failed)
+		})
+	}
+}
+
+func TestHelloController_ImportGreetings_ScheduleAndTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	publishAt := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	expireAt := time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)
+	expected := dto.GreetingInput{Message: "Merhaba, Dünya!", Locale: "tr", PublishAt: &publishAt, ExpireAt: &expireAt,
+		Tags: []string{"hello, world", "turkish"}}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		// withoutTags is read from the second row, which has no schedule and no tags
+		withoutTags dto.GreetingInput
+	}{
+		{
+			name:     "csv",
+			filename: "greetings.csv",
+			content: "id,message,locale,version,createdAt,updatedAt,publishAt,expireAt,tags\n" +
+				"2,\"Merhaba, Dünya!\",tr,2,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,2025-01-06T10:00:00Z,2025-01-07T10:00:00Z," +
+				"\"\"\"hello, world\"\",turkish\"\n" +
+				"3,Hello,en,1,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,,,\n" +
+				"4,Hello,en,1,2025-01-05T10:00:00Z,2025-01-05T10:00:00Z,tomorrow,,\n",
+			withoutTags: dto.GreetingInput{Message: "Hello", Locale: "en", Tags: []string{}},
+		},
+		{
+			name:     "json",
+			filename: "greetings.json",
+			content: `[{"message":"Merhaba, Dünya!","locale":"tr","publishAt":"2025-01-06T10:00:00Z",` +
+				`"expireAt":"2025-01-07T10:00:00Z","tags":["hello, world","turkish"]},` + "\n" +
+				`{"message":"Hello","locale":"en"},` + "\n" +
+				`{"message":"Hello","locale":"en","publishAt":"tomorrow"}]`,
+			withoutTags: dto.GreetingInput{Message: "Hello", Locale: "en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHelloService)
+			mockService.On("ImportGreetings", mock.Anything, dto.GreetingImportQuery{OnDuplicate: dto.OnDuplicateOverwrite}).
+				Return([]service.GreetingImportResult{{}, {}, {}}, nil)
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+			router.POST("/api/admin/hello/import", controller.ImportGreetings)
+
+			req := newImportRequest("/api/admin/hello/import?onDuplicate=overwrite", tt.filename, "", tt.content)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			// The rows of an export are read back with their schedule and tags
+			rows := mockService.Calls[0].Arguments.Get(0).([]service.GreetingImportRow)
+			if assert.Len(t, rows, 3) {
+				assert.NoError(t, rows[0].Err)
+				assert.Equal(t, expected, rows[0].Input)
+				assert.NoError(t, rows[1].Err)
+				assert.Equal(t, tt.withoutTags, rows[1].Input)
+				assert.ErrorAs(t, rows[2].Err, new(*customError.MessageNotReadableError))
+			}
+		})
+	}
+}
+
+func TestHelloController_ImportGreetings_InvalidFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		filename    string
+		contentType string
+		content     string
+		expectedErr any
+	}{
+		{
+			name:        "unsupported format",
+			filename:    "greetings.xml",
+			contentType: "application/xml",
+			content:     "<greetings/>",
+			expectedErr: new(*customError.UnsupportedMediaTypeError),
+		},
+		{
+			name:        "csv without message column",
+			filename:    "greetings.csv",
+			content:     "id,locale\n1,en\n",
+			expectedErr: new(*customError.MessageNotReadableError),
+		},
+		{
+			name:        "json object",
+			filename:    "greetings.json",
+			content:     `{"message": "Hello, Import!"}`,
+			expectedErr: new(*customError.MessageNotReadableError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHelloService)
+
+			controller := NewHelloController(mockService, validator.New(), nil)
+			router := gin.Default()
+
+			// Capture the errors passed to the error handling middleware
+			var errs []*gin.Error
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				errs = c.Errors
+			})
+			router.POST("/api/admin/hello/import", controller.ImportGreetings)
+
+			req := newImportRequest("/api/admin/hello/import", tt.filename, tt.contentType, tt.content)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Len(t, errs, 1)
+			assert.ErrorAs(t, errs[0].Err, tt.expectedErr)
+			mockService.AssertNotCalled(t, "ImportGreetings", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHelloController_GetRandomGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockHelloService)
+	query := dto.RandomGreetingQuery{Locale: "tr", Tags: []string{"christmas", "winter"}, TagMatch: dto.TagMatchAll}
+	mockService.On("GetRandomGreeting", query).Return(dto.GreetingResponse{
+		ID:        2,
+		Message:   "Mutlu yıllar!",
+		Locale:    "tr",
+		Version:   1,
+		CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		Tags:      []string{"christmas", "winter"},
+	}, nil)
+
+	// Controller Setup
+	controller := NewHelloController(mockService, validator.New(), nil)
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/hello/random", controller.GetRandomGreeting)
+
+	// Random greeting
+	req, _ := http.NewRequest("GET", "/api/hello/random?locale=tr&tag=christmas&tag=winter&tagMatch=all", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "tr", w.Header().Get("Content-Language"))
+	assert.JSONEq(t, `{
+		"id": 2,
+		"message": "Mutlu yıllar!",
+		"locale": "tr",
+		"version": 1,
+		"createdAt": "2025-01-05T10:00:00Z",
+		"updatedAt": "2025-01-05T10:00:00Z",
+		"tags": ["christmas", "winter"]
+	}`, w.Body.String())
+
+	// Invalid tag match
+	req, _ = http.NewRequest("GET", "/api/hello/random?tagMatch=some", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var validationErrs validator.ValidationErrors
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &validationErrs) {
+		assert.Equal(t, "TagMatch", validationErrs[0].Field())
+	}
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type ModerationController interface {
+	GetModerationQueue(c *gin.Context)
+	SubmitGreeting(c *gin.Context)
+	ArchiveGreeting(c *gin.Context)
+	ApproveGreeting(c *gin.Context)
+	RejectGreeting(c *gin.Context)
+}
+
+type moderationControllerImpl struct {
+	moderationService service.ModerationService
+	validator         *validator.Validate
+}
+
+// NewModerationController creates a new instance of ModerationController
+func NewModerationController(moderationService service.ModerationService,
+	validator *validator.Validate) ModerationController {
+	return &moderationControllerImpl{
+		moderationService: moderationService,
+		validator:         validator,
+	}
+}
+
+// GetModerationQueue godoc
+// @Summary List the moderation queue
+// @Description Returns a page of greeting messages waiting for moderation, the longest waiting first by default. Links to the neighbouring pages are returned in the Link header.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Zero-based page index" default(0) minimum(0)
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query []string false "Sort criteria in the format property[,asc|desc]; properties: id, message, createdAt, updatedAt" collectionFormat(multi)
+// @Success 200 {object} dto.PagedResponse[dto.GreetingResponse]
+// @Header 200 {string} Link "Links to the first, prev, next and last pages"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/moderation [get]
+func (mc *moderationControllerImpl) GetModerationQueue(c *gin.Context) {
+	var query dto.ModerationQueueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := mc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greetings, err := mc.moderationService.GetModerationQueue(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setPageLinks(c, greetings.Page)
+	c.JSON(http.StatusOK, greetings)
+}
+
+// SubmitGreeting godoc
+// @Summary Submit a greeting message for moderation
+// @Description Submits a draft or rejected greeting message for moderation. Only its owner and admins may submit it.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/submit [post]
+func (mc *moderationControllerImpl) SubmitGreeting(c *gin.Context) {
+	mc.moderateGreeting(c, mc.moderationService.SubmitGreeting)
+}
+
+// ArchiveGreeting godoc
+// @Summary Archive a greeting message
+// @Description Archives a greeting message, which hides it from everyone but its owner and admins. Only its owner and admins may archive it.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/archive [post]
+func (mc *moderationControllerImpl) ArchiveGreeting(c *gin.Context) {
+	mc.moderateGreeting(c, mc.moderationService.ArchiveGreeting)
+}
+
+// ApproveGreeting godoc
+// @Summary Approve a greeting message
+// @Description Approves a greeting message waiting for moderation, which makes it visible to everyone
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/moderation/{id}/approve [post]
+func (mc *moderationControllerImpl) ApproveGreeting(c *gin.Context) {
+	mc.moderateGreeting(c, mc.moderationService.ApproveGreeting)
+}
+
+// RejectGreeting godoc
+// @Summary Reject a greeting message
+// @Description Rejects a greeting message waiting for moderation with a reason for its owner, who may change and submit it again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param input body dto.GreetingRejectionInput true "Reason of the rejection"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/moderation/{id}/reject [post]
+func (mc *moderationControllerImpl) RejectGreeting(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input dto.GreetingRejectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := mc.validator.Struct(input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rejectedGreeting, err := mc.moderationService.RejectGreeting(c.Request.Context(), id, input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, rejectedGreeting.ID, rejectedGreeting.Version)
+	c.JSON(http.StatusOK, rejectedGreeting)
+}
+
+// moderateGreeting changes the status of the greeting in the path with the moderation action
+func (mc *moderationControllerImpl) moderateGreeting(c *gin.Context,
+	action func(ctx context.Context, id uint) (dto.GreetingResponse, error)) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	moderatedGreeting, err := action(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, moderatedGreeting.ID, moderatedGreeting.Version)
+	c.JSON(http.StatusOK, moderatedGreeting)
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// MockModerationService simulates the ModerationService
+type MockModerationService struct {
+	mock.Mock
+}
+
+func (m *MockModerationService) GetModerationQueue(_ context.Context, query dto.ModerationQueueQuery) (dto.PagedResponse[dto.GreetingResponse], error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.PagedResponse[dto.GreetingResponse]), args.Error(1)
+}
+
+func (m *MockModerationService) SubmitGreeting(_ context.Context, id uint) (dto.GreetingResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+func (m *MockModerationService) ArchiveGreeting(_ context.Context, id uint) (dto.GreetingResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+func (m *MockModerationService) ApproveGreeting(_ context.Context, id uint) (dto.GreetingResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+func (m *MockModerationService) RejectGreeting(_ context.Context, id uint,
+	input dto.GreetingRejectionInput) (dto.GreetingResponse, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+func TestModerationController_GetModerationQueue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockModerationService)
+	mockService.On("GetModerationQueue", dto.ModerationQueueQuery{Page: 0, Size: 20}).
+		Return(dto.PagedResponse[dto.GreetingResponse]{
+			Content: []dto.GreetingResponse{{
+				ID:        1,
+				Message:   "Hello, World!",
+				Locale:    "en",
+				Status:    "PENDING",
+				OwnerID:   "user",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			}},
+			Page: dto.PageMetadata{Number: 0, Size: 20, TotalElements: 1, TotalPages: 1},
+		}, nil)
+
+	// Controller Setup
+	controller := NewModerationController(mockService, validator.New())
+	router := gin.Default()
+	router.GET("/api/admin/hello/moderation", controller.GetModerationQueue)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/admin/hello/moderation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	expectedResponse := `{
+		"content": [{
+			"id": 1,
+			"message": "Hello, World!",
+			"locale": "en",
+			"status": "PENDING",
+			"ownerId": "user",
+			"version": 1,
+			"createdAt": "2025-01-05T10:00:00Z",
+			"updatedAt": "2025-01-05T10:00:00Z"
+		}],
+		"page": {"number": 0, "size": 20, "totalElements": 1, "totalPages": 1}
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestModerationController_ModerateGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockModerationService)
+	mockService.On("SubmitGreeting", uint(1)).
+		Return(dto.GreetingResponse{ID: 1, Message: "Hello, World!", Locale: "en", Status: "PENDING", Version: 2}, nil)
+	mockService.On("ArchiveGreeting", uint(1)).
+		Return(dto.GreetingResponse{ID: 1, Message: "Hello, World!", Locale: "en", Status: "ARCHIVED", Version: 3}, nil)
+	mockService.On("ApproveGreeting", uint(2)).
+		Return(dto.GreetingResponse{}, &customError.InvalidStateTransitionError{
+			Resource: "Greeting", Value: "2", From: "APPROVED", To: "APPROVED"})
+
+	// Controller Setup
+	controller := NewModerationController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.POST("/api/hello/:id/submit", controller.SubmitGreeting)
+	router.POST("/api/hello/:id/archive", controller.ArchiveGreeting)
+	router.POST("/api/admin/hello/moderation/:id/approve", controller.ApproveGreeting)
+
+	// Submitted greeting
+	req, _ := http.NewRequest("POST", "/api/hello/1/submit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"status":"PENDING"`)
+
+	// Archived greeting
+	req, _ = http.NewRequest("POST", "/api/hello/1/archive", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-3"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"status":"ARCHIVED"`)
+
+	// An approved greeting cannot be approved again
+	req, _ = http.NewRequest("POST", "/api/admin/hello/moderation/2/approve", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var transitionErr *customError.InvalidStateTransitionError
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &transitionErr) {
+		assert.Equal(t, "APPROVED", transitionErr.From)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestModerationController_RejectGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	reason := "Contains a link"
+	mockService := new(MockModerationService)
+	mockService.On("RejectGreeting", uint(1), dto.GreetingRejectionInput{Reason: reason}).
+		Return(dto.GreetingResponse{ID: 1, Message: "Hello, World!", Locale: "en", Status: "REJECTED",
+			RejectionReason: &reason, Version: 2}, nil)
+
+	// Controller Setup
+	controller := NewModerationController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.POST("/api/admin/hello/moderation/:id/reject", controller.RejectGreeting)
+
+	// Rejected greeting
+	req, _ := http.NewRequest("POST", "/api/admin/hello/moderation/1/reject",
+		bytes.NewBufferString(`{"reason":"Contains a link"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+	assert.JSONEq(t, `{"id": 1, "message": "Hello, World!", "locale": "en", "status": "REJECTED",
+		"rejectionReason": "Contains a link", "version": 2,
+		"createdAt": "0001-01-01T00:00:00Z", "updatedAt": "0001-01-01T00:00:00Z"}`, w.Body.String())
+
+	// Missing reason
+	req, _ = http.NewRequest("POST", "/api/admin/hello/moderation/1/reject", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var validationErrs validator.ValidationErrors
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &validationErrs) {
+		assert.Equal(t, "Reason", validationErrs[0].Field())
+	}
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"fmt"
+	"gin-samples/internal/dto"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+)
+
+// setPageLinks adds a Link header (RFC 8288) pointing to the first, previous, next and last pages.
+// The links keep the other query parameters of the request, so filters and sorting are preserved.
+func setPageLinks(c *gin.Context, page dto.PageMetadata) {
+	if page.TotalPages == 0 {
+		return
+	}
+
+	link := func(number int, rel string) string {
+		query := c.Request.URL.Query()
+		query.Set("page", strconv.Itoa(number))
+		query.Set("size", strconv.Itoa(page.Size))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, query.Encode(), rel)
+	}
+
+	lastPage := page.TotalPages - 1
+	links := []string{link(0, "first")}
+	if page.Number > 0 {
+		links = append(links, link(min(page.Number-1, lastPage), "prev"))
+	}
+	if page.Number < lastPage {
+		links = append(links, link(page.Number+1, "next"))
+	}
+	links = append(links, link(lastPage, "last"))
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// setCursorLinks adds a Link header (RFC 8288) pointing to the next and previous pages of a cursor based listing
+func setCursorLinks(c *gin.Context, next, prev string) {
+	link := func(cursor, rel string) string {
+		query := c.Request.URL.Query()
+		query.Del("sort") // The cursor carries the sort order
+		query.Set("cursor", cursor)
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, query.Encode(), rel)
+	}
+
+	var links []string
+	if prev != "" {
+		links = append(links, link(prev, "prev"))
+	}
+	if next != "" {
+		links = append(links, link(next, "next"))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	customError "gin-samples/internal/error"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+)
+
+// Media types of the supported patch formats
+const (
+	MediaTypeMergePatch = "application/merge-patch+json" // JSON Merge Patch (RFC 7396)
+	MediaTypeJSONPatch  = "application/json-patch+json"  // JSON Patch (RFC 6902)
+)
+
+var patchMediaTypes = []string{MediaTypeMergePatch, MediaTypeJSONPatch}
+
+// checkPatchMediaType rejects request bodies that are not in one of the supported patch formats
+func checkPatchMediaType(c *gin.Context) error {
+	switch c.ContentType() {
+	case MediaTypeMergePatch, MediaTypeJSONPatch:
+		return nil
+	}
+	return &customError.UnsupportedMediaTypeError{MediaType: c.ContentType(), Supported: patchMediaTypes}
+}
+
+// applyPatch applies the patch body of the given media type to the JSON representation of document
+// and decodes the patched document into target. Fields unknown to target are rejected,
+// so a patch cannot silently target read-only properties such as the id.
+func applyPatch(mediaType string, body []byte, document any, target any) error {
+	original, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch mediaType {
+	case MediaTypeMergePatch:
+		if !json.Valid(body) {
+			return &customError.MessageNotReadableError{Detail: "the merge patch is not valid JSON"}
+		}
+		if patched, err = jsonpatch.MergePatch(original, body); err != nil {
+			return &customError.UnprocessablePatchError{Detail: err.Error()}
+		}
+	case MediaTypeJSONPatch:
+		patch, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return &customError.MessageNotReadableError{Detail: err.Error()}
+		}
+		if patched, err = patch.Apply(original); err != nil {
+			return &customError.UnprocessablePatchError{Detail: err.Error()}
+		}
+	default:
+		return &customError.UnsupportedMediaTypeError{MediaType: mediaType, Supported: patchMediaTypes}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return &customError.UnprocessablePatchError{Detail: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type ReactionController interface {
+	GetReactions(c *gin.Context)
+	AddReaction(c *gin.Context)
+	RemoveReaction(c *gin.Context)
+	GetTopGreetings(c *gin.Context)
+}
+
+type reactionControllerImpl struct {
+	reactionService service.ReactionService
+	validator       *validator.Validate
+}
+
+// NewReactionController creates a new instance of ReactionController
+func NewReactionController(reactionService service.ReactionService, validator *validator.Validate) ReactionController {
+	return &reactionControllerImpl{
+		reactionService: reactionService,
+		validator:       validator,
+	}
+}
+
+// GetReactions godoc
+// @Summary Get the reactions to a greeting message
+// @Description Returns the number of reactions per type to a greeting message and the types the caller reacted with
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Success 200 {object} dto.ReactionSummaryResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/reactions [get]
+func (rc *reactionControllerImpl) GetReactions(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reactions, err := rc.reactionService.GetReactions(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reactions)
+}
+
+// AddReaction godoc
+// @Summary React to a greeting message
+// @Description Adds a reaction of the caller to a greeting message. A user reacts at most once per type, so reacting again has no effect.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param type path string true "Reaction type" Enums(like, love, laugh, wow, sad, celebrate)
+// @Success 200 {object} dto.ReactionSummaryResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/reactions/{type} [put]
+func (rc *reactionControllerImpl) AddReaction(c *gin.Context) {
+	rc.changeReaction(c, rc.reactionService.AddReaction)
+}
+
+// RemoveReaction godoc
+// @Summary Remove a reaction from a greeting message
+// @Description Removes a reaction of the caller from a greeting message. Removing a missing reaction has no effect.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param type path string true "Reaction type" Enums(like, love, laugh, wow, sad, celebrate)
+// @Success 200 {object} dto.ReactionSummaryResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/reactions/{type} [delete]
+func (rc *reactionControllerImpl) RemoveReaction(c *gin.Context) {
+	rc.changeReaction(c, rc.reactionService.RemoveReaction)
+}
+
+// changeReaction changes the reaction of the caller to the greeting in the path with the action
+func (rc *reactionControllerImpl) changeReaction(c *gin.Context,
+	action func(ctx context.Context, id uint, reactionType string) (dto.ReactionSummaryResponse, error)) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reactions, err := action(c.Request.Context(), id, c.Param("type"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reactions)
+}
+
+// GetTopGreetings godoc
+// @Summary List the top greeting messages
+// @Description Ranks the greeting messages by the number of reactions they received in a period ending now, most reactions first. Greetings without reactions in the period are not ranked.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period query string false "Period whose reactions are counted" Enums(day, week, month, year, all) default(week)
+// @Param type query string false "Only count reactions of the type" Enums(like, love, laugh, wow, sad, celebrate)
+// @Param size query int false "Maximum number of greetings" default(10) minimum(1) maximum(100)
+// @Success 200 {array} dto.TopGreetingResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/top [get]
+func (rc *reactionControllerImpl) GetTopGreetings(c *gin.Context) {
+	var query dto.TopGreetingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := rc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	greetings, err := rc.reactionService.GetTopGreetings(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, greetings)
+}
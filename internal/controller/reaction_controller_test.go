@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// MockReactionService simulates the ReactionService
+type MockReactionService struct {
+	mock.Mock
+}
+
+func (m *MockReactionService) GetReactions(_ context.Context, greetingID uint) (dto.ReactionSummaryResponse, error) {
+	args := m.Called(greetingID)
+	return args.Get(0).(dto.ReactionSummaryResponse), args.Error(1)
+}
+
+func (m *MockReactionService) AddReaction(_ context.Context, greetingID uint,
+	reactionType string) (dto.ReactionSummaryResponse, error) {
+	args := m.Called(greetingID, reactionType)
+	return args.Get(0).(dto.ReactionSummaryResponse), args.Error(1)
+}
+
+func (m *MockReactionService) RemoveReaction(_ context.Context, greetingID uint,
+	reactionType string) (dto.ReactionSummaryResponse, error) {
+	args := m.Called(greetingID, reactionType)
+	return args.Get(0).(dto.ReactionSummaryResponse), args.Error(1)
+}
+
+func (m *MockReactionService) GetTopGreetings(_ context.Context, query dto.TopGreetingQuery) ([]dto.TopGreetingResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]dto.TopGreetingResponse), args.Error(1)
+}
+
+func TestReactionController_Reactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockReactionService)
+	mockService.On("GetReactions", uint(1)).
+		Return(dto.ReactionSummaryResponse{GreetingID: 1, Reactions: map[string]int64{"like": 2}, Mine: []string{}}, nil)
+	mockService.On("AddReaction", uint(1), "love").
+		Return(dto.ReactionSummaryResponse{GreetingID: 1, Reactions: map[string]int64{"like": 2, "love": 1},
+			Mine: []string{"love"}}, nil)
+	mockService.On("RemoveReaction", uint(1), "love").
+		Return(dto.ReactionSummaryResponse{GreetingID: 1, Reactions: map[string]int64{"like": 2}, Mine: []string{}}, nil)
+
+	// Controller Setup
+	controller := NewReactionController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/hello/:id/reactions", controller.GetReactions)
+	router.PUT("/api/hello/:id/reactions/:type", controller.AddReaction)
+	router.DELETE("/api/hello/:id/reactions/:type", controller.RemoveReaction)
+
+	// Reactions of a greeting
+	req, _ := http.NewRequest("GET", "/api/hello/1/reactions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"greetingId": 1, "reactions": {"like": 2}, "mine": []}`, w.Body.String())
+
+	// Added reaction
+	req, _ = http.NewRequest("PUT", "/api/hello/1/reactions/love", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"greetingId": 1, "reactions": {"like": 2, "love": 1}, "mine": ["love"]}`, w.Body.String())
+
+	// Removed reaction
+	req, _ = http.NewRequest("DELETE", "/api/hello/1/reactions/love", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"greetingId": 1, "reactions": {"like": 2}, "mine": []}`, w.Body.String())
+
+	// Invalid ID
+	req, _ = http.NewRequest("PUT", "/api/hello/abc/reactions/love", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var constraintErr customError.ConstraintViolationError
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &constraintErr) {
+		assert.Equal(t, "id", constraintErr.Violations[0].Field)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReactionController_GetTopGreetings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockReactionService)
+	mockService.On("GetTopGreetings", dto.TopGreetingQuery{Period: dto.TopPeriodWeek, Type: "like", Size: 10}).
+		Return([]dto.TopGreetingResponse{{
+			GreetingResponse: dto.GreetingResponse{
+				ID:        1,
+				Message:   "Hello, World!",
+				Locale:    "en",
+				Version:   1,
+				CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+				Reactions: map[string]int64{"like": 3, "love": 1},
+			},
+			Score: 3,
+		}}, nil)
+
+	// Controller Setup
+	controller := NewReactionController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/hello/top", controller.GetTopGreetings)
+
+	// The period defaults to a week
+	req, _ := http.NewRequest("GET", "/api/hello/top?type=like", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	expectedResponse := `[{
+		"id": 1,
+		"message": "Hello, World!",
+		"locale": "en",
+		"version": 1,
+		"createdAt": "2025-01-05T10:00:00Z",
+		"updatedAt": "2025-01-05T10:00:00Z",
+		"reactions": {"like": 3, "love": 1},
+		"score": 3
+	}]`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	// Unknown period
+	req, _ = http.NewRequest("GET", "/api/hello/top?period=decade", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var validationErrs validator.ValidationErrors
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &validationErrs) {
+		assert.Equal(t, "Period", validationErrs[0].Field())
+	}
+
+	mockService.AssertExpectations(t)
+}
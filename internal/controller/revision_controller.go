@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type RevisionController interface {
+	GetGreetingRevisions(c *gin.Context)
+	DiffGreetingRevisions(c *gin.Context)
+	RollbackGreeting(c *gin.Context)
+}
+
+type revisionControllerImpl struct {
+	revisionService service.RevisionService
+	validator       *validator.Validate
+}
+
+// NewRevisionController creates a new instance of RevisionController
+func NewRevisionController(revisionService service.RevisionService, validator *validator.Validate) RevisionController {
+	return &revisionControllerImpl{
+		revisionService: revisionService,
+		validator:       validator,
+	}
+}
+
+// GetGreetingRevisions godoc
+// @Summary List the revisions of a greeting message
+// @Description Lists the recorded changes of a greeting message with the old and new values, most recent first. Greetings the user cannot see are not found; admins list the revisions of greetings in the trash as well.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param page query int false "Page index (zero-based)" default(0)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} dto.PagedResponse[dto.GreetingRevisionResponse]
+// @Header 200 {string} Link "Links to the first, previous, next and last pages (RFC 8288)"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/revisions [get]
+func (rc *revisionControllerImpl) GetGreetingRevisions(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var query dto.GreetingRevisionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := rc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	revisions, err := rc.revisionService.GetGreetingRevisions(c.Request.Context(), id, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setPageLinks(c, revisions.Page)
+	c.JSON(http.StatusOK, revisions)
+}
+
+// DiffGreetingRevisions godoc
+// @Summary Compare two revisions of a greeting message
+// @Description Lists the fields whose values after the two revisions differ. A value is null when the revision deleted the greeting. Greetings the user cannot see are not found.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param from query int true "Revision to compare from"
+// @Param to query int true "Revision to compare to"
+// @Success 200 {object} dto.GreetingRevisionDiffResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/revisions/diff [get]
+func (rc *revisionControllerImpl) DiffGreetingRevisions(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var query dto.GreetingRevisionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := rc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	diff, err := rc.revisionService.DiffGreetingRevisions(c.Request.Context(), id, query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RollbackGreeting godoc
+// @Summary Roll back a greeting message to a revision
+// @Description Sets the message and locale of a greeting back to their values after the revision. The rollback is recorded as a new revision. Revisions which deleted the greeting cannot be rolled back to; deleted greetings are restored from the trash instead.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Greeting ID"
+// @Param revision path int true "Revision to roll back to"
+// @Param If-Match header string false "ETag of the greeting version the change is based on"
+// @Success 200 {object} dto.GreetingResponse
+// @Header 200 {string} ETag "Version of the greeting"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 412 {object} dto.ProblemDetail
+// @Failure 428 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/{id}/revisions/{revision}/rollback [post]
+func (rc *revisionControllerImpl) RollbackGreeting(c *gin.Context) {
+	id, err := parseGreetingID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	revision, err := parsePathNumber(c, "revision", "Revision")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rolledBackGreeting, err := rc.revisionService.RollbackGreeting(c.Request.Context(), id, revision,
+		ifMatchPrecondition(c, id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, rolledBackGreeting.ID, rolledBackGreeting.Version)
+	c.JSON(http.StatusOK, rolledBackGreeting)
+}
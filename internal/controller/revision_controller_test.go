@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// MockRevisionService simulates the RevisionService
+type MockRevisionService struct {
+	mock.Mock
+}
+
+func (m *MockRevisionService) GetGreetingRevisions(_ context.Context, id uint,
+	query dto.GreetingRevisionQuery) (dto.PagedResponse[dto.GreetingRevisionResponse], error) {
+	args := m.Called(id, query)
+	return args.Get(0).(dto.PagedResponse[dto.GreetingRevisionResponse]), args.Error(1)
+}
+
+func (m *MockRevisionService) DiffGreetingRevisions(_ context.Context, id uint,
+	query dto.GreetingRevisionDiffQuery) (dto.GreetingRevisionDiffResponse, error) {
+	args := m.Called(id, query)
+	return args.Get(0).(dto.GreetingRevisionDiffResponse), args.Error(1)
+}
+
+func (m *MockRevisionService) RollbackGreeting(_ context.Context, id, revision uint,
+	precondition *dto.VersionPrecondition) (dto.GreetingResponse, error) {
+	args := m.Called(id, revision, precondition)
+	return args.Get(0).(dto.GreetingResponse), args.Error(1)
+}
+
+func TestRevisionController_GetGreetingRevisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockRevisionService)
+	mockService.On("GetGreetingRevisions", uint(1), dto.GreetingRevisionQuery{Page: 0, Size: 20}).
+		Return(dto.PagedResponse[dto.GreetingRevisionResponse]{
+			Content: []dto.GreetingRevisionResponse{{
+				Revision:  2,
+				Action:    "UPDATE",
+				Version:   2,
+				Old:       &dto.GreetingState{Message: "Hello, World!", Locale: "en"},
+				New:       &dto.GreetingState{Message: "Hello, Revisions!", Locale: "en"},
+				ChangedBy: "1",
+				ChangedAt: time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC),
+			}, {
+				Revision:  1,
+				Action:    "CREATE",
+				Version:   1,
+				New:       &dto.GreetingState{Message: "Hello, World!", Locale: "en"},
+				ChangedBy: "1",
+				ChangedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			}},
+			Page: dto.PageMetadata{Number: 0, Size: 20, TotalElements: 2, TotalPages: 1},
+		}, nil)
+
+	// Controller Setup
+	controller := NewRevisionController(mockService, validator.New())
+	router := gin.Default()
+	router.GET("/api/hello/:id/revisions", controller.GetGreetingRevisions)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/hello/1/revisions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	expectedResponse := `{
+		"content": [{
+			"revision": 2,
+			"action": "UPDATE",
+			"version": 2,
+			"old": {"message": "Hello, World!", "locale": "en"},
+			"new": {"message": "Hello, Revisions!", "locale": "en"},
+			"changedBy": "1",
+			"changedAt": "2025-01-05T12:00:00Z"
+		}, {
+			"revision": 1,
+			"action": "CREATE",
+			"version": 1,
+			"old": null,
+			"new": {"message": "Hello, World!", "locale": "en"},
+			"changedBy": "1",
+			"changedAt": "2025-01-05T10:00:00Z"
+		}],
+		"page": {"number": 0, "size": 20, "totalElements": 2, "totalPages": 1}
+	}`
+	assert.JSONEq(t, expectedResponse, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRevisionController_DiffGreetingRevisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from, to := "Hello, World!", "Hello, Revisions!"
+
+	// Mock Service
+	mockService := new(MockRevisionService)
+	mockService.On("DiffGreetingRevisions", uint(1), dto.GreetingRevisionDiffQuery{From: 1, To: 2}).
+		Return(dto.GreetingRevisionDiffResponse{
+			From:    dto.GreetingRevisionResponse{Revision: 1, Action: "CREATE", Version: 1},
+			To:      dto.GreetingRevisionResponse{Revision: 2, Action: "UPDATE", Version: 2},
+			Changes: []dto.GreetingFieldChange{{Field: "message", From: &from, To: &to}},
+		}, nil)
+
+	// Controller Setup
+	controller := NewRevisionController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.GET("/api/hello/:id/revisions/diff", controller.DiffGreetingRevisions)
+
+	// Compared revisions
+	req, _ := http.NewRequest("GET", "/api/hello/1/revisions/diff?from=1&to=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.GreetingRevisionDiffResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(1), response.From.Revision)
+	assert.Equal(t, uint(2), response.To.Revision)
+	if assert.Len(t, response.Changes, 1) {
+		assert.Equal(t, "message", response.Changes[0].Field)
+		assert.Equal(t, "Hello, Revisions!", *response.Changes[0].To)
+	}
+
+	// Missing revision to compare to
+	req, _ = http.NewRequest("GET", "/api/hello/1/revisions/diff?from=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Len(t, errs, 1)
+	assert.ErrorAs(t, errs[0].Err, new(validator.ValidationErrors))
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRevisionController_RollbackGreeting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockRevisionService)
+	mockService.On("RollbackGreeting", uint(1), uint(1), &dto.VersionPrecondition{Versions: []uint{2}}).
+		Return(dto.GreetingResponse{
+			ID:        1,
+			Message:   "Hello, World!",
+			Locale:    "en",
+			Version:   3,
+			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+		}, nil)
+
+	// Controller Setup
+	controller := NewRevisionController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.POST("/api/hello/:id/revisions/:revision/rollback", controller.RollbackGreeting)
+
+	// Rolled back greeting
+	req, _ := http.NewRequest("POST", "/api/hello/1/revisions/1/rollback", nil)
+	req.Header.Set("If-Match", `"1-2"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-3"`, w.Header().Get("ETag"))
+
+	// Invalid revision
+	req, _ = http.NewRequest("POST", "/api/hello/1/revisions/0/rollback", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var constraintErr customError.ConstraintViolationError
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &constraintErr) {
+		assert.Equal(t, "revision", constraintErr.Violations[0].Field)
+	}
+
+	mockService.AssertExpectations(t)
+}
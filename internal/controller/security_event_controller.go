@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type SecurityEventController interface {
+	GetSecurityEvents(c *gin.Context)
+}
+
+type securityEventControllerImpl struct {
+	auditService service.SecurityAuditService
+	validator    *validator.Validate
+}
+
+// NewSecurityEventController creates a new instance of SecurityEventController
+func NewSecurityEventController(auditService service.SecurityAuditService, validator *validator.Validate) SecurityEventController {
+	return &securityEventControllerImpl{
+		auditService: auditService,
+		validator:    validator,
+	}
+}
+
+// GetSecurityEvents godoc
+// @Summary Query the security audit log
+// @Description Returns security events filtered by user, type and time range, newest first. Admins only see the events of the users of their tenant.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user query string false "User id or attempted username"
+// @Param type query string false "Event type" Enums(LOGIN_SUCCESS, LOGIN_FAILURE, TOKEN_REJECTED, ACCESS_DENIED, ADMIN_ACTION)
+// @Param from query string false "Inclusive start of the time range (RFC 3339)"
+// @Param to query string false "Exclusive end of the time range (RFC 3339)"
+// @Param limit query int false "Maximum number of events" default(100) minimum(1) maximum(1000)
+// @Success 200 {array} dto.SecurityEventResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/security-events [get]
+func (s *securityEventControllerImpl) GetSecurityEvents(c *gin.Context) {
+	var query dto.SecurityEventQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := s.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	events, err := s.auditService.FindEvents(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
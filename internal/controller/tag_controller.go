@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type TagController interface {
+	GetTags(c *gin.Context)
+	RenameTag(c *gin.Context)
+	MergeTags(c *gin.Context)
+}
+
+type tagControllerImpl struct {
+	tagService service.TagService
+	validator  *validator.Validate
+}
+
+// NewTagController creates a new instance of TagController
+func NewTagController(tagService service.TagService, validator *validator.Validate) TagController {
+	return &tagControllerImpl{
+		tagService: tagService,
+		validator:  validator,
+	}
+}
+
+// GetTags godoc
+// @Summary Get a page of tags
+// @Description Returns a page of the tags of greeting messages with the number of greetings tagged with each, not counting the trash. Only the tags and greetings of the caller's tenant are listed and counted. Links to the neighbouring pages are returned in the Link header.
+// @Tags hello
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Zero-based page index" default(0) minimum(0)
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query []string false "Sort criteria in the format property[,asc|desc]; properties: name, count, createdAt" collectionFormat(multi)
+// @Success 200 {object} dto.PagedResponse[dto.TagResponse]
+// @Header 200 {string} Link "Links to the first, prev, next and last pages"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/hello/tags [get]
+func (tc *tagControllerImpl) GetTags(c *gin.Context) {
+	var query dto.TagQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := tc.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tags, err := tc.tagService.GetTags(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setPageLinks(c, tags.Page)
+	c.JSON(http.StatusOK, tags)
+}
+
+// RenameTag godoc
+// @Summary Rename a tag
+// @Description Renames a tag of greeting messages. The greetings tagged with it get a new version. Fails with a conflict when another tag has the name; merge the tags instead. Tags are shared by all tenants, so only super-admins rename them.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Param input body dto.TagInput true "Tag Input"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 409 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/tags/{id} [put]
+func (tc *tagControllerImpl) RenameTag(c *gin.Context) {
+	id, err := parsePathNumber(c, "id", "ID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var input dto.TagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := tc.validator.Struct(input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tag, err := tc.tagService.RenameTag(c.Request.Context(), id, input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// MergeTags godoc
+// @Summary Merge tags
+// @Description Tags the greeting messages tagged with the source tags with the target tag instead, and deletes the source tags. The greetings tagged with a source tag get a new version. Tags are shared by all tenants, so only super-admins merge them.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body dto.TagMergeInput true "Tags to merge"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 404 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/hello/tags/merge [post]
+func (tc *tagControllerImpl) MergeTags(c *gin.Context) {
+	var input dto.TagMergeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := tc.validator.Struct(input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tag, err := tc.tagService.MergeTags(c.Request.Context(), input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"gin-samples/internal/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// MockTagService simulates the TagService
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) GetTags(_ context.Context, query dto.TagQuery) (dto.PagedResponse[dto.TagResponse], error) {
+	args := m.Called(query)
+	return args.Get(0).(dto.PagedResponse[dto.TagResponse]), args.Error(1)
+}
+
+func (m *MockTagService) RenameTag(_ context.Context, id uint, input dto.TagInput) (dto.TagResponse, error) {
+	args := m.Called(id, input)
+	return args.Get(0).(dto.TagResponse), args.Error(1)
+}
+
+func (m *MockTagService) MergeTags(_ context.Context, input dto.TagMergeInput) (dto.TagResponse, error) {
+	args := m.Called(input)
+	return args.Get(0).(dto.TagResponse), args.Error(1)
+}
+
+func TestTagController_GetTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockTagService)
+	query := dto.TagQuery{Page: 0, Size: 1, Sort: []string{"count,desc"}}
+	mockService.On("GetTags", query).Return(dto.PagedResponse[dto.TagResponse]{
+		Content: []dto.TagResponse{{
+			ID:        1,
+			Name:      "christmas",
+			Count:     2,
+			CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		}},
+		Page: dto.PageMetadata{Number: 0, Size: 1, TotalElements: 2, TotalPages: 2},
+	}, nil)
+
+	// Controller Setup
+	controller := NewTagController(mockService, validator.New())
+	router := gin.Default()
+	router.GET("/api/hello/tags", controller.GetTags)
+
+	// Mock Request
+	req, _ := http.NewRequest("GET", "/api/hello/tags?size=1&sort=count,desc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Link"), `rel="next"`)
+	assert.JSONEq(t, `{
+		"content": [{
+			"id": 1,
+			"name": "christmas",
+			"count": 2,
+			"createdAt": "2025-01-05T10:00:00Z"
+		}],
+		"page": {"number": 0, "size": 1, "totalElements": 2, "totalPages": 2}
+	}`, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTagController_RenameTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockTagService)
+	mockService.On("RenameTag", uint(1), dto.TagInput{Name: "xmas"}).
+		Return(dto.TagResponse{ID: 1, Name: "xmas", Count: 2, CreatedBy: "2", UpdatedBy: "9"}, nil)
+
+	// Controller Setup
+	controller := NewTagController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.PUT("/api/admin/hello/tags/:id", controller.RenameTag)
+
+	// Renamed tag
+	req, _ := http.NewRequest("PUT", "/api/admin/hello/tags/1", bytes.NewBufferString(`{"name":"xmas"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": 1, "name": "xmas", "count": 2, "createdAt": "0001-01-01T00:00:00Z",
+		"createdBy": "2", "updatedBy": "9"}`, w.Body.String())
+
+	// Missing name
+	req, _ = http.NewRequest("PUT", "/api/admin/hello/tags/1", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var validationErrs validator.ValidationErrors
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &validationErrs) {
+		assert.Equal(t, "Name", validationErrs[0].Field())
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTagController_MergeTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Mock Service
+	mockService := new(MockTagService)
+	input := dto.TagMergeInput{SourceIDs: []uint{2, 3}, TargetID: 1}
+	mockService.On("MergeTags", input).
+		Return(dto.TagResponse{ID: 1, Name: "christmas", Count: 3}, nil)
+
+	// Controller Setup
+	controller := NewTagController(mockService, validator.New())
+	router := gin.Default()
+
+	// Capture the errors passed to the error handling middleware
+	var errs []*gin.Error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	router.POST("/api/admin/hello/tags/merge", controller.MergeTags)
+
+	// Merged tags
+	req, _ := http.NewRequest("POST", "/api/admin/hello/tags/merge",
+		bytes.NewBufferString(`{"sourceIds":[2,3],"targetId":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": 1, "name": "christmas", "count": 3, "createdAt": "0001-01-01T00:00:00Z"}`, w.Body.String())
+
+	// No source tags
+	req, _ = http.NewRequest("POST", "/api/admin/hello/tags/merge",
+		bytes.NewBufferString(`{"sourceIds":[],"targetId":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var validationErrs validator.ValidationErrors
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0].Err, &validationErrs) {
+		assert.Equal(t, "SourceIDs", validationErrs[0].Field())
+	}
+
+	mockService.AssertExpectations(t)
+}
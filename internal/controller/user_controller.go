@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type UserController interface {
+	GetUsers(c *gin.Context)
+}
+
+type userControllerImpl struct {
+	userService service.UserService
+	validator   *validator.Validate
+}
+
+// NewUserController creates a new instance of UserController
+func NewUserController(userService service.UserService, validator *validator.Validate) UserController {
+	return &userControllerImpl{
+		userService: userService,
+		validator:   validator,
+	}
+}
+
+// GetUsers godoc
+// @Summary List users
+// @Description Returns a page of the users of the caller's tenant using keyset pagination. Follow the next and prev cursors to scroll through the users.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "Cursor of the requested page"
+// @Param size query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort criterion in the format property[,asc|desc] for the first page; properties: id, username, email, createdAt"
+// @Success 200 {object} dto.CursorPagedResponse[dto.UserResponse]
+// @Header 200 {string} Link "Links to the prev and next pages"
+// @Failure 400 {object} dto.ProblemDetail
+// @Failure 401 {object} dto.ProblemDetail
+// @Failure 403 {object} dto.ProblemDetail
+// @Failure 500 {object} dto.ProblemDetail
+// @Router /api/admin/users [get]
+func (u *userControllerImpl) GetUsers(c *gin.Context) {
+	var query dto.CursorQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(&customError.MessageNotReadableError{
+			Detail: err.Error(),
+		})
+		return
+	}
+
+	if err := u.validator.Struct(query); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	users, err := u.userService.GetUsersByCursor(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setCursorLinks(c, users.Next, users.Prev)
+	c.JSON(http.StatusOK, users)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
+	"log"
 )
 
 type Container struct {
@@ -23,12 +24,35 @@ type Container struct {
 	DB                    *gorm.DB
 	HelloRepository       repository.HelloRepository
 	UserRepository        repository.UserRepository
+	SecurityEventRepo     repository.SecurityEventRepository
+	CommentRepository     repository.CommentRepository
+	TagRepository         repository.TagRepository
+	ReactionRepository    repository.ReactionRepository
+	GreetingPinRepository repository.GreetingPinRepository
+	RevisionRepository    repository.GreetingRevisionRepository
 	HelloMapper           mapper.HelloMapper
 	HelloService          service.HelloService
+	CommentService        service.CommentService
+	TagService            service.TagService
+	ReactionService       service.ReactionService
+	DailyGreetingService  service.DailyGreetingService
+	RevisionService       service.RevisionService
+	ModerationService     service.ModerationService
 	AuthenticationService service.AuthenticationService
+	UserService           service.UserService
+	SecurityAuditService  service.SecurityAuditService
+	GreetingScheduler     service.GreetingScheduler
 	TokenGenerator        security.TokenGenerator
 	HelloController       controller.HelloController
+	CommentController     controller.CommentController
+	TagController         controller.TagController
+	ReactionController    controller.ReactionController
+	DailyGreetingCtrl     controller.DailyGreetingController
+	RevisionController    controller.RevisionController
+	ModerationController  controller.ModerationController
 	AuthController        controller.AuthenticationController
+	SecurityEventCtrl     controller.SecurityEventController
+	UserController        controller.UserController
 	HealthController      controller.HealthController
 	Router                *gin.Engine
 	Validator             *validator.Validate
@@ -45,35 +69,97 @@ func NewContainer(cfg *config.Config) *Container {
 	db := config.DatabaseConfig.InitDB()
 	helloRepository := repository.NewHelloRepository(db, cacheManager)
 	userRepository := repository.NewUserRepository(db, cacheManager)
+	securityEventRepository := repository.NewSecurityEventRepository(db)
+	commentRepository := repository.NewCommentRepository(db, cacheManager)
+	tagRepository := repository.NewTagRepository(db, cacheManager)
+	reactionRepository := repository.NewReactionRepository(db, cacheManager)
+	greetingPinRepository := repository.NewGreetingPinRepository(db, cacheManager)
+	revisionRepository := repository.NewGreetingRevisionRepository(db, cacheManager)
 
 	// Clock
 	clock := &util.RealClock{} // Use RealClock for production
 
 	// Mapper
 	helloMapper := mapper.NewHelloMapper()
+	securityEventMapper := mapper.NewSecurityEventMapper()
+	userMapper := mapper.NewUserMapper()
+	commentMapper := mapper.NewCommentMapper()
+	tagMapper := mapper.NewTagMapper()
+	reactionMapper := mapper.NewReactionMapper(helloMapper)
+	dailyGreetingMapper := mapper.NewDailyGreetingMapper()
+	revisionMapper := mapper.NewRevisionMapper()
 
 	// JWT KeyPair
-	signKeyPair, encKeyPair := config.JweTokenConfig.InitJweKeyPair()
+	signKeyPair, encKeyPair := config.JweTokenConfig.InitJweKeyPair(cfg)
 
 	// Token Generator
 	tokenGenerator := security.NewTokenGenerator(
 		signKeyPair, encKeyPair, cfg.TokenDuration, cfg.TokenIssuer)
 
+	// Trusted Proxies (none unless configured, so forwarding headers of clients are ignored)
+	trustedProxies, err := security.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to parse TRUSTED_PROXIES: %v", err)
+	}
+
+	// DPoP Verifier (nil when sender-constrained tokens are disabled)
+	var dpopVerifier security.DPoPVerifier
+	if cfg.DPoP.Enabled {
+		dpopVerifier = security.NewDPoPVerifier(cfg.DPoP.ProofMaxAge, trustedProxies, clock)
+	}
+
+	// Cursor Codec (cursors only survive a restart with a configured secret)
+	if cfg.CursorSecret == "" {
+		log.Printf("CURSOR_SECRET is not set, pagination cursors are signed with a random secret")
+	}
+	cursorCodec, err := security.NewCursorCodec([]byte(cfg.CursorSecret))
+	if err != nil {
+		log.Fatalf("Failed to initialize cursor codec: %v", err)
+	}
+
 	// Services
-	helloService := service.NewHelloService(helloRepository, helloMapper, clock)
-	authService := service.NewAuthenticationService(userRepository, tokenGenerator)
+	defaultLocale, err := util.NormalizeLocale(cfg.DefaultLocale)
+	if err != nil {
+		log.Printf("Invalid DEFAULT_LOCALE %q, using en: %v", cfg.DefaultLocale, err)
+		defaultLocale = "en"
+	}
+	helloService := service.NewHelloService(helloRepository, userRepository, helloMapper, clock, cursorCodec, defaultLocale)
+	commentService := service.NewCommentService(commentRepository, helloRepository, commentMapper, clock)
+	tagService := service.NewTagService(tagRepository, tagMapper)
+	reactionService := service.NewReactionService(reactionRepository, helloRepository, reactionMapper, clock)
+	dailyGreetingService := service.NewDailyGreetingService(greetingPinRepository, helloRepository, userRepository,
+		helloMapper, dailyGreetingMapper, clock)
+	revisionService := service.NewRevisionService(revisionRepository, helloRepository, helloMapper, revisionMapper, clock)
+	moderationService := service.NewModerationService(helloRepository, helloMapper, clock)
+	userService := service.NewUserService(userRepository, userMapper, cursorCodec)
+	authService := service.NewAuthenticationService(
+		newAuthenticationProviders(cfg.AuthProviders, userRepository), tokenGenerator, cfg.AuthCookie.Enabled)
+	auditService := service.NewSecurityAuditService(
+		newSecurityEventSink(cfg.AuditSink, securityEventRepository), securityEventRepository, securityEventMapper, clock)
+	greetingScheduler := service.NewGreetingScheduler(helloRepository, service.NewLogGreetingEventSink(), clock,
+		cfg.GreetingScheduler.Interval, cfg.GreetingScheduler.Retention)
 
 	// Validator and Translator
 	validate, translator := config.NewValidator()
 
 	// Controllers
 	helloController := controller.NewHelloController(helloService, validate, translator)
-	authController := controller.NewAuthenticationController(authService, validate, translator)
+	authController := controller.NewAuthenticationController(authService, validate, translator, cfg.AuthCookie, dpopVerifier)
+	commentController := controller.NewCommentController(commentService, validate)
+	tagController := controller.NewTagController(tagService, validate)
+	reactionController := controller.NewReactionController(reactionService, validate)
+	dailyGreetingController := controller.NewDailyGreetingController(dailyGreetingService, validate)
+	revisionController := controller.NewRevisionController(revisionService, validate)
+	moderationController := controller.NewModerationController(moderationService, validate)
 	healthController := controller.NewHealthController()
+	securityEventController := controller.NewSecurityEventController(auditService, validate)
+	userController := controller.NewUserController(userService, validate)
 
 	// Router
-	r := router.SetupRouter(helloController, healthController,
-		authController, translator, tokenGenerator)
+	r := router.SetupRouter(helloController, dailyGreetingController, revisionController, moderationController,
+		commentController, tagController, reactionController, healthController,
+		authController, securityEventController, userController, auditService, translator, tokenGenerator, cfg.AuthCookie, dpopVerifier,
+		cfg.IfMatchRequired, cfg.CacheControl, cfg.TrustedProxies)
 
 	return &Container{
 		Config:                cfg,
@@ -81,12 +167,35 @@ func NewContainer(cfg *config.Config) *Container {
 		DB:                    db,
 		HelloRepository:       helloRepository,
 		UserRepository:        userRepository,
+		SecurityEventRepo:     securityEventRepository,
+		CommentRepository:     commentRepository,
+		TagRepository:         tagRepository,
+		ReactionRepository:    reactionRepository,
+		GreetingPinRepository: greetingPinRepository,
+		RevisionRepository:    revisionRepository,
 		HelloMapper:           helloMapper,
 		HelloService:          helloService,
+		CommentService:        commentService,
+		TagService:            tagService,
+		ReactionService:       reactionService,
+		DailyGreetingService:  dailyGreetingService,
+		RevisionService:       revisionService,
+		ModerationService:     moderationService,
 		AuthenticationService: authService,
+		UserService:           userService,
+		SecurityAuditService:  auditService,
+		GreetingScheduler:     greetingScheduler,
 		TokenGenerator:        tokenGenerator,
 		HelloController:       helloController,
+		CommentController:     commentController,
+		TagController:         tagController,
+		ReactionController:    reactionController,
+		DailyGreetingCtrl:     dailyGreetingController,
+		RevisionController:    revisionController,
+		ModerationController:  moderationController,
 		AuthController:        authController,
+		SecurityEventCtrl:     securityEventController,
+		UserController:        userController,
 		HealthController:      healthController,
 		Router:                r,
 		Validator:             validate,
@@ -94,3 +203,46 @@ func NewContainer(cfg *config.Config) *Container {
 		Clock:                 clock,
 	}
 }
+
+// newAuthenticationProviders builds the enabled authentication providers in the configured order
+func newAuthenticationProviders(cfg config.AuthProvidersConfig,
+	userRepository repository.UserRepository) []service.AuthenticationProvider {
+	order := cfg.Order
+	if len(order) == 0 {
+		order = []string{"db"}
+	}
+
+	var providers []service.AuthenticationProvider
+	for _, name := range order {
+		var provider service.AuthenticationProvider
+		var err error
+		switch name {
+		case "db":
+			provider = service.NewDBAuthenticationProvider(userRepository)
+		case "htpasswd":
+			provider, err = service.NewHtpasswdAuthenticationProvider(cfg.HtpasswdFile, cfg.HtpasswdRoles)
+		case "static":
+			provider, err = service.NewStaticUsersAuthenticationProvider(cfg.UsersFile)
+		default:
+			log.Fatalf("Unknown authentication provider: %s", name)
+		}
+		if err != nil {
+			log.Fatalf("Failed to initialize %s authentication provider: %v", name, err)
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// newSecurityEventSink builds the configured destination of security events
+func newSecurityEventSink(name string, repo repository.SecurityEventRepository) service.SecurityEventSink {
+	switch name {
+	case "", "db":
+		return service.NewDBSecurityEventSink(repo)
+	case "log":
+		return service.NewLogSecurityEventSink()
+	default:
+		log.Fatalf("Unknown audit sink: %s", name)
+		return nil
+	}
+}
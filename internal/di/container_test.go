@@ -32,6 +32,24 @@ func TestNewContainer(t *testing.T) {
 	// Check HelloController
 	assert.NotNil(t, container.HelloController, "HelloController should not be nil")
 
+	// Check CommentController
+	assert.NotNil(t, container.CommentController, "CommentController should not be nil")
+
+	// Check TagController
+	assert.NotNil(t, container.TagController, "TagController should not be nil")
+
+	// Check ReactionController
+	assert.NotNil(t, container.ReactionController, "ReactionController should not be nil")
+
+	// Check DailyGreetingCtrl
+	assert.NotNil(t, container.DailyGreetingCtrl, "DailyGreetingCtrl should not be nil")
+
+	// Check RevisionController
+	assert.NotNil(t, container.RevisionController, "RevisionController should not be nil")
+
+	// Check ModerationController
+	assert.NotNil(t, container.ModerationController, "ModerationController should not be nil")
+
 	// Check HealthController
 	assert.NotNil(t, container.HealthController, "HealthController should not be nil")
 
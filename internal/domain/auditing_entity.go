@@ -1,9 +1,20 @@
 package domain
 
-import "time"
+import (
+	"time"
+)
 
-// AuditingEntity provides common audit fields for entities
+// SystemAuditor is recorded as creator and modifier of entities changed without an authenticated user,
+// e.g. by scheduled jobs
+const SystemAuditor = "system"
+
+// AuditingEntity provides common audit fields for entities.
+// The creator and modifier are recorded by the auditing callbacks of the database, see config.InitDB,
+// from the authenticated user carried by the context of the statement, so repositories must be bound
+// to the request context to record them.
 type AuditingEntity struct {
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"` // Custom column name
+	CreatedBy string    `gorm:"type:text;column:created_by"`      // User ID of the creator
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy string    `gorm:"type:text;column:updated_by"` // User ID of the last modifier
 }
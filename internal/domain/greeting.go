@@ -1,10 +1,56 @@
 package domain
 
+import (
+	"slices"
+	"time"
+)
+
+// GreetingStatus represents the moderation state of a greeting
+type GreetingStatus string
+
+// Greeting statuses. Only approved greetings are shown to users other than their owner and admins.
+const (
+	GreetingStatusDraft    GreetingStatus = "DRAFT"
+	GreetingStatusPending  GreetingStatus = "PENDING"
+	GreetingStatusApproved GreetingStatus = "APPROVED"
+	GreetingStatusRejected GreetingStatus = "REJECTED"
+	GreetingStatusArchived GreetingStatus = "ARCHIVED"
+)
+
+// greetingTransitions lists the statuses a greeting can move to from each status. Approved greetings go back
+// to pending when their owner changes them, and archived greetings stay archived.
+var greetingTransitions = map[GreetingStatus][]GreetingStatus{
+	GreetingStatusDraft:    {GreetingStatusPending, GreetingStatusArchived},
+	GreetingStatusPending:  {GreetingStatusApproved, GreetingStatusRejected, GreetingStatusArchived},
+	GreetingStatusApproved: {GreetingStatusPending, GreetingStatusArchived},
+	GreetingStatusRejected: {GreetingStatusPending, GreetingStatusArchived},
+}
+
+// CanTransitionTo reports whether a greeting in the status can move to the target status
+func (s GreetingStatus) CanTransitionTo(target GreetingStatus) bool {
+	return slices.Contains(greetingTransitions[s], target)
+}
+
 // Greeting represents a greeting domain in the database
 type Greeting struct {
-	ID             uint   `gorm:"primaryKey;autoIncrement;column:id"` // Primary key
-	Message        string `gorm:"type:text;not null;column:message"`  // Message column
-	AuditingEntity        // Embedded AuditingEntity for auditing fields
+	ID                  uint                   `gorm:"primaryKey;autoIncrement;column:id"` // Primary key
+	Message             string                 `gorm:"type:text;not null;column:message"`  // Message column
+	Locale              string                 `gorm:"type:text;not null;column:locale"`   // BCP 47 language tag of the message
+	PublishAt           *time.Time             `gorm:"column:publish_at"`                  // Time the greeting goes live, live from creation when nil
+	ExpireAt            *time.Time             `gorm:"column:expire_at"`                   // Time the greeting disappears, never when nil
+	Status              GreetingStatus         `gorm:"type:text;not null;column:status"`   // Moderation state
+	RejectionReason     *string                `gorm:"type:text;column:rejection_reason"`  // Reason given by the moderator who rejected the greeting
+	OwnerID             string                 `gorm:"type:text;column:owner_id"`          // User id of the user who created the greeting
+	ModeratedBy         string                 `gorm:"type:text;column:moderated_by"`      // User id of the admin who approved or rejected the greeting
+	ModeratedAt         *time.Time             `gorm:"column:moderated_at"`                // Time the greeting was approved or rejected
+	Tags                []Tag                  `gorm:"-"`                                  // Tags sorted by name, loaded and saved by the repository
+	Reactions           map[ReactionType]int64 `gorm:"-"`                                  // Number of reactions per type, loaded by the repository
+	CommentCount        int64                  `gorm:"-"`                                  // Number of comments including replies, loaded by the repository
+	LastActivityAt      *time.Time             `gorm:"-"`                                  // Time of the newest reaction or comment change, loaded by the repository for single greetings
+	TenantEntity                               // Embedded TenantEntity for tenant scoping
+	AuditingEntity                             // Embedded AuditingEntity for auditing fields
+	VersionedEntity                            // Embedded VersionedEntity for optimistic locking
+	SoftDeletableEntity                        // Embedded SoftDeletableEntity for soft delete
 }
 
 func (Greeting) TableName() string {
@@ -14,3 +60,20 @@ func (Greeting) TableName() string {
 func (g Greeting) GetID() interface{} {
 	return g.ID
 }
+
+// IsLiveAt reports whether the greeting is published and not yet expired at the given time
+func (g Greeting) IsLiveAt(t time.Time) bool {
+	return (g.PublishAt == nil || !g.PublishAt.After(t)) && (g.ExpireAt == nil || g.ExpireAt.After(t))
+}
+
+// TagNames returns the names of the tags of the greeting
+func (g Greeting) TagNames() []string {
+	if g.Tags == nil {
+		return nil
+	}
+	names := make([]string, len(g.Tags))
+	for i, tag := range g.Tags {
+		names[i] = tag.Name
+	}
+	return names
+}
@@ -0,0 +1,21 @@
+package domain
+
+// GreetingComment represents a comment on a greeting. Replies refer to the comment they answer,
+// so the comments of a greeting form threads.
+type GreetingComment struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement;column:id"`  // Primary key
+	GreetingID     uint   `gorm:"not null;column:greeting_id"`         // Greeting commented on
+	ParentID       *uint  `gorm:"column:parent_id"`                    // Comment replied to, nil for top-level comments
+	AuthorID       string `gorm:"type:text;not null;column:author_id"` // User id of the user who wrote the comment
+	Body           string `gorm:"type:text;not null;column:body"`      // Text of the comment
+	AuditingEntity        // Embedded AuditingEntity for auditing fields
+}
+
+// TableName specifies the table name for GreetingComment
+func (GreetingComment) TableName() string {
+	return "greeting_comment"
+}
+
+func (c GreetingComment) GetID() interface{} {
+	return c.ID
+}
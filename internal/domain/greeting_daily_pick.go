@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// GreetingDailyPick records the greeting chosen as greeting of the day of a tenant for a calendar date,
+// so that the choice holds all day, even when greetings are published, expire or are moderated during the day
+type GreetingDailyPick struct {
+	TenantID   string    `gorm:"primaryKey;type:text;column:tenant_id"` // Tenant whose greeting of the day was chosen
+	Date       string    `gorm:"primaryKey;type:text;column:date"`      // Calendar date in the format 2006-01-02
+	GreetingID uint      `gorm:"not null;column:greeting_id"`           // Chosen greeting
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`      // Time of the choice
+}
+
+// TableName specifies the table name for GreetingDailyPick
+func (GreetingDailyPick) TableName() string {
+	return "greeting_daily_pick"
+}
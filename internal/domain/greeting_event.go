@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// GreetingEventType represents the kind of greeting event
+type GreetingEventType string
+
+// Greeting event types
+const (
+	GreetingEventPublished GreetingEventType = "GREETING_PUBLISHED"
+	GreetingEventExpired   GreetingEventType = "GREETING_EXPIRED"
+)
+
+// GreetingEvent reports that a scheduled greeting went live or expired
+type GreetingEvent struct {
+	Type       GreetingEventType // Event type
+	GreetingID uint              // ID of the greeting
+	Message    string            // Message of the greeting
+	Locale     string            // Locale of the greeting
+	OccurredAt time.Time         // Publish or expire time of the greeting
+}
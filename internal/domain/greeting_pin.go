@@ -0,0 +1,14 @@
+package domain
+
+// GreetingPin overrides the greeting of the day of a tenant for a calendar date
+type GreetingPin struct {
+	TenantID       string `gorm:"primaryKey;type:text;column:tenant_id"` // Tenant whose greeting of the day is pinned
+	Date           string `gorm:"primaryKey;type:text;column:date"`      // Calendar date in the format 2006-01-02
+	GreetingID     uint   `gorm:"not null;column:greeting_id"`           // Pinned greeting
+	AuditingEntity        // Embedded AuditingEntity for auditing fields
+}
+
+// TableName specifies the table name for GreetingPin
+func (GreetingPin) TableName() string {
+	return "greeting_pin"
+}
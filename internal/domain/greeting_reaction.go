@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// ReactionType represents the kind of a reaction to a greeting
+type ReactionType string
+
+// Reaction types, each shown as an emoji by clients
+const (
+	ReactionLike      ReactionType = "like"      // 👍
+	ReactionLove      ReactionType = "love"      // ❤️
+	ReactionLaugh     ReactionType = "laugh"     // 😂
+	ReactionWow       ReactionType = "wow"       // 😮
+	ReactionSad       ReactionType = "sad"       // 😢
+	ReactionCelebrate ReactionType = "celebrate" // 🎉
+)
+
+// ReactionTypes lists the supported reaction types
+var ReactionTypes = []ReactionType{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionCelebrate}
+
+// IsValid reports whether the reaction type is supported
+func (t ReactionType) IsValid() bool {
+	return slices.Contains(ReactionTypes, t)
+}
+
+// GreetingReaction records a reaction of a user to a greeting. A user reacts at most once per type.
+type GreetingReaction struct {
+	GreetingID uint         `gorm:"primaryKey;column:greeting_id"` // Greeting reacted to
+	UserID     string       `gorm:"primaryKey;column:user_id"`     // User id of the user who reacted
+	Type       ReactionType `gorm:"primaryKey;column:type"`        // Kind of the reaction
+	CreatedAt  time.Time    `gorm:"not null;column:created_at"`    // Time of the reaction
+}
+
+// TableName specifies the table name for GreetingReaction
+func (GreetingReaction) TableName() string {
+	return "greeting_reaction"
+}
+
+// GreetingScore represents a greeting along with the number of reactions it received in a time window
+type GreetingScore struct {
+	Greeting `gorm:"embedded"`
+	Score    int64 `gorm:"column:score"` // Number of reactions
+}
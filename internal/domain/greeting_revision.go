@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// GreetingRevisionAction represents the kind of change recorded by a greeting revision
+type GreetingRevisionAction string
+
+// Greeting revision actions
+const (
+	GreetingRevisionCreate   GreetingRevisionAction = "CREATE"
+	GreetingRevisionUpdate   GreetingRevisionAction = "UPDATE"
+	GreetingRevisionDelete   GreetingRevisionAction = "DELETE"
+	GreetingRevisionRestore  GreetingRevisionAction = "RESTORE"
+	GreetingRevisionRollback GreetingRevisionAction = "ROLLBACK"
+)
+
+// GreetingRevision records a change of a greeting. The old values are nil when the greeting did not exist
+// before the change, e.g. when it was created, and the new values are nil when the change deleted it.
+type GreetingRevision struct {
+	ID           uint                   `gorm:"primaryKey;autoIncrement;column:id"` // Primary key
+	GreetingID   uint                   `gorm:"not null;column:greeting_id"`        // Revised greeting
+	Revision     uint                   `gorm:"not null;column:revision"`           // Sequence number within the greeting
+	Action       GreetingRevisionAction `gorm:"type:text;not null;column:action"`   // Kind of change
+	OldMessage   *string                `gorm:"type:text;column:old_message"`       // Message before the change
+	OldLocale    *string                `gorm:"type:text;column:old_locale"`        // Locale before the change
+	NewMessage   *string                `gorm:"type:text;column:new_message"`       // Message after the change
+	NewLocale    *string                `gorm:"type:text;column:new_locale"`        // Locale after the change
+	Version      uint                   `gorm:"not null;column:version"`            // Version of the greeting after the change
+	RolledBackTo *uint                  `gorm:"column:rolled_back_to"`              // Revision restored by a rollback
+	ChangedBy    string                 `gorm:"type:text;column:changed_by"`        // User id of the user who made the change
+	ChangedAt    time.Time              `gorm:"not null;column:changed_at"`         // Time of the change
+}
+
+// TableName specifies the table name for GreetingRevision
+func (GreetingRevision) TableName() string {
+	return "greeting_revision"
+}
+
+func (r GreetingRevision) GetID() interface{} {
+	return r.ID
+}
@@ -0,0 +1,15 @@
+package domain
+
+// Markers wrapping the matched terms in search snippets. They are characters of the Unicode private use area,
+// which cannot be mistaken for markup, so the snippet can be HTML escaped before the terms are highlighted.
+const (
+	SnippetHighlightStart = "\uE000"
+	SnippetHighlightEnd   = "\uE001"
+)
+
+// GreetingSearchResult represents a greeting matched by a full-text search
+type GreetingSearchResult struct {
+	Greeting `gorm:"embedded"`
+	Snippet  string  `gorm:"column:snippet"` // Message excerpt with the matched terms between the highlight markers
+	Rank     float64 `gorm:"column:rank"`    // BM25 rank, lower is more relevant
+}
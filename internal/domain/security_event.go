@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// SecurityEventType represents the kind of security event
+type SecurityEventType string
+
+// Security event types
+const (
+	SecurityEventLoginSuccess  SecurityEventType = "LOGIN_SUCCESS"
+	SecurityEventLoginFailure  SecurityEventType = "LOGIN_FAILURE"
+	SecurityEventTokenRejected SecurityEventType = "TOKEN_REJECTED"
+	SecurityEventAccessDenied  SecurityEventType = "ACCESS_DENIED"
+	SecurityEventAdminAction   SecurityEventType = "ADMIN_ACTION"
+)
+
+// SecurityEvent represents an authentication or authorization event in the security audit log
+type SecurityEvent struct {
+	ID         uint              `gorm:"primaryKey;autoIncrement;column:id"` // Primary key
+	Type       SecurityEventType `gorm:"type:text;not null;column:type"`     // Event type
+	Principal  string            `gorm:"type:text;column:principal"`         // User id or attempted username
+	TenantID   string            `gorm:"type:text;column:tenant_id"`         // Tenant of the user, empty when the user is unknown
+	Reason     string            `gorm:"type:text;column:reason"`            // Reason of a failure or rejection
+	Authority  string            `gorm:"type:text;column:authority"`         // Required authority of a denied request
+	Method     string            `gorm:"type:text;column:method"`            // HTTP method
+	Path       string            `gorm:"type:text;column:path"`              // Request path
+	ClientIP   string            `gorm:"type:text;column:client_ip"`         // Client IP address
+	Status     int               `gorm:"column:status"`                      // HTTP response status
+	OccurredAt time.Time         `gorm:"not null;column:occurred_at"`        // Time the event occurred
+}
+
+// TableName specifies the table name for SecurityEvent
+func (SecurityEvent) TableName() string {
+	return "security_event"
+}
+
+func (e SecurityEvent) GetID() interface{} {
+	return e.ID
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "gorm.io/gorm"
+
+// DeletedAtColumn is the column holding the deletion time of soft-deletable entities
+const DeletedAtColumn = "deleted_at"
+
+// SoftDeletableEntity provides the deletion time for soft delete.
+// Embedding it makes deletes only mark the entity, which GORM then excludes from queries until it is restored.
+type SoftDeletableEntity struct {
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"` // Set when the entity is moved to the trash
+}
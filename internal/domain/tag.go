@@ -0,0 +1,34 @@
+package domain
+
+// Tag groups greetings, e.g. by campaign or occasion
+type Tag struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement;column:id"`    // Primary key
+	Name           string `gorm:"type:text;not null;unique;column:name"` // Tag name in lowercase
+	AuditingEntity        // Embedded AuditingEntity for auditing fields
+}
+
+// TableName specifies the table name for Tag
+func (Tag) TableName() string {
+	return "tag"
+}
+
+func (t Tag) GetID() interface{} {
+	return t.ID
+}
+
+// TagUsage represents a tag along with the number of greetings tagged with it, not counting the trash
+type TagUsage struct {
+	Tag   `gorm:"embedded"`
+	Count int64 `gorm:"column:count"` // Number of tagged greetings
+}
+
+// GreetingTag represents the many-to-many relationship between greetings and tags
+type GreetingTag struct {
+	GreetingID uint `gorm:"not null;primaryKey;column:greeting_id"` // Tagged greeting
+	TagID      uint `gorm:"not null;primaryKey;column:tag_id"`      // Tag of the greeting
+}
+
+// TableName specifies the table name for GreetingTag
+func (GreetingTag) TableName() string {
+	return "greeting_tag"
+}
@@ -0,0 +1,21 @@
+package domain
+
+// TenantColumn is the column holding the tenant of tenant-scoped entities
+const TenantColumn = "tenant_id"
+
+// DefaultTenant is the tenant of the entities and users existing before multi-tenancy, and of tokens without a tenant
+const DefaultTenant = "default"
+
+// TenantOrDefault returns the tenant, or DefaultTenant for users and tokens without one
+func TenantOrDefault(tenantID string) string {
+	if tenantID == "" {
+		return DefaultTenant
+	}
+	return tenantID
+}
+
+// TenantEntity provides the tenant of an entity.
+// Embedding it makes BaseRepository restrict the queries of a repository bound to a tenant to the entities of that tenant.
+type TenantEntity struct {
+	TenantID string `gorm:"type:text;not null;default:default;index;column:tenant_id"` // Organisation owning the entity
+}
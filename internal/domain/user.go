@@ -10,6 +10,7 @@ type User struct {
 	LastName       string            `gorm:"type:text;column:last_name"`                // Last name
 	Enabled        bool              `gorm:"type:boolean;not null;column:enabled"`      // Is the user enabled?
 	Roles          []UserRoleMapping `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TenantEntity                     // Embedded TenantEntity for tenant scoping
 	AuditingEntity                   // Embedded AuditingEntity for auditing fields
 }
 
@@ -0,0 +1,10 @@
+package domain
+
+// VersionColumn is the column holding the version of entities using optimistic locking
+const VersionColumn = "version"
+
+// VersionedEntity provides the version field for optimistic locking.
+// Embedding it makes BaseRepository detect concurrent updates and deletes of the entity.
+type VersionedEntity struct {
+	Version uint `gorm:"column:version;not null;default:1"` // Incremented by every update
+}
@@ -0,0 +1,73 @@
+package dto
+
+// Bulk operation modes
+const (
+	// BulkModeAllOrNothing applies either every item or none of them
+	BulkModeAllOrNothing = "all-or-nothing"
+	// BulkModeBestEffort applies the valid items and reports the failed ones
+	BulkModeBestEffort = "best-effort"
+)
+
+// MaxBulkItems is the maximum number of items of a bulk operation
+const MaxBulkItems = 100
+
+// BulkQuery represents the parameters of a bulk operation
+// @Description Query parameters for bulk operations
+type BulkQuery struct {
+	// Mode selects whether the operation is applied all-or-nothing or best-effort
+	Mode string `form:"mode,default=all-or-nothing" json:"mode" example:"all-or-nothing" validate:"oneof=all-or-nothing best-effort"`
+}
+
+// BulkGreetingUpdate represents one update of a bulk update
+// @Description Input dto for one greeting of a bulk update
+type BulkGreetingUpdate struct {
+	// ID of the greeting to update
+	ID uint `json:"id" example:"1" validate:"required,min=1"`
+
+	// Version the update is based on; the update fails if the greeting has changed since
+	Version *uint `json:"version,omitempty" example:"1"`
+
+	GreetingInput
+}
+
+// BulkGreetingDelete represents one delete of a bulk delete
+// @Description Input dto for one greeting of a bulk delete
+type BulkGreetingDelete struct {
+	// ID of the greeting to delete
+	ID uint `json:"id" example:"1" validate:"required,min=1"`
+
+	// Version the delete is based on; the delete fails if the greeting has changed since
+	Version *uint `json:"version,omitempty" example:"1"`
+}
+
+// BulkItemResult reports the outcome of one item of a bulk operation
+// @Description Outcome of one item of a bulk operation
+type BulkItemResult[T any] struct {
+	// Index is the position of the item in the request
+	Index int `json:"index" example:"0"`
+
+	// Status is the HTTP status the item would have had as a single request
+	Status int `json:"status" example:"201"`
+
+	// Data holds the resulting resource of a successful item, if any
+	Data *T `json:"data,omitempty"`
+
+	// Problem describes why the item failed
+	Problem *ProblemDetail `json:"problem,omitempty"`
+}
+
+// BulkResponse reports the outcome of every item of a bulk operation, in request order
+// @Description Multi-status envelope of a bulk operation
+type BulkResponse[T any] struct {
+	// Mode is the mode the operation was applied in
+	Mode string `json:"mode" example:"all-or-nothing"`
+
+	// Succeeded is the number of applied items
+	Succeeded int `json:"succeeded" example:"2"`
+
+	// Failed is the number of items which were not applied
+	Failed int `json:"failed" example:"0"`
+
+	// Results holds the outcome of every item
+	Results []BulkItemResult[T] `json:"results"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+// CommentInput represents the payload for commenting on a greeting
+// @Description Comment input dto
+type CommentInput struct {
+	// Body is the text of the comment
+	Body string `json:"body" example:"Nice greeting!" minLength:"1" maxLength:"1000" validate:"required,max=1000"`
+
+	// ParentID is the ID of the comment replied to; a top-level comment when omitted
+	ParentID *uint `json:"parentId,omitempty" example:"1" validate:"omitempty,min=1"`
+}
+
+// CommentUpdateInput represents the payload for editing a comment
+// @Description Comment update input dto
+type CommentUpdateInput struct {
+	// Body is the new text of the comment
+	Body string `json:"body" example:"Nice greeting, thanks!" minLength:"1" maxLength:"1000" validate:"required,max=1000"`
+}
+
+// CommentResponse represents a comment on a greeting
+// @Description Comment response dto
+type CommentResponse struct {
+	// ID of the comment
+	ID uint `json:"id" example:"1"`
+
+	// GreetingID is the ID of the greeting commented on
+	GreetingID uint `json:"greetingId" example:"1"`
+
+	// ParentID is the ID of the comment replied to, absent for top-level comments
+	ParentID *uint `json:"parentId,omitempty" example:"1"`
+
+	// AuthorID is the user ID of the user who wrote the comment
+	AuthorID string `json:"authorId" example:"2"`
+
+	// Body is the text of the comment
+	Body string `json:"body" example:"Nice greeting!"`
+
+	// CreatedAt is the timestamp when the comment was written
+	CreatedAt time.Time `json:"createdAt" example:"2025-01-05T10:00:00Z"`
+
+	// CreatedBy is the user ID of the user who wrote the comment, absent for records created before auditing
+	CreatedBy string `json:"createdBy,omitempty" example:"1"`
+
+	// UpdatedAt is the timestamp when the comment was last edited
+	UpdatedAt time.Time `json:"updatedAt" example:"2025-01-05T12:00:00Z"`
+
+	// UpdatedBy is the user ID of the user who last modified the comment, or system for changes by scheduled jobs
+	UpdatedBy string `json:"updatedBy,omitempty" example:"1"`
+}
+
+// CommentQuery represents the pagination, sorting and thread parameters for listing the comments on a greeting
+// @Description Query parameters for listing comments
+type CommentQuery struct {
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of comments per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+
+	// Sort holds the sort criteria in the format property[,asc|desc]; the oldest come first by default
+	Sort []string `form:"sort" json:"sort" example:"createdAt,desc"`
+
+	// ParentID restricts the listing to the direct replies to the comment; all comments are listed when omitted
+	ParentID *uint `form:"parentId" json:"parentId" example:"1" validate:"omitempty,min=1"`
+}
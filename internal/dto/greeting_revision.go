@@ -0,0 +1,87 @@
+package dto
+
+import "time"
+
+// GreetingState represents the message and locale of a greeting at a revision
+// @Description Greeting values recorded by a revision
+type GreetingState struct {
+	// Message is the greeting text
+	Message string `json:"message" example:"Hello, World!"`
+
+	// Locale is the BCP 47 language tag of the message
+	Locale string `json:"locale" example:"en"`
+}
+
+// GreetingRevisionResponse represents a recorded change of a greeting
+// @Description Greeting revision dto
+type GreetingRevisionResponse struct {
+	// Revision is the sequence number of the revision within the greeting, starting at 1
+	Revision uint `json:"revision" example:"2"`
+
+	// Action is the kind of change
+	Action string `json:"action" example:"UPDATE" enums:"CREATE,UPDATE,DELETE,RESTORE,ROLLBACK"`
+
+	// Version is the version of the greeting after the change
+	Version uint `json:"version" example:"2"`
+
+	// Old holds the values before the change, null if the greeting did not exist
+	Old *GreetingState `json:"old"`
+
+	// New holds the values after the change, null if the change deleted the greeting
+	New *GreetingState `json:"new"`
+
+	// RolledBackTo is the revision restored by a rollback, absent for other changes
+	RolledBackTo *uint `json:"rolledBackTo,omitempty" example:"1"`
+
+	// ChangedBy is the user id of the user who made the change
+	ChangedBy string `json:"changedBy,omitempty" example:"1"`
+
+	// ChangedAt is the timestamp of the change
+	ChangedAt time.Time `json:"changedAt" example:"2025-01-05T12:00:00Z"`
+}
+
+// GreetingRevisionQuery represents the pagination parameters for listing the revisions of a greeting
+// @Description Query parameters for listing greeting revisions
+type GreetingRevisionQuery struct {
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of revisions per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+}
+
+// GreetingRevisionDiffQuery represents the revisions to compare
+// @Description Query parameters for comparing two greeting revisions
+type GreetingRevisionDiffQuery struct {
+	// From is the revision compared from
+	From uint `form:"from" json:"from" example:"1" validate:"required,min=1"`
+
+	// To is the revision compared to
+	To uint `form:"to" json:"to" example:"3" validate:"required,min=1"`
+}
+
+// GreetingFieldChange represents a field whose value differs between two revisions
+// @Description Changed field of a greeting
+type GreetingFieldChange struct {
+	// Field is the name of the changed field
+	Field string `json:"field" example:"message"`
+
+	// From is the value at the revision compared from, null if the greeting did not exist then
+	From *string `json:"from" example:"Hello, World!"`
+
+	// To is the value at the revision compared to, null if the greeting did not exist then
+	To *string `json:"to" example:"Hello, Revisions!"`
+}
+
+// GreetingRevisionDiffResponse represents the differences between the greeting values after two revisions
+// @Description Greeting revision diff dto
+type GreetingRevisionDiffResponse struct {
+	// From is the revision compared from
+	From GreetingRevisionResponse `json:"from"`
+
+	// To is the revision compared to
+	To GreetingRevisionResponse `json:"to"`
+
+	// Changes lists the fields whose values differ, empty when both revisions have the same values
+	Changes []GreetingFieldChange `json:"changes"`
+}
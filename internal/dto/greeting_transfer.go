@@ -0,0 +1,91 @@
+package dto
+
+import "time"
+
+// Outcomes of an imported row
+const (
+	ImportOutcomeCreated     = "created"
+	ImportOutcomeOverwritten = "overwritten"
+	ImportOutcomeSkipped     = "skipped"
+	ImportOutcomeFailed      = "failed"
+)
+
+// Handling of imported greetings whose message already exists in their locale
+const (
+	// OnDuplicateSkip leaves the existing greeting as it is
+	OnDuplicateSkip = "skip"
+	// OnDuplicateOverwrite replaces the existing greeting with the imported one
+	OnDuplicateOverwrite = "overwrite"
+	// OnDuplicateFail reports the row as failed
+	OnDuplicateFail = "fail"
+)
+
+// GreetingExportQuery represents the filters for exporting greetings
+// @Description Query parameters for exporting greetings
+type GreetingExportQuery struct {
+	// Locale filters greetings in the locale
+	Locale string `form:"locale" json:"locale" example:"en" validate:"omitempty,bcp47_language_tag"`
+
+	// Message filters greetings whose message contains the text, ignoring case
+	Message string `form:"message" json:"message" example:"hello"`
+
+	// CreatedBefore filters greetings created before the given time
+	CreatedBefore *time.Time `form:"createdBefore" json:"createdBefore" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-06T00:00:00Z"`
+
+	// CreatedAfter filters greetings created after the given time
+	CreatedAfter *time.Time `form:"createdAfter" json:"createdAfter" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-05T00:00:00Z"`
+}
+
+// GreetingImportQuery represents the options of a greeting import
+// @Description Query parameters for importing greetings
+type GreetingImportQuery struct {
+	// DryRun reports the outcome of the import without saving anything
+	DryRun bool `form:"dryRun" json:"dryRun" example:"false"`
+
+	// OnDuplicate selects how greetings whose message already exists in their locale are handled
+	OnDuplicate string `form:"onDuplicate,default=skip" json:"onDuplicate" example:"skip" validate:"oneof=skip overwrite fail"`
+}
+
+// GreetingImportRowResult reports the outcome of one imported row
+// @Description Outcome of one imported row
+type GreetingImportRowResult struct {
+	// Line is the line of the file the row starts at
+	Line int `json:"line" example:"2"`
+
+	// Outcome is one of created, overwritten, skipped and failed
+	Outcome string `json:"outcome" example:"created"`
+
+	// ID of the created or overwritten greeting, absent in a dry run
+	ID uint `json:"id,omitempty" example:"7"`
+
+	// Problem describes why the row failed
+	Problem *ProblemDetail `json:"problem,omitempty"`
+}
+
+// GreetingImportReport summarizes a greeting import
+// @Description Summary of a greeting import
+type GreetingImportReport struct {
+	// DryRun tells whether the import was only simulated
+	DryRun bool `json:"dryRun" example:"false"`
+
+	// OnDuplicate is the handling applied to duplicate greetings
+	OnDuplicate string `json:"onDuplicate" example:"skip"`
+
+	// Total is the number of rows in the file
+	Total int `json:"total" example:"3"`
+
+	// Created is the number of created greetings
+	Created int `json:"created" example:"1"`
+
+	// Overwritten is the number of overwritten greetings
+	Overwritten int `json:"overwritten" example:"0"`
+
+	// Skipped is the number of skipped duplicates
+	Skipped int `json:"skipped" example:"1"`
+
+	// Failed is the number of rows which could not be imported
+	Failed int `json:"failed" example:"1"`
+
+	// Rows holds the outcome of every row in file order
+	Rows []GreetingImportRowResult `json:"rows"`
+}
@@ -11,11 +11,56 @@ type GreetingResponse struct {
 	// Message is the greeting text
 	Message string `json:"message" example:"Hello, World!" minLength:"3" maxLength:"100" validate:"required"`
 
+	// Locale is the BCP 47 language tag of the message
+	Locale string `json:"locale" example:"en"`
+
+	// Version is incremented by every update and sent as the ETag
+	Version uint `json:"version" example:"1"`
+
 	// CreatedAt is the timestamp when the greeting was created
 	CreatedAt time.Time `json:"createdAt" example:"2025-01-05T10:00:00Z" validate:"required"`
 
+	// CreatedBy is the user ID of the user who created the greeting, absent for records created before auditing
+	CreatedBy string `json:"createdBy,omitempty" example:"1"`
+
 	// UpdatedAt is the timestamp when the greeting was last updated
 	UpdatedAt time.Time `json:"updatedAt" example:"2025-01-05T12:00:00Z"`
+
+	// UpdatedBy is the user ID of the user who last modified the greeting, or system for changes by scheduled jobs
+	UpdatedBy string `json:"updatedBy,omitempty" example:"1"`
+
+	// LastModified is the time the greeting, its reactions or its comments last changed, sent as Last-Modified
+	LastModified time.Time `json:"-"`
+
+	// PublishAt is the time the greeting goes live, absent for greetings live from their creation
+	PublishAt *time.Time `json:"publishAt,omitempty" example:"2025-01-06T08:00:00Z"`
+
+	// ExpireAt is the time the greeting disappears, absent for greetings which never expire
+	ExpireAt *time.Time `json:"expireAt,omitempty" example:"2025-02-06T08:00:00Z"`
+
+	// DeletedAt is the timestamp when the greeting was moved to the trash, absent for other greetings
+	DeletedAt *time.Time `json:"deletedAt,omitempty" example:"2025-01-06T09:00:00Z"`
+
+	// Tags are the names of the tags of the greeting, sorted by name; absent for greetings without tags
+	Tags []string `json:"tags,omitempty" example:"christmas,campaign-2025"`
+
+	// Status is the moderation state: DRAFT, PENDING, APPROVED, REJECTED or ARCHIVED
+	Status string `json:"status,omitempty" example:"APPROVED"`
+
+	// RejectionReason is the reason given by the moderator, present for rejected greetings
+	RejectionReason *string `json:"rejectionReason,omitempty" example:"Contains a link"`
+
+	// OwnerID is the user ID of the user who created the greeting
+	OwnerID string `json:"ownerId,omitempty" example:"2"`
+
+	// TenantID is the organisation owning the greeting
+	TenantID string `json:"tenantId,omitempty" example:"default"`
+
+	// Reactions holds the number of reactions per type, e.g. like; absent for greetings without reactions
+	Reactions map[string]int64 `json:"reactions,omitempty" example:"like:2,love:1"`
+
+	// CommentCount is the number of comments on the greeting including replies, absent for greetings without comments
+	CommentCount int64 `json:"commentCount,omitempty" example:"3"`
 }
 
 // GreetingInput represents the input for creating a greeting
@@ -23,4 +68,182 @@ type GreetingResponse struct {
 type GreetingInput struct {
 	// Message is the greeting text to be created
 	Message string `json:"message" example:"Hello, World!" minLength:"3" maxLength:"100" validate:"required,min=3,max=100"`
+
+	// Locale is the BCP 47 language tag of the message; the default locale when omitted
+	Locale string `json:"locale,omitempty" example:"pt-BR" validate:"omitempty,bcp47_language_tag"`
+
+	// PublishAt is the time the greeting goes live; it is live from its creation when omitted
+	PublishAt *time.Time `json:"publishAt,omitempty" example:"2025-01-06T08:00:00Z"`
+
+	// ExpireAt is the time the greeting disappears, after PublishAt; it never expires when omitted
+	ExpireAt *time.Time `json:"expireAt,omitempty" example:"2025-02-06T08:00:00Z"`
+
+	// Tags are the names of the tags of the greeting, which are stored in lowercase and created when missing.
+	// Updates keep the tags when omitted, and remove them when empty.
+	Tags []string `json:"tags,omitempty" example:"christmas,campaign-2025" validate:"omitempty,max=10,dive,required,max=50"`
+
+	// Draft keeps a created greeting as a draft instead of submitting it for moderation; updates ignore it
+	Draft bool `json:"draft,omitempty" example:"false"`
+}
+
+// GreetingQuery represents the pagination, sorting and filter parameters for listing greetings
+// @Description Query parameters for listing greetings
+type GreetingQuery struct {
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of greetings per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+
+	// Sort holds the sort criteria in the format property[,asc|desc]
+	Sort []string `form:"sort" json:"sort" example:"createdAt,desc"`
+
+	// Message filters greetings whose message contains the text, ignoring case
+	Message string `form:"message" json:"message" example:"hello"`
+
+	// CreatedBefore filters greetings created before the given time
+	CreatedBefore *time.Time `form:"createdBefore" json:"createdBefore" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-06T00:00:00Z"`
+
+	// CreatedAfter filters greetings created after the given time
+	CreatedAfter *time.Time `form:"createdAfter" json:"createdAfter" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-05T00:00:00Z"`
+
+	// Tags filters greetings by the names of their tags, see TagMatch
+	Tags []string `form:"tag" json:"tag" example:"christmas" validate:"max=10,dive,required,max=50"`
+
+	// TagMatch decides whether greetings need any or all of the tags to be listed
+	TagMatch string `form:"tagMatch,default=any" json:"tagMatch" example:"any" validate:"oneof=any all"`
+
+	// Locale restricts the listing to the locale negotiated from the Accept-Language header
+	Locale string `form:"-" json:"-"`
+}
+
+// GreetingTrashQuery represents the pagination and sorting parameters for listing deleted greetings
+// @Description Query parameters for listing the greeting trash
+type GreetingTrashQuery struct {
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of greetings per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+
+	// Sort holds the sort criteria in the format property[,asc|desc]; the most recently deleted come first by default
+	Sort []string `form:"sort" json:"sort" example:"deletedAt,desc"`
+}
+
+// GreetingSearchQuery represents the parameters of a full-text search over greetings
+// @Description Query parameters for searching greetings
+type GreetingSearchQuery struct {
+	// Q holds the words and "quoted phrases" to search for; a word ending with * matches as a prefix
+	Q string `form:"q" json:"q" example:"good morning" validate:"required,max=200"`
+
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of results per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+}
+
+// GreetingSearchResponse represents a greeting matched by a search
+// @Description Greeting search result dto
+type GreetingSearchResponse struct {
+	GreetingResponse
+
+	// Snippet is an HTML escaped excerpt of the message with the matched terms wrapped in <mark> tags
+	Snippet string `json:"snippet" example:"<mark>Good</mark> <mark>morning</mark>, World &amp; all!"`
+
+	// Score is the relevance of the result, higher is more relevant
+	Score float64 `json:"score" example:"1.52"`
+}
+
+// GreetingRenderQuery represents the parameters for rendering a templated greeting
+// @Description Query parameters for rendering a greeting
+type GreetingRenderQuery struct {
+	// TimeZone is the IANA time zone of the caller, which decides e.g. the time of day; UTC by default
+	TimeZone string `form:"timeZone" json:"timeZone" example:"Europe/Istanbul" validate:"omitempty,timezone"`
+}
+
+// RenderedGreetingResponse represents a greeting whose message was rendered for the caller
+// @Description Rendered greeting response dto
+type RenderedGreetingResponse struct {
+	GreetingResponse
+
+	// Template is the message before rendering
+	Template string `json:"template" example:"Good {{timeOfDay}}, {{.FirstName}}!"`
+
+	// RenderedAt is the time the message was rendered for, in the requested time zone
+	RenderedAt time.Time `json:"renderedAt" example:"2025-01-05T15:04:05+03:00"`
+}
+
+// RandomGreetingQuery represents the filters for picking a random greeting
+// @Description Query parameters for a random greeting
+type RandomGreetingQuery struct {
+	// Locale restricts the choice to greetings in the locale
+	Locale string `form:"locale" json:"locale" example:"en" validate:"omitempty,bcp47_language_tag"`
+
+	// Tags restricts the choice to greetings with the tags, see TagMatch
+	Tags []string `form:"tag" json:"tag" example:"christmas" validate:"max=10,dive,required,max=50"`
+
+	// TagMatch decides whether greetings need any or all of the tags to be chosen
+	TagMatch string `form:"tagMatch,default=any" json:"tagMatch" example:"any" validate:"oneof=any all"`
+
+	// TimeZone is the IANA time zone of the caller, which decides e.g. the time of day; UTC by default
+	TimeZone string `form:"timeZone" json:"timeZone" example:"Europe/Istanbul" validate:"omitempty,timezone"`
+}
+
+// DailyGreetingResponse represents the greeting of the day
+// @Description Greeting of the day response dto
+type DailyGreetingResponse struct {
+	GreetingResponse
+
+	// Date is the calendar date the greeting was chosen for, in the requested time zone
+	Date string `json:"date" example:"2025-01-05"`
+
+	// Pinned tells whether an admin pinned the greeting for the date instead of it being chosen
+	Pinned bool `json:"pinned" example:"false"`
+
+	// Pin holds the auditing details of the pin, only returned when pinning a greeting
+	Pin *GreetingPinResponse `json:"pin,omitempty"`
+}
+
+// GreetingPinResponse represents the auditing details of the pin of a greeting of the day
+// @Description Greeting pin dto
+type GreetingPinResponse struct {
+	// CreatedAt is the timestamp when a greeting was first pinned for the date
+	CreatedAt time.Time `json:"createdAt" example:"2025-01-05T10:00:00Z"`
+
+	// CreatedBy is the user ID of the admin who first pinned a greeting for the date
+	CreatedBy string `json:"createdBy,omitempty" example:"1"`
+
+	// UpdatedAt is the timestamp when the pin was last changed
+	UpdatedAt time.Time `json:"updatedAt" example:"2025-01-05T12:00:00Z"`
+
+	// UpdatedBy is the user ID of the admin who last pinned a greeting for the date
+	UpdatedBy string `json:"updatedBy,omitempty" example:"1"`
+}
+
+// GreetingPinInput represents the greeting to pin as greeting of the day
+// @Description Greeting pin input dto
+type GreetingPinInput struct {
+	// GreetingID is the ID of the greeting to pin
+	GreetingID uint `json:"greetingId" example:"1" validate:"required,min=1"`
+}
+
+// ModerationQueueQuery represents the pagination and sorting parameters for listing greetings awaiting moderation
+// @Description Query parameters for listing the moderation queue
+type ModerationQueueQuery struct {
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of greetings per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+
+	// Sort holds the sort criteria in the format property[,asc|desc]; the longest waiting come first by default
+	Sort []string `form:"sort" json:"sort" example:"createdAt"`
+}
+
+// GreetingRejectionInput represents the reason for rejecting a greeting
+// @Description Input dto for rejecting a greeting
+type GreetingRejectionInput struct {
+	// Reason tells the owner why the greeting was rejected
+	Reason string `json:"reason" example:"Contains a link" validate:"required,max=500"`
 }
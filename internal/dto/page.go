@@ -0,0 +1,56 @@
+package dto
+
+// PageMetadata describes the position of a page within the full result set
+// @Description Pagination metadata
+type PageMetadata struct {
+	// Number is the zero-based index of the page
+	Number int `json:"number" example:"0"`
+
+	// Size is the requested number of elements per page
+	Size int `json:"size" example:"20"`
+
+	// TotalElements is the number of elements matching the query
+	TotalElements int64 `json:"totalElements" example:"42"`
+
+	// TotalPages is the number of pages for the requested size
+	TotalPages int `json:"totalPages" example:"3"`
+}
+
+// PagedResponse wraps a page of elements with the pagination metadata
+// @Description Page envelope
+type PagedResponse[T any] struct {
+	// Content holds the elements of the page
+	Content []T `json:"content"`
+
+	// Page holds the pagination metadata
+	Page PageMetadata `json:"page"`
+}
+
+// CursorPagedResponse wraps a slice of elements with the cursors of the neighbouring pages
+// @Description Cursor page envelope
+type CursorPagedResponse[T any] struct {
+	// Content holds the elements of the page
+	Content []T `json:"content"`
+
+	// Size is the requested number of elements per page
+	Size int `json:"size" example:"20"`
+
+	// Next is the cursor of the following page, absent on the last page
+	Next string `json:"next,omitempty" example:"eyJjIjoiaWQiLCJ2IjoyMCwiaWQiOjIwfQ.c2lnbmF0dXJl"`
+
+	// Prev is the cursor of the preceding page, absent on the first page
+	Prev string `json:"prev,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxLCJpZCI6MSwiYiI6dHJ1ZX0.c2lnbmF0dXJl"`
+}
+
+// CursorQuery represents the parameters of a cursor based listing
+// @Description Query parameters for cursor based listings
+type CursorQuery struct {
+	// Cursor is the opaque cursor of the requested page, as returned in next or prev
+	Cursor string `form:"cursor" json:"cursor"`
+
+	// Size is the number of elements per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+
+	// Sort is the sort criterion in the format property[,asc|desc], ignored when a cursor is given
+	Sort string `form:"sort" json:"sort" example:"createdAt,desc"`
+}
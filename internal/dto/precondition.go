@@ -0,0 +1,7 @@
+package dto
+
+// VersionPrecondition holds the resource versions accepted by an If-Match request header.
+// A nil precondition makes the request unconditional; an empty one matches no version.
+type VersionPrecondition struct {
+	Versions []uint
+}
@@ -0,0 +1,45 @@
+package dto
+
+// Ranking periods of the top greetings
+const (
+	TopPeriodDay   = "day"
+	TopPeriodWeek  = "week"
+	TopPeriodMonth = "month"
+	TopPeriodYear  = "year"
+	TopPeriodAll   = "all"
+)
+
+// ReactionSummaryResponse represents the reactions to a greeting
+// @Description Greeting reactions dto
+type ReactionSummaryResponse struct {
+	// GreetingID is the ID of the greeting reacted to
+	GreetingID uint `json:"greetingId" example:"1"`
+
+	// Reactions holds the number of reactions per type; types without reactions are absent
+	Reactions map[string]int64 `json:"reactions" example:"like:2,love:1"`
+
+	// Mine lists the types the caller reacted with, sorted by name
+	Mine []string `json:"mine" example:"like"`
+}
+
+// TopGreetingQuery represents the parameters for ranking greetings by their reactions
+// @Description Query parameters for the top greetings
+type TopGreetingQuery struct {
+	// Period is the time window whose reactions are counted, ending now
+	Period string `form:"period,default=week" json:"period" example:"week" validate:"oneof=day week month year all"`
+
+	// Type restricts the ranking to reactions of the type; reactions of every type are counted when omitted
+	Type string `form:"type" json:"type" example:"like"`
+
+	// Size is the maximum number of greetings returned
+	Size int `form:"size,default=10" json:"size" example:"10" validate:"min=1,max=100"`
+}
+
+// TopGreetingResponse represents a greeting ranked by its reactions
+// @Description Top greeting dto
+type TopGreetingResponse struct {
+	GreetingResponse
+
+	// Score is the number of reactions counted for the ranking
+	Score int64 `json:"score" example:"5"`
+}
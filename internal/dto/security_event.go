@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// SecurityEventResponse represents an entry of the security audit log
+// @Description Security event dto
+type SecurityEventResponse struct {
+	// ID of the event
+	ID uint `json:"id" example:"1"`
+
+	// Type of the event
+	Type string `json:"type" example:"LOGIN_FAILURE" enums:"LOGIN_SUCCESS,LOGIN_FAILURE,TOKEN_REJECTED,ACCESS_DENIED,ADMIN_ACTION"`
+
+	// Principal is the user id or the attempted username
+	Principal string `json:"principal,omitempty" example:"admin"`
+
+	// TenantID is the tenant of the user, absent when the user is unknown
+	TenantID string `json:"tenantId,omitempty" example:"default"`
+
+	// Reason of a failure or rejection
+	Reason string `json:"reason,omitempty" example:"bad credentials"`
+
+	// Authority required by a denied request
+	Authority string `json:"authority,omitempty" example:"ROLE_ADMIN"`
+
+	// Method is the HTTP method of the request
+	Method string `json:"method" example:"POST"`
+
+	// Path of the request
+	Path string `json:"path" example:"/api/auth/login"`
+
+	// ClientIP is the IP address of the client
+	ClientIP string `json:"clientIp" example:"127.0.0.1"`
+
+	// Status is the HTTP response status
+	Status int `json:"status" example:"401"`
+
+	// OccurredAt is the time the event occurred
+	OccurredAt time.Time `json:"occurredAt" example:"2025-01-05T10:00:00Z"`
+}
+
+// SecurityEventQuery represents the filters for querying the security audit log
+// @Description Query parameters for the security audit log
+type SecurityEventQuery struct {
+	// User filters by user id or attempted username
+	User string `form:"user" json:"user" example:"admin"`
+
+	// Type filters by event type
+	Type string `form:"type" json:"type" example:"LOGIN_FAILURE" validate:"omitempty,oneof=LOGIN_SUCCESS LOGIN_FAILURE TOKEN_REJECTED ACCESS_DENIED ADMIN_ACTION"`
+
+	// From is the inclusive start of the time range
+	From *time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-05T00:00:00Z"`
+
+	// To is the exclusive end of the time range
+	To *time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-06T00:00:00Z"`
+
+	// Limit is the maximum number of events to return
+	Limit int `form:"limit,default=100" json:"limit" example:"100" validate:"min=1,max=1000"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// Tag filter matches of greeting listings
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// TagResponse represents a tag with the number of greetings tagged with it
+// @Description Tag dto
+type TagResponse struct {
+	// ID of the tag
+	ID uint `json:"id" example:"1"`
+
+	// Name of the tag in lowercase
+	Name string `json:"name" example:"christmas"`
+
+	// Count is the number of greetings tagged with the tag, not counting the trash
+	Count int64 `json:"count" example:"3"`
+
+	// CreatedAt is the timestamp when the tag was first used
+	CreatedAt time.Time `json:"createdAt" example:"2025-01-05T10:00:00Z"`
+
+	// CreatedBy is the user ID of the user who first used the tag, absent for tags created before auditing
+	CreatedBy string `json:"createdBy,omitempty" example:"1"`
+
+	// UpdatedBy is the user ID of the user who last renamed the tag, or who created it
+	UpdatedBy string `json:"updatedBy,omitempty" example:"1"`
+}
+
+// TagQuery represents the pagination and sorting parameters for listing tags
+// @Description Query parameters for listing tags
+type TagQuery struct {
+	// Page is the zero-based page index
+	Page int `form:"page,default=0" json:"page" example:"0" validate:"min=0"`
+
+	// Size is the number of tags per page
+	Size int `form:"size,default=20" json:"size" example:"20" validate:"min=1,max=100"`
+
+	// Sort holds the sort criteria in the format property[,asc|desc]; tags are sorted by name by default
+	Sort []string `form:"sort" json:"sort" example:"count,desc"`
+}
+
+// TagInput represents the input for renaming a tag
+// @Description Input dto for renaming a tag
+type TagInput struct {
+	// Name is the new name of the tag, stored in lowercase
+	Name string `json:"name" example:"xmas" validate:"required,max=50"`
+}
+
+// TagMergeInput represents the tags to merge
+// @Description Input dto for merging tags
+type TagMergeInput struct {
+	// SourceIDs are the IDs of the tags to merge, which are deleted
+	SourceIDs []uint `json:"sourceIds" example:"2,3" validate:"required,min=1,max=100,dive,min=1"`
+
+	// TargetID is the ID of the tag the greetings of the source tags are tagged with instead
+	TargetID uint `json:"targetId" example:"1" validate:"required,min=1"`
+}
@@ -3,12 +3,15 @@ package dto
 // TokenResponse represents the JWT token response
 // @Description JWT token response DTO
 type TokenResponse struct {
-	// AccessToken is the JWT access token
-	AccessToken string `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..." validate:"required"`
+	// AccessToken is the JWT access token, omitted when the token is delivered in an HttpOnly cookie
+	AccessToken string `json:"accessToken,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 
 	// TokenType is the type of the token
 	TokenType string `json:"tokenType" example:"Bearer" validate:"required"`
 
 	// AccessTokenExpiresIn is the expiration time of the access token in seconds
 	AccessTokenExpiresIn int64 `json:"accessTokenExpiresIn" example:"3600" validate:"required"`
+
+	// CsrfToken must be echoed in the CSRF header on unsafe requests when using the cookie token mode
+	CsrfToken string `json:"csrfToken,omitempty" example:"hTz4Yb1x0Jb6m6pQ2ZQ0cVbJkqZ2m1Qk3p8b3n0fL5E"`
 }
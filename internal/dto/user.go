@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// UserResponse represents a user account
+// @Description User dto
+type UserResponse struct {
+	// ID of the user
+	ID string `json:"id" example:"a1b2c3d4-e5f6-7890-abcd-ef1234567890"`
+
+	// Username of the user
+	Username string `json:"username" example:"admin"`
+
+	// Email of the user
+	Email string `json:"email" example:"admin@example.com"`
+
+	// TenantID is the organisation of the user
+	TenantID string `json:"tenantId,omitempty" example:"default"`
+
+	// FirstName of the user
+	FirstName string `json:"firstName,omitempty" example:"Admin"`
+
+	// LastName of the user
+	LastName string `json:"lastName,omitempty" example:"User"`
+
+	// Enabled tells whether the user can log in
+	Enabled bool `json:"enabled" example:"true"`
+
+	// Roles granted to the user
+	Roles []string `json:"roles" example:"ROLE_ADMIN"`
+
+	// CreatedAt is the timestamp when the user was created
+	CreatedAt time.Time `json:"createdAt" example:"2025-01-05T10:00:00Z"`
+
+	// CreatedBy is the user ID of the user who created the user, absent for records created before auditing
+	CreatedBy string `json:"createdBy,omitempty" example:"1"`
+
+	// UpdatedAt is the timestamp when the user was last updated
+	UpdatedAt time.Time `json:"updatedAt" example:"2025-01-05T12:00:00Z"`
+
+	// UpdatedBy is the user ID of the user who last modified the user, or system for changes by scheduled jobs
+	UpdatedBy string `json:"updatedBy,omitempty" example:"1"`
+}
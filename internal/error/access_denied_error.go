@@ -2,7 +2,8 @@ package error
 
 // AccessDeniedError represents an error for access denial
 type AccessDeniedError struct {
-	Message string // Error message, must be provided
+	Message   string // Error message, must be provided
+	Authority string // Required authority, if any
 }
 
 // Error returns the error message
@@ -0,0 +1,14 @@
+package error
+
+import "fmt"
+
+// ConcurrentModificationError represents an error when a resource was modified by another request during an update
+type ConcurrentModificationError struct {
+	Resource string
+	Criteria string
+	Value    string
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("The %s with %s: %s was modified concurrently, please retry", e.Resource, e.Criteria, e.Value)
+}
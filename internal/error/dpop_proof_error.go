@@ -0,0 +1,11 @@
+package error
+
+// DPoPProofError represents an error for a missing or invalid DPoP proof (RFC 9449)
+type DPoPProofError struct {
+	Message string // Error message, must be provided
+}
+
+// Error returns the error message
+func (e *DPoPProofError) Error() string {
+	return e.Message
+}
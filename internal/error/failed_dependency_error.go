@@ -0,0 +1,13 @@
+package error
+
+import "fmt"
+
+// FailedDependencyError represents an error when an item of a bulk operation was not applied
+// because another item of the same all-or-nothing operation failed
+type FailedDependencyError struct {
+	Index int
+}
+
+func (e *FailedDependencyError) Error() string {
+	return fmt.Sprintf("The item %d was rolled back, because another item of the operation failed", e.Index)
+}
@@ -1,7 +1,18 @@
 package error
 
-// InvalidCredentialsError represents an error for invalid username or password
-type InvalidCredentialsError struct{}
+// Reasons for an InvalidCredentialsError, recorded in the security audit log
+const (
+	ReasonUserNotFound   = "user not found"
+	ReasonUserDisabled   = "user disabled"
+	ReasonBadCredentials = "bad credentials"
+)
+
+// InvalidCredentialsError represents an error for invalid username or password.
+// Username and Reason are only used for auditing and never returned to the client.
+type InvalidCredentialsError struct {
+	Username string
+	Reason   string
+}
 
 func (e *InvalidCredentialsError) Error() string {
 	return "Invalid credentials provided"
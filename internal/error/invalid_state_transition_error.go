@@ -0,0 +1,15 @@
+package error
+
+import "fmt"
+
+// InvalidStateTransitionError represents an error when a resource cannot move from its current state to the requested one
+type InvalidStateTransitionError struct {
+	Resource string
+	Value    string
+	From     string
+	To       string
+}
+
+func (e *InvalidStateTransitionError) Error() string {
+	return fmt.Sprintf("The %s with id %s cannot change from %s to %s", e.Resource, e.Value, e.From, e.To)
+}
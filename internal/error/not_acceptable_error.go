@@ -0,0 +1,17 @@
+package error
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NotAcceptableError represents an error when none of the media types accepted by the client can be produced
+type NotAcceptableError struct {
+	Accept    string
+	Supported []string
+}
+
+func (e *NotAcceptableError) Error() string {
+	return fmt.Sprintf("None of the accepted media types %q is available, supported media types are: %s",
+		e.Accept, strings.Join(e.Supported, ", "))
+}
@@ -0,0 +1,14 @@
+package error
+
+import "fmt"
+
+// PreconditionFailedError represents an error when a resource no longer matches the version sent in If-Match
+type PreconditionFailedError struct {
+	Resource string
+	Criteria string
+	Value    string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("The %s with %s: %s has been modified", e.Resource, e.Criteria, e.Value)
+}
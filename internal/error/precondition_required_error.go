@@ -0,0 +1,12 @@
+package error
+
+import "fmt"
+
+// PreconditionRequiredError represents an error when a conditional request header is required but missing
+type PreconditionRequiredError struct {
+	Header string
+}
+
+func (e *PreconditionRequiredError) Error() string {
+	return fmt.Sprintf("The request must be conditional, the %s header is missing", e.Header)
+}
@@ -0,0 +1,12 @@
+package error
+
+import "fmt"
+
+// UnprocessablePatchError represents an error when a well-formed patch document cannot be applied to the resource
+type UnprocessablePatchError struct {
+	Detail string
+}
+
+func (e *UnprocessablePatchError) Error() string {
+	return fmt.Sprintf("The patch could not be applied. Detail: %s", e.Detail)
+}
@@ -0,0 +1,16 @@
+package error
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedMediaTypeError represents an error when the request body has a media type the endpoint does not accept
+type UnsupportedMediaTypeError struct {
+	MediaType string
+	Supported []string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("Unsupported media type %q, supported media types: %s", e.MediaType, strings.Join(e.Supported, ", "))
+}
@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// CommentMapper defines the interface for mapping operations related to the comments on greetings
+type CommentMapper interface {
+	ToCommentResponse(domain.GreetingComment) dto.CommentResponse
+	ToCommentResponses([]domain.GreetingComment) []dto.CommentResponse
+	ToCommentEntity(greetingID uint, authorID string, input dto.CommentInput) domain.GreetingComment
+}
+
+// commentMapperImpl is the default implementation of CommentMapper
+type commentMapperImpl struct{}
+
+// NewCommentMapper creates a new instance of commentMapperImpl
+func NewCommentMapper() CommentMapper {
+	return &commentMapperImpl{}
+}
+
+// ToCommentResponse maps a GreetingComment domain to CommentResponse DTO
+func (m *commentMapperImpl) ToCommentResponse(c domain.GreetingComment) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:         c.ID,
+		GreetingID: c.GreetingID,
+		ParentID:   c.ParentID,
+		AuthorID:   c.AuthorID,
+		Body:       c.Body,
+		CreatedAt:  c.CreatedAt,
+		CreatedBy:  c.CreatedBy,
+		UpdatedAt:  c.UpdatedAt,
+		UpdatedBy:  c.UpdatedBy,
+	}
+}
+
+// ToCommentResponses maps a slice of GreetingComment entities to CommentResponse DTOs
+func (m *commentMapperImpl) ToCommentResponses(comments []domain.GreetingComment) []dto.CommentResponse {
+	responses := make([]dto.CommentResponse, len(comments))
+	for i, c := range comments {
+		responses[i] = m.ToCommentResponse(c)
+	}
+	return responses
+}
+
+// ToCommentEntity maps a CommentInput DTO to a GreetingComment domain written by the author
+func (m *commentMapperImpl) ToCommentEntity(greetingID uint, authorID string, input dto.CommentInput) domain.GreetingComment {
+	return domain.GreetingComment{
+		GreetingID: greetingID,
+		ParentID:   input.ParentID,
+		AuthorID:   authorID,
+		Body:       input.Body,
+	}
+}
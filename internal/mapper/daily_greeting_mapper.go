@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// DailyGreetingMapper defines the interface for mapping operations related to the greeting of the day
+type DailyGreetingMapper interface {
+	ToGreetingPinResponse(domain.GreetingPin) dto.GreetingPinResponse
+}
+
+// dailyGreetingMapperImpl is the default implementation of DailyGreetingMapper
+type dailyGreetingMapperImpl struct{}
+
+// NewDailyGreetingMapper creates a new instance of dailyGreetingMapperImpl
+func NewDailyGreetingMapper() DailyGreetingMapper {
+	return &dailyGreetingMapperImpl{}
+}
+
+// ToGreetingPinResponse maps a GreetingPin domain to GreetingPinResponse DTO
+func (m *dailyGreetingMapperImpl) ToGreetingPinResponse(p domain.GreetingPin) dto.GreetingPinResponse {
+	return dto.GreetingPinResponse{
+		CreatedAt: p.CreatedAt,
+		CreatedBy: p.CreatedBy,
+		UpdatedAt: p.UpdatedAt,
+		UpdatedBy: p.UpdatedBy,
+	}
+}
@@ -3,14 +3,25 @@ package mapper
 import (
 	"gin-samples/internal/domain"
 	"gin-samples/internal/dto"
+	"html"
+	"strings"
+	"time"
+)
+
+// snippetHighlighter replaces the highlight markers of search snippets with <mark> tags
+var snippetHighlighter = strings.NewReplacer(
+	domain.SnippetHighlightStart, "<mark>",
+	domain.SnippetHighlightEnd, "</mark>",
 )
 
 // HelloMapper defines the interface for mapping operations related to greetings
 type HelloMapper interface {
 	ToGreetingResponse(domain.Greeting) dto.GreetingResponse
 	ToGreetingResponses([]domain.Greeting) []dto.GreetingResponse
+	ToGreetingSearchResponses([]domain.GreetingSearchResult) []dto.GreetingSearchResponse
 	ToGreetingEntity(dto.GreetingInput) domain.Greeting
 	PartialUpdateGreeting(*domain.Greeting, dto.GreetingInput)
+	UpdateGreeting(*domain.Greeting, dto.GreetingInput)
 }
 
 // helloMapperImpl is the default implementation of HelloMapper
@@ -23,12 +34,40 @@ func NewHelloMapper() HelloMapper {
 
 // ToGreetingResponse maps a Greeting domain to GreetingResponse DTO
 func (m *helloMapperImpl) ToGreetingResponse(g domain.Greeting) dto.GreetingResponse {
-	return dto.GreetingResponse{
-		ID:        g.ID,
-		Message:   g.Message,
-		CreatedAt: g.CreatedAt,
-		UpdatedAt: g.UpdatedAt,
+	response := dto.GreetingResponse{
+		ID:           g.ID,
+		Message:      g.Message,
+		Locale:       g.Locale,
+		Version:      g.Version,
+		PublishAt:    g.PublishAt,
+		ExpireAt:     g.ExpireAt,
+		CreatedAt:    g.CreatedAt,
+		CreatedBy:    g.CreatedBy,
+		UpdatedAt:    g.UpdatedAt,
+		UpdatedBy:    g.UpdatedBy,
+		LastModified: g.UpdatedAt,
+		Status:       string(g.Status),
+		OwnerID:      g.OwnerID,
+		TenantID:     g.TenantID,
+		CommentCount: g.CommentCount,
+	}
+	if g.LastActivityAt != nil && g.LastActivityAt.After(g.UpdatedAt) {
+		response.LastModified = *g.LastActivityAt
+	}
+	if g.Status == domain.GreetingStatusRejected {
+		response.RejectionReason = g.RejectionReason
+	}
+	if len(g.Tags) > 0 {
+		response.Tags = g.TagNames()
+	}
+	if len(g.Reactions) > 0 {
+		response.Reactions = toReactionCounts(g.Reactions)
+	}
+	if g.DeletedAt.Valid {
+		deletedAt := g.DeletedAt.Time
+		response.DeletedAt = &deletedAt
 	}
+	return response
 }
 
 // ToGreetingResponses maps a slice of Greeting entities to GreetingResponse DTOs
@@ -40,10 +79,43 @@ func (m *helloMapperImpl) ToGreetingResponses(greetings []domain.Greeting) []dto
 	return responses
 }
 
+// ToGreetingSearchResponses maps search results to GreetingSearchResponse DTOs.
+// The BM25 rank is negated, so a higher score means a more relevant result.
+func (m *helloMapperImpl) ToGreetingSearchResponses(results []domain.GreetingSearchResult) []dto.GreetingSearchResponse {
+	responses := make([]dto.GreetingSearchResponse, len(results))
+	for i, r := range results {
+		responses[i] = dto.GreetingSearchResponse{
+			GreetingResponse: m.ToGreetingResponse(r.Greeting),
+			Snippet:          highlightSnippet(r.Snippet),
+			Score:            -r.Rank,
+		}
+	}
+	return responses
+}
+
+// highlightSnippet HTML escapes the user submitted message text of a snippet and wraps the matched terms in <mark> tags,
+// so the snippet can be rendered as HTML
+func highlightSnippet(snippet string) string {
+	return snippetHighlighter.Replace(html.EscapeString(snippet))
+}
+
+// toReactionCounts maps the number of reactions per type to a map keyed by the type names
+func toReactionCounts(counts map[domain.ReactionType]int64) map[string]int64 {
+	reactions := make(map[string]int64, len(counts))
+	for reactionType, count := range counts {
+		reactions[string(reactionType)] = count
+	}
+	return reactions
+}
+
 // ToGreetingEntity maps a GreetingInput DTO to a Greeting domain
 func (m *helloMapperImpl) ToGreetingEntity(input dto.GreetingInput) domain.Greeting {
 	return domain.Greeting{
-		Message: input.Message,
+		Message:   input.Message,
+		Locale:    input.Locale,
+		PublishAt: toUTC(input.PublishAt),
+		ExpireAt:  toUTC(input.ExpireAt),
+		Tags:      toTags(input.Tags),
 	}
 }
 
@@ -52,4 +124,43 @@ func (m *helloMapperImpl) PartialUpdateGreeting(entity *domain.Greeting, input d
 	if input.Message != "" {
 		entity.Message = input.Message
 	}
+	if input.Locale != "" {
+		entity.Locale = input.Locale
+	}
+	if input.PublishAt != nil {
+		entity.PublishAt = toUTC(input.PublishAt)
+	}
+	if input.ExpireAt != nil {
+		entity.ExpireAt = toUTC(input.ExpireAt)
+	}
+	if input.Tags != nil {
+		entity.Tags = toTags(input.Tags)
+	}
+}
+
+// UpdateGreeting replaces all fields of the domain entity with the input, including empty ones
+func (m *helloMapperImpl) UpdateGreeting(entity *domain.Greeting, input dto.GreetingInput) {
+	entity.Message = input.Message
+	entity.Locale = input.Locale
+	entity.PublishAt = toUTC(input.PublishAt)
+	entity.ExpireAt = toUTC(input.ExpireAt)
+	entity.Tags = toTags(input.Tags)
+}
+
+// toTags maps tag names to tags, which the repository matches by name
+func toTags(names []string) []domain.Tag {
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{Name: name}
+	}
+	return tags
+}
+
+// toUTC converts a schedule time to UTC, as the database compares times by their text
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
 }
@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// ReactionMapper defines the interface for mapping operations related to the reactions to greetings
+type ReactionMapper interface {
+	ToReactionSummaryResponse(uint, map[domain.ReactionType]int64, []domain.ReactionType) dto.ReactionSummaryResponse
+	ToTopGreetingResponses([]domain.GreetingScore) []dto.TopGreetingResponse
+}
+
+// reactionMapperImpl is the default implementation of ReactionMapper
+type reactionMapperImpl struct {
+	helloMapper HelloMapper
+}
+
+// NewReactionMapper creates a new instance of reactionMapperImpl.
+// Ranked greetings are mapped with helloMapper.
+func NewReactionMapper(helloMapper HelloMapper) ReactionMapper {
+	return &reactionMapperImpl{helloMapper: helloMapper}
+}
+
+// ToReactionSummaryResponse maps the reaction counts of a greeting and the reaction types of the caller
+// to a ReactionSummaryResponse DTO
+func (m *reactionMapperImpl) ToReactionSummaryResponse(greetingID uint, counts map[domain.ReactionType]int64,
+	mine []domain.ReactionType) dto.ReactionSummaryResponse {
+	response := dto.ReactionSummaryResponse{
+		GreetingID: greetingID,
+		Reactions:  toReactionCounts(counts),
+		Mine:       make([]string, len(mine)),
+	}
+	for i, reactionType := range mine {
+		response.Mine[i] = string(reactionType)
+	}
+	return response
+}
+
+// ToTopGreetingResponses maps ranked greetings to TopGreetingResponse DTOs
+func (m *reactionMapperImpl) ToTopGreetingResponses(scores []domain.GreetingScore) []dto.TopGreetingResponse {
+	responses := make([]dto.TopGreetingResponse, len(scores))
+	for i, s := range scores {
+		responses[i] = dto.TopGreetingResponse{
+			GreetingResponse: m.helloMapper.ToGreetingResponse(s.Greeting),
+			Score:            s.Score,
+		}
+	}
+	return responses
+}
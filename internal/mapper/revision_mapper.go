@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// RevisionMapper defines the interface for mapping operations related to the revisions of greetings
+type RevisionMapper interface {
+	ToGreetingRevisionResponse(domain.GreetingRevision) dto.GreetingRevisionResponse
+	ToGreetingRevisionResponses([]domain.GreetingRevision) []dto.GreetingRevisionResponse
+}
+
+// revisionMapperImpl is the default implementation of RevisionMapper
+type revisionMapperImpl struct{}
+
+// NewRevisionMapper creates a new instance of revisionMapperImpl
+func NewRevisionMapper() RevisionMapper {
+	return &revisionMapperImpl{}
+}
+
+// ToGreetingRevisionResponse maps a GreetingRevision domain to GreetingRevisionResponse DTO
+func (m *revisionMapperImpl) ToGreetingRevisionResponse(r domain.GreetingRevision) dto.GreetingRevisionResponse {
+	return dto.GreetingRevisionResponse{
+		Revision:     r.Revision,
+		Action:       string(r.Action),
+		Version:      r.Version,
+		Old:          toGreetingState(r.OldMessage, r.OldLocale),
+		New:          toGreetingState(r.NewMessage, r.NewLocale),
+		RolledBackTo: r.RolledBackTo,
+		ChangedBy:    r.ChangedBy,
+		ChangedAt:    r.ChangedAt,
+	}
+}
+
+// ToGreetingRevisionResponses maps a slice of GreetingRevision entities to GreetingRevisionResponse DTOs
+func (m *revisionMapperImpl) ToGreetingRevisionResponses(revisions []domain.GreetingRevision) []dto.GreetingRevisionResponse {
+	responses := make([]dto.GreetingRevisionResponse, len(revisions))
+	for i, r := range revisions {
+		responses[i] = m.ToGreetingRevisionResponse(r)
+	}
+	return responses
+}
+
+// toGreetingState maps the values recorded by a revision, or nil when the greeting did not exist
+func toGreetingState(message, locale *string) *dto.GreetingState {
+	if message == nil {
+		return nil
+	}
+	state := &dto.GreetingState{Message: *message}
+	if locale != nil {
+		state.Locale = *locale
+	}
+	return state
+}
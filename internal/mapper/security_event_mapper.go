@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// SecurityEventMapper defines the interface for mapping operations related to security events
+type SecurityEventMapper interface {
+	ToSecurityEventResponse(domain.SecurityEvent) dto.SecurityEventResponse
+	ToSecurityEventResponses([]domain.SecurityEvent) []dto.SecurityEventResponse
+}
+
+// securityEventMapperImpl is the default implementation of SecurityEventMapper
+type securityEventMapperImpl struct{}
+
+// NewSecurityEventMapper creates a new instance of securityEventMapperImpl
+func NewSecurityEventMapper() SecurityEventMapper {
+	return &securityEventMapperImpl{}
+}
+
+// ToSecurityEventResponse maps a SecurityEvent domain to SecurityEventResponse DTO
+func (m *securityEventMapperImpl) ToSecurityEventResponse(e domain.SecurityEvent) dto.SecurityEventResponse {
+	return dto.SecurityEventResponse{
+		ID:         e.ID,
+		Type:       string(e.Type),
+		Principal:  e.Principal,
+		TenantID:   e.TenantID,
+		Reason:     e.Reason,
+		Authority:  e.Authority,
+		Method:     e.Method,
+		Path:       e.Path,
+		ClientIP:   e.ClientIP,
+		Status:     e.Status,
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+// ToSecurityEventResponses maps a slice of SecurityEvent entities to SecurityEventResponse DTOs
+func (m *securityEventMapperImpl) ToSecurityEventResponses(events []domain.SecurityEvent) []dto.SecurityEventResponse {
+	responses := make([]dto.SecurityEventResponse, len(events))
+	for i, e := range events {
+		responses[i] = m.ToSecurityEventResponse(e)
+	}
+	return responses
+}
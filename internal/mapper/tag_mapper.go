@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// TagMapper defines the interface for mapping operations related to the tags of greetings
+type TagMapper interface {
+	ToTagResponse(domain.TagUsage) dto.TagResponse
+	ToTagResponses([]domain.TagUsage) []dto.TagResponse
+}
+
+// tagMapperImpl is the default implementation of TagMapper
+type tagMapperImpl struct{}
+
+// NewTagMapper creates a new instance of tagMapperImpl
+func NewTagMapper() TagMapper {
+	return &tagMapperImpl{}
+}
+
+// ToTagResponse maps a TagUsage domain to TagResponse DTO
+func (m *tagMapperImpl) ToTagResponse(t domain.TagUsage) dto.TagResponse {
+	return dto.TagResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Count:     t.Count,
+		CreatedAt: t.CreatedAt,
+		CreatedBy: t.CreatedBy,
+		UpdatedBy: t.UpdatedBy,
+	}
+}
+
+// ToTagResponses maps a slice of TagUsage domains to TagResponse DTOs
+func (m *tagMapperImpl) ToTagResponses(tags []domain.TagUsage) []dto.TagResponse {
+	responses := make([]dto.TagResponse, len(tags))
+	for i, t := range tags {
+		responses[i] = m.ToTagResponse(t)
+	}
+	return responses
+}
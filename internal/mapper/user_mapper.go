@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+)
+
+// UserMapper defines the interface for mapping operations related to users
+type UserMapper interface {
+	ToUserResponse(domain.User) dto.UserResponse
+	ToUserResponses([]domain.User) []dto.UserResponse
+}
+
+// userMapperImpl is the default implementation of UserMapper
+type userMapperImpl struct{}
+
+// NewUserMapper creates a new instance of userMapperImpl
+func NewUserMapper() UserMapper {
+	return &userMapperImpl{}
+}
+
+// ToUserResponse maps a User domain to UserResponse DTO without the password
+func (m *userMapperImpl) ToUserResponse(u domain.User) dto.UserResponse {
+	roles := make([]string, 0, len(u.Roles))
+	for _, roleMapping := range u.Roles {
+		roles = append(roles, roleMapping.Role.Name)
+	}
+	return dto.UserResponse{
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		TenantID:  u.TenantID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Enabled:   u.Enabled,
+		Roles:     roles,
+		CreatedAt: u.CreatedAt,
+		CreatedBy: u.CreatedBy,
+		UpdatedAt: u.UpdatedAt,
+		UpdatedBy: u.UpdatedBy,
+	}
+}
+
+// ToUserResponses maps a slice of User entities to UserResponse DTOs
+func (m *userMapperImpl) ToUserResponses(users []domain.User) []dto.UserResponse {
+	responses := make([]dto.UserResponse, len(users))
+	for i, u := range users {
+		responses[i] = m.ToUserResponse(u)
+	}
+	return responses
+}
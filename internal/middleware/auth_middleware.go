@@ -4,17 +4,34 @@ import (
 	customError "gin-samples/internal/error"
 	"gin-samples/internal/security"
 	"github.com/gin-gonic/gin"
+	"net/http"
 	"regexp"
 )
 
 // Regex pattern for Authorization header
-var authorizationPattern = regexp.MustCompile(`^Bearer (?P<token>[a-zA-Z0-9-._~+/]+=*)$`)
+var authorizationPattern = regexp.MustCompile(`^(?P<scheme>Bearer|DPoP) (?P<token>[a-zA-Z0-9-._~+/]+=*)$`)
+
+// safeMethods are the HTTP methods that do not require CSRF protection
+var safeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
 
 // AuthMiddleware validates the JWT token from the Authorization header and sets claims in the context.
-func AuthMiddleware(tokenGenerator security.TokenGenerator) gin.HandlerFunc {
+// When the cookie token mode is enabled, the token may also be sent in the HttpOnly cookie,
+// in which case unsafe methods must echo the CSRF token in the configured header.
+// DPoP-bound tokens must be sent with the DPoP scheme and a fresh proof; dpopVerifier may be nil when DPoP is disabled.
+func AuthMiddleware(tokenGenerator security.TokenGenerator, cookieOptions security.CookieOptions,
+	dpopVerifier security.DPoPVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && cookieOptions.Enabled {
+			authenticateWithCookie(c, tokenGenerator, cookieOptions, dpopVerifier)
+			return
+		}
 		if authHeader == "" {
 			_ = c.Error(&customError.JwtError{Message: "Missing Authorization header"})
 			c.Abort()
@@ -37,6 +54,7 @@ func AuthMiddleware(tokenGenerator security.TokenGenerator) gin.HandlerFunc {
 			return
 		}
 		token := matches[tokenIndex]
+		scheme := matches[authorizationPattern.SubexpIndex("scheme")]
 
 		// Validate the token
 		claims, err := tokenGenerator.Validate(token)
@@ -46,10 +64,95 @@ func AuthMiddleware(tokenGenerator security.TokenGenerator) gin.HandlerFunc {
 			return
 		}
 
+		// Enforce the DPoP key binding of sender-constrained tokens
+		if err := verifySenderConstraint(c, claims, token, scheme == "DPoP", dpopVerifier); err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
 		// Add the entire claims to the context
-		c.Set("jwt", claims)
+		setClaims(c, claims)
 
 		// If the token is valid, continue to the next handler
 		c.Next()
 	}
 }
+
+// authenticateWithCookie validates the token from the HttpOnly cookie and enforces CSRF protection
+func authenticateWithCookie(c *gin.Context, tokenGenerator security.TokenGenerator, cookieOptions security.CookieOptions,
+	dpopVerifier security.DPoPVerifier) {
+	token, err := c.Cookie(cookieOptions.Name)
+	if err != nil || token == "" {
+		_ = c.Error(&customError.JwtError{Message: "Missing Authorization header or token cookie"})
+		c.Abort()
+		return
+	}
+
+	// Validate the token
+	claims, err := tokenGenerator.Validate(token)
+	if err != nil {
+		_ = c.Error(&customError.JwtError{Message: "Invalid or expired token"})
+		c.Abort()
+		return
+	}
+
+	// Cookies are sent automatically by the browser, so unsafe requests must prove they were issued by our client
+	if !safeMethods[c.Request.Method] &&
+		!security.VerifyCSRFToken(claims.CSRFToken, c.GetHeader(cookieOptions.CSRFHeaderName)) {
+		_ = c.Error(&customError.AccessDeniedError{Message: "Access Denied: Invalid CSRF token"})
+		c.Abort()
+		return
+	}
+
+	// A DPoP-bound token in a cookie still requires a proof of possession
+	if err := verifySenderConstraint(c, claims, token, claims.Confirmation != nil, dpopVerifier); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	// Add the entire claims to the context
+	setClaims(c, claims)
+
+	c.Next()
+}
+
+// setClaims adds the claims to the context, and to the request context so that services can read them
+func setClaims(c *gin.Context, claims *security.TokenClaims) {
+	c.Set("jwt", claims)
+	c.Request = c.Request.WithContext(security.ContextWithClaims(c.Request.Context(), claims))
+}
+
+// verifySenderConstraint checks that DPoP-bound tokens are presented with a valid proof from the bound key,
+// and that unbound tokens are not presented with the DPoP scheme
+func verifySenderConstraint(c *gin.Context, claims *security.TokenClaims, token string, dpopScheme bool,
+	dpopVerifier security.DPoPVerifier) error {
+	if !dpopScheme {
+		if claims.Confirmation != nil {
+			return &customError.DPoPProofError{Message: "DPoP-bound token must be used with the DPoP scheme"}
+		}
+		return nil
+	}
+
+	if dpopVerifier == nil {
+		return &customError.JwtError{Message: "DPoP is not enabled"}
+	}
+	if claims.Confirmation == nil {
+		return &customError.JwtError{Message: "Token is not DPoP-bound"}
+	}
+
+	proof := c.GetHeader(security.DPoPProofHeader)
+	if proof == "" {
+		return &customError.DPoPProofError{Message: "Missing DPoP proof"}
+	}
+
+	jkt, err := dpopVerifier.Verify(proof, c.Request.Method, dpopVerifier.RequestURL(c.Request), token)
+	if err != nil {
+		return err
+	}
+	if jkt != claims.Confirmation.JKT {
+		return &customError.DPoPProofError{Message: "DPoP proof key does not match the token binding"}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"errors"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/security"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeTokenGenerator validates the tokens of a fixed set of claims
+type fakeTokenGenerator struct {
+	claims map[string]*security.TokenClaims
+}
+
+func (g fakeTokenGenerator) Generate(security.TokenClaims) (security.Token, error) {
+	return security.Token{}, errors.New("not supported")
+}
+
+func (g fakeTokenGenerator) Validate(tokenString string) (*security.TokenClaims, error) {
+	if claims, ok := g.claims[tokenString]; ok {
+		return claims, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+func TestAuthMiddleware_CookieMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cookieOptions := security.CookieOptions{
+		Enabled:        true,
+		Name:           "access_token",
+		CSRFCookieName: "XSRF-TOKEN",
+		CSRFHeaderName: "X-XSRF-TOKEN",
+		Path:           "/",
+		Secure:         true,
+		SameSite:       http.SameSiteStrictMode,
+	}
+	tokenGenerator := fakeTokenGenerator{claims: map[string]*security.TokenClaims{
+		"cookie-token": {UserID: "1", CSRFToken: "csrf-token"},
+		"bearer-token": {UserID: "2"},
+	}}
+
+	tests := []struct {
+		name          string
+		cookieOptions security.CookieOptions
+		method        string
+		cookie        string
+		authorization string
+		csrfHeader    string
+		expectedUser  string
+		expectedError error
+	}{
+		{
+			name:          "Safe method with cookie",
+			cookieOptions: cookieOptions,
+			method:        http.MethodGet,
+			cookie:        "cookie-token",
+			expectedUser:  "1",
+		},
+		{
+			name:          "Unsafe method with matching CSRF header",
+			cookieOptions: cookieOptions,
+			method:        http.MethodPost,
+			cookie:        "cookie-token",
+			csrfHeader:    "csrf-token",
+			expectedUser:  "1",
+		},
+		{
+			name:          "Unsafe method without CSRF header",
+			cookieOptions: cookieOptions,
+			method:        http.MethodPost,
+			cookie:        "cookie-token",
+			expectedError: &customError.AccessDeniedError{},
+		},
+		{
+			name:          "Unsafe method with mismatched CSRF header",
+			cookieOptions: cookieOptions,
+			method:        http.MethodDelete,
+			cookie:        "cookie-token",
+			csrfHeader:    "other-token",
+			expectedError: &customError.AccessDeniedError{},
+		},
+		{
+			name:          "Missing cookie",
+			cookieOptions: cookieOptions,
+			method:        http.MethodGet,
+			expectedError: &customError.JwtError{},
+		},
+		{
+			name:          "Invalid cookie token",
+			cookieOptions: cookieOptions,
+			method:        http.MethodGet,
+			cookie:        "expired-token",
+			expectedError: &customError.JwtError{},
+		},
+		{
+			name:          "Authorization header takes precedence and needs no CSRF header",
+			cookieOptions: cookieOptions,
+			method:        http.MethodPost,
+			cookie:        "cookie-token",
+			authorization: "Bearer bearer-token",
+			expectedUser:  "2",
+		},
+		{
+			name:          "Cookie ignored when the cookie mode is disabled",
+			cookieOptions: security.CookieOptions{Name: "access_token"},
+			method:        http.MethodGet,
+			cookie:        "cookie-token",
+			expectedError: &customError.JwtError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+
+			// Capture the errors passed to the error handling middleware
+			var errs []*gin.Error
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				errs = c.Errors
+			})
+			router.Use(AuthMiddleware(tokenGenerator, tt.cookieOptions, nil))
+			router.Handle(tt.method, "/api/hello", func(c *gin.Context) {
+				claims, _ := security.ClaimsFromContext(c.Request.Context())
+				c.String(http.StatusOK, claims.UserID)
+			})
+
+			req, _ := http.NewRequest(tt.method, "/api/hello", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "access_token", Value: tt.cookie})
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set("X-XSRF-TOKEN", tt.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if tt.expectedError != nil {
+				if assert.Len(t, errs, 1) {
+					assert.IsType(t, tt.expectedError, errs[0].Err)
+				}
+				return
+			}
+			assert.Empty(t, errs)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedUser, w.Body.String())
+		})
+	}
+}
@@ -31,7 +31,10 @@ func AuthorityMiddleware(requiredAuthority string) gin.HandlerFunc {
 		authorities := tokenClaims.Authorities
 		if len(authorities) == 0 {
 			// If "authorities" claim is missing or empty, return AccessDeniedError
-			_ = c.Error(&customError.AccessDeniedError{Message: "Access Denied: Missing authorities claim"})
+			_ = c.Error(&customError.AccessDeniedError{
+				Message:   "Access Denied: Missing authorities claim",
+				Authority: requiredAuthority,
+			})
 			c.Abort()
 			return
 		}
@@ -47,7 +50,10 @@ func AuthorityMiddleware(requiredAuthority string) gin.HandlerFunc {
 
 		// If the user does not have the required authority, return AccessDeniedError
 		if !hasAuthority {
-			_ = c.Error(&customError.AccessDeniedError{Message: "Access Denied: Insufficient permissions"})
+			_ = c.Error(&customError.AccessDeniedError{
+				Message:   "Access Denied: Insufficient permissions",
+				Authority: requiredAuthority,
+			})
 			c.Abort()
 			return
 		}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// CacheControlMiddleware sets the Cache-Control header of successful GET responses from the policies,
+// keyed by route pattern, e.g. "/api/hello/:id". Error responses never get a policy, so they are not cached.
+func CacheControlMiddleware(policies map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, found := policies[c.FullPath()]
+		if !found || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, policy: policy}
+		c.Next()
+	}
+}
+
+// cacheControlWriter adds the Cache-Control header once the response status is known
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	w.applyPolicy(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.applyPolicy(w.Status())
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.applyPolicy(w.Status())
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.applyPolicy(w.Status())
+	return w.ResponseWriter.WriteString(s)
+}
+
+// applyPolicy sets the policy unless the response is an error or has been written already
+func (w *cacheControlWriter) applyPolicy(code int) {
+	if !w.Written() && code < http.StatusBadRequest && w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", w.policy)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	customError "gin-samples/internal/error"
 	ut "github.com/go-playground/universal-translator"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -18,12 +19,26 @@ const (
 	ErrorResourceConflict    = "resource_conflict"
 	ErrorResourceNotFound    = "resource_not_found"
 	ErrorAccessDenied        = "access_denied"
+	ErrorUnsupportedMedia    = "unsupported_media_type"
+	ErrorPreconditionFailed  = "precondition_failed"
+	ErrorPreconditionNeeded  = "precondition_required"
+	ErrorConcurrentUpdate    = "concurrent_modification"
+	ErrorInvalidTransition   = "invalid_state_transition"
+	ErrorUnprocessablePatch  = "unprocessable_patch"
+	ErrorFailedDependency    = "failed_dependency"
+	ErrorNotAcceptable       = "not_acceptable"
 	ErrorInternalServer      = "server_error"
 	TitleBadRequest          = "Bad Request"
 	TitleUnauthorized        = "Unauthorized"
 	TitleAccessDenied        = "Access Denied"
 	TitleConflict            = "Conflict"
 	TitleNotFound            = "Not Found"
+	TitleUnsupportedMedia    = "Unsupported Media Type"
+	TitleUnprocessable       = "Unprocessable Entity"
+	TitlePreconditionFailed  = "Precondition Failed"
+	TitlePreconditionNeeded  = "Precondition Required"
+	TitleFailedDependency    = "Failed Dependency"
+	TitleNotAcceptable       = "Not Acceptable"
 	TitleInternalServerError = "Internal Server Error"
 	DetailValidationError    = "Validation error occurred."
 )
@@ -33,6 +48,12 @@ func ErrorHandlingMiddleware(trans ut.Translator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
+		// Once a streamed response has started, its status can no longer be changed
+		if len(c.Errors) > 0 && c.Writer.Written() {
+			c.Abort()
+			return
+		}
+
 		if len(c.Errors) > 0 {
 			problemDetail := handleErrors(c, trans)
 			c.JSON(problemDetail.Status, problemDetail)
@@ -43,28 +64,7 @@ func ErrorHandlingMiddleware(trans ut.Translator) gin.HandlerFunc {
 
 func handleErrors(c *gin.Context, trans ut.Translator) dto.ProblemDetail {
 	for _, err := range c.Errors {
-		if problemDetail, ok := handleMessageNotReadableError(err, c); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleValidationErrors(err, c, trans); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleConstraintViolationErrors(err, c); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleInvalidCredentialsErrors(err, c); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleJwtErrors(err, c); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleAccessDeniedErrors(err, c); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleConflictErrors(err, c); ok {
-			return problemDetail
-		}
-		if problemDetail, ok := handleNotFoundErrors(err, c); ok { // Yeni hata tipi
+		if problemDetail, ok := handleError(err, c, trans); ok {
 			return problemDetail
 		}
 	}
@@ -72,6 +72,70 @@ func handleErrors(c *gin.Context, trans ut.Translator) dto.ProblemDetail {
 	return handleInternalServerError(c)
 }
 
+// ProblemDetailFor formats an error the way ErrorHandlingMiddleware would,
+// e.g. for the failed items of a bulk operation
+func ProblemDetailFor(c *gin.Context, err error, trans ut.Translator) dto.ProblemDetail {
+	if problemDetail, ok := handleError(&gin.Error{Err: err}, c, trans); ok {
+		return problemDetail
+	}
+	return handleInternalServerError(c)
+}
+
+func handleError(err *gin.Error, c *gin.Context, trans ut.Translator) (dto.ProblemDetail, bool) {
+	if problemDetail, ok := handleMessageNotReadableError(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleUnsupportedMediaTypeErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleUnprocessablePatchErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleNotAcceptableErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleValidationErrors(err, c, trans); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleConstraintViolationErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleInvalidCredentialsErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleJwtErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleDPoPProofErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleAccessDeniedErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleConflictErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleConcurrentModificationErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleInvalidStateTransitionErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handlePreconditionFailedErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handlePreconditionRequiredErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleFailedDependencyErrors(err, c); ok {
+		return problemDetail, true
+	}
+	if problemDetail, ok := handleNotFoundErrors(err, c); ok { // Yeni hata tipi
+		return problemDetail, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
 func handleMessageNotReadableError(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
 	var messageNotReadableErr *customError.MessageNotReadableError
 	if errors.As(err.Err, &messageNotReadableErr) {
@@ -87,6 +151,55 @@ func handleMessageNotReadableError(err *gin.Error, c *gin.Context) (dto.ProblemD
 	return dto.ProblemDetail{}, false
 }
 
+func handleUnsupportedMediaTypeErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var unsupportedMediaTypeErr *customError.UnsupportedMediaTypeError
+	if errors.As(err.Err, &unsupportedMediaTypeErr) {
+		// Advertise the accepted patch formats (RFC 5789)
+		if c.Request.Method == http.MethodPatch {
+			c.Header("Accept-Patch", strings.Join(unsupportedMediaTypeErr.Supported, ", "))
+		}
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleUnsupportedMedia,
+			Status:   http.StatusUnsupportedMediaType,
+			Detail:   unsupportedMediaTypeErr.Error(),
+			Error:    ErrorUnsupportedMedia,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
+func handleNotAcceptableErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var notAcceptableErr *customError.NotAcceptableError
+	if errors.As(err.Err, &notAcceptableErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleNotAcceptable,
+			Status:   http.StatusNotAcceptable,
+			Detail:   notAcceptableErr.Error(),
+			Error:    ErrorNotAcceptable,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
+func handleUnprocessablePatchErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var unprocessablePatchErr *customError.UnprocessablePatchError
+	if errors.As(err.Err, &unprocessablePatchErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleUnprocessable,
+			Status:   http.StatusUnprocessableEntity,
+			Detail:   unprocessablePatchErr.Error(),
+			Error:    ErrorUnprocessablePatch,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
 func handleValidationErrors(err *gin.Error, c *gin.Context, trans ut.Translator) (dto.ProblemDetail, bool) {
 	var validationErrs validator.ValidationErrors
 	if errors.As(err.Err, &validationErrs) {
@@ -160,6 +273,22 @@ func handleJwtErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
 	return dto.ProblemDetail{}, false
 }
 
+func handleDPoPProofErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var dpopProofErr *customError.DPoPProofError
+	if errors.As(err.Err, &dpopProofErr) {
+		c.Header("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleUnauthorized,
+			Status:   http.StatusUnauthorized,
+			Detail:   "Invalid DPoP proof.",
+			Error:    "invalid_dpop_proof",
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
 func handleAccessDeniedErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
 	var accessDeniedErr *customError.AccessDeniedError
 	if errors.As(err.Err, &accessDeniedErr) {
@@ -190,6 +319,81 @@ func handleConflictErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bo
 	return dto.ProblemDetail{}, false
 }
 
+func handleConcurrentModificationErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var concurrentModificationErr *customError.ConcurrentModificationError
+	if errors.As(err.Err, &concurrentModificationErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleConflict,
+			Status:   http.StatusConflict,
+			Detail:   concurrentModificationErr.Error(),
+			Error:    ErrorConcurrentUpdate,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
+func handleInvalidStateTransitionErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var invalidTransitionErr *customError.InvalidStateTransitionError
+	if errors.As(err.Err, &invalidTransitionErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleConflict,
+			Status:   http.StatusConflict,
+			Detail:   invalidTransitionErr.Error(),
+			Error:    ErrorInvalidTransition,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
+func handlePreconditionFailedErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var preconditionFailedErr *customError.PreconditionFailedError
+	if errors.As(err.Err, &preconditionFailedErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitlePreconditionFailed,
+			Status:   http.StatusPreconditionFailed,
+			Detail:   preconditionFailedErr.Error(),
+			Error:    ErrorPreconditionFailed,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
+func handlePreconditionRequiredErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var preconditionRequiredErr *customError.PreconditionRequiredError
+	if errors.As(err.Err, &preconditionRequiredErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitlePreconditionNeeded,
+			Status:   http.StatusPreconditionRequired,
+			Detail:   preconditionRequiredErr.Error(),
+			Error:    ErrorPreconditionNeeded,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
+func handleFailedDependencyErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
+	var failedDependencyErr *customError.FailedDependencyError
+	if errors.As(err.Err, &failedDependencyErr) {
+		return dto.ProblemDetail{
+			Type:     TypeAboutBlank,
+			Title:    TitleFailedDependency,
+			Status:   http.StatusFailedDependency,
+			Detail:   failedDependencyErr.Error(),
+			Error:    ErrorFailedDependency,
+			Instance: c.Request.URL.Path,
+		}, true
+	}
+	return dto.ProblemDetail{}, false
+}
+
 func handleNotFoundErrors(err *gin.Error, c *gin.Context) (dto.ProblemDetail, bool) {
 	var notFoundErr *customError.ResourceNotFoundError
 	if errors.As(err.Err, &notFoundErr) {
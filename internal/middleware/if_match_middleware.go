@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	customError "gin-samples/internal/error"
+	"github.com/gin-gonic/gin"
+)
+
+// IfMatchMiddleware rejects requests without an If-Match header when required is true,
+// so clients cannot overwrite changes they have not seen. Otherwise, If-Match is optional.
+func IfMatchMiddleware(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			_ = c.Error(&customError.PreconditionRequiredError{Header: "If-Match"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ItemVersionRequiredKey is the context key telling bulk handlers whether their items must carry a version
+const ItemVersionRequiredKey = "itemVersionRequired"
+
+// ItemVersionMiddleware is the If-Match requirement of bulk operations: when required is true,
+// every item of a bulk update or delete must carry the version it is based on.
+func ItemVersionMiddleware(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ItemVersionRequiredKey, required)
+		c.Next()
+	}
+}
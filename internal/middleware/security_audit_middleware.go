@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"errors"
+	"gin-samples/internal/domain"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/security"
+	"gin-samples/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityAuditMiddleware records authentication and authorization failures, as well as the security events
+// handlers attach to the context under "securityEvent". It must be registered before ErrorHandlingMiddleware
+// so that the final response status is known when the events are recorded.
+func SecurityAuditMiddleware(auditService service.SecurityAuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		for _, err := range c.Errors {
+			if event, ok := securityEventFromError(err.Err); ok {
+				recordSecurityEvent(c, auditService, event)
+			}
+		}
+
+		if value, exists := c.Get("securityEvent"); exists {
+			if event, ok := value.(domain.SecurityEvent); ok {
+				recordSecurityEvent(c, auditService, event)
+			}
+		}
+	}
+}
+
+// AdminAuditMiddleware records every successful request handled by the admin routes
+func AdminAuditMiddleware(auditService service.SecurityAuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			recordSecurityEvent(c, auditService, domain.SecurityEvent{Type: domain.SecurityEventAdminAction})
+		}
+	}
+}
+
+func securityEventFromError(err error) (domain.SecurityEvent, bool) {
+	var invalidCredentialsErr *customError.InvalidCredentialsError
+	var jwtErr *customError.JwtError
+	var dpopProofErr *customError.DPoPProofError
+	var accessDeniedErr *customError.AccessDeniedError
+
+	switch {
+	case errors.As(err, &invalidCredentialsErr):
+		return domain.SecurityEvent{
+			Type:      domain.SecurityEventLoginFailure,
+			Principal: invalidCredentialsErr.Username,
+			Reason:    invalidCredentialsErr.Reason,
+		}, true
+	case errors.As(err, &jwtErr):
+		return domain.SecurityEvent{Type: domain.SecurityEventTokenRejected, Reason: jwtErr.Message}, true
+	case errors.As(err, &dpopProofErr):
+		return domain.SecurityEvent{Type: domain.SecurityEventTokenRejected, Reason: dpopProofErr.Message}, true
+	case errors.As(err, &accessDeniedErr):
+		return domain.SecurityEvent{
+			Type:      domain.SecurityEventAccessDenied,
+			Reason:    accessDeniedErr.Message,
+			Authority: accessDeniedErr.Authority,
+		}, true
+	}
+	return domain.SecurityEvent{}, false
+}
+
+// recordSecurityEvent completes the event with the request details and records it
+func recordSecurityEvent(c *gin.Context, auditService service.SecurityAuditService, event domain.SecurityEvent) {
+	if claims, exists := c.Get("jwt"); exists {
+		if tokenClaims, ok := claims.(*security.TokenClaims); ok {
+			if event.Principal == "" {
+				event.Principal = tokenClaims.UserID
+			}
+			if event.TenantID == "" {
+				event.TenantID = domain.TenantOrDefault(tokenClaims.TenantID)
+			}
+		}
+	}
+	event.Method = c.Request.Method
+	event.Path = c.Request.URL.Path
+	event.ClientIP = c.ClientIP()
+	event.Status = c.Writer.Status()
+
+	auditService.Record(event)
+}
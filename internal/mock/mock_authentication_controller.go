@@ -31,3 +31,8 @@ func (m *MockAuthenticationController) Login(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid username or password"})
 	}
 }
+
+// Logout is a mock implementation for logout endpoint
+func (m *MockAuthenticationController) Logout(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
@@ -15,3 +15,12 @@ func (m *MockClock) Now() time.Time {
 	args := m.Called()
 	return args.Get(0).(time.Time)
 }
+
+// After returns a mocked timer channel
+func (m *MockClock) After(d time.Duration) <-chan time.Time {
+	args := m.Called(d)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(<-chan time.Time)
+}
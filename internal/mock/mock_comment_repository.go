@@ -0,0 +1,104 @@
+package mock
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCommentRepository is a mock implementation of CommentRepository
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+// Save saves a comment
+func (m *MockCommentRepository) Save(comment domain.GreetingComment) (domain.GreetingComment, error) {
+	args := m.Called(comment)
+	if args.Get(0) == nil {
+		return domain.GreetingComment{}, args.Error(1)
+	}
+	return args.Get(0).(domain.GreetingComment), args.Error(1)
+}
+
+// FindAll retrieves all comments
+func (m *MockCommentRepository) FindAll() ([]domain.GreetingComment, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.GreetingComment), args.Error(1)
+}
+
+// FindAllPaged retrieves a page of comments
+func (m *MockCommentRepository) FindAllPaged(pageable repository.Pageable,
+	specs ...repository.Specification) (repository.Page[domain.GreetingComment], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.Page[domain.GreetingComment]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.GreetingComment]), args.Error(1)
+}
+
+// FindAllByKeyset retrieves a keyset page of comments
+func (m *MockCommentRepository) FindAllByKeyset(pageable repository.KeysetPageable,
+	specs ...repository.Specification) (repository.KeysetPage[domain.GreetingComment], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.KeysetPage[domain.GreetingComment]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.KeysetPage[domain.GreetingComment]), args.Error(1)
+}
+
+// FindAllInBatches passes the comments returned by the mock to fn as a single batch
+func (m *MockCommentRepository) FindAllInBatches(batchSize int, fn func([]domain.GreetingComment) error,
+	specs ...repository.Specification) error {
+	args := m.Called(batchSize, specs)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(args.Get(0).([]domain.GreetingComment))
+}
+
+// FindByID retrieves a comment by its ID and returns an Optional
+func (m *MockCommentRepository) FindByID(id uint) (util.Optional[domain.GreetingComment], error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return util.Optional[domain.GreetingComment]{}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.GreetingComment]), args.Error(1)
+}
+
+// FindPagedByGreeting retrieves a page of the comments on a greeting
+func (m *MockCommentRepository) FindPagedByGreeting(greetingID uint, parentID *uint,
+	pageable repository.Pageable) (repository.Page[domain.GreetingComment], error) {
+	args := m.Called(greetingID, parentID, pageable)
+	if args.Get(0) == nil {
+		return repository.Page[domain.GreetingComment]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.GreetingComment]), args.Error(1)
+}
+
+// Delete deletes a comment
+func (m *MockCommentRepository) Delete(comment domain.GreetingComment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+// DeleteByID deletes a comment by its ID
+func (m *MockCommentRepository) DeleteByID(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// DeleteThread deletes a comment along with the replies to it
+func (m *MockCommentRepository) DeleteThread(comment domain.GreetingComment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockCommentRepository) WithContext(context.Context) repository.CommentRepository {
+	return m
+}
@@ -0,0 +1,61 @@
+package mock
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGreetingPinRepository is a mock implementation of GreetingPinRepository
+type MockGreetingPinRepository struct {
+	mock.Mock
+}
+
+// FindPinByDate simulates finding the greeting pinned for a date
+func (m *MockGreetingPinRepository) FindPinByDate(date string) (util.Optional[domain.GreetingPin], error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return util.Optional[domain.GreetingPin]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.GreetingPin]), args.Error(1)
+}
+
+// SavePin simulates pinning a greeting for a date
+func (m *MockGreetingPinRepository) SavePin(pin domain.GreetingPin) (domain.GreetingPin, error) {
+	args := m.Called(pin)
+	return args.Get(0).(domain.GreetingPin), args.Error(1)
+}
+
+// DeletePinByDate simulates removing the pin of a date
+func (m *MockGreetingPinRepository) DeletePinByDate(date string) (bool, error) {
+	args := m.Called(date)
+	return args.Bool(0), args.Error(1)
+}
+
+// FindDailyPickByDate simulates finding the greeting chosen as greeting of the day for a date
+func (m *MockGreetingPinRepository) FindDailyPickByDate(date string) (util.Optional[domain.GreetingDailyPick], error) {
+	args := m.Called(date)
+	if args.Get(0) == nil {
+		return util.Optional[domain.GreetingDailyPick]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.GreetingDailyPick]), args.Error(1)
+}
+
+// SaveDailyPick simulates recording the greeting chosen as greeting of the day for a date
+func (m *MockGreetingPinRepository) SaveDailyPick(pick domain.GreetingDailyPick) (domain.GreetingDailyPick, error) {
+	args := m.Called(pick)
+	return args.Get(0).(domain.GreetingDailyPick), args.Error(1)
+}
+
+// DeleteDailyPick simulates removing the greeting chosen as greeting of the day for a date
+func (m *MockGreetingPinRepository) DeleteDailyPick(pick domain.GreetingDailyPick) error {
+	args := m.Called(pick)
+	return args.Error(0)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockGreetingPinRepository) WithContext(context.Context) repository.GreetingPinRepository {
+	return m
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGreetingRevisionRepository is a mock implementation of GreetingRevisionRepository
+type MockGreetingRevisionRepository struct {
+	mock.Mock
+}
+
+// FindPagedByGreeting retrieves a page of the revisions of a greeting
+func (m *MockGreetingRevisionRepository) FindPagedByGreeting(greetingID uint, pageable repository.Pageable) (repository.Page[domain.GreetingRevision], error) {
+	args := m.Called(greetingID, pageable)
+	return args.Get(0).(repository.Page[domain.GreetingRevision]), args.Error(1)
+}
+
+// FindByGreetingAndNumber retrieves a revision of a greeting by its number and returns an Optional
+func (m *MockGreetingRevisionRepository) FindByGreetingAndNumber(greetingID, revision uint) (util.Optional[domain.GreetingRevision], error) {
+	args := m.Called(greetingID, revision)
+	if args.Get(0) == nil {
+		return util.Optional[domain.GreetingRevision]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.GreetingRevision]), args.Error(1)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockGreetingRevisionRepository) WithContext(context.Context) repository.GreetingRevisionRepository {
+	return m
+}
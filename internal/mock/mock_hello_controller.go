@@ -49,7 +49,36 @@ func (m *MockHelloController) GetAllGreetings(c *gin.Context) {
 		{ID: 1, Message: "Mocked Hello, World!", CreatedAt: fixedTime, UpdatedAt: fixedTime},
 		{ID: 2, Message: "Mocked Hi!", CreatedAt: fixedTime, UpdatedAt: fixedTime},
 	}
-	c.JSON(http.StatusOK, mockGreetings)
+	c.JSON(http.StatusOK, dto.PagedResponse[dto.GreetingResponse]{
+		Content: mockGreetings,
+		Page:    dto.PageMetadata{Number: 0, Size: 20, TotalElements: 2, TotalPages: 1},
+	})
+}
+
+// GetGreetingsByCursor simulates retrieving the first page of a cursor based listing
+func (m *MockHelloController) GetGreetingsByCursor(c *gin.Context) {
+	fixedTime := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	c.JSON(http.StatusOK, dto.CursorPagedResponse[dto.GreetingResponse]{
+		Content: []dto.GreetingResponse{
+			{ID: 1, Message: "Mocked Hello, World!", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+		},
+		Size: 20,
+	})
+}
+
+// SearchGreetings simulates a full-text search with a single result
+func (m *MockHelloController) SearchGreetings(c *gin.Context) {
+	fixedTime := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	c.JSON(http.StatusOK, dto.PagedResponse[dto.GreetingSearchResponse]{
+		Content: []dto.GreetingSearchResponse{{
+			GreetingResponse: dto.GreetingResponse{ID: 1, Message: "Mocked Hello, World!", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			Snippet:          "Mocked <mark>Hello</mark>, World!",
+			Score:            1,
+		}},
+		Page: dto.PageMetadata{Number: 0, Size: 20, TotalElements: 1, TotalPages: 1},
+	})
 }
 
 // GetGreetingByID simulates retrieving a greeting by its ID
@@ -70,6 +99,14 @@ func (m *MockHelloController) GetGreetingByID(c *gin.Context) {
 	}
 }
 
+// RenderGreeting simulates rendering a greeting for the caller
+func (m *MockHelloController) RenderGreeting(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.RenderedGreetingResponse{
+		GreetingResponse: dto.GreetingResponse{ID: 1, Message: "Good morning, John!", Locale: "en"},
+		Template:         "Good {{timeOfDay}}, {{.FirstName}}!",
+	})
+}
+
 // UpdateGreeting simulates updating a greeting by its ID
 func (m *MockHelloController) UpdateGreeting(c *gin.Context) {
 	idParam := c.Param("id")
@@ -96,6 +133,37 @@ func (m *MockHelloController) UpdateGreeting(c *gin.Context) {
 	})
 }
 
+// PatchGreeting simulates patching a greeting by its ID with a merge patch
+func (m *MockHelloController) PatchGreeting(c *gin.Context) {
+	idParam := c.Param("id")
+	if idParam != "1" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Greeting not found",
+		})
+		return
+	}
+
+	if c.ContentType() != "application/merge-patch+json" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Unsupported media type"})
+		return
+	}
+
+	var input dto.GreetingInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Simulate the patched greeting
+	c.JSON(http.StatusOK, dto.GreetingResponse{
+		ID:        1,
+		Message:   input.Message,
+		Locale:    "en",
+		CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 5, 11, 30, 0, 0, time.UTC),
+	})
+}
+
 // DeleteGreeting simulates deleting a greeting by its ID
 func (m *MockHelloController) DeleteGreeting(c *gin.Context) {
 	idParam := c.Param("id")
@@ -109,3 +177,80 @@ func (m *MockHelloController) DeleteGreeting(c *gin.Context) {
 		})
 	}
 }
+
+// GetDeletedGreetings simulates listing the greeting trash
+func (m *MockHelloController) GetDeletedGreetings(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"content": []gin.H{},
+		"page":    gin.H{"number": 0, "size": 20, "totalElements": 0, "totalPages": 0},
+	})
+}
+
+// RestoreGreeting simulates restoring a deleted greeting by its ID
+func (m *MockHelloController) RestoreGreeting(c *gin.Context) {
+	if c.Param("id") != "1" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Greeting not found",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, dto.GreetingResponse{
+		ID:        1,
+		Message:   "Hello, World!",
+		Locale:    "en",
+		Version:   2,
+		CreatedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+	})
+}
+
+// PurgeGreeting simulates permanently deleting a greeting by its ID
+func (m *MockHelloController) PurgeGreeting(c *gin.Context) {
+	if c.Param("id") != "1" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Greeting not found",
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// BulkCreateGreetings simulates creating greetings in bulk
+func (m *MockHelloController) BulkCreateGreetings(c *gin.Context) {
+	c.JSON(http.StatusMultiStatus, gin.H{"mode": "all-or-nothing", "succeeded": 0, "failed": 0, "results": []gin.H{}})
+}
+
+// BulkUpdateGreetings simulates updating greetings in bulk
+func (m *MockHelloController) BulkUpdateGreetings(c *gin.Context) {
+	c.JSON(http.StatusMultiStatus, gin.H{"mode": "all-or-nothing", "succeeded": 0, "failed": 0, "results": []gin.H{}})
+}
+
+// BulkDeleteGreetings simulates deleting greetings in bulk
+func (m *MockHelloController) BulkDeleteGreetings(c *gin.Context) {
+	c.JSON(http.StatusMultiStatus, gin.H{"mode": "all-or-nothing", "succeeded": 0, "failed": 0, "results": []gin.H{}})
+}
+
+// ExportGreetings simulates exporting greetings
+func (m *MockHelloController) ExportGreetings(c *gin.Context) {
+	c.JSON(http.StatusOK, []dto.GreetingResponse{})
+}
+
+// ImportGreetings simulates importing greetings
+func (m *MockHelloController) ImportGreetings(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.GreetingImportReport{OnDuplicate: dto.OnDuplicateSkip, Rows: []dto.GreetingImportRowResult{}})
+}
+
+// RenameTag simulates renaming a tag
+func (m *MockHelloController) RenameTag(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.TagResponse{ID: 1, Name: "xmas", Count: 1})
+}
+
+// MergeTags simulates merging tags
+func (m *MockHelloController) MergeTags(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.TagResponse{ID: 1, Name: "christmas", Count: 2})
+}
+
+// GetRandomGreeting simulates picking a random greeting
+func (m *MockHelloController) GetRandomGreeting(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.GreetingResponse{ID: 1, Message: "Hello, World!", Locale: "en"})
+}
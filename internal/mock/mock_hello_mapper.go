@@ -21,6 +21,11 @@ func (m *MockHelloMapper) ToGreetingResponses(greetings []domain.Greeting) []dto
 	return args.Get(0).([]dto.GreetingResponse)
 }
 
+func (m *MockHelloMapper) ToGreetingSearchResponses(results []domain.GreetingSearchResult) []dto.GreetingSearchResponse {
+	args := m.Called(results)
+	return args.Get(0).([]dto.GreetingSearchResponse)
+}
+
 func (m *MockHelloMapper) ToGreetingEntity(input dto.GreetingInput) domain.Greeting {
 	args := m.Called(input)
 	return args.Get(0).(domain.Greeting)
@@ -29,3 +34,7 @@ func (m *MockHelloMapper) ToGreetingEntity(input dto.GreetingInput) domain.Greet
 func (m *MockHelloMapper) PartialUpdateGreeting(entity *domain.Greeting, input dto.GreetingInput) {
 	m.Called(entity, input)
 }
+
+func (m *MockHelloMapper) UpdateGreeting(entity *domain.Greeting, input dto.GreetingInput) {
+	m.Called(entity, input)
+}
@@ -1,9 +1,12 @@
 package mock
 
 import (
+	"context"
 	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
 	"gin-samples/internal/util"
 	"github.com/stretchr/testify/mock"
+	"time"
 )
 
 // MockHelloRepository is a mock implementation of HelloRepository
@@ -11,12 +14,21 @@ type MockHelloRepository struct {
 	mock.Mock
 }
 
-// ExistsByMessage checks if a message exists
-func (m *MockHelloRepository) ExistsByMessage(message string) (bool, error) {
-	args := m.Called(message)
+// ExistsByMessage checks if a message exists in a locale
+func (m *MockHelloRepository) ExistsByMessage(message, locale string) (bool, error) {
+	args := m.Called(message, locale)
 	return args.Bool(0), args.Error(1)
 }
 
+// FindLocales retrieves the distinct greeting locales matching the specifications
+func (m *MockHelloRepository) FindLocales(specs ...repository.Specification) ([]string, error) {
+	args := m.Called(specs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // Save saves a greeting
 func (m *MockHelloRepository) Save(greeting domain.Greeting) (domain.Greeting, error) {
 	args := m.Called(greeting)
@@ -35,6 +47,36 @@ func (m *MockHelloRepository) FindAll() ([]domain.Greeting, error) {
 	return args.Get(0).([]domain.Greeting), args.Error(1)
 }
 
+// Search performs a full-text search over greetings
+func (m *MockHelloRepository) Search(text string, pageable repository.Pageable,
+	specs ...repository.Specification) (repository.Page[domain.GreetingSearchResult], error) {
+	args := m.Called(text, pageable, specs)
+	if args.Get(0) == nil {
+		return repository.Page[domain.GreetingSearchResult]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.GreetingSearchResult]), args.Error(1)
+}
+
+// FindAllByKeyset retrieves a keyset page of greetings
+func (m *MockHelloRepository) FindAllByKeyset(pageable repository.KeysetPageable,
+	specs ...repository.Specification) (repository.KeysetPage[domain.Greeting], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.KeysetPage[domain.Greeting]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.KeysetPage[domain.Greeting]), args.Error(1)
+}
+
+// FindAllPaged retrieves a page of greetings
+func (m *MockHelloRepository) FindAllPaged(pageable repository.Pageable,
+	specs ...repository.Specification) (repository.Page[domain.Greeting], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.Page[domain.Greeting]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.Greeting]), args.Error(1)
+}
+
 // FindByID retrieves a greeting by its ID and returns an Optional
 func (m *MockHelloRepository) FindByID(id uint) (util.Optional[domain.Greeting], error) {
 	args := m.Called(id)
@@ -44,8 +86,92 @@ func (m *MockHelloRepository) FindByID(id uint) (util.Optional[domain.Greeting],
 	return args.Get(0).(util.Optional[domain.Greeting]), args.Error(1)
 }
 
+// Delete deletes a greeting if its version is unchanged
+func (m *MockHelloRepository) Delete(entity domain.Greeting) error {
+	args := m.Called(entity)
+	return args.Error(0)
+}
+
 // DeleteByID deletes a greeting by its ID
 func (m *MockHelloRepository) DeleteByID(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+// FindAllDeletedPaged retrieves a page of greetings in the trash
+func (m *MockHelloRepository) FindAllDeletedPaged(pageable repository.Pageable) (repository.Page[domain.Greeting], error) {
+	args := m.Called(pageable)
+	if args.Get(0) == nil {
+		return repository.Page[domain.Greeting]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.Greeting]), args.Error(1)
+}
+
+// FindDeletedByID retrieves a greeting in the trash by its ID and returns an Optional
+func (m *MockHelloRepository) FindDeletedByID(id uint) (util.Optional[domain.Greeting], error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return util.Optional[domain.Greeting]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.Greeting]), args.Error(1)
+}
+
+// Restore moves a greeting out of the trash
+func (m *MockHelloRepository) Restore(entity domain.Greeting) (domain.Greeting, error) {
+	args := m.Called(entity)
+	if args.Get(0) == nil {
+		return domain.Greeting{}, args.Error(1)
+	}
+	return args.Get(0).(domain.Greeting), args.Error(1)
+}
+
+// Purge permanently deletes a greeting in the trash
+func (m *MockHelloRepository) Purge(entity domain.Greeting) error {
+	args := m.Called(entity)
+	return args.Error(0)
+}
+
+// Transaction runs fn with the mock itself in place of the transactional repository
+func (m *MockHelloRepository) Transaction(fn func(repository.HelloRepository) error) error {
+	args := m.Called()
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(m)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockHelloRepository) WithContext(context.Context) repository.HelloRepository {
+	return m
+}
+
+// FindByMessage retrieves a greeting by its message and locale and returns an Optional
+func (m *MockHelloRepository) FindByMessage(message, locale string) (util.Optional[domain.Greeting], error) {
+	args := m.Called(message, locale)
+	if args.Get(0) == nil {
+		return util.Optional[domain.Greeting]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.Greeting]), args.Error(1)
+}
+
+// FindAllInBatches passes the greetings returned by the mock to fn as a single batch
+func (m *MockHelloRepository) FindAllInBatches(batchSize int, fn func([]domain.Greeting) error,
+	specs ...repository.Specification) error {
+	args := m.Called(batchSize, specs)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(args.Get(0).([]domain.Greeting))
+}
+
+// SaveRevision simulates appending a revision to the history of its greeting
+func (m *MockHelloRepository) SaveRevision(revision domain.GreetingRevision) (domain.GreetingRevision, error) {
+	args := m.Called(revision)
+	return args.Get(0).(domain.GreetingRevision), args.Error(1)
+}
+
+// PurgeExpired simulates permanently deleting the greetings which expired before the given time
+func (m *MockHelloRepository) PurgeExpired(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,56 @@
+package mock
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+// MockReactionRepository is a mock implementation of ReactionRepository
+type MockReactionRepository struct {
+	mock.Mock
+}
+
+// Save simulates adding a reaction to a greeting
+func (m *MockReactionRepository) Save(reaction domain.GreetingReaction) (bool, error) {
+	args := m.Called(reaction)
+	return args.Bool(0), args.Error(1)
+}
+
+// Delete simulates removing a reaction from a greeting
+func (m *MockReactionRepository) Delete(reaction domain.GreetingReaction) (bool, error) {
+	args := m.Called(reaction)
+	return args.Bool(0), args.Error(1)
+}
+
+// CountByGreeting simulates counting the reactions to a greeting per type
+func (m *MockReactionRepository) CountByGreeting(greetingID uint) (map[domain.ReactionType]int64, error) {
+	args := m.Called(greetingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[domain.ReactionType]int64), args.Error(1)
+}
+
+// FindTypesByUser simulates retrieving the types of the reactions of a user to a greeting
+func (m *MockReactionRepository) FindTypesByUser(greetingID uint, userID string) ([]domain.ReactionType, error) {
+	args := m.Called(greetingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReactionType), args.Error(1)
+}
+
+// FindTopScored simulates ranking greetings by their reactions
+func (m *MockReactionRepository) FindTopScored(since time.Time, reactionType domain.ReactionType, limit int,
+	specs ...repository.Specification) ([]domain.GreetingScore, error) {
+	args := m.Called(since, reactionType, limit, specs)
+	return args.Get(0).([]domain.GreetingScore), args.Error(1)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockReactionRepository) WithContext(context.Context) repository.ReactionRepository {
+	return m
+}
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSecurityEventRepository is a mock implementation of SecurityEventRepository
+type MockSecurityEventRepository struct {
+	mock.Mock
+}
+
+// Save appends a security event
+func (m *MockSecurityEventRepository) Save(event domain.SecurityEvent) (domain.SecurityEvent, error) {
+	args := m.Called(event)
+	if args.Get(0) == nil {
+		return domain.SecurityEvent{}, args.Error(1)
+	}
+	return args.Get(0).(domain.SecurityEvent), args.Error(1)
+}
+
+// FindByCriteria retrieves the security events matching the criteria
+func (m *MockSecurityEventRepository) FindByCriteria(criteria repository.SecurityEventCriteria) ([]domain.SecurityEvent, error) {
+	args := m.Called(criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SecurityEvent), args.Error(1)
+}
@@ -0,0 +1,133 @@
+package mock
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagRepository is a mock implementation of TagRepository
+type MockTagRepository struct {
+	mock.Mock
+}
+
+// Save saves a tag
+func (m *MockTagRepository) Save(tag domain.Tag) (domain.Tag, error) {
+	args := m.Called(tag)
+	if args.Get(0) == nil {
+		return domain.Tag{}, args.Error(1)
+	}
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+// FindAll retrieves all tags
+func (m *MockTagRepository) FindAll() ([]domain.Tag, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
+// FindAllPaged retrieves a page of tags
+func (m *MockTagRepository) FindAllPaged(pageable repository.Pageable,
+	specs ...repository.Specification) (repository.Page[domain.Tag], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.Page[domain.Tag]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.Tag]), args.Error(1)
+}
+
+// FindAllByKeyset retrieves a keyset page of tags
+func (m *MockTagRepository) FindAllByKeyset(pageable repository.KeysetPageable,
+	specs ...repository.Specification) (repository.KeysetPage[domain.Tag], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.KeysetPage[domain.Tag]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.KeysetPage[domain.Tag]), args.Error(1)
+}
+
+// FindAllInBatches passes the tags returned by the mock to fn as a single batch
+func (m *MockTagRepository) FindAllInBatches(batchSize int, fn func([]domain.Tag) error,
+	specs ...repository.Specification) error {
+	args := m.Called(batchSize, specs)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(args.Get(0).([]domain.Tag))
+}
+
+// FindByID retrieves a tag by its ID and returns an Optional
+func (m *MockTagRepository) FindByID(id uint) (util.Optional[domain.Tag], error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return util.Optional[domain.Tag]{}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.Tag]), args.Error(1)
+}
+
+// FindUsagePaged retrieves a page of the tags with their usage counts
+func (m *MockTagRepository) FindUsagePaged(pageable repository.Pageable) (repository.Page[domain.TagUsage], error) {
+	args := m.Called(pageable)
+	return args.Get(0).(repository.Page[domain.TagUsage]), args.Error(1)
+}
+
+// FindUsageByID retrieves a tag by its ID with its usage count and returns an Optional
+func (m *MockTagRepository) FindUsageByID(id uint) (util.Optional[domain.TagUsage], error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return util.Optional[domain.TagUsage]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.TagUsage]), args.Error(1)
+}
+
+// FindByName retrieves a tag by its name and returns an Optional
+func (m *MockTagRepository) FindByName(name string) (util.Optional[domain.Tag], error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return util.Optional[domain.Tag]{Value: nil}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.Tag]), args.Error(1)
+}
+
+// Rename simulates renaming a tag
+func (m *MockTagRepository) Rename(id uint, name string) error {
+	args := m.Called(id, name)
+	return args.Error(0)
+}
+
+// Merge simulates merging the source tags into the target tag
+func (m *MockTagRepository) Merge(sourceIDs []uint, targetID uint) error {
+	args := m.Called(sourceIDs, targetID)
+	return args.Error(0)
+}
+
+// Delete deletes a tag
+func (m *MockTagRepository) Delete(tag domain.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+// DeleteByID deletes a tag by its ID
+func (m *MockTagRepository) DeleteByID(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Transaction runs fn with the mock itself in place of the transactional repository
+func (m *MockTagRepository) Transaction(fn func(repository.TagRepository) error) error {
+	args := m.Called()
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(m)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockTagRepository) WithContext(context.Context) repository.TagRepository {
+	return m
+}
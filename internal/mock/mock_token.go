@@ -5,6 +5,7 @@ import (
 	"crypto/rsa"
 	"log"
 
+	"gin-samples/config"
 	"gin-samples/internal/util"
 )
 
@@ -12,7 +13,7 @@ import (
 type MockJweTokenConfig struct{}
 
 // InitJweKeyPair dynamically generates mock RSA key pairs for signing and encryption
-func (m *MockJweTokenConfig) InitJweKeyPair() (*util.RSAKeyPair, *util.RSAKeyPair) {
+func (m *MockJweTokenConfig) InitJweKeyPair(_ *config.Config) (*util.RSAKeyPairHolder, *util.RSAKeyPairHolder) {
 	// Generate signing key pair
 	signPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
@@ -35,5 +36,5 @@ func (m *MockJweTokenConfig) InitJweKeyPair() (*util.RSAKeyPair, *util.RSAKeyPai
 		PublicKey:  encPublicKey,
 	}
 
-	return signKeyPair, encKeyPair
+	return util.NewRSAKeyPairHolder(signKeyPair), util.NewRSAKeyPairHolder(encKeyPair)
 }
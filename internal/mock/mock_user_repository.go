@@ -0,0 +1,106 @@
+package mock
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+// Save saves a user
+func (m *MockUserRepository) Save(user domain.User) (domain.User, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return domain.User{}, args.Error(1)
+	}
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+// FindAll retrieves all users
+func (m *MockUserRepository) FindAll() ([]domain.User, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+// FindAllPaged retrieves a page of users
+func (m *MockUserRepository) FindAllPaged(pageable repository.Pageable,
+	specs ...repository.Specification) (repository.Page[domain.User], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.Page[domain.User]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.Page[domain.User]), args.Error(1)
+}
+
+// FindAllByKeyset retrieves a keyset page of users
+func (m *MockUserRepository) FindAllByKeyset(pageable repository.KeysetPageable,
+	specs ...repository.Specification) (repository.KeysetPage[domain.User], error) {
+	args := m.Called(pageable, specs)
+	if args.Get(0) == nil {
+		return repository.KeysetPage[domain.User]{}, args.Error(1)
+	}
+	return args.Get(0).(repository.KeysetPage[domain.User]), args.Error(1)
+}
+
+// FindAllInBatches passes the users returned by the mock to fn as a single batch
+func (m *MockUserRepository) FindAllInBatches(batchSize int, fn func([]domain.User) error,
+	specs ...repository.Specification) error {
+	args := m.Called(batchSize, specs)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(args.Get(0).([]domain.User))
+}
+
+// FindByID retrieves a user by their ID and returns an Optional
+func (m *MockUserRepository) FindByID(id string) (util.Optional[domain.User], error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return util.Optional[domain.User]{}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.User]), args.Error(1)
+}
+
+// FindByUsername retrieves a user by their username and returns an Optional
+func (m *MockUserRepository) FindByUsername(username string) (util.Optional[domain.User], error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return util.Optional[domain.User]{}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.User]), args.Error(1)
+}
+
+// FindByEmail retrieves a user by their email and returns an Optional
+func (m *MockUserRepository) FindByEmail(email string) (util.Optional[domain.User], error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return util.Optional[domain.User]{}, args.Error(1)
+	}
+	return args.Get(0).(util.Optional[domain.User]), args.Error(1)
+}
+
+// Delete deletes a user
+func (m *MockUserRepository) Delete(user domain.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+// DeleteByID deletes a user by their ID
+func (m *MockUserRepository) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// WithContext returns the mock itself in place of the repository bound to ctx
+func (m *MockUserRepository) WithContext(context.Context) repository.UserRepository {
+	return m
+}
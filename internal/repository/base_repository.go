@@ -1,12 +1,16 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"gin-samples/internal/cache"
 	"gin-samples/internal/domain"
 	"gin-samples/internal/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
 	"time"
 )
 
@@ -14,15 +18,35 @@ import (
 type CrudRepository[T domain.Identifiable, ID any] interface {
 	Save(entity T) (T, error)
 	FindAll() ([]T, error)
+	FindAllPaged(pageable Pageable, specs ...Specification) (Page[T], error)
+	FindAllByKeyset(pageable KeysetPageable, specs ...Specification) (KeysetPage[T], error)
+	FindAllInBatches(batchSize int, fn func([]T) error, specs ...Specification) error
 	FindByID(id ID) (util.Optional[T], error)
+	Delete(entity T) error
 	DeleteByID(id ID) error
 }
 
+// SoftDeleteRepository defines the trash operations for entities embedding domain.SoftDeletableEntity.
+// Deletes through CrudRepository move such entities to the trash, which hides them from its queries.
+type SoftDeleteRepository[T domain.Identifiable, ID any] interface {
+	FindAllDeletedPaged(pageable Pageable) (Page[T], error)
+	FindDeletedByID(id ID) (util.Optional[T], error)
+	Restore(entity T) (T, error)
+	Purge(entity T) error
+}
+
+// ErrOptimisticLock is returned when a versioned entity was updated or deleted since it was read
+var ErrOptimisticLock = errors.New("entity was modified or deleted concurrently")
+
 // BaseRepository provides generic CRUD operations for any entity type T with ID type
 type BaseRepository[T domain.Identifiable, ID any] struct {
 	db           *gorm.DB
 	cacheManager *cache.CacheManager
 	cacheName    string
+	// tenant restricts the queries of entities embedding domain.TenantEntity, see withContext
+	tenant tenantScope
+	// afterCommit collects the cache updates of a transaction, nil outside a transaction
+	afterCommit *[]func()
 }
 
 // NewBaseRepository creates a new instance of BaseRepository
@@ -34,41 +58,152 @@ func NewBaseRepository[T domain.Identifiable, ID any](db *gorm.DB, cacheManager
 	}
 }
 
-// Save creates or updates an entity and updates the cache
+// Save creates or updates an entity and updates the cache. Entities embedding domain.TenantEntity are saved
+// to the tenant of the repository, unless it is not restricted and they already have one.
+// Entities embedding domain.VersionedEntity are updated only if their version is unchanged in the database,
+// otherwise ErrOptimisticLock is returned; the version is incremented by every update.
 func (r *BaseRepository[T, ID]) Save(entity T) (T, error) {
-	if err := r.db.Save(&entity).Error; err != nil {
-		return *new(T), fmt.Errorf("failed to save entity: %w", err)
+	entitySchema, err := r.schema()
+	if err != nil {
+		return *new(T), err
+	}
+	if err := r.assignTenant(&entity); err != nil {
+		return *new(T), err
 	}
 
-	// Cache the entity with a 1-hour TTL using the entity's ID
-	cacheKey := fmt.Sprintf("%s:%v", r.cacheName, entity.GetID())
-	r.cacheManager.Set(cacheKey, &entity, 1*time.Hour)
+	versionField := entitySchema.LookUpField(domain.VersionColumn)
+	if versionField == nil {
+		if err := r.db.Save(&entity).Error; err != nil {
+			return *new(T), fmt.Errorf("failed to save entity: %w", err)
+		}
+	} else if err := r.saveVersioned(&entity, entitySchema, versionField); err != nil {
+		return *new(T), err
+	}
+
+	// Cache the entity with a 1-hour TTL using the entity's tenant and ID
+	r.cacheStore(&entity)
 
 	return entity, nil
 }
 
+// saveVersioned creates an entity with version 1 or updates it where the version is still the one it was read with
+func (r *BaseRepository[T, ID]) saveVersioned(entity *T, entitySchema *schema.Schema, versionField *schema.Field) error {
+	ctx := context.Background()
+	value := reflect.ValueOf(entity).Elem()
+	rawVersion, _ := versionField.ValueOf(ctx, value)
+	version := reflect.ValueOf(rawVersion).Convert(reflect.TypeOf(uint64(0))).Uint()
+
+	// Entities which were never saved have no version yet
+	if version == 0 {
+		if err := versionField.Set(ctx, value, 1); err != nil {
+			return fmt.Errorf("failed to set entity version: %w", err)
+		}
+		if err := r.db.Create(entity).Error; err != nil {
+			return fmt.Errorf("failed to save entity: %w", err)
+		}
+		return nil
+	}
+
+	if err := versionField.Set(ctx, value, version+1); err != nil {
+		return fmt.Errorf("failed to set entity version: %w", err)
+	}
+	result := r.scopedDB().Model(entity).
+		Where(fmt.Sprintf("%s = ?", r.db.Statement.Quote(versionField.DBName)), version).
+		Select("*").
+		Updates(entity)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save entity: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// The cached copy is outdated, so the next read goes to the database
+		r.cacheEvict(r.tenantOf(entity), (*entity).GetID())
+		return fmt.Errorf("failed to save %s %v: %w", entitySchema.Name, (*entity).GetID(), ErrOptimisticLock)
+	}
+	return nil
+}
+
 // FindAll retrieves all entities
 func (r *BaseRepository[T, ID]) FindAll() ([]T, error) {
 	var entities []T
-	if err := r.db.Find(&entities).Error; err != nil {
+	if err := r.scopedDB().Find(&entities).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch entities: %w", err)
 	}
 	return entities, nil
 }
 
+// FindAllPaged retrieves a page of the entities matching all specifications.
+// Results are ordered by the requested columns and then by primary key, so pages are stable.
+func (r *BaseRepository[T, ID]) FindAllPaged(pageable Pageable, specs ...Specification) (Page[T], error) {
+	scoped := func() *gorm.DB {
+		query := r.scopedDB().Model(new(T))
+		for _, spec := range specs {
+			query = spec(query)
+		}
+		return query
+	}
+
+	var total int64
+	if err := scoped().Count(&total).Error; err != nil {
+		return Page[T]{}, fmt.Errorf("failed to count entities: %w", err)
+	}
+
+	page := Page[T]{
+		Content:       []T{},
+		Page:          pageable.Page,
+		Size:          pageable.Size,
+		TotalElements: total,
+	}
+	if total == 0 || int64(pageable.Offset()) >= total {
+		return page, nil
+	}
+
+	columns := make([]clause.OrderByColumn, 0, len(pageable.Sort)+1)
+	for _, order := range pageable.Sort {
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: order.Column}, Desc: order.Desc})
+	}
+	columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: clause.PrimaryKey}})
+
+	if err := scoped().
+		Order(clause.OrderBy{Columns: columns}).
+		Offset(pageable.Offset()).
+		Limit(pageable.Size).
+		Find(&page.Content).Error; err != nil {
+		return Page[T]{}, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+	return page, nil
+}
+
+// FindAllInBatches passes the entities matching all specifications to fn in batches ordered by primary key,
+// so large result sets are never loaded at once. An error returned by fn stops the iteration.
+func (r *BaseRepository[T, ID]) FindAllInBatches(batchSize int, fn func([]T) error, specs ...Specification) error {
+	query := r.scopedDB().Model(new(T))
+	for _, spec := range specs {
+		query = spec(query)
+	}
+
+	var batch []T
+	result := query.FindInBatches(&batch, batchSize, func(*gorm.DB, int) error {
+		return fn(batch)
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to fetch entities in batches: %w", result.Error)
+	}
+	return nil
+}
+
 // FindByID retrieves an entity by its ID and caches the result
 func (r *BaseRepository[T, ID]) FindByID(id ID) (util.Optional[T], error) {
-	// Build the cache key using the entity's ID
+	// Build the cache key using the tenant and the entity's ID
 	var entity T
-	cacheKey := fmt.Sprintf("%s:%v", r.cacheName, id)
+	cacheKey := r.readCacheKey(id)
 
 	// Check the cache first
-	if cachedValue, found := r.cacheManager.Get(cacheKey); found {
+	if cachedValue, found := r.cacheGet(cacheKey); found {
 		return util.Optional[T]{Value: cachedValue.(*T)}, nil
 	}
 
 	// If not in cache, query the database
-	if err := r.db.First(&entity, id).Error; err != nil {
+	if err := r.scopedDB().First(&entity, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return util.EmptyOptional[T](), nil
 		}
@@ -76,22 +211,230 @@ func (r *BaseRepository[T, ID]) FindByID(id ID) (util.Optional[T], error) {
 	}
 
 	// Cache the result with a 1-hour TTL
-	r.cacheManager.Set(cacheKey, &entity, 1*time.Hour)
+	r.cacheSet(cacheKey, &entity)
 
 	return util.Optional[T]{Value: &entity}, nil
 }
 
+// Delete deletes an entity by its primary key and removes it from the cache.
+// Entities embedding domain.VersionedEntity are deleted only if their version is unchanged in the database,
+// otherwise ErrOptimisticLock is returned.
+func (r *BaseRepository[T, ID]) Delete(entity T) error {
+	entitySchema, err := r.schema()
+	if err != nil {
+		return err
+	}
+
+	// Without a primary key, the delete would not be restricted to the entity
+	for _, field := range entitySchema.PrimaryFields {
+		if _, isZero := field.ValueOf(context.Background(), reflect.ValueOf(&entity).Elem()); isZero {
+			return fmt.Errorf("failed to delete %s: missing primary key", entitySchema.Name)
+		}
+	}
+
+	query := r.scopedDB()
+	versionField := entitySchema.LookUpField(domain.VersionColumn)
+	if versionField != nil {
+		version, _ := versionField.ValueOf(context.Background(), reflect.ValueOf(&entity).Elem())
+		query = query.Where(fmt.Sprintf("%s = ?", r.db.Statement.Quote(versionField.DBName)), version)
+	}
+
+	result := query.Delete(&entity)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete entity: %w", result.Error)
+	}
+
+	// Remove from the cache
+	r.cacheEvict(r.tenantOf(&entity), entity.GetID())
+
+	if versionField != nil && result.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete %s %v: %w", entitySchema.Name, entity.GetID(), ErrOptimisticLock)
+	}
+	return nil
+}
+
 // DeleteByID deletes an entity by its ID and removes it from the cache
 func (r *BaseRepository[T, ID]) DeleteByID(id ID) error {
-	// Build the cache key using the entity's ID
-	cacheKey := fmt.Sprintf("%s:%v", r.cacheName, id)
+	// The tenant of the entity is part of its cache key
+	tenantID := r.tenant.tenantID
+	if r.tenantField() != nil && r.tenant.allTenants {
+		var tenantIDs []string
+		if err := r.db.Model(new(T)).Where("id = ?", id).Pluck(domain.TenantColumn, &tenantIDs).Error; err != nil {
+			return fmt.Errorf("failed to fetch entity tenant: %w", err)
+		}
+		if len(tenantIDs) == 0 {
+			return nil
+		}
+		tenantID = tenantIDs[0]
+	}
 
-	if err := r.db.Delete(new(T), id).Error; err != nil {
+	if err := r.scopedDB().Delete(new(T), "id = ?", id).Error; err != nil {
 		return fmt.Errorf("failed to delete entity by ID: %w", err)
 	}
 
 	// Remove from the cache
-	r.cacheManager.Delete(cacheKey)
+	r.cacheEvict(tenantID, id)
 
 	return nil
 }
+
+// FindAllDeletedPaged retrieves a page of the entities in the trash
+func (r *BaseRepository[T, ID]) FindAllDeletedPaged(pageable Pageable) (Page[T], error) {
+	deletedAtField, err := r.deletedAtField()
+	if err != nil {
+		return Page[T]{}, err
+	}
+	return r.FindAllPaged(pageable, r.deleted(deletedAtField))
+}
+
+// FindDeletedByID retrieves an entity in the trash by its ID. Entities in the trash are never cached.
+func (r *BaseRepository[T, ID]) FindDeletedByID(id ID) (util.Optional[T], error) {
+	deletedAtField, err := r.deletedAtField()
+	if err != nil {
+		return util.Optional[T]{}, err
+	}
+
+	var entity T
+	if err := r.deleted(deletedAtField)(r.scopedDB()).First(&entity, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[T](), nil
+		}
+		return util.Optional[T]{}, fmt.Errorf("failed to fetch deleted entity by ID: %w", err)
+	}
+	return util.Optional[T]{Value: &entity}, nil
+}
+
+// Restore moves an entity out of the trash and caches it. Versioned entities get a new version,
+// so representations read before the delete are outdated. If the entity is no longer in the trash,
+// ErrOptimisticLock is returned.
+func (r *BaseRepository[T, ID]) Restore(entity T) (T, error) {
+	deletedAtField, err := r.deletedAtField()
+	if err != nil {
+		return *new(T), err
+	}
+
+	updates := map[string]any{deletedAtField.DBName: nil}
+	if versionField := deletedAtField.Schema.LookUpField(domain.VersionColumn); versionField != nil {
+		updates[versionField.DBName] = gorm.Expr(fmt.Sprintf("%s + 1", r.db.Statement.Quote(versionField.DBName)))
+	}
+
+	result := r.deleted(deletedAtField)(r.scopedDB()).Model(&entity).Updates(updates)
+	if result.Error != nil {
+		return *new(T), fmt.Errorf("failed to restore entity: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return *new(T), fmt.Errorf("failed to restore %s %v: %w", deletedAtField.Schema.Name, entity.GetID(), ErrOptimisticLock)
+	}
+
+	// Reload the entity to pick up the new version and update time
+	var restored T
+	if err := r.scopedDB().First(&restored, "id = ?", entity.GetID()).Error; err != nil {
+		return *new(T), fmt.Errorf("failed to fetch restored entity: %w", err)
+	}
+
+	r.cacheStore(&restored)
+
+	return restored, nil
+}
+
+// Purge permanently deletes an entity in the trash. If the entity is no longer in the trash,
+// ErrOptimisticLock is returned.
+func (r *BaseRepository[T, ID]) Purge(entity T) error {
+	deletedAtField, err := r.deletedAtField()
+	if err != nil {
+		return err
+	}
+
+	result := r.deleted(deletedAtField)(r.scopedDB()).Delete(&entity)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge entity: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to purge %s %v: %w", deletedAtField.Schema.Name, entity.GetID(), ErrOptimisticLock)
+	}
+	return nil
+}
+
+// transaction runs fn with a repository bound to a database transaction, which is committed when fn returns nil.
+// Nested transactions use savepoints, so they are rolled back on their own.
+// Cache updates are deferred until the outermost transaction commits, and dropped on rollback.
+func (r *BaseRepository[T, ID]) transaction(fn func(*BaseRepository[T, ID]) error) error {
+	var afterCommit []func()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&BaseRepository[T, ID]{
+			db:           tx,
+			cacheManager: r.cacheManager,
+			cacheName:    r.cacheName,
+			tenant:       r.tenant,
+			afterCommit:  &afterCommit,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if r.afterCommit != nil {
+		*r.afterCommit = append(*r.afterCommit, afterCommit...)
+		return nil
+	}
+	for _, update := range afterCommit {
+		update()
+	}
+	return nil
+}
+
+// cacheGet reads an entity from the cache. Within a transaction, the cache is bypassed,
+// as it does not reflect the uncommitted changes.
+func (r *BaseRepository[T, ID]) cacheGet(key string) (interface{}, bool) {
+	if r.afterCommit != nil {
+		return nil, false
+	}
+	return r.cacheManager.Get(key)
+}
+
+// cacheSet caches an entity with a 1-hour TTL, after the commit within a transaction
+func (r *BaseRepository[T, ID]) cacheSet(key string, value *T) {
+	if r.afterCommit != nil {
+		*r.afterCommit = append(*r.afterCommit, func() { r.cacheManager.Set(key, value, 1*time.Hour) })
+		return
+	}
+	r.cacheManager.Set(key, value, 1*time.Hour)
+}
+
+// cacheDelete removes an entity from the cache. Within a transaction, it is removed again after the commit,
+// in case it was cached from the database in the meantime.
+func (r *BaseRepository[T, ID]) cacheDelete(key string) {
+	r.cacheManager.Delete(key)
+	if r.afterCommit != nil {
+		*r.afterCommit = append(*r.afterCommit, func() { r.cacheManager.Delete(key) })
+	}
+}
+
+// deletedAtField returns the deletion time field of the entity type, which must support soft delete
+func (r *BaseRepository[T, ID]) deletedAtField() (*schema.Field, error) {
+	entitySchema, err := r.schema()
+	if err != nil {
+		return nil, err
+	}
+	field := entitySchema.LookUpField(domain.DeletedAtColumn)
+	if field == nil {
+		return nil, fmt.Errorf("%s does not support soft delete", entitySchema.Name)
+	}
+	return field, nil
+}
+
+// deleted matches the entities in the trash only
+func (r *BaseRepository[T, ID]) deleted(deletedAtField *schema.Field) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where(fmt.Sprintf("%s IS NOT NULL", r.db.Statement.Quote(deletedAtField.DBName)))
+	}
+}
+
+// schema returns the parsed GORM schema of the entity type
+func (r *BaseRepository[T, ID]) schema() (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, fmt.Errorf("failed to parse entity schema: %w", err)
+	}
+	return stmt.Schema, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/cache"
+	"gin-samples/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CommentRepository extends CrudRepository with the queries of the comments on greetings.
+// A repository bound to a request with WithContext records its user as creator and modifier of the comments.
+type CommentRepository interface {
+	CrudRepository[domain.GreetingComment, uint]
+	FindPagedByGreeting(greetingID uint, parentID *uint, pageable Pageable) (Page[domain.GreetingComment], error)
+	DeleteThread(comment domain.GreetingComment) error
+	WithContext(ctx context.Context) CommentRepository
+}
+
+type commentRepositoryImpl struct {
+	*BaseRepository[domain.GreetingComment, uint]
+}
+
+// NewCommentRepository creates a new instance of CommentRepository
+func NewCommentRepository(db *gorm.DB, cacheManager *cache.CacheManager) CommentRepository {
+	return &commentRepositoryImpl{
+		BaseRepository: NewBaseRepository[domain.GreetingComment, uint](db, cacheManager, "greeting_comment"),
+	}
+}
+
+// WithContext returns a repository bound to ctx, see BaseRepository.withContext
+func (r *commentRepositoryImpl) WithContext(ctx context.Context) CommentRepository {
+	return &commentRepositoryImpl{BaseRepository: r.withContext(ctx)}
+}
+
+// FindPagedByGreeting retrieves a page of the comments on a greeting. With a parent ID, only the direct replies
+// to that comment are retrieved.
+func (r *commentRepositoryImpl) FindPagedByGreeting(greetingID uint, parentID *uint,
+	pageable Pageable) (Page[domain.GreetingComment], error) {
+	return r.FindAllPaged(pageable, func(db *gorm.DB) *gorm.DB {
+		db = db.Where("greeting_id = ?", greetingID)
+		if parentID != nil {
+			db = db.Where("parent_id = ?", *parentID)
+		}
+		return db
+	})
+}
+
+// DeleteThread deletes a comment along with all replies to it, direct or indirect, and removes them from the cache
+func (r *commentRepositoryImpl) DeleteThread(comment domain.GreetingComment) error {
+	return r.transaction(func(tx *BaseRepository[domain.GreetingComment, uint]) error {
+		var ids []uint
+		if err := tx.db.Raw(`WITH RECURSIVE thread(id) AS (
+				SELECT id FROM greeting_comment WHERE id = ?
+				UNION ALL
+				SELECT c.id FROM greeting_comment c JOIN thread t ON c.parent_id = t.id
+			)
+			SELECT id FROM thread`, comment.ID).Scan(&ids).Error; err != nil {
+			return fmt.Errorf("failed to fetch comment thread: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.db.Where("id IN ?", ids).Delete(&domain.GreetingComment{}).Error; err != nil {
+			return fmt.Errorf("failed to delete comment thread: %w", err)
+		}
+		for _, id := range ids {
+			tx.cacheDelete(fmt.Sprintf("%s:%v", tx.cacheName, id))
+		}
+		return nil
+	})
+}
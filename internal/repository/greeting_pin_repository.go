@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-samples/internal/cache"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GreetingPinRepository stores the greetings pinned by admins and the greetings chosen as greeting of the day,
+// both per calendar date. Pins and picks are not cached. A repository bound to a request with WithContext
+// only sees the pins and picks of the tenant of its user.
+type GreetingPinRepository interface {
+	FindPinByDate(date string) (util.Optional[domain.GreetingPin], error)
+	SavePin(pin domain.GreetingPin) (domain.GreetingPin, error)
+	DeletePinByDate(date string) (bool, error)
+	FindDailyPickByDate(date string) (util.Optional[domain.GreetingDailyPick], error)
+	SaveDailyPick(pick domain.GreetingDailyPick) (domain.GreetingDailyPick, error)
+	DeleteDailyPick(pick domain.GreetingDailyPick) error
+	WithContext(ctx context.Context) GreetingPinRepository
+}
+
+type greetingPinRepositoryImpl struct {
+	// greetings holds the database and tenant scope of the repository
+	greetings *BaseRepository[domain.Greeting, uint]
+}
+
+// NewGreetingPinRepository creates a new instance of GreetingPinRepository
+func NewGreetingPinRepository(db *gorm.DB, cacheManager *cache.CacheManager) GreetingPinRepository {
+	return &greetingPinRepositoryImpl{
+		greetings: NewBaseRepository[domain.Greeting, uint](db, cacheManager, greetingCacheName),
+	}
+}
+
+// WithContext returns a repository bound to ctx, see BaseRepository.withContext
+func (r *greetingPinRepositoryImpl) WithContext(ctx context.Context) GreetingPinRepository {
+	return &greetingPinRepositoryImpl{greetings: r.greetings.withContext(ctx)}
+}
+
+// FindPinByDate retrieves the greeting pinned for a calendar date in the tenant of the repository
+func (r *greetingPinRepositoryImpl) FindPinByDate(date string) (util.Optional[domain.GreetingPin], error) {
+	var pin domain.GreetingPin
+	if err := r.greetings.db.Where("tenant_id = ? AND date = ?", r.greetings.tenant.tenantID, date).
+		First(&pin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[domain.GreetingPin](), nil
+		}
+		return util.Optional[domain.GreetingPin]{}, fmt.Errorf("failed to fetch greeting pin: %w", err)
+	}
+	return util.Optional[domain.GreetingPin]{Value: &pin}, nil
+}
+
+// SavePin pins a greeting for the date of the pin in the tenant of the repository,
+// replacing the greeting pinned before. The stored pin is returned, whose creator is kept when it is replaced.
+func (r *greetingPinRepositoryImpl) SavePin(pin domain.GreetingPin) (domain.GreetingPin, error) {
+	tenantID, err := r.greetings.tenant.newTenantID()
+	if err != nil {
+		return domain.GreetingPin{}, fmt.Errorf("failed to save greeting pin: %w", err)
+	}
+	pin.TenantID = tenantID
+	if err := r.greetings.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"greeting_id", "updated_at", "updated_by"}),
+	}).Create(&pin).Error; err != nil {
+		return domain.GreetingPin{}, fmt.Errorf("failed to save greeting pin: %w", err)
+	}
+
+	var stored domain.GreetingPin
+	if err := r.greetings.db.Where("tenant_id = ? AND date = ?", pin.TenantID, pin.Date).First(&stored).Error; err != nil {
+		return domain.GreetingPin{}, fmt.Errorf("failed to fetch greeting pin: %w", err)
+	}
+	return stored, nil
+}
+
+// DeletePinByDate removes the pin of a calendar date in the tenant of the repository
+// and reports whether there was one
+func (r *greetingPinRepositoryImpl) DeletePinByDate(date string) (bool, error) {
+	result := r.greetings.db.Where("tenant_id = ? AND date = ?", r.greetings.tenant.tenantID, date).
+		Delete(&domain.GreetingPin{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete greeting pin: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindDailyPickByDate retrieves the greeting chosen as greeting of the day for a calendar date
+// in the tenant of the repository
+func (r *greetingPinRepositoryImpl) FindDailyPickByDate(date string) (util.Optional[domain.GreetingDailyPick], error) {
+	var pick domain.GreetingDailyPick
+	if err := r.greetings.db.Where("tenant_id = ? AND date = ?", r.greetings.tenant.tenantID, date).
+		First(&pick).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[domain.GreetingDailyPick](), nil
+		}
+		return util.Optional[domain.GreetingDailyPick]{}, fmt.Errorf("failed to fetch greeting daily pick: %w", err)
+	}
+	return util.Optional[domain.GreetingDailyPick]{Value: &pick}, nil
+}
+
+// SaveDailyPick records the greeting chosen for the date of the pick in the tenant of the repository,
+// unless a greeting was chosen for the date before, e.g. by a concurrent request. It returns the pick in effect.
+func (r *greetingPinRepositoryImpl) SaveDailyPick(pick domain.GreetingDailyPick) (domain.GreetingDailyPick, error) {
+	tenantID, err := r.greetings.tenant.newTenantID()
+	if err != nil {
+		return domain.GreetingDailyPick{}, fmt.Errorf("failed to save greeting daily pick: %w", err)
+	}
+	pick.TenantID = tenantID
+	result := r.greetings.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&pick)
+	if result.Error != nil {
+		return domain.GreetingDailyPick{}, fmt.Errorf("failed to save greeting daily pick: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return pick, nil
+	}
+
+	var stored domain.GreetingDailyPick
+	if err := r.greetings.db.Where("tenant_id = ? AND date = ?", pick.TenantID, pick.Date).First(&stored).Error; err != nil {
+		return domain.GreetingDailyPick{}, fmt.Errorf("failed to fetch greeting daily pick: %w", err)
+	}
+	return stored, nil
+}
+
+// DeleteDailyPick removes the pick of its date in the tenant of the repository, provided it still names its greeting
+func (r *greetingPinRepositoryImpl) DeleteDailyPick(pick domain.GreetingDailyPick) error {
+	if err := r.greetings.db.
+		Where("tenant_id = ? AND date = ? AND greeting_id = ?", r.greetings.tenant.tenantID, pick.Date, pick.GreetingID).
+		Delete(&domain.GreetingDailyPick{}).Error; err != nil {
+		return fmt.Errorf("failed to delete greeting daily pick: %w", err)
+	}
+	return nil
+}
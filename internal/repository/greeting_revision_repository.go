@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-samples/internal/cache"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/util"
+	"gorm.io/gorm"
+)
+
+// GreetingRevisionRepository reads the revision history of greetings, including greetings in the trash.
+// Revisions are recorded with HelloRepository.SaveRevision in the transaction of the change, and are not cached.
+// A repository bound to a request with WithContext only sees the revisions of the greetings of the tenant of its user.
+type GreetingRevisionRepository interface {
+	FindPagedByGreeting(greetingID uint, pageable Pageable) (Page[domain.GreetingRevision], error)
+	FindByGreetingAndNumber(greetingID, revision uint) (util.Optional[domain.GreetingRevision], error)
+	WithContext(ctx context.Context) GreetingRevisionRepository
+}
+
+type greetingRevisionRepositoryImpl struct {
+	// greetings holds the database and tenant scope of the repository
+	greetings    *BaseRepository[domain.Greeting, uint]
+	cacheManager *cache.CacheManager
+}
+
+// NewGreetingRevisionRepository creates a new instance of GreetingRevisionRepository
+func NewGreetingRevisionRepository(db *gorm.DB, cacheManager *cache.CacheManager) GreetingRevisionRepository {
+	return &greetingRevisionRepositoryImpl{
+		greetings:    NewBaseRepository[domain.Greeting, uint](db, cacheManager, greetingCacheName),
+		cacheManager: cacheManager,
+	}
+}
+
+// WithContext returns a repository bound to ctx, see BaseRepository.withContext
+func (r *greetingRevisionRepositoryImpl) WithContext(ctx context.Context) GreetingRevisionRepository {
+	return &greetingRevisionRepositoryImpl{greetings: r.greetings.withContext(ctx), cacheManager: r.cacheManager}
+}
+
+// FindPagedByGreeting retrieves a page of the revisions of a greeting of the tenant of the repository
+func (r *greetingRevisionRepositoryImpl) FindPagedByGreeting(greetingID uint,
+	pageable Pageable) (Page[domain.GreetingRevision], error) {
+	revisions := NewBaseRepository[domain.GreetingRevision, uint](r.greetings.db, r.cacheManager, "greeting_revision")
+	return revisions.FindAllPaged(pageable, r.revisionsOf(greetingID))
+}
+
+// FindByGreetingAndNumber retrieves a revision of a greeting of the tenant of the repository by its number
+func (r *greetingRevisionRepositoryImpl) FindByGreetingAndNumber(greetingID,
+	revision uint) (util.Optional[domain.GreetingRevision], error) {
+	var greetingRevision domain.GreetingRevision
+	if err := r.revisionsOf(greetingID)(r.greetings.db).Where("revision = ?", revision).
+		First(&greetingRevision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[domain.GreetingRevision](), nil
+		}
+		return util.Optional[domain.GreetingRevision]{}, fmt.Errorf("failed to fetch greeting revision: %w", err)
+	}
+	return util.Optional[domain.GreetingRevision]{Value: &greetingRevision}, nil
+}
+
+// revisionsOf restricts a query of revisions to the revisions of a greeting, provided the greeting belongs
+// to the tenant of the repository. Revisions have no tenant of their own, so it is taken from their greeting.
+func (r *greetingRevisionRepositoryImpl) revisionsOf(greetingID uint) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		greetings := r.greetings.tenant.withTenant(
+			r.greetings.db.Table("greeting g").Select("g.id").Where("g.id = ?", greetingID), "g")
+		return db.Where("greeting_revision.greeting_id IN (?)", greetings)
+	}
+}
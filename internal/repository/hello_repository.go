@@ -1,17 +1,39 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"gin-samples/internal/cache"
 	"gin-samples/internal/domain"
+	"gin-samples/internal/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"regexp"
+	"strings"
+	"time"
 )
 
-// HelloRepository extends CrudRepository with additional methods
+// HelloRepository extends CrudRepository with additional methods.
+// Greetings are read along with their tags, reaction counts and comment counts, and saving a greeting replaces
+// its tags with those of the entity. Counts are never cached, so they are always current.
+// A repository bound to a request with WithContext only sees the greetings of the tenant of its user.
 type HelloRepository interface {
 	CrudRepository[domain.Greeting, uint]
-	ExistsByMessage(message string) (bool, error)
+	SoftDeleteRepository[domain.Greeting, uint]
+	ExistsByMessage(message, locale string) (bool, error)
+	FindByMessage(message, locale string) (util.Optional[domain.Greeting], error)
+	FindLocales(specs ...Specification) ([]string, error)
+	Search(text string, pageable Pageable, specs ...Specification) (Page[domain.GreetingSearchResult], error)
+	PurgeExpired(before time.Time) (int64, error)
+	SaveRevision(revision domain.GreetingRevision) (domain.GreetingRevision, error)
+	Transaction(fn func(HelloRepository) error) error
+	WithContext(ctx context.Context) HelloRepository
 }
 
+// greetingCacheName prefixes the cache keys of greetings
+const greetingCacheName = "greeting"
+
 type helloRepositoryImpl struct {
 	*BaseRepository[domain.Greeting, uint]
 	cacheManager *cache.CacheManager
@@ -22,15 +44,653 @@ func NewHelloRepository(db *gorm.DB, cacheManager *cache.CacheManager) HelloRepo
 	return &helloRepositoryImpl{
 		BaseRepository: NewBaseRepository[domain.Greeting, uint](db,
 			cacheManager,
-			"greeting"),
+			greetingCacheName),
 		cacheManager: cacheManager,
 	}
 }
 
-func (r *helloRepositoryImpl) ExistsByMessage(message string) (bool, error) {
+// Transaction runs fn with a repository bound to a database transaction, which is committed when fn returns nil
+// and rolled back otherwise. Calling Transaction on that repository starts a nested transaction (savepoint).
+func (r *helloRepositoryImpl) Transaction(fn func(HelloRepository) error) error {
+	return r.transaction(func(tx *BaseRepository[domain.Greeting, uint]) error {
+		return fn(&helloRepositoryImpl{BaseRepository: tx, cacheManager: r.cacheManager})
+	})
+}
+
+// WithContext returns a repository bound to ctx and restricted to the tenant of its authenticated user,
+// see BaseRepository.withContext
+func (r *helloRepositoryImpl) WithContext(ctx context.Context) HelloRepository {
+	return &helloRepositoryImpl{BaseRepository: r.withContext(ctx), cacheManager: r.cacheManager}
+}
+
+// Save creates or updates a greeting, see BaseRepository.Save, and replaces its tags with the tags of the entity.
+// Tags are matched by name; missing tags are created.
+func (r *helloRepositoryImpl) Save(entity domain.Greeting) (domain.Greeting, error) {
+	var savedEntity domain.Greeting
+	err := r.transaction(func(tx *BaseRepository[domain.Greeting, uint]) error {
+		var err error
+		if savedEntity, err = tx.Save(entity); err != nil {
+			return err
+		}
+		if savedEntity.Tags, err = saveGreetingTags(tx.db, savedEntity.ID, entity.TagNames()); err != nil {
+			return err
+		}
+
+		// Replace the entity cached by Save, whose tags were not saved yet
+		cachedEntity := savedEntity
+		tx.cacheStore(&cachedEntity)
+		return loadGreetingCount(tx.db, &savedEntity)
+	})
+	if err != nil {
+		return domain.Greeting{}, err
+	}
+	return savedEntity, nil
+}
+
+// saveGreetingTags replaces the tags of a greeting with the named tags and returns them sorted by name
+func saveGreetingTags(db *gorm.DB, greetingID uint, names []string) ([]domain.Tag, error) {
+	if err := db.Where("greeting_id = ?", greetingID).Delete(&domain.GreetingTag{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to remove greeting tags: %w", err)
+	}
+	tags := []domain.Tag{}
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	newTags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		newTags[i] = domain.Tag{Name: name}
+	}
+	if err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&newTags).Error; err != nil {
+		return nil, fmt.Errorf("failed to save tags: %w", err)
+	}
+	if err := db.Where("name IN ?", names).Order("name").Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	greetingTags := make([]domain.GreetingTag, len(tags))
+	for i, tag := range tags {
+		greetingTags[i] = domain.GreetingTag{GreetingID: greetingID, TagID: tag.ID}
+	}
+	if err := db.Create(&greetingTags).Error; err != nil {
+		return nil, fmt.Errorf("failed to save greeting tags: %w", err)
+	}
+	return tags, nil
+}
+
+// FindAll retrieves all greetings with their tags and counts
+func (r *helloRepositoryImpl) FindAll() ([]domain.Greeting, error) {
+	greetings, err := r.BaseRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return greetings, loadGreetingDetails(r.db, greetings)
+}
+
+// FindAllPaged retrieves a page of the greetings matching all specifications with their tags and counts
+func (r *helloRepositoryImpl) FindAllPaged(pageable Pageable, specs ...Specification) (Page[domain.Greeting], error) {
+	page, err := r.BaseRepository.FindAllPaged(pageable, specs...)
+	if err != nil {
+		return Page[domain.Greeting]{}, err
+	}
+	return page, loadGreetingDetails(r.db, page.Content)
+}
+
+// FindAllByKeyset retrieves a keyset page of the greetings matching all specifications with their tags
+// and counts
+func (r *helloRepositoryImpl) FindAllByKeyset(pageable KeysetPageable, specs ...Specification) (KeysetPage[domain.Greeting], error) {
+	page, err := r.BaseRepository.FindAllByKeyset(pageable, specs...)
+	if err != nil {
+		return KeysetPage[domain.Greeting]{}, err
+	}
+	return page, loadGreetingDetails(r.db, page.Content)
+}
+
+// FindAllInBatches passes the greetings matching all specifications to fn in batches, each with their tags
+// and counts
+func (r *helloRepositoryImpl) FindAllInBatches(batchSize int, fn func([]domain.Greeting) error, specs ...Specification) error {
+	return r.BaseRepository.FindAllInBatches(batchSize, func(batch []domain.Greeting) error {
+		if err := loadGreetingDetails(r.db, batch); err != nil {
+			return err
+		}
+		return fn(batch)
+	}, specs...)
+}
+
+// FindByID retrieves a greeting with its tags and counts by its ID and caches the greeting with its tags
+func (r *helloRepositoryImpl) FindByID(id uint) (util.Optional[domain.Greeting], error) {
+	cacheKey := r.readCacheKey(id)
+	if cachedValue, found := r.cacheGet(cacheKey); found {
+		greeting := *cachedValue.(*domain.Greeting)
+		if err := loadGreetingCount(r.db, &greeting); err != nil {
+			return util.Optional[domain.Greeting]{}, err
+		}
+		return util.Optional[domain.Greeting]{Value: &greeting}, nil
+	}
+
+	var greeting domain.Greeting
+	if err := r.scopedDB().First(&greeting, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[domain.Greeting](), nil
+		}
+		return util.Optional[domain.Greeting]{}, fmt.Errorf("failed to fetch entity by ID: %w", err)
+	}
+	if err := loadGreetingTag(r.db, &greeting); err != nil {
+		return util.Optional[domain.Greeting]{}, err
+	}
+
+	cachedGreeting := greeting
+	r.cacheSet(cacheKey, &cachedGreeting)
+	if err := loadGreetingCount(r.db, &greeting); err != nil {
+		return util.Optional[domain.Greeting]{}, err
+	}
+	return util.Optional[domain.Greeting]{Value: &greeting}, nil
+}
+
+// FindAllDeletedPaged retrieves a page of the greetings in the trash with their tags and counts
+func (r *helloRepositoryImpl) FindAllDeletedPaged(pageable Pageable) (Page[domain.Greeting], error) {
+	page, err := r.BaseRepository.FindAllDeletedPaged(pageable)
+	if err != nil {
+		return Page[domain.Greeting]{}, err
+	}
+	return page, loadGreetingDetails(r.db, page.Content)
+}
+
+// FindDeletedByID retrieves a greeting in the trash with its tags and counts by its ID
+func (r *helloRepositoryImpl) FindDeletedByID(id uint) (util.Optional[domain.Greeting], error) {
+	optionalEntity, err := r.BaseRepository.FindDeletedByID(id)
+	if err != nil || optionalEntity.IsEmpty() {
+		return optionalEntity, err
+	}
+	return optionalEntity, loadGreetingDetail(r.db, optionalEntity.Value)
+}
+
+// Restore moves a greeting with its tags, reactions and comments out of the trash, see BaseRepository.Restore
+func (r *helloRepositoryImpl) Restore(entity domain.Greeting) (domain.Greeting, error) {
+	var restoredEntity domain.Greeting
+	err := r.transaction(func(tx *BaseRepository[domain.Greeting, uint]) error {
+		var err error
+		if restoredEntity, err = tx.Restore(entity); err != nil {
+			return err
+		}
+		if err := loadGreetingTag(tx.db, &restoredEntity); err != nil {
+			return err
+		}
+
+		// Replace the entity cached by Restore, whose tags were not loaded yet
+		cachedEntity := restoredEntity
+		tx.cacheStore(&cachedEntity)
+		return loadGreetingCount(tx.db, &restoredEntity)
+	})
+	if err != nil {
+		return domain.Greeting{}, err
+	}
+	return restoredEntity, nil
+}
+
+// Purge permanently deletes a greeting in the trash along with the records referencing it, see greetingChildren
+func (r *helloRepositoryImpl) Purge(entity domain.Greeting) error {
+	return r.transaction(func(tx *BaseRepository[domain.Greeting, uint]) error {
+		if err := purgeGreetingChildren(tx.db, []uint{entity.ID}); err != nil {
+			return err
+		}
+		return tx.Purge(entity)
+	})
+}
+
+// greetingChildren holds the records of the tables referencing greetings, which are purged along with their greeting
+var greetingChildren = []struct {
+	name  string
+	model any
+}{
+	{name: "revisions", model: &domain.GreetingRevision{}},
+	{name: "tags", model: &domain.GreetingTag{}},
+	{name: "pins", model: &domain.GreetingPin{}},
+	{name: "daily picks", model: &domain.GreetingDailyPick{}},
+	{name: "reactions", model: &domain.GreetingReaction{}},
+	{name: "comments", model: &domain.GreetingComment{}},
+}
+
+// purgeGreetingChildren permanently deletes the records referencing the greetings with the given IDs.
+// Every purge of greetings must call it, so that no table referencing greetings is left behind.
+func purgeGreetingChildren(db *gorm.DB, ids []uint) error {
+	for _, child := range greetingChildren {
+		if err := db.Where("greeting_id IN ?", ids).Delete(child.model).Error; err != nil {
+			return fmt.Errorf("failed to purge greeting %s: %w", child.name, err)
+		}
+	}
+	return nil
+}
+
+// loadGreetingDetail sets the tags, reaction counts and comment count of the greeting
+func loadGreetingDetail(db *gorm.DB, greeting *domain.Greeting) error {
+	if err := loadGreetingTag(db, greeting); err != nil {
+		return err
+	}
+	return loadGreetingCount(db, greeting)
+}
+
+// loadGreetingDetails sets the tags, reaction counts and comment counts of the greetings
+func loadGreetingDetails(db *gorm.DB, greetings []domain.Greeting) error {
+	if err := loadGreetingTags(db, greetings); err != nil {
+		return err
+	}
+	return loadGreetingCounts(db, greetings)
+}
+
+// loadGreetingCount sets the reaction counts and comment count of the greeting, and the time they last changed
+func loadGreetingCount(db *gorm.DB, greeting *domain.Greeting) error {
+	greetings := []domain.Greeting{*greeting}
+	if err := loadGreetingCounts(db, greetings); err != nil {
+		return err
+	}
+	greeting.Reactions = greetings[0].Reactions
+	greeting.CommentCount = greetings[0].CommentCount
+	return loadGreetingActivity(db, greeting)
+}
+
+// loadGreetingActivity sets the time of the newest reaction to the greeting or change of its comments
+func loadGreetingActivity(db *gorm.DB, greeting *domain.Greeting) error {
+	greeting.LastActivityAt = nil
+	for _, activity := range []struct {
+		model  any
+		column string
+	}{
+		{&domain.GreetingReaction{}, "created_at"},
+		{&domain.GreetingComment{}, "updated_at"},
+	} {
+		// Times are read from their column, as SQLite returns aggregates like MAX as text
+		var times []time.Time
+		if err := db.Model(activity.model).
+			Where("greeting_id = ?", greeting.ID).
+			Order(activity.column+" DESC").
+			Limit(1).
+			Pluck(activity.column, &times).Error; err != nil {
+			return fmt.Errorf("failed to fetch greeting activity: %w", err)
+		}
+		if len(times) > 0 && (greeting.LastActivityAt == nil || times[0].After(*greeting.LastActivityAt)) {
+			greeting.LastActivityAt = &times[0]
+		}
+	}
+	return nil
+}
+
+// loadGreetingCounts sets the reaction counts and comment counts of the greetings
+func loadGreetingCounts(db *gorm.DB, greetings []domain.Greeting) error {
+	if err := loadGreetingReactions(db, greetings); err != nil {
+		return err
+	}
+	return loadGreetingComments(db, greetings)
+}
+
+// loadGreetingComments sets the number of comments on the greetings, including replies
+func loadGreetingComments(db *gorm.DB, greetings []domain.Greeting) error {
+	if len(greetings) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(greetings))
+	for i, greeting := range greetings {
+		ids[i] = greeting.ID
+	}
+
+	var rows []struct {
+		GreetingID uint
+		Count      int64
+	}
+	if err := db.Model(&domain.GreetingComment{}).
+		Select("greeting_id, COUNT(*) AS count").
+		Where("greeting_id IN ?", ids).
+		Group("greeting_id").
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to count greeting comments: %w", err)
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.GreetingID] = row.Count
+	}
+	for i := range greetings {
+		greetings[i].CommentCount = counts[greetings[i].ID]
+	}
+	return nil
+}
+
+// loadGreetingReactions sets the number of reactions per type of the greetings
+func loadGreetingReactions(db *gorm.DB, greetings []domain.Greeting) error {
+	if len(greetings) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(greetings))
+	for i, greeting := range greetings {
+		ids[i] = greeting.ID
+	}
+
+	var rows []struct {
+		GreetingID uint
+		Type       domain.ReactionType
+		Count      int64
+	}
+	if err := db.Model(&domain.GreetingReaction{}).
+		Select("greeting_id, type, COUNT(*) AS count").
+		Where("greeting_id IN ?", ids).
+		Group("greeting_id, type").
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to count greeting reactions: %w", err)
+	}
+
+	reactions := make(map[uint]map[domain.ReactionType]int64, len(greetings))
+	for _, row := range rows {
+		if reactions[row.GreetingID] == nil {
+			reactions[row.GreetingID] = map[domain.ReactionType]int64{}
+		}
+		reactions[row.GreetingID][row.Type] = row.Count
+	}
+	for i := range greetings {
+		greetings[i].Reactions = reactions[greetings[i].ID]
+	}
+	return nil
+}
+
+// loadGreetingTag sets the tags of the greeting, sorted by name
+func loadGreetingTag(db *gorm.DB, greeting *domain.Greeting) error {
+	greetings := []domain.Greeting{*greeting}
+	if err := loadGreetingTags(db, greetings); err != nil {
+		return err
+	}
+	greeting.Tags = greetings[0].Tags
+	return nil
+}
+
+// loadGreetingTags sets the tags of the greetings, sorted by name
+func loadGreetingTags(db *gorm.DB, greetings []domain.Greeting) error {
+	if len(greetings) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(greetings))
+	for i, greeting := range greetings {
+		ids[i] = greeting.ID
+	}
+
+	var rows []struct {
+		GreetingID uint
+		domain.Tag
+	}
+	if err := db.Table("greeting_tag gt").
+		Select("gt.greeting_id, t.*").
+		Joins("JOIN tag t ON t.id = gt.tag_id").
+		Where("gt.greeting_id IN ?", ids).
+		Order("t.name").
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to fetch greeting tags: %w", err)
+	}
+
+	tags := make(map[uint][]domain.Tag, len(greetings))
+	for _, row := range rows {
+		tags[row.GreetingID] = append(tags[row.GreetingID], row.Tag)
+	}
+	for i := range greetings {
+		greetings[i].Tags = tags[greetings[i].ID]
+		if greetings[i].Tags == nil {
+			greetings[i].Tags = []domain.Tag{}
+		}
+	}
+	return nil
+}
+
+// ExistsByMessage checks whether a greeting with the message exists in the locale, ignoring the trash
+func (r *helloRepositoryImpl) ExistsByMessage(message, locale string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&domain.Greeting{}).Where("message = ?", message).Count(&count).Error; err != nil {
+	if err := r.scopedDB().Model(&domain.Greeting{}).
+		Where("message = ? AND locale = ?", message, locale).
+		Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+// FindByMessage retrieves the greeting with the message in the locale, ignoring the trash
+func (r *helloRepositoryImpl) FindByMessage(message, locale string) (util.Optional[domain.Greeting], error) {
+	var greeting domain.Greeting
+	if err := r.scopedDB().Where("message = ? AND locale = ?", message, locale).First(&greeting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[domain.Greeting](), nil
+		}
+		return util.Optional[domain.Greeting]{}, fmt.Errorf("failed to fetch greeting by message: %w", err)
+	}
+	if err := loadGreetingDetail(r.db, &greeting); err != nil {
+		return util.Optional[domain.Greeting]{}, err
+	}
+	return util.Optional[domain.Greeting]{Value: &greeting}, nil
+}
+
+// FindLocales returns the distinct locales of the stored greetings matching all specifications
+func (r *helloRepositoryImpl) FindLocales(specs ...Specification) ([]string, error) {
+	query := r.scopedDB().Model(&domain.Greeting{})
+	for _, spec := range specs {
+		query = spec(query)
+	}
+
+	var locales []string
+	if err := query.Distinct().Order("locale").Pluck("locale", &locales).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch greeting locales: %w", err)
+	}
+	return locales, nil
+}
+
+// Search finds the greetings matching the words and "quoted phrases" of the text and all specifications,
+// most relevant first. A word ending with * matches every word starting with it. Greetings in the trash are not searched.
+func (r *helloRepositoryImpl) Search(text string, pageable Pageable, specs ...Specification) (Page[domain.GreetingSearchResult], error) {
+	page := Page[domain.GreetingSearchResult]{
+		Content: []domain.GreetingSearchResult{},
+		Page:    pageable.Page,
+		Size:    pageable.Size,
+	}
+
+	match := toMatchExpression(text)
+	if match == "" {
+		return page, nil
+	}
+
+	scoped := func() *gorm.DB {
+		query := r.db.Table("greeting_fts").
+			Joins("JOIN greeting g ON g.id = greeting_fts.rowid").
+			Where("greeting_fts MATCH ? AND g.deleted_at IS NULL", match)
+		query = r.tenant.withTenant(query, "g")
+		for _, spec := range specs {
+			query = spec(query)
+		}
+		return query
+	}
+
+	if err := scoped().Count(&page.TotalElements).Error; err != nil {
+		return Page[domain.GreetingSearchResult]{}, fmt.Errorf("failed to count search results: %w", err)
+	}
+	if page.TotalElements == 0 || int64(pageable.Offset()) >= page.TotalElements {
+		return page, nil
+	}
+
+	if err := scoped().
+		Select("g.*, snippet(greeting_fts, 0, ?, ?, '…', 12) AS snippet, bm25(greeting_fts) AS rank",
+			domain.SnippetHighlightStart, domain.SnippetHighlightEnd).
+		Order("rank, g.id").
+		Limit(pageable.Size).
+		Offset(pageable.Offset()).
+		Scan(&page.Content).Error; err != nil {
+		return Page[domain.GreetingSearchResult]{}, fmt.Errorf("failed to search greetings: %w", err)
+	}
+
+	greetings := make([]domain.Greeting, len(page.Content))
+	for i, result := range page.Content {
+		greetings[i] = result.Greeting
+	}
+	if err := loadGreetingDetails(r.db, greetings); err != nil {
+		return Page[domain.GreetingSearchResult]{}, err
+	}
+	for i := range page.Content {
+		page.Content[i].Tags = greetings[i].Tags
+		page.Content[i].Reactions = greetings[i].Reactions
+		page.Content[i].CommentCount = greetings[i].CommentCount
+	}
+	return page, nil
+}
+
+// PurgeExpired permanently deletes the greetings which expired before the given time, including those in the trash,
+// along with the records referencing them, see greetingChildren. It returns the number of purged greetings.
+func (r *helloRepositoryImpl) PurgeExpired(before time.Time) (int64, error) {
+	var purged int64
+	err := r.transaction(func(tx *BaseRepository[domain.Greeting, uint]) error {
+		var expired []greetingKey
+		if err := tx.scopedDB().Unscoped().Model(&domain.Greeting{}).
+			Where("expire_at < ?", before.UTC()).
+			Select("id, " + domain.TenantColumn).
+			Scan(&expired).Error; err != nil {
+			return fmt.Errorf("failed to fetch expired greetings: %w", err)
+		}
+		if len(expired) == 0 {
+			return nil
+		}
+		ids := make([]uint, len(expired))
+		for i, key := range expired {
+			ids[i] = key.ID
+		}
+
+		if err := purgeGreetingChildren(tx.db, ids); err != nil {
+			return err
+		}
+		result := tx.db.Unscoped().Where("id IN ?", ids).Delete(&domain.Greeting{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge expired greetings: %w", result.Error)
+		}
+		for _, key := range expired {
+			tx.cacheEvict(key.TenantID, key.ID)
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	return purged, err
+}
+
+// SaveRevision appends a revision to the history of its greeting, numbered after the latest revision.
+// It must run in the transaction of the change it records, so that concurrent changes are numbered in order.
+// Revisions are read with GreetingRevisionRepository.
+func (r *helloRepositoryImpl) SaveRevision(revision domain.GreetingRevision) (domain.GreetingRevision, error) {
+	var latest uint
+	if err := r.db.Model(&domain.GreetingRevision{}).
+		Where("greeting_id = ?", revision.GreetingID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error; err != nil {
+		return domain.GreetingRevision{}, fmt.Errorf("failed to fetch latest greeting revision: %w", err)
+	}
+
+	revision.Revision = latest + 1
+	if err := r.db.Create(&revision).Error; err != nil {
+		return domain.GreetingRevision{}, fmt.Errorf("failed to save greeting revision: %w", err)
+	}
+	return revision, nil
+}
+
+// greetingKey identifies a cached greeting
+type greetingKey struct {
+	ID       uint
+	TenantID string
+}
+
+// searchTermPattern matches "quoted phrases" and single words, optionally followed by * for a prefix match
+var searchTermPattern = regexp.MustCompile(`"([^"]*)"|([^\s"]+)`)
+
+// toMatchExpression turns free text into an FTS5 query. Every word and phrase is quoted,
+// so FTS5 operators and special characters in the text are searched for literally instead of failing the query.
+func toMatchExpression(text string) string {
+	var terms []string
+	for _, match := range searchTermPattern.FindAllStringSubmatch(text, -1) {
+		term, prefix := match[1], false
+		if match[1] == "" {
+			term = match[2]
+			prefix = strings.HasSuffix(term, "*")
+			term = strings.TrimRight(term, "*")
+		}
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+
+		quoted := `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			quoted += "*"
+		}
+		terms = append(terms, quoted)
+	}
+	return strings.Join(terms, " ")
+}
+
+// StatusEquals matches greetings in the given moderation state
+func StatusEquals(status domain.GreetingStatus) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", status)
+	}
+}
+
+// ApprovedOrOwnedBy matches approved greetings and the greetings of the given user in any moderation state
+func ApprovedOrOwnedBy(userID string) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(status = ? OR owner_id = ?)", domain.GreetingStatusApproved, userID)
+	}
+}
+
+// LocaleEquals matches greetings in the given locale
+func LocaleEquals(locale string) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("locale = ?", locale)
+	}
+}
+
+// MessageContains matches greetings whose message contains the given text, ignoring case
+func MessageContains(text string) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`LOWER(message) LIKE LOWER(?) ESCAPE '\'`, containsPattern(text))
+	}
+}
+
+// IsTemplate matches greetings whose message has placeholders, e.g. {{.FirstName}}
+func IsTemplate() Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("message LIKE ?", "%{{%")
+	}
+}
+
+// TaggedWithAny matches greetings tagged with at least one of the named tags
+func TaggedWithAny(names []string) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN (SELECT gt.greeting_id FROM greeting_tag gt JOIN tag t ON t.id = gt.tag_id "+
+			"WHERE t.name IN ?)", names)
+	}
+}
+
+// TaggedWithAll matches greetings tagged with all of the named tags, which must be distinct
+func TaggedWithAll(names []string) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN (SELECT gt.greeting_id FROM greeting_tag gt JOIN tag t ON t.id = gt.tag_id "+
+			"WHERE t.name IN ? GROUP BY gt.greeting_id HAVING COUNT(*) = ?)", names, len(names))
+	}
+}
+
+// LiveAt matches greetings which are published and not yet expired at the given time
+func LiveAt(t time.Time) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(publish_at IS NULL OR publish_at <= ?) AND (expire_at IS NULL OR expire_at > ?)", t.UTC(), t.UTC())
+	}
+}
+
+// PublishedBetween matches greetings going live after from and no later than to
+func PublishedBetween(from, to time.Time) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("publish_at > ? AND publish_at <= ?", from.UTC(), to.UTC())
+	}
+}
+
+// ExpiredBetween matches greetings expiring after from and no later than to
+func ExpiredBetween(from, to time.Time) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("expire_at > ? AND expire_at <= ?", from.UTC(), to.UTC())
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+)
+
+// Keyset identifies a row by the value of the sort column and its primary key.
+// When Backward is true, the rows before the key are requested instead of the rows after it.
+type Keyset struct {
+	Column   string `json:"c"`
+	Desc     bool   `json:"d,omitempty"`
+	Value    any    `json:"v"`
+	ID       any    `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// KeysetPageable defines a keyset page request. Without a Keyset, the first page is returned.
+// The sort order of a Keyset takes precedence over Sort, so a listing keeps its order while it is scrolled.
+type KeysetPageable struct {
+	Size   int
+	Sort   SortOrder
+	Keyset *Keyset
+}
+
+// KeysetPage holds a slice of entities with the keysets of the neighbouring pages, nil when there is none
+type KeysetPage[T any] struct {
+	Content []T
+	Next    *Keyset
+	Prev    *Keyset
+}
+
+// FindAllByKeyset retrieves the entities following (or preceding) the keyset, ordered by the sort column
+// and then by primary key. Unlike offset pagination, the cost does not grow with the position in the table,
+// and concurrent inserts do not shift the pages.
+func (r *BaseRepository[T, ID]) FindAllByKeyset(pageable KeysetPageable, specs ...Specification) (KeysetPage[T], error) {
+	entitySchema, err := r.schema()
+	if err != nil {
+		return KeysetPage[T]{}, err
+	}
+	primaryField := entitySchema.PrioritizedPrimaryField
+	if primaryField == nil {
+		return KeysetPage[T]{}, fmt.Errorf("entity %s has no primary key", entitySchema.Name)
+	}
+
+	sort := pageable.Sort
+	backward := false
+	if pageable.Keyset != nil {
+		sort = SortOrder{Column: pageable.Keyset.Column, Desc: pageable.Keyset.Desc}
+		backward = pageable.Keyset.Backward
+	}
+	sortField := entitySchema.LookUpField(sort.Column)
+	if sortField == nil {
+		return KeysetPage[T]{}, fmt.Errorf("unknown sort column %q", sort.Column)
+	}
+
+	query := r.scopedDB().Model(new(T))
+	for _, spec := range specs {
+		query = spec(query)
+	}
+
+	// Reading backward reverses the order; the rows are put back in order afterwards
+	desc := sort.Desc != backward
+	if pageable.Keyset != nil {
+		value, err := convertKeyValue(pageable.Keyset.Value, sortField)
+		if err != nil {
+			return KeysetPage[T]{}, err
+		}
+		id, err := convertKeyValue(pageable.Keyset.ID, primaryField)
+		if err != nil {
+			return KeysetPage[T]{}, err
+		}
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)",
+			r.db.Statement.Quote(sortField.DBName), r.db.Statement.Quote(primaryField.DBName), operator), value, id)
+	}
+
+	// Fetch one extra row to find out whether there is a further page
+	var entities []T
+	if err := query.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: sortField.DBName}, Desc: desc},
+		{Column: clause.Column{Name: primaryField.DBName}, Desc: desc},
+	}}).Limit(pageable.Size + 1).Find(&entities).Error; err != nil {
+		return KeysetPage[T]{}, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+
+	hasMore := len(entities) > pageable.Size
+	if hasMore {
+		entities = entities[:pageable.Size]
+	}
+	if backward {
+		for i, j := 0, len(entities)-1; i < j; i, j = i+1, j-1 {
+			entities[i], entities[j] = entities[j], entities[i]
+		}
+	}
+
+	page := KeysetPage[T]{Content: entities}
+	if page.Content == nil {
+		page.Content = []T{}
+	}
+	if len(entities) == 0 {
+		return page, nil
+	}
+
+	keysetOf := func(entity *T, backward bool) *Keyset {
+		value := reflect.ValueOf(entity).Elem()
+		sortValue, _ := sortField.ValueOf(context.Background(), value)
+		idValue, _ := primaryField.ValueOf(context.Background(), value)
+		return &Keyset{Column: sortField.DBName, Desc: sort.Desc, Value: sortValue, ID: idValue, Backward: backward}
+	}
+
+	// A further page exists in the reading direction when the extra row was found,
+	// and in the opposite direction whenever the page was requested relative to a keyset
+	if (!backward && hasMore) || backward {
+		page.Next = keysetOf(&entities[len(entities)-1], false)
+	}
+	if (backward && hasMore) || (!backward && pageable.Keyset != nil) {
+		page.Prev = keysetOf(&entities[0], true)
+	}
+	return page, nil
+}
+
+// convertKeyValue converts a keyset value, e.g. decoded from JSON, to the Go type of the field,
+// so it is compared with the column exactly like the values written by GORM
+func convertKeyValue(value any, field *schema.Field) (any, error) {
+	if value != nil && reflect.TypeOf(value) == field.FieldType {
+		return value, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keyset value for %s: %w", field.DBName, err)
+	}
+	converted := reflect.New(field.FieldType)
+	if err := json.Unmarshal(raw, converted.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid keyset value for %s: %w", field.DBName, err)
+	}
+	return converted.Elem().Interface(), nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// SortOrder defines the ordering of a single column
+type SortOrder struct {
+	Column string
+	Desc   bool
+}
+
+// Pageable defines the requested page, its size and ordering. Page numbers are zero-based.
+type Pageable struct {
+	Page int
+	Size int
+	Sort []SortOrder
+}
+
+// Offset returns the number of rows to skip for the requested page
+func (p Pageable) Offset() int {
+	return p.Page * p.Size
+}
+
+// Specification narrows down a query, e.g. with a WHERE clause
+type Specification func(db *gorm.DB) *gorm.DB
+
+// Page holds a slice of entities together with the pagination totals
+type Page[T any] struct {
+	Content       []T
+	Page          int
+	Size          int
+	TotalElements int64
+}
+
+// TotalPages returns the number of pages for the total number of entities
+func (p Page[T]) TotalPages() int {
+	if p.Size <= 0 {
+		return 0
+	}
+	return int((p.TotalElements + int64(p.Size) - 1) / int64(p.Size))
+}
+
+// CreatedBefore matches auditable entities created strictly before the given time
+func CreatedBefore(t time.Time) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("created_at < ?", t)
+	}
+}
+
+// CreatedAfter matches auditable entities created strictly after the given time
+func CreatedAfter(t time.Time) Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("created_at > ?", t)
+	}
+}
+
+// likeEscaper escapes the LIKE wildcards so user input is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern builds a LIKE pattern matching values that contain the given text
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
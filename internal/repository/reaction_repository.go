@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/cache"
+	"gin-samples/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// ReactionRepository stores the reactions of users to greetings and ranks greetings by them.
+// Reactions are not cached, so counts are always current. A repository bound to a request with WithContext
+// only ranks the greetings of the tenant of its user.
+type ReactionRepository interface {
+	Save(reaction domain.GreetingReaction) (bool, error)
+	Delete(reaction domain.GreetingReaction) (bool, error)
+	CountByGreeting(greetingID uint) (map[domain.ReactionType]int64, error)
+	FindTypesByUser(greetingID uint, userID string) ([]domain.ReactionType, error)
+	FindTopScored(since time.Time, reactionType domain.ReactionType, limit int, specs ...Specification) ([]domain.GreetingScore, error)
+	WithContext(ctx context.Context) ReactionRepository
+}
+
+type reactionRepositoryImpl struct {
+	// greetings queries the greetings reacted to within the tenant scope of the repository
+	greetings *BaseRepository[domain.Greeting, uint]
+}
+
+// NewReactionRepository creates a new instance of ReactionRepository
+func NewReactionRepository(db *gorm.DB, cacheManager *cache.CacheManager) ReactionRepository {
+	return &reactionRepositoryImpl{
+		greetings: NewBaseRepository[domain.Greeting, uint](db, cacheManager, greetingCacheName),
+	}
+}
+
+// WithContext returns a repository bound to ctx, see BaseRepository.withContext
+func (r *reactionRepositoryImpl) WithContext(ctx context.Context) ReactionRepository {
+	return &reactionRepositoryImpl{greetings: r.greetings.withContext(ctx)}
+}
+
+// Save adds the reaction unless the user already reacted to the greeting with its type,
+// and reports whether it was added. Concurrent duplicates are ignored by the primary key.
+func (r *reactionRepositoryImpl) Save(reaction domain.GreetingReaction) (bool, error) {
+	result := r.greetings.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reaction)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save greeting reaction: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete removes the reaction of the user to the greeting with its type and reports whether there was one
+func (r *reactionRepositoryImpl) Delete(reaction domain.GreetingReaction) (bool, error) {
+	result := r.greetings.db.
+		Where("greeting_id = ? AND user_id = ? AND type = ?", reaction.GreetingID, reaction.UserID, reaction.Type).
+		Delete(&domain.GreetingReaction{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete greeting reaction: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountByGreeting returns the number of reactions per type to the greeting
+func (r *reactionRepositoryImpl) CountByGreeting(greetingID uint) (map[domain.ReactionType]int64, error) {
+	greetings := []domain.Greeting{{ID: greetingID}}
+	if err := loadGreetingReactions(r.greetings.db, greetings); err != nil {
+		return nil, err
+	}
+	return greetings[0].Reactions, nil
+}
+
+// FindTypesByUser returns the types of the reactions of the user to the greeting, sorted by name
+func (r *reactionRepositoryImpl) FindTypesByUser(greetingID uint, userID string) ([]domain.ReactionType, error) {
+	var types []domain.ReactionType
+	if err := r.greetings.db.Model(&domain.GreetingReaction{}).
+		Where("greeting_id = ? AND user_id = ?", greetingID, userID).
+		Order("type").
+		Pluck("type", &types).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch greeting reactions: %w", err)
+	}
+	return types, nil
+}
+
+// FindTopScored retrieves up to limit greetings matching all specifications with the most reactions since
+// the given time, most reactions first. A zero since counts all reactions, and an empty reaction type counts
+// reactions of every type. Greetings without reactions and greetings in the trash are not ranked.
+func (r *reactionRepositoryImpl) FindTopScored(since time.Time, reactionType domain.ReactionType, limit int,
+	specs ...Specification) ([]domain.GreetingScore, error) {
+	db := r.greetings.db
+	reactions := db.Model(&domain.GreetingReaction{}).
+		Select("greeting_id, COUNT(*) AS score").
+		Group("greeting_id")
+	if !since.IsZero() {
+		reactions = reactions.Where("created_at >= ?", since.UTC())
+	}
+	if reactionType != "" {
+		reactions = reactions.Where("type = ?", reactionType)
+	}
+
+	query := r.greetings.scopedDB().Model(&domain.Greeting{}).
+		Select("greeting.*, r.score").
+		Joins("JOIN (?) r ON r.greeting_id = greeting.id", reactions)
+	for _, spec := range specs {
+		query = spec(query)
+	}
+
+	scores := []domain.GreetingScore{}
+	if err := query.Order("r.score DESC, greeting.id").Limit(limit).Scan(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to rank greetings: %w", err)
+	}
+
+	greetings := make([]domain.Greeting, len(scores))
+	for i, score := range scores {
+		greetings[i] = score.Greeting
+	}
+	if err := loadGreetingDetails(db, greetings); err != nil {
+		return nil, err
+	}
+	for i := range scores {
+		scores[i].Greeting = greetings[i]
+	}
+	return scores, nil
+}
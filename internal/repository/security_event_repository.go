@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"fmt"
+	"gin-samples/internal/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+// SecurityEventCriteria defines the filters for querying security events. Zero values are ignored,
+// except for the tenant, so criteria without one match no events.
+type SecurityEventCriteria struct {
+	Principal  string
+	TenantID   string // Tenant of the events; no events match an empty tenant, unless AllTenants is set
+	AllTenants bool   // Whether the events of all tenants and of unknown users match, regardless of TenantID
+	Type       domain.SecurityEventType
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+// SecurityEventRepository stores and queries the security audit log.
+// Events are append-only and not cached.
+type SecurityEventRepository interface {
+	Save(event domain.SecurityEvent) (domain.SecurityEvent, error)
+	FindByCriteria(criteria SecurityEventCriteria) ([]domain.SecurityEvent, error)
+}
+
+type securityEventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSecurityEventRepository creates a new SecurityEventRepository instance
+func NewSecurityEventRepository(db *gorm.DB) SecurityEventRepository {
+	return &securityEventRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save appends an event to the audit log
+func (r *securityEventRepositoryImpl) Save(event domain.SecurityEvent) (domain.SecurityEvent, error) {
+	if err := r.db.Create(&event).Error; err != nil {
+		return domain.SecurityEvent{}, fmt.Errorf("failed to save security event: %w", err)
+	}
+	return event, nil
+}
+
+// FindByCriteria retrieves the events matching the criteria, newest first
+func (r *securityEventRepositoryImpl) FindByCriteria(criteria SecurityEventCriteria) ([]domain.SecurityEvent, error) {
+	query := r.db.Model(&domain.SecurityEvent{})
+	if criteria.Principal != "" {
+		query = query.Where("principal = ?", criteria.Principal)
+	}
+	if !criteria.AllTenants {
+		if criteria.TenantID == "" {
+			return []domain.SecurityEvent{}, nil
+		}
+		query = query.Where("tenant_id = ?", criteria.TenantID)
+	}
+	if criteria.Type != "" {
+		query = query.Where("type = ?", criteria.Type)
+	}
+	if !criteria.From.IsZero() {
+		query = query.Where("occurred_at >= ?", criteria.From)
+	}
+	if !criteria.To.IsZero() {
+		query = query.Where("occurred_at < ?", criteria.To)
+	}
+	if criteria.Limit > 0 {
+		query = query.Limit(criteria.Limit)
+	}
+
+	var events []domain.SecurityEvent
+	if err := query.Order("occurred_at DESC, id DESC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch security events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-samples/internal/cache"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository extends CrudRepository with the queries of the tags of greetings.
+// Tags are shared by all tenants, but a repository bound to a request with WithContext only lists the tags
+// of the greetings of the tenant of its user, and only counts these greetings.
+type TagRepository interface {
+	CrudRepository[domain.Tag, uint]
+	FindUsagePaged(pageable Pageable) (Page[domain.TagUsage], error)
+	FindUsageByID(id uint) (util.Optional[domain.TagUsage], error)
+	FindByName(name string) (util.Optional[domain.Tag], error)
+	Rename(id uint, name string) error
+	Merge(sourceIDs []uint, targetID uint) error
+	Transaction(fn func(TagRepository) error) error
+	WithContext(ctx context.Context) TagRepository
+}
+
+type tagRepositoryImpl struct {
+	*BaseRepository[domain.Tag, uint]
+}
+
+// NewTagRepository creates a new instance of TagRepository
+func NewTagRepository(db *gorm.DB, cacheManager *cache.CacheManager) TagRepository {
+	return &tagRepositoryImpl{
+		BaseRepository: NewBaseRepository[domain.Tag, uint](db, cacheManager, "tag"),
+	}
+}
+
+// Transaction runs fn with a repository bound to a database transaction, which is committed when fn returns nil
+// and rolled back otherwise
+func (r *tagRepositoryImpl) Transaction(fn func(TagRepository) error) error {
+	return r.transaction(func(tx *BaseRepository[domain.Tag, uint]) error {
+		return fn(&tagRepositoryImpl{BaseRepository: tx})
+	})
+}
+
+// WithContext returns a repository bound to ctx, see BaseRepository.withContext
+func (r *tagRepositoryImpl) WithContext(ctx context.Context) TagRepository {
+	return &tagRepositoryImpl{BaseRepository: r.withContext(ctx)}
+}
+
+// FindUsagePaged retrieves a page of the tags with the number of greetings tagged with them.
+// A repository restricted to a tenant only lists the tags of the greetings of the tenant, including the trash.
+func (r *tagRepositoryImpl) FindUsagePaged(pageable Pageable) (Page[domain.TagUsage], error) {
+	page := Page[domain.TagUsage]{
+		Content: []domain.TagUsage{},
+		Page:    pageable.Page,
+		Size:    pageable.Size,
+	}
+	if err := r.tenantTags(r.db.Model(&domain.Tag{})).Count(&page.TotalElements).Error; err != nil {
+		return Page[domain.TagUsage]{}, fmt.Errorf("failed to count tags: %w", err)
+	}
+	if page.TotalElements == 0 || int64(pageable.Offset()) >= page.TotalElements {
+		return page, nil
+	}
+
+	columns := make([]clause.OrderByColumn, 0, len(pageable.Sort)+1)
+	for _, order := range pageable.Sort {
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: order.Column}, Desc: order.Desc})
+	}
+	columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: "tag.id"}})
+
+	if err := r.tagUsage().
+		Order(clause.OrderBy{Columns: columns}).
+		Offset(pageable.Offset()).
+		Limit(pageable.Size).
+		Scan(&page.Content).Error; err != nil {
+		return Page[domain.TagUsage]{}, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	return page, nil
+}
+
+// FindUsageByID retrieves a tag by its ID with the number of greetings tagged with it
+func (r *tagRepositoryImpl) FindUsageByID(id uint) (util.Optional[domain.TagUsage], error) {
+	var usages []domain.TagUsage
+	if err := r.tagUsage().Where("tag.id = ?", id).Scan(&usages).Error; err != nil {
+		return util.Optional[domain.TagUsage]{}, fmt.Errorf("failed to fetch tag by ID: %w", err)
+	}
+	if len(usages) == 0 {
+		return util.EmptyOptional[domain.TagUsage](), nil
+	}
+	return util.Optional[domain.TagUsage]{Value: &usages[0]}, nil
+}
+
+// tagUsage selects the tags with the number of greetings tagged with them, not counting the trash
+// and, for a repository restricted to a tenant, the greetings of other tenants
+func (r *tagRepositoryImpl) tagUsage() *gorm.DB {
+	greetings := "LEFT JOIN greeting g ON g.id = gt.greeting_id AND g.deleted_at IS NULL"
+	var args []any
+	if r.tenant.restricted() {
+		greetings += " AND g." + domain.TenantColumn + " = ?"
+		args = append(args, r.tenant.tenantID)
+	}
+	return r.tenantTags(r.db.Table("tag").
+		Select("tag.*, COUNT(g.id) AS count").
+		Joins("LEFT JOIN greeting_tag gt ON gt.tag_id = tag.id").
+		Joins(greetings, args...).
+		Group("tag.id"))
+}
+
+// tenantTags restricts a query of tags to the tags of the greetings of the tenant, including the trash,
+// for a repository restricted to a tenant
+func (r *tagRepositoryImpl) tenantTags(db *gorm.DB) *gorm.DB {
+	if r.tenant.allTenants {
+		return db
+	}
+	return db.Where("EXISTS (SELECT 1 FROM greeting_tag tgt JOIN greeting tg ON tg.id = tgt.greeting_id "+
+		"WHERE tgt.tag_id = tag.id AND tg."+domain.TenantColumn+" = ?)", r.tenant.tenantID)
+}
+
+// FindByName retrieves a tag by its name
+func (r *tagRepositoryImpl) FindByName(name string) (util.Optional[domain.Tag], error) {
+	var tag domain.Tag
+	if err := r.db.Where("name = ?", name).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.EmptyOptional[domain.Tag](), nil
+		}
+		return util.Optional[domain.Tag]{}, fmt.Errorf("failed to fetch tag by name: %w", err)
+	}
+	return util.Optional[domain.Tag]{Value: &tag}, nil
+}
+
+// Rename renames a tag. The greetings tagged with it get a new version, as their representation changes.
+func (r *tagRepositoryImpl) Rename(id uint, name string) error {
+	return r.transaction(func(tx *BaseRepository[domain.Tag, uint]) error {
+		var greetingIDs []uint
+		if err := tx.db.Model(&domain.GreetingTag{}).Where("tag_id = ?", id).Pluck("greeting_id", &greetingIDs).Error; err != nil {
+			return fmt.Errorf("failed to fetch tagged greetings: %w", err)
+		}
+		if err := tx.db.Model(&domain.Tag{ID: id}).Update("name", name).Error; err != nil {
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+		tx.cacheEvict("", id)
+		return touchGreetings(taggedGreetings(tx), greetingIDs)
+	})
+}
+
+// Merge moves the greetings tagged with the source tags to the target tag and deletes the source tags.
+// The greetings tagged with a source tag get a new version, as their representation changes.
+func (r *tagRepositoryImpl) Merge(sourceIDs []uint, targetID uint) error {
+	return r.transaction(func(tx *BaseRepository[domain.Tag, uint]) error {
+		var greetingIDs []uint
+		if err := tx.db.Model(&domain.GreetingTag{}).Distinct().
+			Where("tag_id IN ?", sourceIDs).Pluck("greeting_id", &greetingIDs).Error; err != nil {
+			return fmt.Errorf("failed to fetch tagged greetings: %w", err)
+		}
+
+		// Greetings already tagged with the target tag keep a single one
+		if err := tx.db.Exec("INSERT INTO greeting_tag (greeting_id, tag_id) "+
+			"SELECT DISTINCT greeting_id, ? FROM greeting_tag WHERE tag_id IN ? ON CONFLICT DO NOTHING",
+			targetID, sourceIDs).Error; err != nil {
+			return fmt.Errorf("failed to move greeting tags: %w", err)
+		}
+		if err := tx.db.Where("tag_id IN ?", sourceIDs).Delete(&domain.GreetingTag{}).Error; err != nil {
+			return fmt.Errorf("failed to remove greeting tags: %w", err)
+		}
+		if err := tx.db.Where("id IN ?", sourceIDs).Delete(&domain.Tag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete merged tags: %w", err)
+		}
+		for _, id := range sourceIDs {
+			tx.cacheEvict("", id)
+		}
+		return touchGreetings(taggedGreetings(tx), greetingIDs)
+	})
+}
+
+// taggedGreetings returns a greeting repository sharing the transaction and tenant scope of a tag repository,
+// so the greetings changed along with their tags are removed from the cache of HelloRepository on commit
+func taggedGreetings(tx *BaseRepository[domain.Tag, uint]) *BaseRepository[domain.Greeting, uint] {
+	return &BaseRepository[domain.Greeting, uint]{
+		db:           tx.db,
+		cacheManager: tx.cacheManager,
+		cacheName:    greetingCacheName,
+		tenant:       tx.tenant,
+		afterCommit:  tx.afterCommit,
+	}
+}
+
+// touchGreetings increments the version and update time of the greetings, including those in the trash,
+// and removes them from the cache
+func touchGreetings(tx *BaseRepository[domain.Greeting, uint], ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var keys []greetingKey
+	if err := tx.db.Unscoped().Model(&domain.Greeting{}).Where("id IN ?", ids).
+		Select("id, " + domain.TenantColumn).Scan(&keys).Error; err != nil {
+		return fmt.Errorf("failed to fetch tagged greetings: %w", err)
+	}
+	if err := tx.db.Unscoped().Model(&domain.Greeting{}).Where("id IN ?", ids).Updates(map[string]any{
+		domain.VersionColumn: gorm.Expr(domain.VersionColumn + " + 1"),
+		"updated_at":         tx.db.NowFunc(),
+	}).Error; err != nil {
+		return fmt.Errorf("failed to update tagged greetings: %w", err)
+	}
+	for _, key := range keys {
+		tx.cacheEvict(key.TenantID, key.ID)
+	}
+	return nil
+}
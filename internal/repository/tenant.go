@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/security"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+)
+
+// allTenantsCacheKey takes the place of the tenant in the cache keys of entities read across tenants
+const allTenantsCacheKey = "*"
+
+// ErrNoTenant is returned when a repository without a tenant, see tenantScope, creates tenant data
+var ErrNoTenant = errors.New("repository is not bound to a tenant")
+
+// tenantScope binds a repository to the tenant of an authenticated user.
+// The zero value, e.g. of a repository never bound with withContext, has no tenant: it matches no entities
+// of any tenant and cannot create any, so a missing binding fails closed.
+type tenantScope struct {
+	tenantID   string // Tenant of the entities created through the repository, empty without one
+	allTenants bool   // Whether queries match the entities of all tenants
+}
+
+// newTenantScope returns the tenant scope of the authenticated user carried by ctx, see security.TenantFromContext
+func newTenantScope(ctx context.Context) tenantScope {
+	tenantID, allTenants := security.TenantFromContext(ctx)
+	return tenantScope{tenantID: tenantID, allTenants: allTenants}
+}
+
+// restricted reports whether queries only match the entities of the tenant of the scope
+func (s tenantScope) restricted() bool {
+	return !s.allTenants
+}
+
+// cacheTenant returns the tenant part of the cache keys of the entities read within the scope
+func (s tenantScope) cacheTenant() string {
+	if s.allTenants {
+		return allTenantsCacheKey
+	}
+	return s.tenantID
+}
+
+// newTenantID returns the tenant of the entities created within the scope, or ErrNoTenant without one
+func (s tenantScope) newTenantID() (string, error) {
+	if s.tenantID == "" {
+		return "", ErrNoTenant
+	}
+	return s.tenantID, nil
+}
+
+// withTenant restricts a query to the rows of the tenant of a restricted scope, whose tenant is held by the
+// tenant column of table, e.g. clause.CurrentTable or the alias of a joined table. A scope without a tenant
+// matches no rows.
+func (s tenantScope) withTenant(db *gorm.DB, table string) *gorm.DB {
+	if s.allTenants {
+		return db
+	}
+	return db.Where(clause.Eq{Column: clause.Column{Table: table, Name: domain.TenantColumn}, Value: s.tenantID})
+}
+
+// withContext returns a copy of the repository bound to ctx and restricted to the tenant of its authenticated user.
+// Super-administrators and system code, see security.ContextForAllTenants, are not restricted; contexts without
+// either have no tenant.
+func (r *BaseRepository[T, ID]) withContext(ctx context.Context) *BaseRepository[T, ID] {
+	scoped := *r
+	scoped.db = r.db.WithContext(ctx)
+	scoped.tenant = newTenantScope(ctx)
+	return &scoped
+}
+
+// scopedDB returns the database handle restricted to the tenant of the repository,
+// for entities embedding domain.TenantEntity
+func (r *BaseRepository[T, ID]) scopedDB() *gorm.DB {
+	if r.tenantField() == nil {
+		return r.db
+	}
+	return r.tenant.withTenant(r.db, clause.CurrentTable)
+}
+
+// tenantField returns the tenant field of the entity type, nil for entities without a tenant
+func (r *BaseRepository[T, ID]) tenantField() *schema.Field {
+	entitySchema, err := r.schema()
+	if err != nil {
+		return nil
+	}
+	return entitySchema.LookUpField(domain.TenantColumn)
+}
+
+// tenantOf returns the tenant of an entity, or the tenant of the repository when it has none yet
+func (r *BaseRepository[T, ID]) tenantOf(entity *T) string {
+	if field := r.tenantField(); field != nil {
+		if tenantID, _ := field.ValueOf(context.Background(), reflect.ValueOf(entity).Elem()); tenantID != "" {
+			return tenantID.(string)
+		}
+	}
+	return r.tenant.tenantID
+}
+
+// assignTenant puts a new entity into the tenant of the repository. A restricted repository always saves
+// entities to its tenant, so they cannot be moved to another tenant.
+func (r *BaseRepository[T, ID]) assignTenant(entity *T) error {
+	field := r.tenantField()
+	if field == nil {
+		return nil
+	}
+	tenantID := r.tenantOf(entity)
+	if r.tenant.restricted() || tenantID == "" {
+		var err error
+		if tenantID, err = r.tenant.newTenantID(); err != nil {
+			return err
+		}
+	}
+	if err := field.Set(context.Background(), reflect.ValueOf(entity).Elem(), tenantID); err != nil {
+		return fmt.Errorf("failed to set entity tenant: %w", err)
+	}
+	return nil
+}
+
+// cacheKey builds the key an entity of a tenant is cached under. Keys of tenant entities include the tenant,
+// or allTenantsCacheKey for entities read across tenants, so a tenant is never served the entity of another.
+func (r *BaseRepository[T, ID]) cacheKey(tenantID string, id any) string {
+	if r.tenantField() == nil {
+		return fmt.Sprintf("%s:%v", r.cacheName, id)
+	}
+	return fmt.Sprintf("%s:%s:%v", r.cacheName, tenantID, id)
+}
+
+// readCacheKey builds the key an entity read through the repository is cached under
+func (r *BaseRepository[T, ID]) readCacheKey(id any) string {
+	return r.cacheKey(r.tenant.cacheTenant(), id)
+}
+
+// cacheStore caches a written entity under the key of its tenant, and evicts the copy read across tenants
+func (r *BaseRepository[T, ID]) cacheStore(entity *T) {
+	id := (*entity).GetID()
+	r.cacheSet(r.cacheKey(r.tenantOf(entity), id), entity)
+	if r.tenantField() != nil {
+		r.cacheDelete(r.cacheKey(allTenantsCacheKey, id))
+	}
+}
+
+// cacheEvict removes an entity of a tenant from the cache, including the copy read across tenants
+func (r *BaseRepository[T, ID]) cacheEvict(tenantID string, id any) {
+	r.cacheDelete(r.cacheKey(tenantID, id))
+	if r.tenantField() != nil {
+		r.cacheDelete(r.cacheKey(allTenantsCacheKey, id))
+	}
+}
@@ -0,0 +1,339 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gin-samples/internal/cache"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/security"
+	"github.com/dgraph-io/ristretto"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file" // File source driver
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+)
+
+// tenantFixture holds repositories over an in-memory database with the greetings and users of two tenants
+type tenantFixture struct {
+	db        *gorm.DB
+	cache     *ristretto.Cache
+	hello     HelloRepository
+	revisions GreetingRevisionRepository
+	pins      GreetingPinRepository
+	users     UserRepository
+	acme      domain.Greeting // Greeting of the acme tenant
+	globex    domain.Greeting // Greeting of the globex tenant
+	message   string          // Message shared by the greetings of both tenants
+}
+
+// newTenantFixture migrates an in-memory database and saves a greeting and a user to each of two tenants
+func newTenantFixture(t *testing.T) *tenantFixture {
+	t.Helper()
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// Every connection opens another in-memory database
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	driver, err := sqlite3.WithInstance(sqlDB, &sqlite3.Config{})
+	require.NoError(t, err)
+	m, err := migrate.NewWithDatabaseInstance("file://../../resources/db/migrations", "sqlite3", driver)
+	require.NoError(t, err)
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	ristrettoCache, err := ristretto.NewCache(&ristretto.Config{NumCounters: 1e4, MaxCost: 1 << 20, BufferItems: 64})
+	require.NoError(t, err)
+	cacheManager := cache.NewCacheManager(ristrettoCache)
+
+	f := &tenantFixture{
+		db:        db,
+		cache:     ristrettoCache,
+		hello:     NewHelloRepository(db, cacheManager),
+		revisions: NewGreetingRevisionRepository(db, cacheManager),
+		pins:      NewGreetingPinRepository(db, cacheManager),
+		users:     NewUserRepository(db, cacheManager),
+		message:   "Hello, Tenant!",
+	}
+	f.acme = f.saveGreeting(t, "acme")
+	f.globex = f.saveGreeting(t, "globex")
+	for _, tenantID := range []string{"acme", "globex"} {
+		_, err := f.users.WithContext(userContext(tenantID)).Save(domain.User{
+			ID:       tenantID + "-user",
+			Username: tenantID + "-user",
+			Password: "secret",
+			Email:    tenantID + "@example.com",
+			Enabled:  true,
+		})
+		require.NoError(t, err)
+	}
+	return f
+}
+
+// saveGreeting saves a greeting with the shared message through a repository bound to a user of the tenant
+func (f *tenantFixture) saveGreeting(t *testing.T, tenantID string) domain.Greeting {
+	t.Helper()
+	greeting, err := f.hello.WithContext(userContext(tenantID)).Save(domain.Greeting{
+		Message: f.message,
+		Locale:  "en",
+		Status:  domain.GreetingStatusApproved,
+	})
+	require.NoError(t, err)
+	require.Equal(t, tenantID, greeting.TenantID)
+	return greeting
+}
+
+// userContext returns a context carrying the claims of a user of the tenant
+func userContext(tenantID string) context.Context {
+	return security.ContextWithClaims(context.Background(),
+		&security.TokenClaims{UserID: tenantID + "-user", TenantID: tenantID, Authorities: []string{"ROLE_USER"}})
+}
+
+// superAdminContext returns a context carrying the claims of a super-administrator of the default tenant
+func superAdminContext() context.Context {
+	return security.ContextWithClaims(context.Background(),
+		&security.TokenClaims{UserID: "super", Authorities: []string{security.AuthorityAdmin, security.AuthoritySuperAdmin}})
+}
+
+func TestTenantScope_FindByID(t *testing.T) {
+	f := newTenantFixture(t)
+
+	// Users only find the greetings of their tenant, also once the other tenant cached its own
+	for _, tenantID := range []string{"acme", "acme", "globex", "globex"} {
+		own, other := f.acme.ID, f.globex.ID
+		if tenantID == "globex" {
+			own, other = other, own
+		}
+		repo := f.hello.WithContext(userContext(tenantID))
+		greeting, err := repo.FindByID(own)
+		require.NoError(t, err)
+		assert.True(t, greeting.IsPresent())
+		greeting, err = repo.FindByID(other)
+		require.NoError(t, err)
+		assert.True(t, greeting.IsEmpty(), "%s found the greeting of another tenant", tenantID)
+		f.cache.Wait()
+	}
+
+	// Super-admins and system code find the greetings of every tenant
+	for _, ctx := range []context.Context{superAdminContext(), security.ContextForAllTenants(context.Background())} {
+		for _, id := range []uint{f.acme.ID, f.globex.ID} {
+			greeting, err := f.hello.WithContext(ctx).FindByID(id)
+			require.NoError(t, err)
+			assert.True(t, greeting.IsPresent())
+		}
+	}
+
+	// Repositories never bound to a context, and contexts without a user, find no greetings
+	for _, repo := range []HelloRepository{f.hello, f.hello.WithContext(context.Background())} {
+		greeting, err := repo.FindByID(f.acme.ID)
+		require.NoError(t, err)
+		assert.True(t, greeting.IsEmpty())
+	}
+}
+
+func TestTenantScope_FindAllPaged(t *testing.T) {
+	f := newTenantFixture(t)
+	pageable := Pageable{Size: 10, Sort: []SortOrder{{Column: "id"}}}
+
+	tests := []struct {
+		name string
+		repo HelloRepository
+		want []uint
+	}{
+		{"acme", f.hello.WithContext(userContext("acme")), []uint{f.acme.ID}},
+		{"globex", f.hello.WithContext(userContext("globex")), []uint{f.globex.ID}},
+		{"super-admin", f.hello.WithContext(superAdminContext()), []uint{f.acme.ID, f.globex.ID}},
+		{"all tenants", f.hello.WithContext(security.ContextForAllTenants(context.Background())), []uint{f.acme.ID, f.globex.ID}},
+		{"unbound", f.hello, nil},
+		{"anonymous", f.hello.WithContext(context.Background()), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.repo.FindAllPaged(pageable)
+			require.NoError(t, err)
+			var ids []uint
+			for _, greeting := range page.Content {
+				ids = append(ids, greeting.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+			assert.Equal(t, int64(len(tt.want)), page.TotalElements)
+		})
+	}
+}
+
+func TestTenantScope_ExistsByMessage(t *testing.T) {
+	f := newTenantFixture(t)
+	other := "Hello, Acme!"
+	_, err := f.hello.WithContext(userContext("acme")).Save(domain.Greeting{Message: other, Locale: "en",
+		Status: domain.GreetingStatusApproved})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		repo    HelloRepository
+		message string
+		want    bool
+	}{
+		{"shared message of acme", f.hello.WithContext(userContext("acme")), f.message, true},
+		{"own message of acme", f.hello.WithContext(userContext("acme")), other, true},
+		{"message of another tenant", f.hello.WithContext(userContext("globex")), other, false},
+		{"super-admin", f.hello.WithContext(superAdminContext()), other, true},
+		{"unbound", f.hello, f.message, false},
+		{"anonymous", f.hello.WithContext(context.Background()), f.message, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := tt.repo.ExistsByMessage(tt.message, "en")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, exists)
+		})
+	}
+}
+
+func TestTenantScope_FindRevisions(t *testing.T) {
+	f := newTenantFixture(t)
+	for _, greeting := range []domain.Greeting{f.acme, f.globex} {
+		_, err := f.hello.WithContext(userContext(greeting.TenantID)).SaveRevision(domain.GreetingRevision{
+			GreetingID: greeting.ID,
+			Action:     domain.GreetingRevisionCreate,
+			NewMessage: &greeting.Message,
+			Version:    1,
+			ChangedAt:  greeting.CreatedAt,
+		})
+		require.NoError(t, err)
+	}
+	// The revisions of greetings in the trash are still found by their tenant
+	require.NoError(t, f.hello.WithContext(userContext("globex")).Delete(f.globex))
+
+	tests := []struct {
+		name       string
+		repo       GreetingRevisionRepository
+		greetingID uint
+		want       bool
+	}{
+		{"own greeting", f.revisions.WithContext(userContext("acme")), f.acme.ID, true},
+		{"own greeting in the trash", f.revisions.WithContext(userContext("globex")), f.globex.ID, true},
+		{"greeting of another tenant", f.revisions.WithContext(userContext("acme")), f.globex.ID, false},
+		{"super-admin", f.revisions.WithContext(superAdminContext()), f.globex.ID, true},
+		{"unbound", f.revisions, f.acme.ID, false},
+		{"anonymous", f.revisions.WithContext(context.Background()), f.acme.ID, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.repo.FindPagedByGreeting(tt.greetingID, Pageable{Size: 10, Sort: []SortOrder{{Column: "revision"}}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, len(page.Content) == 1)
+
+			revision, err := tt.repo.FindByGreetingAndNumber(tt.greetingID, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, revision.IsPresent())
+		})
+	}
+}
+
+func TestTenantScope_FindByUsername(t *testing.T) {
+	f := newTenantFixture(t)
+
+	tests := []struct {
+		name     string
+		repo     UserRepository
+		username string
+		want     bool
+	}{
+		{"own user", f.users.WithContext(userContext("acme")), "acme-user", true},
+		{"user of another tenant", f.users.WithContext(userContext("globex")), "acme-user", false},
+		{"user of another tenant after a lookup", f.users.WithContext(userContext("acme")), "globex-user", false},
+		{"super-admin", f.users.WithContext(superAdminContext()), "globex-user", true},
+		{"login", f.users.WithContext(security.ContextForAllTenants(context.Background())), "acme-user", true},
+		{"unbound", f.users, "acme-user", false},
+		{"anonymous", f.users.WithContext(context.Background()), "acme-user", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Look the user up twice, so the second lookup is served by the cache
+			for range 2 {
+				user, err := tt.repo.FindByUsername(tt.username)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, user.IsPresent())
+				f.cache.Wait()
+			}
+		})
+	}
+}
+
+func TestTenantScope_Save(t *testing.T) {
+	f := newTenantFixture(t)
+
+	// Users cannot move greetings to another tenant
+	moved := f.acme
+	moved.TenantID = "globex"
+	saved, err := f.hello.WithContext(userContext("acme")).Save(moved)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", saved.TenantID)
+
+	// Without a tenant, greetings and pins cannot be created
+	allTenants := security.ContextForAllTenants(context.Background())
+	for _, repos := range []struct {
+		hello HelloRepository
+		pins  GreetingPinRepository
+	}{
+		{f.hello, f.pins},
+		{f.hello.WithContext(context.Background()), f.pins.WithContext(context.Background())},
+		{f.hello.WithContext(allTenants), f.pins.WithContext(allTenants)},
+	} {
+		_, err := repos.hello.Save(domain.Greeting{Message: "Hello, Nobody!", Locale: "en", Status: domain.GreetingStatusApproved})
+		assert.ErrorIs(t, err, ErrNoTenant)
+		_, err = repos.pins.SavePin(domain.GreetingPin{Date: "2025-01-01", GreetingID: f.acme.ID})
+		assert.ErrorIs(t, err, ErrNoTenant)
+	}
+}
+
+func TestTenantScope_Cache(t *testing.T) {
+	f := newTenantFixture(t)
+	superAdmin := f.hello.WithContext(superAdminContext())
+	acme := f.hello.WithContext(userContext("acme"))
+
+	// The super-admin caches the greeting of acme under the key of all tenants
+	greeting, err := superAdmin.FindByID(f.acme.ID)
+	require.NoError(t, err)
+	require.True(t, greeting.IsPresent())
+	f.cache.Wait()
+
+	// A write of the tenant evicts the copy read across tenants
+	changed := *greeting.Value
+	changed.Message = "Hello, Acme!"
+	_, err = acme.Save(changed)
+	require.NoError(t, err)
+	f.cache.Wait()
+
+	greeting, err = superAdmin.FindByID(f.acme.ID)
+	require.NoError(t, err)
+	require.True(t, greeting.IsPresent())
+	assert.Equal(t, "Hello, Acme!", greeting.Value.Message)
+	f.cache.Wait()
+
+	// Neither copy is served to another tenant
+	greeting, err = f.hello.WithContext(userContext("globex")).FindByID(f.acme.ID)
+	require.NoError(t, err)
+	assert.True(t, greeting.IsEmpty())
+
+	// Deleting the greeting evicts both copies
+	require.NoError(t, acme.DeleteByID(f.acme.ID))
+	f.cache.Wait()
+	for _, repo := range []HelloRepository{acme, superAdmin} {
+		greeting, err = repo.FindByID(f.acme.ID)
+		require.NoError(t, err)
+		assert.True(t, greeting.IsEmpty())
+	}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"gin-samples/internal/cache"
@@ -10,11 +11,13 @@ import (
 	"time"
 )
 
-// UserRepository defines additional methods for User-specific queries
+// UserRepository defines additional methods for User-specific queries.
+// A repository bound to a request with WithContext only sees the users of the tenant of its user.
 type UserRepository interface {
 	CrudRepository[domain.User, string]
 	FindByUsername(username string) (util.Optional[domain.User], error)
 	FindByEmail(email string) (util.Optional[domain.User], error)
+	WithContext(ctx context.Context) UserRepository
 }
 
 type userRepositoryImpl struct {
@@ -32,9 +35,16 @@ func NewUserRepository(db *gorm.DB, cacheManager *cache.CacheManager) UserReposi
 	}
 }
 
+// WithContext returns a repository bound to ctx and restricted to the tenant of its authenticated user,
+// see BaseRepository.withContext
+func (r *userRepositoryImpl) WithContext(ctx context.Context) UserRepository {
+	scoped := r.withContext(ctx)
+	return &userRepositoryImpl{BaseRepository: scoped, cacheManager: r.cacheManager, db: scoped.db}
+}
+
 // FindByUsername retrieves a user by their username, including roles and caches the result
 func (r *userRepositoryImpl) FindByUsername(username string) (util.Optional[domain.User], error) {
-	cacheKey := fmt.Sprintf("userByUsername:%s", username)
+	cacheKey := fmt.Sprintf("userByUsername:%s:%s", r.tenant.cacheTenant(), username)
 
 	// Check the cache first
 	if cachedValue, found := r.cacheManager.Get(cacheKey); found {
@@ -43,7 +53,7 @@ func (r *userRepositoryImpl) FindByUsername(username string) (util.Optional[doma
 
 	// If not in cache, query the database
 	var user domain.User
-	err := r.db.Preload("Roles.Role").Where("username = ?", username).First(&user).Error
+	err := r.scopedDB().Preload("Roles.Role").Where("username = ?", username).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return util.Optional[domain.User]{Value: nil}, nil
@@ -59,7 +69,7 @@ func (r *userRepositoryImpl) FindByUsername(username string) (util.Optional[doma
 
 // FindByEmail retrieves a user by their email without roles and caches the result
 func (r *userRepositoryImpl) FindByEmail(email string) (util.Optional[domain.User], error) {
-	cacheKey := fmt.Sprintf("userByEmail:%s", email)
+	cacheKey := fmt.Sprintf("userByEmail:%s:%s", r.tenant.cacheTenant(), email)
 
 	// Check the cache first
 	if cachedValue, found := r.cacheManager.Get(cacheKey); found {
@@ -68,7 +78,7 @@ func (r *userRepositoryImpl) FindByEmail(email string) (util.Optional[domain.Use
 
 	// If not in cache, query the database
 	var user domain.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.scopedDB().Where("email = ?", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return util.Optional[domain.User]{Value: nil}, nil
@@ -81,3 +91,10 @@ func (r *userRepositoryImpl) FindByEmail(email string) (util.Optional[domain.Use
 
 	return util.Optional[domain.User]{Value: &user}, nil
 }
+
+// WithRoles loads the roles of the listed users
+func WithRoles() Specification {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Roles.Role")
+	}
+}
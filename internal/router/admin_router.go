@@ -2,12 +2,52 @@ package router
 
 import (
 	"gin-samples/internal/controller"
+	"gin-samples/internal/middleware"
+	"gin-samples/internal/security"
 	"github.com/gin-gonic/gin"
 )
 
 // AddAdminRoutes sets up Admin-specific API routes
-func AddAdminRoutes(r *gin.RouterGroup, helloController controller.HelloController) {
+func AddAdminRoutes(r *gin.RouterGroup, helloController controller.HelloController,
+	dailyGreetingController controller.DailyGreetingController,
+	moderationController controller.ModerationController,
+	commentController controller.CommentController,
+	tagController controller.TagController,
+	securityEventController controller.SecurityEventController,
+	userController controller.UserController) {
 	// Admin-only route for /hello in the admin group
 	r.GET("/hello", helloController.Hello) // Admin users only (adminGroup)
+
+	// Security audit log
+	r.GET("/admin/security-events", securityEventController.GetSecurityEvents)
+
+	// Greeting trash
+	r.GET("/admin/hello/trash", helloController.GetDeletedGreetings)          // List deleted greetings
+	r.POST("/admin/hello/trash/:id/restore", helloController.RestoreGreeting) // Restore a deleted greeting
+	r.DELETE("/admin/hello/trash/:id", helloController.PurgeGreeting)         // Permanently delete a deleted greeting
+
+	// Greeting moderation
+	r.GET("/admin/hello/moderation", moderationController.GetModerationQueue)           // List greetings waiting for moderation
+	r.POST("/admin/hello/moderation/:id/approve", moderationController.ApproveGreeting) // Approve a greeting
+	r.POST("/admin/hello/moderation/:id/reject", moderationController.RejectGreeting)   // Reject a greeting with a reason
+
+	// Greeting comments
+	r.DELETE("/admin/hello/:id/comments/:commentId", commentController.RemoveComment) // Remove any comment on a greeting
+
+	// Greeting import and export
+	r.GET("/admin/hello/export", helloController.ExportGreetings)  // Stream greetings as JSON, NDJSON or CSV
+	r.POST("/admin/hello/import", helloController.ImportGreetings) // Import greetings from an uploaded file
+
+	// Greeting tags, which are shared by all tenants
+	superAdmin := middleware.AuthorityMiddleware(security.AuthoritySuperAdmin)
+	r.PUT("/admin/hello/tags/:id", superAdmin, tagController.RenameTag)    // Rename a tag
+	r.POST("/admin/hello/tags/merge", superAdmin, tagController.MergeTags) // Merge tags into one
+
+	// Greeting of the day
+	r.PUT("/admin/hello/daily/:date", dailyGreetingController.PinDailyGreeting)      // Pin the greeting of a date
+	r.DELETE("/admin/hello/daily/:date", dailyGreetingController.UnpinDailyGreeting) // Unpin the greeting of a date
+
+	// User management
+	r.GET("/admin/users", userController.GetUsers)
 	// You can add more admin-specific routes here
 }
@@ -9,4 +9,5 @@ import (
 // AddAuthRoutes adds authentication routes to the router
 func AddAuthRoutes(r *gin.Engine, authController controller.AuthenticationController) {
 	r.POST("/api/auth/login", authController.Login)
+	r.POST("/api/auth/logout", authController.Logout)
 }
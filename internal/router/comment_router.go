@@ -0,0 +1,14 @@
+package router
+
+import (
+	"gin-samples/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// AddCommentRoutes sets up the routes of the comments on greetings
+func AddCommentRoutes(r *gin.RouterGroup, commentController controller.CommentController) {
+	r.GET("/hello/:id/comments", commentController.GetComments)                 // List the comments on a greeting
+	r.POST("/hello/:id/comments", commentController.CreateComment)              // Comment on a greeting
+	r.PUT("/hello/:id/comments/:commentId", commentController.UpdateComment)    // Edit an own comment
+	r.DELETE("/hello/:id/comments/:commentId", commentController.DeleteComment) // Delete an own comment
+}
@@ -0,0 +1,11 @@
+package router
+
+import (
+	"gin-samples/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// AddDailyGreetingRoutes sets up the routes of the greeting of the day. Admins pin it with the admin routes.
+func AddDailyGreetingRoutes(r *gin.RouterGroup, dailyGreetingController controller.DailyGreetingController) {
+	r.GET("/hello/daily", dailyGreetingController.GetDailyGreeting) // Get the greeting of the day
+}
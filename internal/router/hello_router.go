@@ -2,15 +2,30 @@ package router
 
 import (
 	"gin-samples/internal/controller"
+	"gin-samples/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-// AddHelloRoutes sets up Hello API routes
+// AddHelloRoutes sets up Hello API routes.
+// When requireIfMatch is true, updates and deletes must carry an If-Match header, and bulk items a version.
 func AddHelloRoutes(r *gin.RouterGroup,
-	helloController controller.HelloController) {
-	r.GET("/hello/:id", helloController.GetGreetingByID)   // Get a greeting by ID
-	r.POST("/hello", helloController.CreateGreeting)       // Create a new greeting
-	r.GET("/hello/all", helloController.GetAllGreetings)   // Get all greetings
-	r.PUT("/hello/:id", helloController.UpdateGreeting)    // Update a greeting by ID
-	r.DELETE("/hello/:id", helloController.DeleteGreeting) // Delete a greeting by ID
+	helloController controller.HelloController,
+	requireIfMatch bool) {
+	ifMatch := middleware.IfMatchMiddleware(requireIfMatch)
+	itemVersion := middleware.ItemVersionMiddleware(requireIfMatch)
+
+	r.GET("/hello/:id", helloController.GetGreetingByID)             // Get a greeting by ID
+	r.GET("/hello/:id/render", helloController.RenderGreeting)       // Render a greeting for the caller
+	r.POST("/hello", helloController.CreateGreeting)                 // Create a new greeting
+	r.GET("/hello/all", helloController.GetAllGreetings)             // Get all greetings
+	r.GET("/hello/all/cursor", helloController.GetGreetingsByCursor) // Scroll through greetings with cursors
+	r.GET("/hello/search", helloController.SearchGreetings)          // Full-text search over greetings
+	r.GET("/hello/random", helloController.GetRandomGreeting)        // Get a random greeting
+	r.PUT("/hello/:id", ifMatch, helloController.UpdateGreeting)     // Update a greeting by ID
+	r.PATCH("/hello/:id", ifMatch, helloController.PatchGreeting)    // Patch a greeting by ID
+	r.DELETE("/hello/:id", ifMatch, helloController.DeleteGreeting)  // Delete a greeting by ID
+
+	r.POST("/hello/bulk", helloController.BulkCreateGreetings)                // Create greetings in bulk
+	r.PUT("/hello/bulk", itemVersion, helloController.BulkUpdateGreetings)    // Update greetings in bulk
+	r.DELETE("/hello/bulk", itemVersion, helloController.BulkDeleteGreetings) // Delete greetings in bulk
 }
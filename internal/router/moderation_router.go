@@ -0,0 +1,13 @@
+package router
+
+import (
+	"gin-samples/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// AddModerationRoutes sets up the moderation routes of the owners of greetings. Admins approve and reject
+// greetings with the admin routes.
+func AddModerationRoutes(r *gin.RouterGroup, moderationController controller.ModerationController) {
+	r.POST("/hello/:id/submit", moderationController.SubmitGreeting)   // Submit a greeting for moderation
+	r.POST("/hello/:id/archive", moderationController.ArchiveGreeting) // Archive a greeting
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"gin-samples/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// AddReactionRoutes sets up the routes of the reactions to greetings
+func AddReactionRoutes(r *gin.RouterGroup, reactionController controller.ReactionController) {
+	r.GET("/hello/top", reactionController.GetTopGreetings)                   // Rank greetings by their reactions
+	r.GET("/hello/:id/reactions", reactionController.GetReactions)            // Get the reactions to a greeting
+	r.PUT("/hello/:id/reactions/:type", reactionController.AddReaction)       // React to a greeting
+	r.DELETE("/hello/:id/reactions/:type", reactionController.RemoveReaction) // Remove a reaction from a greeting
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"gin-samples/internal/controller"
+	"gin-samples/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// AddRevisionRoutes sets up the routes of the revision history of greetings.
+// When requireIfMatch is true, rollbacks must carry an If-Match header.
+func AddRevisionRoutes(r *gin.RouterGroup,
+	revisionController controller.RevisionController,
+	requireIfMatch bool) {
+	ifMatch := middleware.IfMatchMiddleware(requireIfMatch)
+
+	r.GET("/hello/:id/revisions", revisionController.GetGreetingRevisions)                          // List the revisions of a greeting
+	r.GET("/hello/:id/revisions/diff", revisionController.DiffGreetingRevisions)                    // Compare two revisions of a greeting
+	r.POST("/hello/:id/revisions/:revision/rollback", ifMatch, revisionController.RollbackGreeting) // Roll back a greeting to a revision
+}
@@ -4,32 +4,73 @@ import (
 	"gin-samples/internal/controller"
 	"gin-samples/internal/middleware"
 	"gin-samples/internal/security"
+	"gin-samples/internal/service"
 	ut "github.com/go-playground/universal-translator"
 	swaggerFiles "github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"log"
 
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRouter(helloController controller.HelloController,
+	dailyGreetingController controller.DailyGreetingController,
+	revisionController controller.RevisionController,
+	moderationController controller.ModerationController,
+	commentController controller.CommentController,
+	tagController controller.TagController,
+	reactionController controller.ReactionController,
 	healthController controller.HealthController,
 	authController controller.AuthenticationController,
+	securityEventController controller.SecurityEventController,
+	userController controller.UserController,
+	auditService service.SecurityAuditService,
 	trans ut.Translator,
-	tokenGenerator security.TokenGenerator) *gin.Engine {
+	tokenGenerator security.TokenGenerator,
+	cookieOptions security.CookieOptions,
+	dpopVerifier security.DPoPVerifier,
+	requireIfMatch bool,
+	cacheControlPolicies map[string]string,
+	trustedProxies []string) *gin.Engine {
 	r := gin.Default()
+	// Only the configured proxies may set the client IP with forwarding headers
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
 	r.StaticFile("/favicon.ico", "./resources/favicons/favicon.ico")
+	r.Use(middleware.SecurityAuditMiddleware(auditService)) // Registered first to see the final response status
 	r.Use(middleware.ErrorHandlingMiddleware(trans))
+	r.Use(middleware.CacheControlMiddleware(cacheControlPolicies))
 	// Group for authenticated users (all users who have a valid JWT)
 	authenticatedGroup := r.Group("/api")
-	authenticatedGroup.Use(middleware.AuthMiddleware(tokenGenerator))
+	authenticatedGroup.Use(middleware.AuthMiddleware(tokenGenerator, cookieOptions, dpopVerifier))
 
 	// Create an admin-specific group with additional access controls (admin check)
 	adminGroup := r.Group("/api")
-	adminGroup.Use(middleware.AuthMiddleware(tokenGenerator))
-	adminGroup.Use(middleware.AuthorityMiddleware("ROLE_ADMIN")) // Ensures only admin has access to this group
+	adminGroup.Use(middleware.AuthMiddleware(tokenGenerator, cookieOptions, dpopVerifier))
+	adminGroup.Use(middleware.AuthorityMiddleware(security.AuthorityAdmin)) // Ensures only admin has access to this group
+	adminGroup.Use(middleware.AdminAuditMiddleware(auditService))
 
 	// Add Hello routes
-	AddHelloRoutes(authenticatedGroup, helloController)
+	AddHelloRoutes(authenticatedGroup, helloController, requireIfMatch)
+
+	// Add Daily Greeting routes
+	AddDailyGreetingRoutes(authenticatedGroup, dailyGreetingController)
+
+	// Add Revision routes
+	AddRevisionRoutes(authenticatedGroup, revisionController, requireIfMatch)
+
+	// Add Moderation routes
+	AddModerationRoutes(authenticatedGroup, moderationController)
+
+	// Add Comment routes
+	AddCommentRoutes(authenticatedGroup, commentController)
+
+	// Add Tag routes
+	AddTagRoutes(authenticatedGroup, tagController)
+
+	// Add Reaction routes
+	AddReactionRoutes(authenticatedGroup, reactionController)
 
 	// Add Health routes
 	AddHealthRoutes(r, healthController)
@@ -37,7 +78,8 @@ func SetupRouter(helloController controller.HelloController,
 	// Add Authentication routes
 	AddAuthRoutes(r, authController)
 
-	AddAdminRoutes(adminGroup, helloController)
+	AddAdminRoutes(adminGroup, helloController, dailyGreetingController, moderationController, commentController,
+		tagController, securityEventController, userController)
 
 	// Swagger route
 	r.GET("/swagger-ui/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
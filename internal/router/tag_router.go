@@ -0,0 +1,11 @@
+package router
+
+import (
+	"gin-samples/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// AddTagRoutes sets up the routes of the tags of greetings
+func AddTagRoutes(r *gin.RouterGroup, tagController controller.TagController) {
+	r.GET("/hello/tags", tagController.GetTags) // List tags with usage counts
+}
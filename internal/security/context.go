@@ -0,0 +1,54 @@
+package security
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"slices"
+)
+
+// AuthorityAdmin is the authority granted to administrators, who act within their tenant
+const AuthorityAdmin = "ROLE_ADMIN"
+
+// AuthoritySuperAdmin is the authority granted to super-administrators, who act across tenants
+const AuthoritySuperAdmin = "ROLE_SUPER_ADMIN"
+
+// claimsContextKey is the context key of the claims of the authenticated user
+type claimsContextKey struct{}
+
+// allTenantsContextKey is the context key marking system code acting across tenants, see ContextForAllTenants
+type allTenantsContextKey struct{}
+
+// ContextWithClaims returns a copy of ctx carrying the claims of the authenticated user
+func ContextWithClaims(ctx context.Context, claims *TokenClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the authenticated user carried by ctx, if any
+func ClaimsFromContext(ctx context.Context) (*TokenClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*TokenClaims)
+	return claims, ok && claims != nil
+}
+
+// HasAuthority reports whether the authenticated user carried by ctx was granted the authority
+func HasAuthority(ctx context.Context, authority string) bool {
+	claims, ok := ClaimsFromContext(ctx)
+	return ok && slices.Contains(claims.Authorities, authority)
+}
+
+// ContextForAllTenants returns a copy of ctx for system code acting across tenants without an authenticated user,
+// e.g. the login or scheduled jobs
+func ContextForAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsContextKey{}, true)
+}
+
+// TenantFromContext returns the tenant of the authenticated user carried by ctx, the default tenant for tokens
+// without one, and whether the user may act across tenants. Without an authenticated user, the tenant is empty
+// and access is denied to every tenant, unless ctx was created by ContextForAllTenants.
+func TenantFromContext(ctx context.Context) (tenantID string, allTenants bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		allTenants, _ = ctx.Value(allTenantsContextKey{}).(bool)
+		return "", allTenants
+	}
+	return domain.TenantOrDefault(claims.TenantID), slices.Contains(claims.Authorities, AuthoritySuperAdmin)
+}
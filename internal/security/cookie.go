@@ -0,0 +1,63 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+)
+
+// CookieOptions configures the HttpOnly cookie token mode and its CSRF protection
+type CookieOptions struct {
+	Enabled        bool
+	Name           string // Name of the HttpOnly cookie holding the access token
+	CSRFCookieName string // Name of the cookie exposing the CSRF token to the browser
+	CSRFHeaderName string // Name of the header the browser must echo the CSRF token in
+	Path           string
+	Domain         string
+	Secure         bool
+	SameSite       http.SameSite
+}
+
+// GenerateCSRFToken creates a random, URL safe CSRF token
+func GenerateCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate CSRF token: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// VerifyCSRFToken compares the CSRF token sent by the client with the one bound to the access token
+func VerifyCSRFToken(expected, actual string) bool {
+	if expected == "" || actual == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
+
+// SetTokenCookies writes the access token cookie and the readable CSRF cookie
+func SetTokenCookies(w http.ResponseWriter, opts CookieOptions, accessToken, csrfToken string, maxAge int) {
+	http.SetCookie(w, opts.cookie(opts.Name, accessToken, maxAge, true))
+	http.SetCookie(w, opts.cookie(opts.CSRFCookieName, csrfToken, maxAge, false))
+}
+
+// ClearTokenCookies expires the access token and CSRF cookies
+func ClearTokenCookies(w http.ResponseWriter, opts CookieOptions) {
+	http.SetCookie(w, opts.cookie(opts.Name, "", -1, true))
+	http.SetCookie(w, opts.cookie(opts.CSRFCookieName, "", -1, false))
+}
+
+func (o CookieOptions) cookie(name, value string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     o.Path,
+		Domain:   o.Domain,
+		MaxAge:   maxAge,
+		Secure:   o.Secure,
+		HttpOnly: httpOnly,
+		SameSite: o.SameSite,
+	}
+}
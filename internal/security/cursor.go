@@ -0,0 +1,73 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor is malformed or its signature does not match
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorCodec turns pagination state into opaque, tamper-proof cursors
+type CursorCodec interface {
+	Encode(payload any) (string, error)
+	Decode(cursor string, payload any) error
+}
+
+// hmacCursorCodec signs the JSON encoded payload with HMAC-SHA256
+type hmacCursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates a CursorCodec signing cursors with the given secret.
+// Without a secret, a random one is generated, so cursors do not survive a restart.
+func NewCursorCodec(secret []byte) (CursorCodec, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, errors.New("failed to generate cursor secret: " + err.Error())
+		}
+	}
+	return &hmacCursorCodec{secret: secret}, nil
+}
+
+// Encode returns the cursor in the form base64url(payload) "." base64url(signature)
+func (c *hmacCursorCodec) Encode(payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+// Decode verifies the signature of the cursor and unmarshals its payload
+func (c *hmacCursorCodec) Decode(cursor string, payload any) error {
+	encoded, signature, found := strings.Cut(cursor, ".")
+	if !found {
+		return ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return ErrInvalidCursor
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (c *hmacCursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,244 @@
+package security
+
+import (
+	"container/heap"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/util"
+	"github.com/go-jose/go-jose/v4"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DPoPProofHeader is the request header carrying the DPoP proof JWT
+const DPoPProofHeader = "DPoP"
+
+// dpopProofType is the required "typ" header of a DPoP proof
+const dpopProofType = "dpop+jwt"
+
+// dpopSignatureAlgorithms are the asymmetric algorithms accepted for DPoP proofs
+var dpopSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// Confirmation represents the "cnf" claim binding a token to a proof-of-possession key
+type Confirmation struct {
+	JKT string `json:"jkt"` // JWK SHA-256 thumbprint of the DPoP key
+}
+
+// dpopProofClaims represents the claims of a DPoP proof JWT
+type dpopProofClaims struct {
+	JTI         string `json:"jti"`
+	HTTPMethod  string `json:"htm"`
+	HTTPURI     string `json:"htu"`
+	IssuedAt    int64  `json:"iat"`
+	AccessToken string `json:"ath,omitempty"`
+}
+
+// DPoPVerifier verifies DPoP proofs as defined in RFC 9449
+type DPoPVerifier interface {
+	// Verify validates the proof for the given request and returns the JWK thumbprint of the proof key.
+	// When accessToken is not empty, the proof must contain its hash in the "ath" claim.
+	Verify(proof, method, requestURL, accessToken string) (string, error)
+
+	// RequestURL reconstructs the absolute URL of a request for comparison with the "htu" claim
+	RequestURL(r *http.Request) string
+}
+
+// maxReplayCacheEntries caps the proof identifiers remembered at once. Proofs arriving while the cache is full
+// are rejected, as forgetting unexpired identifiers would let their proofs be replayed.
+const maxReplayCacheEntries = 100_000
+
+type dpopVerifier struct {
+	maxAge         time.Duration
+	trustedProxies TrustedProxies
+	clock          util.Clock
+	replayCache    *jtiReplayCache
+}
+
+// NewDPoPVerifier creates a new DPoPVerifier accepting proofs issued within maxAge of the current time.
+// The request scheme is taken from X-Forwarded-Proto only for requests of the trusted proxies.
+func NewDPoPVerifier(maxAge time.Duration, trustedProxies TrustedProxies, clock util.Clock) DPoPVerifier {
+	return &dpopVerifier{
+		maxAge:         maxAge,
+		trustedProxies: trustedProxies,
+		clock:          clock,
+		replayCache:    newJtiReplayCache(maxReplayCacheEntries),
+	}
+}
+
+// Verify validates the DPoP proof and returns the JWK thumbprint of its key
+func (v *dpopVerifier) Verify(proof, method, requestURL, accessToken string) (string, error) {
+	signedObject, err := jose.ParseSigned(proof, dpopSignatureAlgorithms)
+	if err != nil {
+		return "", &customError.DPoPProofError{Message: "Failed to parse DPoP proof: " + err.Error()}
+	}
+	if len(signedObject.Signatures) != 1 {
+		return "", &customError.DPoPProofError{Message: "DPoP proof must have exactly one signature"}
+	}
+
+	header := signedObject.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType {
+		return "", &customError.DPoPProofError{Message: "DPoP proof has an invalid typ header"}
+	}
+	if header.JSONWebKey == nil || !header.JSONWebKey.IsPublic() {
+		return "", &customError.DPoPProofError{Message: "DPoP proof must contain a public jwk header"}
+	}
+
+	payload, err := signedObject.Verify(header.JSONWebKey)
+	if err != nil {
+		return "", &customError.DPoPProofError{Message: "Invalid DPoP proof signature"}
+	}
+
+	var claims dpopProofClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", &customError.DPoPProofError{Message: "Failed to deserialize DPoP proof claims: " + err.Error()}
+	}
+
+	if err := v.validateClaims(claims, method, requestURL, accessToken); err != nil {
+		return "", err
+	}
+
+	thumbprint, err := header.JSONWebKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", &customError.DPoPProofError{Message: "Failed to compute DPoP key thumbprint"}
+	}
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	// A proof can only be used once per key
+	now := v.clock.Now()
+	switch v.replayCache.add(jkt+":"+claims.JTI, now.Add(2*v.maxAge), now) {
+	case errProofReplayed:
+		return "", &customError.DPoPProofError{Message: "DPoP proof has already been used"}
+	case errReplayCacheFull:
+		return "", &customError.DPoPProofError{Message: "Too many DPoP proofs, try again later"}
+	}
+
+	return jkt, nil
+}
+
+func (v *dpopVerifier) validateClaims(claims dpopProofClaims, method, requestURL, accessToken string) error {
+	if claims.JTI == "" {
+		return &customError.DPoPProofError{Message: "DPoP proof is missing the jti claim"}
+	}
+	if claims.HTTPMethod != method {
+		return &customError.DPoPProofError{Message: "DPoP proof htm does not match the request method"}
+	}
+	if normalizeHTU(claims.HTTPURI) != normalizeHTU(requestURL) {
+		return &customError.DPoPProofError{Message: "DPoP proof htu does not match the request URL"}
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	now := v.clock.Now()
+	if issuedAt.Before(now.Add(-v.maxAge)) || issuedAt.After(now.Add(v.maxAge)) {
+		return &customError.DPoPProofError{Message: "DPoP proof iat is outside the acceptable window"}
+	}
+
+	if accessToken != "" && claims.AccessToken != AccessTokenHash(accessToken) {
+		return &customError.DPoPProofError{Message: "DPoP proof ath does not match the access token"}
+	}
+	return nil
+}
+
+// AccessTokenHash returns the "ath" value for an access token: the base64url encoded SHA-256 hash
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// RequestURL reconstructs the absolute URL of a request. The scheme honours X-Forwarded-Proto when the request
+// comes from a trusted TLS terminating proxy; any other client could claim https for a plain request.
+func (v *dpopVerifier) RequestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwardedProto := r.Header.Get("X-Forwarded-Proto"); forwardedProto != "" && v.trustedProxies.Contains(r.RemoteAddr) {
+		scheme = strings.TrimSpace(strings.Split(forwardedProto, ",")[0])
+	}
+	return scheme + "://" + r.Host + r.URL.EscapedPath()
+}
+
+// normalizeHTU strips the query and fragment and lowercases the scheme and host, as required by RFC 9449
+func normalizeHTU(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return strings.ToLower(parsed.Scheme) + "://" + strings.ToLower(parsed.Host) + parsed.EscapedPath()
+}
+
+// errProofReplayed and errReplayCacheFull are the reasons jtiReplayCache.add rejects a key
+var (
+	errProofReplayed   = errors.New("proof replayed")
+	errReplayCacheFull = errors.New("replay cache full")
+)
+
+// jtiReplayCache remembers the proof identifiers seen within their validity window, up to maxEntries at once.
+// Unlike the shared cache, entries are never evicted early, which replay detection relies on.
+// The expiry heap keeps the entry expiring first on top, so expired entries are removed without a full sweep.
+type jtiReplayCache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]time.Time
+	expiries   jtiExpiryHeap
+}
+
+func newJtiReplayCache(maxEntries int) *jtiReplayCache {
+	return &jtiReplayCache{maxEntries: maxEntries, entries: make(map[string]time.Time)}
+}
+
+// add stores the key until expiresAt. It returns errProofReplayed when the key is already present,
+// and errReplayCacheFull when the cache holds maxEntries unexpired keys.
+func (c *jtiReplayCache) add(key string, expiresAt, now time.Time) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for len(c.expiries) > 0 && now.After(c.expiries[0].expiresAt) {
+		delete(c.entries, heap.Pop(&c.expiries).(jtiExpiry).key)
+	}
+
+	if _, found := c.entries[key]; found {
+		return errProofReplayed
+	}
+	if len(c.entries) >= c.maxEntries {
+		return errReplayCacheFull
+	}
+	c.entries[key] = expiresAt
+	heap.Push(&c.expiries, jtiExpiry{key: key, expiresAt: expiresAt})
+	return nil
+}
+
+// jtiExpiry is the expiry time of a key of the jtiReplayCache
+type jtiExpiry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// jtiExpiryHeap orders the keys of the jtiReplayCache by expiry time, implementing heap.Interface
+type jtiExpiryHeap []jtiExpiry
+
+func (h jtiExpiryHeap) Len() int           { return len(h) }
+func (h jtiExpiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h jtiExpiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *jtiExpiryHeap) Push(x any) {
+	*h = append(*h, x.(jtiExpiry))
+}
+
+func (h *jtiExpiryHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
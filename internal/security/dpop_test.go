@@ -0,0 +1,144 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	customError "gin-samples/internal/error"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock returning a fixed time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// After never fires, as the time does not pass
+func (c fixedClock) After(time.Duration) <-chan time.Time {
+	return nil
+}
+
+func newDPoPProof(t *testing.T, key *ecdsa.PrivateKey, claims dpopProofClaims) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, &jose.SignerOptions{
+		EmbedJWK:     true,
+		ExtraHeaders: map[jose.HeaderKey]interface{}{jose.HeaderType: dpopProofType},
+	})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+
+	proof, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func TestDPoPVerifier_Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	fixedTime := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	verifier := NewDPoPVerifier(time.Minute, nil, fixedClock{now: fixedTime})
+	validClaims := dpopProofClaims{
+		JTI:         "proof-1",
+		HTTPMethod:  "GET",
+		HTTPURI:     "http://localhost:8080/api/hello/all",
+		IssuedAt:    fixedTime.Unix(),
+		AccessToken: AccessTokenHash("token"),
+	}
+
+	// A valid proof returns the key thumbprint
+	jkt, err := verifier.Verify(newDPoPProof(t, key, validClaims), "GET", "http://localhost:8080/api/hello/all?page=1", "token")
+	assert.NoError(t, err, "Valid proof should be accepted")
+	assert.NotEmpty(t, jkt, "Thumbprint should be returned")
+
+	// The same proof cannot be replayed
+	_, err = verifier.Verify(newDPoPProof(t, key, validClaims), "GET", "http://localhost:8080/api/hello/all", "token")
+	assert.ErrorContains(t, err, "already been used", "Replayed proof should be rejected")
+
+	tests := []struct {
+		name   string
+		mutate func(*dpopProofClaims)
+		reason string
+	}{
+		{"method", func(c *dpopProofClaims) { c.HTTPMethod = "POST" }, "htm"},
+		{"url", func(c *dpopProofClaims) { c.HTTPURI = "http://evil.example/api/hello/all" }, "htu"},
+		{"iat", func(c *dpopProofClaims) { c.IssuedAt = fixedTime.Add(-time.Hour).Unix() }, "iat"},
+		{"ath", func(c *dpopProofClaims) { c.AccessToken = AccessTokenHash("other") }, "ath"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims
+			claims.JTI = "invalid-" + string(rune('a'+i))
+			tt.mutate(&claims)
+
+			_, err := verifier.Verify(newDPoPProof(t, key, claims), "GET", "http://localhost:8080/api/hello/all", "token")
+
+			var proofErr *customError.DPoPProofError
+			assert.True(t, errors.As(err, &proofErr), "Error should be of type DPoPProofError")
+			assert.ErrorContains(t, err, tt.reason)
+		})
+	}
+}
+
+func TestJtiReplayCache_Add(t *testing.T) {
+	now := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	cache := newJtiReplayCache(2)
+
+	assert.NoError(t, cache.add("proof-1", now.Add(time.Minute), now))
+	assert.ErrorIs(t, cache.add("proof-1", now.Add(time.Minute), now), errProofReplayed)
+	assert.NoError(t, cache.add("proof-2", now.Add(2*time.Minute), now))
+
+	// A full cache rejects new proofs rather than forgetting unexpired ones
+	assert.ErrorIs(t, cache.add("proof-3", now.Add(time.Minute), now), errReplayCacheFull)
+
+	// Expired proofs make room again
+	later := now.Add(90 * time.Second)
+	assert.NoError(t, cache.add("proof-3", later.Add(time.Minute), later))
+	assert.ErrorIs(t, cache.add("proof-2", later.Add(time.Minute), later), errProofReplayed)
+	assert.Len(t, cache.entries, 2)
+	assert.Len(t, cache.expiries, 2)
+}
+
+func TestDPoPVerifier_RequestURL(t *testing.T) {
+	trustedProxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "::1"})
+	require.NoError(t, err)
+	verifier := NewDPoPVerifier(time.Minute, trustedProxies, fixedClock{})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		expected   string
+	}{
+		{"trusted proxy", "10.1.2.3:4567", "https://localhost:8080/api/hello"},
+		{"trusted IPv6 proxy", "[::1]:4567", "https://localhost:8080/api/hello"},
+		{"other client", "192.168.1.10:4567", "http://localhost:8080/api/hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/hello?page=1", nil)
+			request.RemoteAddr = tt.remoteAddr
+			request.Header.Set("X-Forwarded-Proto", "https")
+
+			assert.Equal(t, tt.expected, verifier.RequestURL(request))
+		})
+	}
+
+	_, err = ParseTrustedProxies([]string{"proxy.example"})
+	assert.Error(t, err, "Host names are not valid trusted proxies")
+}
@@ -12,13 +12,16 @@ import (
 
 // TokenClaims represents claims for the JWE token
 type TokenClaims struct {
-	UserID      string   `json:"sub"`
-	Authorities []string `json:"authorities"`
-	IssuedAt    int64    `json:"iat"`
-	ExpiresAt   int64    `json:"exp"`
-	NotBefore   int64    `json:"nbf"`
-	JTI         string   `json:"jti"`
-	Issuer      string   `json:"iss"`
+	UserID       string        `json:"sub"`
+	TenantID     string        `json:"tid,omitempty"` // Tenant of the user, the default tenant when empty
+	Authorities  []string      `json:"authorities"`
+	IssuedAt     int64         `json:"iat"`
+	ExpiresAt    int64         `json:"exp"`
+	NotBefore    int64         `json:"nbf"`
+	JTI          string        `json:"jti"`
+	Issuer       string        `json:"iss"`
+	CSRFToken    string        `json:"csrf,omitempty"` // CSRF token bound to the token in cookie mode
+	Confirmation *Confirmation `json:"cnf,omitempty"`  // DPoP key binding (RFC 9449)
 }
 
 // Token represents the JWE token structure
@@ -35,14 +38,16 @@ type TokenGenerator interface {
 }
 
 type tokenGenerator struct {
-	signKeyPair   *util.RSAKeyPair
-	encKeyPair    *util.RSAKeyPair
+	signKeyPair   *util.RSAKeyPairHolder
+	encKeyPair    *util.RSAKeyPairHolder
 	tokenDuration time.Duration
 	issuer        string
 }
 
-// NewTokenGenerator creates a new instance of TokenGenerator
-func NewTokenGenerator(signKeyPair, encKeyPair *util.RSAKeyPair, tokenDuration time.Duration, issuer string) TokenGenerator {
+// NewTokenGenerator creates a new instance of TokenGenerator.
+// The key pairs are read from their holders on every use, so rotated keys take effect immediately,
+// while tokens issued with the retired key pairs the holders retain stay valid.
+func NewTokenGenerator(signKeyPair, encKeyPair *util.RSAKeyPairHolder, tokenDuration time.Duration, issuer string) TokenGenerator {
 	return &tokenGenerator{
 		signKeyPair:   signKeyPair,
 		encKeyPair:    encKeyPair,
@@ -71,9 +76,15 @@ func (t *tokenGenerator) Generate(claims TokenClaims) (Token, error) {
 		return Token{}, err
 	}
 
+	// Sender-constrained tokens must be presented with the DPoP authorization scheme
+	tokenType := "Bearer"
+	if claims.Confirmation != nil {
+		tokenType = "DPoP"
+	}
+
 	return Token{
 		AccessToken: encryptedPayload,
-		TokenType:   "Bearer",
+		TokenType:   tokenType,
 		ExpiresIn:   int64(t.tokenDuration.Seconds()),
 	}, nil
 }
@@ -122,7 +133,7 @@ func (t *tokenGenerator) serializeClaims(claims TokenClaims) ([]byte, error) {
 }
 
 func (t *tokenGenerator) signClaims(claimsBytes []byte) (string, error) {
-	signingKey := jose.SigningKey{Algorithm: jose.RS256, Key: t.signKeyPair.PrivateKey}
+	signingKey := jose.SigningKey{Algorithm: jose.RS256, Key: t.signKeyPair.Get().PrivateKey}
 	signer, err := jose.NewSigner(signingKey, nil)
 	if err != nil {
 		return "", errors.New("failed to create signer: " + err.Error())
@@ -139,7 +150,7 @@ func (t *tokenGenerator) signClaims(claimsBytes []byte) (string, error) {
 func (t *tokenGenerator) encryptPayload(signedPayload string) (string, error) {
 	encrypter, err := jose.NewEncrypter(
 		jose.A256GCM,
-		jose.Recipient{Algorithm: jose.RSA_OAEP_256, Key: t.encKeyPair.PublicKey},
+		jose.Recipient{Algorithm: jose.RSA_OAEP_256, Key: t.encKeyPair.Get().PublicKey},
 		nil,
 	)
 	if err != nil {
@@ -167,7 +178,14 @@ func (t *tokenGenerator) decryptToken(tokenString string) ([]byte, error) {
 		return nil, &customError.JwtError{Message: "Failed to parse JWE: " + err.Error()}
 	}
 
-	return object.Decrypt(t.encKeyPair.PrivateKey)
+	// Tokens issued before a key rotation are encrypted for a retired key pair
+	for _, keyPair := range t.encKeyPair.KeyPairs(time.Now()) {
+		var decrypted []byte
+		if decrypted, err = object.Decrypt(keyPair.PrivateKey); err == nil {
+			return decrypted, nil
+		}
+	}
+	return nil, err
 }
 
 func (t *tokenGenerator) verifySignature(decryptedBytes []byte) ([]byte, error) {
@@ -179,7 +197,14 @@ func (t *tokenGenerator) verifySignature(decryptedBytes []byte) ([]byte, error)
 		return nil, &customError.JwtError{Message: "Failed to parse signed payload: " + err.Error()}
 	}
 
-	return signedObject.Verify(t.signKeyPair.PublicKey)
+	// Tokens issued before a key rotation are signed with a retired key pair
+	for _, keyPair := range t.signKeyPair.KeyPairs(time.Now()) {
+		var verified []byte
+		if verified, err = signedObject.Verify(keyPair.PublicKey); err == nil {
+			return verified, nil
+		}
+	}
+	return nil, err
 }
 
 func (t *tokenGenerator) deserializeClaims(verifiedBytes []byte) (*TokenClaims, error) {
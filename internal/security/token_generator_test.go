@@ -0,0 +1,48 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newRSAKeyPair(t *testing.T) *util.RSAKeyPair {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return &util.RSAKeyPair{PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}
+}
+
+func TestTokenGenerator_ValidateAfterKeyRotation(t *testing.T) {
+	signHolder := util.NewRSAKeyPairHolder(newRSAKeyPair(t))
+	encHolder := util.NewRSAKeyPairHolder(newRSAKeyPair(t))
+	generator := NewTokenGenerator(signHolder, encHolder, time.Hour, "issuer")
+
+	token, err := generator.Generate(TokenClaims{UserID: "1"})
+	require.NoError(t, err)
+
+	// Tokens issued before the rotation stay valid while the replaced keys are retained
+	signHolder.Rotate(newRSAKeyPair(t), time.Now(), time.Hour)
+	encHolder.Rotate(newRSAKeyPair(t), time.Now(), time.Hour)
+	claims, err := generator.Validate(token.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", claims.UserID)
+
+	rotated, err := generator.Generate(TokenClaims{UserID: "2"})
+	require.NoError(t, err)
+	claims, err = generator.Validate(rotated.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", claims.UserID)
+
+	// Once the retention has passed, or the keys are replaced outright, they are no longer accepted
+	signHolder.Rotate(newRSAKeyPair(t), time.Now(), -time.Second)
+	_, err = generator.Validate(rotated.AccessToken)
+	assert.Error(t, err)
+
+	encHolder.Set(newRSAKeyPair(t))
+	_, err = generator.Validate(token.AccessToken)
+	assert.Error(t, err)
+}
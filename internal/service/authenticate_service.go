@@ -1,73 +1,134 @@
 package service
 
 import (
+	"errors"
 	"gin-samples/internal/dto"
 	customError "gin-samples/internal/error"
-	"gin-samples/internal/repository"
 	"gin-samples/internal/security"
-	"golang.org/x/crypto/bcrypt"
+	"log"
 )
 
 // AuthenticationService defines the authentication service interface
 type AuthenticationService interface {
-	Authenticate(input dto.LoginInput) (dto.TokenResponse, error)
+	Authenticate(input dto.LoginInput, dpopJkt string) (dto.TokenResponse, *AuthenticatedPrincipal, error)
 }
 
 type authenticationServiceImpl struct {
-	userRepository repository.UserRepository
+	providers      []AuthenticationProvider
 	tokenGenerator security.TokenGenerator
+	bindCSRFToken  bool
 }
 
-// NewAuthenticationService creates a new instance of AuthenticationService
-func NewAuthenticationService(userRepo repository.UserRepository, tokenGen security.TokenGenerator) AuthenticationService {
+// NewAuthenticationService creates a new instance of AuthenticationService.
+// Providers are consulted in the given order; the first one accepting the credentials authenticates the user.
+// When bindCSRFToken is true, every issued token carries a CSRF token for the cookie token mode.
+func NewAuthenticationService(providers []AuthenticationProvider, tokenGen security.TokenGenerator, bindCSRFToken bool) AuthenticationService {
 	return &authenticationServiceImpl{
-		userRepository: userRepo,
+		providers:      providers,
 		tokenGenerator: tokenGen,
+		bindCSRFToken:  bindCSRFToken,
 	}
 }
 
-// Authenticate validates the login credentials and returns a TokenResponse
-func (s *authenticationServiceImpl) Authenticate(input dto.LoginInput) (dto.TokenResponse, error) {
-	// Check if the user exists by username
-	userOptional, err := s.userRepository.FindByUsername(input.Username)
+// Authenticate validates the login credentials and returns a TokenResponse along with the authenticated principal.
+// When dpopJkt is not empty, the token is bound to the DPoP key with that thumbprint.
+func (s *authenticationServiceImpl) Authenticate(input dto.LoginInput,
+	dpopJkt string) (dto.TokenResponse, *AuthenticatedPrincipal, error) {
+	principal, err := s.authenticate(input.Username, input.Password)
 	if err != nil {
-		return dto.TokenResponse{}, err
+		return dto.TokenResponse{}, nil, err
 	}
 
-	if userOptional.IsEmpty() {
-		return dto.TokenResponse{}, &customError.InvalidCredentialsError{}
-	}
-
-	user := userOptional.Value
+	authorities := s.mergeAuthorities(principal)
 
-	// Check if the user is enabled
-	if !user.Enabled {
-		return dto.TokenResponse{}, &customError.InvalidCredentialsError{}
+	// Bind a CSRF token to the access token for the cookie token mode
+	var csrfToken string
+	if s.bindCSRFToken {
+		csrfToken, err = security.GenerateCSRFToken()
+		if err != nil {
+			return dto.TokenResponse{}, nil, err
+		}
 	}
 
-	// Validate the password using bcrypt
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password))
-	if err != nil {
-		return dto.TokenResponse{}, &customError.InvalidCredentialsError{}
-	}
-
-	var authorities []string
-	for _, roleMapping := range user.Roles {
-		authorities = append(authorities, roleMapping.Role.Name)
+	// Bind the token to the client's DPoP key
+	var confirmation *security.Confirmation
+	if dpopJkt != "" {
+		confirmation = &security.Confirmation{JKT: dpopJkt}
 	}
 
 	// Generate token using TokenGenerator
 	token, err := s.tokenGenerator.Generate(security.TokenClaims{
-		UserID:      user.ID,
-		Authorities: authorities,
+		UserID:       principal.UserID,
+		TenantID:     principal.TenantID,
+		Authorities:  authorities,
+		CSRFToken:    csrfToken,
+		Confirmation: confirmation,
 	})
 	if err != nil {
-		return dto.TokenResponse{}, err
+		return dto.TokenResponse{}, nil, err
 	}
 
 	return dto.TokenResponse{
 		AccessToken:          token.AccessToken,
 		TokenType:            token.TokenType,
 		AccessTokenExpiresIn: token.ExpiresIn,
-	}, nil
+		CsrfToken:            csrfToken,
+	}, principal, nil
+}
+
+// authenticate walks the provider chain until one of them accepts the credentials.
+// A failing provider does not stop the chain, so file based break-glass users keep working when the database is down.
+// When all providers reject the credentials, the most specific reason is reported.
+func (s *authenticationServiceImpl) authenticate(username, password string) (*AuthenticatedPrincipal, error) {
+	rejection := &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonUserNotFound}
+	var lastErr error
+	for _, provider := range s.providers {
+		principal, err := provider.Authenticate(username, password)
+		var invalidCredentialsErr *customError.InvalidCredentialsError
+		switch {
+		case errors.As(err, &invalidCredentialsErr):
+			if invalidCredentialsErr.Reason != customError.ReasonUserNotFound {
+				rejection = invalidCredentialsErr
+			}
+		case err != nil:
+			log.Printf("Authentication provider %s failed: %v", provider.Name(), err)
+			lastErr = err
+		case principal != nil:
+			return principal, nil
+		}
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, rejection
+}
+
+// mergeAuthorities combines the authorities of the authenticating provider with those granted by the other providers
+// to the user of the same name and tenant, so a user of another tenant never lends its roles
+func (s *authenticationServiceImpl) mergeAuthorities(principal *AuthenticatedPrincipal) []string {
+	seen := make(map[string]bool)
+	var authorities []string
+	add := func(values []string) {
+		for _, authority := range values {
+			if !seen[authority] {
+				seen[authority] = true
+				authorities = append(authorities, authority)
+			}
+		}
+	}
+
+	add(principal.Authorities)
+	for _, provider := range s.providers {
+		if provider.Name() == principal.Provider {
+			continue
+		}
+		values, err := provider.LoadAuthorities(principal.Username, principal.TenantID)
+		if err != nil {
+			log.Printf("Authentication provider %s failed to load authorities: %v", provider.Name(), err)
+			continue
+		}
+		add(values)
+	}
+	return authorities
 }
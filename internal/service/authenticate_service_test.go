@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// MockAuthenticationProvider simulates an AuthenticationProvider
+type MockAuthenticationProvider struct {
+	mock.Mock
+	name string
+}
+
+func (m *MockAuthenticationProvider) Name() string {
+	return m.name
+}
+
+func (m *MockAuthenticationProvider) Authenticate(username, password string) (*AuthenticatedPrincipal, error) {
+	args := m.Called(username, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AuthenticatedPrincipal), args.Error(1)
+}
+
+func (m *MockAuthenticationProvider) LoadAuthorities(username, tenantID string) ([]string, error) {
+	args := m.Called(username, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockTokenGenerator simulates the TokenGenerator
+type MockTokenGenerator struct {
+	mock.Mock
+}
+
+func (m *MockTokenGenerator) Generate(claims security.TokenClaims) (security.Token, error) {
+	args := m.Called(claims)
+	return args.Get(0).(security.Token), args.Error(1)
+}
+
+func (m *MockTokenGenerator) Validate(tokenString string) (*security.TokenClaims, error) {
+	args := m.Called(tokenString)
+	return args.Get(0).(*security.TokenClaims), args.Error(1)
+}
+
+func TestAuthenticationService_Authenticate_MergesAuthorities(t *testing.T) {
+	dbProvider := &MockAuthenticationProvider{name: "db"}
+	staticProvider := &MockAuthenticationProvider{name: "static"}
+	mockTokenGenerator := new(MockTokenGenerator)
+
+	input := dto.LoginInput{Username: "admin", Password: "password"}
+	dbProvider.On("Authenticate", "admin", "password").Return(&AuthenticatedPrincipal{
+		UserID: "1", Username: "admin", TenantID: "acme", Authorities: []string{"ROLE_USER"}, Provider: "db",
+	}, nil)
+	staticProvider.On("LoadAuthorities", "admin", "acme").Return([]string{"ROLE_ADMIN", "ROLE_USER"}, nil)
+	mockTokenGenerator.On("Generate", security.TokenClaims{
+		UserID:      "1",
+		TenantID:    "acme",
+		Authorities: []string{"ROLE_USER", "ROLE_ADMIN"},
+	}).Return(security.Token{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 3600}, nil)
+
+	service := NewAuthenticationService([]AuthenticationProvider{dbProvider, staticProvider}, mockTokenGenerator, false)
+
+	actual, principal, err := service.Authenticate(input, "")
+
+	assert.NoError(t, err, "There should be no error")
+	assert.Equal(t, dto.TokenResponse{AccessToken: "token", TokenType: "Bearer", AccessTokenExpiresIn: 3600}, actual)
+	assert.Equal(t, "1", principal.UserID)
+
+	dbProvider.AssertExpectations(t)
+	staticProvider.AssertExpectations(t)
+	mockTokenGenerator.AssertExpectations(t)
+}
+
+func TestAuthenticationService_Authenticate_MergesAuthoritiesOfSameTenant(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	usersFile := filepath.Join(t.TempDir(), "users.yaml")
+	assert.NoError(t, os.WriteFile(usersFile, []byte(fmt.Sprintf(`users:
+  - username: admin
+    password: %[1]s
+    tenant: globex
+    roles: [ROLE_ADMIN]
+  - username: user
+    password: %[1]s
+    roles: [ROLE_ADMIN]
+`, hash)), 0o600))
+	staticProvider, err := NewStaticUsersAuthenticationProvider(usersFile)
+	assert.NoError(t, err)
+
+	dbProvider := &MockAuthenticationProvider{name: "db"}
+	mockTokenGenerator := new(MockTokenGenerator)
+
+	// The static admin belongs to another tenant, so its roles are not merged
+	dbProvider.On("Authenticate", "admin", "password").Return(&AuthenticatedPrincipal{
+		UserID: "1", Username: "admin", TenantID: "acme", Authorities: []string{"ROLE_USER"}, Provider: "db",
+	}, nil)
+	mockTokenGenerator.On("Generate", security.TokenClaims{
+		UserID:      "1",
+		TenantID:    "acme",
+		Authorities: []string{"ROLE_USER"},
+	}).Return(security.Token{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 3600}, nil)
+
+	// The static user without a tenant shares the default tenant
+	dbProvider.On("Authenticate", "user", "password").Return(&AuthenticatedPrincipal{
+		UserID: "2", Username: "user", TenantID: "default", Authorities: []string{"ROLE_USER"}, Provider: "db",
+	}, nil)
+	mockTokenGenerator.On("Generate", security.TokenClaims{
+		UserID:      "2",
+		TenantID:    "default",
+		Authorities: []string{"ROLE_USER", "ROLE_ADMIN"},
+	}).Return(security.Token{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 3600}, nil)
+
+	service := NewAuthenticationService([]AuthenticationProvider{dbProvider, staticProvider}, mockTokenGenerator, false)
+
+	_, _, err = service.Authenticate(dto.LoginInput{Username: "admin", Password: "password"}, "")
+	assert.NoError(t, err)
+	_, _, err = service.Authenticate(dto.LoginInput{Username: "user", Password: "password"}, "")
+	assert.NoError(t, err)
+
+	mockTokenGenerator.AssertExpectations(t)
+}
+
+func TestAuthenticationService_Authenticate_FallsBackWhenProviderFails(t *testing.T) {
+	dbProvider := &MockAuthenticationProvider{name: "db"}
+	htpasswdProvider := &MockAuthenticationProvider{name: "htpasswd"}
+	mockTokenGenerator := new(MockTokenGenerator)
+
+	dbProvider.On("Authenticate", "breakglass", "secret").Return(nil, errors.New("database error"))
+	dbProvider.On("LoadAuthorities", "breakglass", "").Return(nil, errors.New("database error"))
+	htpasswdProvider.On("Authenticate", "breakglass", "secret").Return(&AuthenticatedPrincipal{
+		UserID: "htpasswd:breakglass", Username: "breakglass", Authorities: []string{"ROLE_ADMIN"}, Provider: "htpasswd",
+	}, nil)
+	mockTokenGenerator.On("Generate", security.TokenClaims{
+		UserID:      "htpasswd:breakglass",
+		Authorities: []string{"ROLE_ADMIN"},
+	}).Return(security.Token{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 3600}, nil)
+
+	service := NewAuthenticationService([]AuthenticationProvider{dbProvider, htpasswdProvider}, mockTokenGenerator, false)
+
+	_, _, err := service.Authenticate(dto.LoginInput{Username: "breakglass", Password: "secret"}, "")
+
+	assert.NoError(t, err, "Break-glass user should authenticate while the database is down")
+	mockTokenGenerator.AssertExpectations(t)
+}
+
+func TestAuthenticationService_Authenticate_InvalidCredentials(t *testing.T) {
+	dbProvider := &MockAuthenticationProvider{name: "db"}
+	dbProvider.On("Authenticate", "user", "wrong").Return(nil, nil)
+
+	service := NewAuthenticationService([]AuthenticationProvider{dbProvider}, new(MockTokenGenerator), false)
+
+	_, _, err := service.Authenticate(dto.LoginInput{Username: "user", Password: "wrong"}, "")
+
+	assert.IsType(t, &customError.InvalidCredentialsError{}, err, "Error should be of type InvalidCredentialsError")
+	dbProvider.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"gin-samples/internal/domain"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AuthenticatedPrincipal represents a user authenticated by an AuthenticationProvider
+type AuthenticatedPrincipal struct {
+	UserID      string
+	Username    string
+	TenantID    string // Tenant of the user, the default tenant when empty
+	Authorities []string
+	Provider    string
+}
+
+// AuthenticationProvider authenticates users against a single credential store
+type AuthenticationProvider interface {
+	// Name returns the name used to enable and order the provider
+	Name() string
+
+	// Authenticate verifies the credentials. It returns an InvalidCredentialsError with the reason
+	// when the user is unknown to the provider, disabled or the credentials do not match.
+	Authenticate(username, password string) (*AuthenticatedPrincipal, error)
+
+	// LoadAuthorities returns the authorities the provider grants to the user of the tenant,
+	// or nil when the user is unknown to the provider or belongs to another tenant.
+	LoadAuthorities(username, tenantID string) ([]string, error)
+}
+
+// dbAuthenticationProvider authenticates users stored in the database with bcrypt hashed passwords
+type dbAuthenticationProvider struct {
+	userRepository repository.UserRepository
+}
+
+// NewDBAuthenticationProvider creates a new AuthenticationProvider backed by the UserRepository.
+// Users log in before their tenant is known, so they are looked up across all tenants.
+func NewDBAuthenticationProvider(userRepo repository.UserRepository) AuthenticationProvider {
+	return &dbAuthenticationProvider{
+		userRepository: userRepo,
+	}
+}
+
+func (p *dbAuthenticationProvider) Name() string {
+	return "db"
+}
+
+// Authenticate validates the credentials against the user table
+func (p *dbAuthenticationProvider) Authenticate(username, password string) (*AuthenticatedPrincipal, error) {
+	// Check if the user exists by username
+	userOptional, err := p.userRepository.WithContext(allTenantsContext).FindByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if userOptional.IsEmpty() {
+		return nil, &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonUserNotFound}
+	}
+
+	user := userOptional.Value
+
+	// Check if the user is enabled
+	if !user.Enabled {
+		return nil, &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonUserDisabled}
+	}
+
+	// Validate the password using bcrypt
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonBadCredentials}
+	}
+
+	var authorities []string
+	for _, roleMapping := range user.Roles {
+		authorities = append(authorities, roleMapping.Role.Name)
+	}
+
+	return &AuthenticatedPrincipal{
+		UserID:      user.ID,
+		Username:    user.Username,
+		TenantID:    user.TenantID,
+		Authorities: authorities,
+		Provider:    p.Name(),
+	}, nil
+}
+
+// LoadAuthorities returns the roles assigned to an enabled user of the tenant in the database
+func (p *dbAuthenticationProvider) LoadAuthorities(username, tenantID string) ([]string, error) {
+	userOptional, err := p.userRepository.WithContext(allTenantsContext).FindByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if userOptional.IsEmpty() || !userOptional.Value.Enabled ||
+		domain.TenantOrDefault(userOptional.Value.TenantID) != domain.TenantOrDefault(tenantID) {
+		return nil, nil
+	}
+
+	var authorities []string
+	for _, roleMapping := range userOptional.Value.Roles {
+		authorities = append(authorities, roleMapping.Role.Name)
+	}
+	return authorities, nil
+}
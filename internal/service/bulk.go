@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+)
+
+// BulkResult is the outcome of one item of a bulk operation: the value of an applied item or the error of a failed one
+type BulkResult[R any] struct {
+	Value R
+	Err   error
+}
+
+// transactional is implemented by repositories which run functions in nested transactions
+type transactional[Repo any] interface {
+	Transaction(fn func(Repo) error) error
+}
+
+// errBulkRollback rolls back an all-or-nothing bulk operation having failed items
+var errBulkRollback = errors.New("bulk operation has failed items")
+
+// runBulk validates the items and applies them in one transaction, each in its own savepoint.
+// In all-or-nothing mode, a failed item rolls back the whole operation and the other items fail
+// with a FailedDependencyError; in best-effort mode, only the failed items are rolled back.
+// The outcomes are returned in item order.
+func runBulk[Repo transactional[Repo], I any, R any](repo Repo, items []I, mode string,
+	validate func(I) error, apply func(Repo, I) (R, error)) ([]BulkResult[R], error) {
+	results := make([]BulkResult[R], len(items))
+	atomic := mode != dto.BulkModeBestEffort
+
+	failed := false
+	for i, item := range items {
+		if err := validate(item); err != nil {
+			results[i].Err = err
+			failed = true
+		}
+	}
+	// Nothing can be applied when an item is invalid
+	if atomic && failed {
+		return rollBackBulk(results), nil
+	}
+
+	err := repo.Transaction(func(tx Repo) error {
+		for i, item := range items {
+			if results[i].Err != nil {
+				continue
+			}
+			results[i].Err = tx.Transaction(func(itemTx Repo) error {
+				var err error
+				results[i].Value, err = apply(itemTx, item)
+				return err
+			})
+			failed = failed || results[i].Err != nil
+		}
+		if atomic && failed {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if errors.Is(err, errBulkRollback) {
+		return rollBackBulk(results), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply bulk operation: %w", err)
+	}
+	return results, nil
+}
+
+// rollBackBulk marks the items which did not fail themselves as rolled back
+func rollBackBulk[R any](results []BulkResult[R]) []BulkResult[R] {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i] = BulkResult[R]{Err: &customError.FailedDependencyError{Index: i}}
+		}
+	}
+	return results
+}
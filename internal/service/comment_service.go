@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+)
+
+// CommentService manages the threaded comments on greetings. Comments are only read and written on greetings
+// visible to the user of the context, see HelloService, and only their author edits or deletes them.
+// Admins remove any comment on the greetings of their tenant with RemoveComment. Deleting a comment deletes the replies to it as well.
+type CommentService interface {
+	GetComments(ctx context.Context, greetingID uint, query dto.CommentQuery) (dto.PagedResponse[dto.CommentResponse], error)
+	CreateComment(ctx context.Context, greetingID uint, input dto.CommentInput) (dto.CommentResponse, error)
+	UpdateComment(ctx context.Context, greetingID, id uint, input dto.CommentUpdateInput) (dto.CommentResponse, error)
+	DeleteComment(ctx context.Context, greetingID, id uint) error
+	RemoveComment(ctx context.Context, greetingID, id uint) error
+}
+
+// commentSortColumns maps the sortable comment properties to their columns
+var commentSortColumns = map[string]string{
+	"id":        "id",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// defaultCommentSort lists the oldest comments first, so threads read in order
+var defaultCommentSort = []string{"createdAt"}
+
+type commentServiceImpl struct {
+	repo      repository.CommentRepository
+	helloRepo repository.HelloRepository
+	mapper    mapper.CommentMapper
+	clock     util.Clock
+}
+
+// NewCommentService creates a new instance of commentServiceImpl.
+// The greetings commented on are read from helloRepo.
+func NewCommentService(repo repository.CommentRepository,
+	helloRepo repository.HelloRepository,
+	mapper mapper.CommentMapper,
+	clock util.Clock) CommentService {
+	return &commentServiceImpl{
+		repo:      repo,
+		helloRepo: helloRepo,
+		mapper:    mapper,
+		clock:     clock,
+	}
+}
+
+// GetComments retrieves a page of the comments on a visible greeting, optionally only the direct replies to a comment
+func (s *commentServiceImpl) GetComments(ctx context.Context, greetingID uint,
+	query dto.CommentQuery) (dto.PagedResponse[dto.CommentResponse], error) {
+	if _, err := s.findVisibleGreeting(ctx, greetingID); err != nil {
+		return dto.PagedResponse[dto.CommentResponse]{}, err
+	}
+	if query.ParentID != nil {
+		if _, err := s.findComment(greetingID, *query.ParentID); err != nil {
+			return dto.PagedResponse[dto.CommentResponse]{}, err
+		}
+	}
+
+	sortParams := query.Sort
+	if len(sortParams) == 0 {
+		sortParams = defaultCommentSort
+	}
+	pageable, err := toPageable(query.Page, query.Size, sortParams, commentSortColumns)
+	if err != nil {
+		return dto.PagedResponse[dto.CommentResponse]{}, err
+	}
+
+	page, err := s.repo.FindPagedByGreeting(greetingID, query.ParentID, pageable)
+	if err != nil {
+		return dto.PagedResponse[dto.CommentResponse]{}, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+
+	return toPagedResponse(page, s.mapper.ToCommentResponses(page.Content)), nil
+}
+
+// CreateComment adds a comment of the user of ctx to a visible greeting, or a reply to one of its comments
+func (s *commentServiceImpl) CreateComment(ctx context.Context, greetingID uint,
+	input dto.CommentInput) (dto.CommentResponse, error) {
+	authorID := currentUserID(ctx)
+	if authorID == "" {
+		return dto.CommentResponse{}, &customError.AccessDeniedError{
+			Message: "Only authenticated users can comment on greetings",
+		}
+	}
+	if _, err := s.findVisibleGreeting(ctx, greetingID); err != nil {
+		return dto.CommentResponse{}, err
+	}
+	if input.ParentID != nil {
+		if _, err := s.findComment(greetingID, *input.ParentID); err != nil {
+			return dto.CommentResponse{}, err
+		}
+	}
+
+	savedEntity, err := s.repo.WithContext(ctx).Save(s.mapper.ToCommentEntity(greetingID, authorID, input))
+	if err != nil {
+		return dto.CommentResponse{}, fmt.Errorf("failed to save comment: %w", err)
+	}
+	return s.mapper.ToCommentResponse(savedEntity), nil
+}
+
+// UpdateComment changes the text of a comment of the user of ctx
+func (s *commentServiceImpl) UpdateComment(ctx context.Context, greetingID, id uint,
+	input dto.CommentUpdateInput) (dto.CommentResponse, error) {
+	comment, err := s.findOwnComment(ctx, greetingID, id)
+	if err != nil {
+		return dto.CommentResponse{}, err
+	}
+
+	comment.Body = input.Body
+	savedEntity, err := s.repo.WithContext(ctx).Save(comment)
+	if err != nil {
+		return dto.CommentResponse{}, fmt.Errorf("failed to save comment: %w", err)
+	}
+	return s.mapper.ToCommentResponse(savedEntity), nil
+}
+
+// DeleteComment deletes a comment of the user of ctx along with the replies to it
+func (s *commentServiceImpl) DeleteComment(ctx context.Context, greetingID, id uint) error {
+	comment, err := s.findOwnComment(ctx, greetingID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteThread(comment); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// RemoveComment deletes any comment on a greeting along with the replies to it, e.g. when an admin moderates comments
+func (s *commentServiceImpl) RemoveComment(ctx context.Context, greetingID, id uint) error {
+	if _, err := s.findVisibleGreeting(ctx, greetingID); err != nil {
+		return err
+	}
+	comment, err := s.findComment(greetingID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteThread(comment); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// findVisibleGreeting returns the greeting, or a ResourceNotFoundError when it does not exist
+// or is not visible to the user of ctx, e.g. as it belongs to another tenant
+func (s *commentServiceImpl) findVisibleGreeting(ctx context.Context, id uint) (domain.Greeting, error) {
+	return findVisibleGreetingOf(ctx, s.helloRepo, id, s.clock)
+}
+
+// findComment returns the comment on the greeting, or a ResourceNotFoundError when it does not exist
+// or belongs to another greeting
+func (s *commentServiceImpl) findComment(greetingID, id uint) (domain.GreetingComment, error) {
+	optionalEntity, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.GreetingComment{}, fmt.Errorf("failed to fetch comment by ID: %w", err)
+	}
+
+	if optionalEntity.IsEmpty() || optionalEntity.Value.GreetingID != greetingID {
+		return domain.GreetingComment{}, &customError.ResourceNotFoundError{
+			Resource: "Comment",
+			Criteria: "id",
+			Value:    fmt.Sprintf("%d", id),
+		}
+	}
+	return *optionalEntity.Value, nil
+}
+
+// findOwnComment returns the comment on a visible greeting, or an AccessDeniedError when the user of ctx
+// did not write it
+func (s *commentServiceImpl) findOwnComment(ctx context.Context, greetingID, id uint) (domain.GreetingComment, error) {
+	if _, err := s.findVisibleGreeting(ctx, greetingID); err != nil {
+		return domain.GreetingComment{}, err
+	}
+	comment, err := s.findComment(greetingID, id)
+	if err != nil {
+		return domain.GreetingComment{}, err
+	}
+
+	if userID := currentUserID(ctx); userID == "" || comment.AuthorID != userID {
+		return domain.GreetingComment{}, &customError.AccessDeniedError{
+			Message: "Only the author of the comment can change it",
+		}
+	}
+	return comment, nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// setUpCommentedGreetings lets the hello repository return an approved greeting 1 and a greeting 2 pending
+// for moderation, which is only visible to its owner 3
+func setUpCommentedGreetings() *customMock.MockHelloRepository {
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	approvedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusApproved}
+	pendingEntity := domain.Greeting{ID: 2, Message: "Hello, Moon!", Status: domain.GreetingStatusPending, OwnerID: "3"}
+	mockHelloRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &approvedEntity}, nil)
+	mockHelloRepo.On("FindByID", uint(2)).Return(util.Optional[domain.Greeting]{Value: &pendingEntity}, nil)
+	mockHelloRepo.On("FindByID", uint(99)).Return(util.EmptyOptional[domain.Greeting](), nil)
+	return mockHelloRepo
+}
+
+func TestCommentService_GetComments(t *testing.T) {
+	mockRepo := new(customMock.MockCommentRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+	parentID := uint(5)
+	comments := []domain.GreetingComment{
+		{ID: 5, GreetingID: 1, AuthorID: "2", Body: "Nice greeting!"},
+		{ID: 6, GreetingID: 1, ParentID: &parentID, AuthorID: "3", Body: "Agreed"},
+	}
+
+	// The oldest comments come first by default
+	mockRepo.On("FindPagedByGreeting", uint(1), (*uint)(nil), repository.Pageable{Page: 0, Size: 20,
+		Sort: []repository.SortOrder{{Column: "created_at"}}}).
+		Return(repository.Page[domain.GreetingComment]{Content: comments, Page: 0, Size: 20, TotalElements: 2}, nil)
+	mockRepo.On("FindByID", uint(5)).Return(util.Optional[domain.GreetingComment]{Value: &comments[0]}, nil)
+	mockRepo.On("FindPagedByGreeting", uint(1), &parentID, repository.Pageable{Page: 0, Size: 20,
+		Sort: []repository.SortOrder{{Column: "created_at", Desc: true}}}).
+		Return(repository.Page[domain.GreetingComment]{Content: comments[1:], Page: 0, Size: 20, TotalElements: 1}, nil)
+
+	service := NewCommentService(mockRepo, mockHelloRepo, mapper.NewCommentMapper(), customMock.NewFakeClock(revisionTime))
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	actual, err := service.GetComments(userCtx, 1, dto.CommentQuery{Size: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), actual.Page.TotalElements)
+	assert.Equal(t, &parentID, actual.Content[1].ParentID)
+
+	// The replies to a comment are listed on their own
+	actual, err = service.GetComments(userCtx, 1, dto.CommentQuery{Size: 20, Sort: []string{"createdAt,desc"}, ParentID: &parentID})
+	assert.NoError(t, err)
+	assert.Len(t, actual.Content, 1)
+
+	// Comments on greetings hidden from the user are not found
+	_, err = service.GetComments(userCtx, 2, dto.CommentQuery{Size: 20})
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	// Unknown sort properties are constraint violations
+	_, err = service.GetComments(userCtx, 1, dto.CommentQuery{Size: 20, Sort: []string{"body"}})
+	assert.ErrorAs(t, err, new(customError.ConstraintViolationError))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	mockRepo := new(customMock.MockCommentRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+	parentID := uint(5)
+	otherParentID := uint(7)
+	parent := domain.GreetingComment{ID: 5, GreetingID: 1, AuthorID: "2", Body: "Nice greeting!"}
+	otherParent := domain.GreetingComment{ID: 7, GreetingID: 2, AuthorID: "3", Body: "Thanks"}
+	reply := domain.GreetingComment{GreetingID: 1, ParentID: &parentID, AuthorID: "3", Body: "Agreed"}
+	savedReply := reply
+	savedReply.ID = 6
+
+	mockRepo.On("FindByID", uint(5)).Return(util.Optional[domain.GreetingComment]{Value: &parent}, nil)
+	mockRepo.On("FindByID", uint(7)).Return(util.Optional[domain.GreetingComment]{Value: &otherParent}, nil)
+	mockRepo.On("Save", reply).Return(savedReply, nil)
+
+	service := NewCommentService(mockRepo, mockHelloRepo, mapper.NewCommentMapper(), customMock.NewFakeClock(revisionTime))
+	ownerCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "3", Authorities: []string{"ROLE_USER"}})
+
+	actual, err := service.CreateComment(ownerCtx, 1, dto.CommentInput{Body: "Agreed", ParentID: &parentID})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(6), actual.ID)
+	assert.Equal(t, "3", actual.AuthorID)
+
+	// Replies must answer a comment on the same greeting
+	_, err = service.CreateComment(ownerCtx, 1, dto.CommentInput{Body: "Agreed", ParentID: &otherParentID})
+	var notFoundErr *customError.ResourceNotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "Comment", notFoundErr.Resource)
+	}
+
+	// Only authenticated users comment, and only on greetings they can see
+	_, err = service.CreateComment(context.Background(), 1, dto.CommentInput{Body: "Agreed"})
+	assert.ErrorAs(t, err, new(*customError.AccessDeniedError))
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+	_, err = service.CreateComment(userCtx, 2, dto.CommentInput{Body: "Agreed"})
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+	_, err = service.CreateComment(userCtx, 99, dto.CommentInput{Body: "Agreed"})
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	mockRepo := new(customMock.MockCommentRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+	comment := domain.GreetingComment{ID: 5, GreetingID: 1, AuthorID: "2", Body: "Nice greeting!"}
+	updated := comment
+	updated.Body = "Nice greeting, thanks!"
+
+	mockRepo.On("FindByID", uint(5)).Return(util.Optional[domain.GreetingComment]{Value: &comment}, nil)
+	mockRepo.On("Save", updated).Return(updated, nil)
+
+	service := NewCommentService(mockRepo, mockHelloRepo, mapper.NewCommentMapper(), customMock.NewFakeClock(revisionTime))
+	authorCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	actual, err := service.UpdateComment(authorCtx, 1, 5, dto.CommentUpdateInput{Body: "Nice greeting, thanks!"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Nice greeting, thanks!", actual.Body)
+
+	// Only the author edits a comment, not even admins
+	otherCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "3", Authorities: []string{"ROLE_USER"}})
+	_, err = service.UpdateComment(otherCtx, 1, 5, dto.CommentUpdateInput{Body: "Spam"})
+	assert.ErrorAs(t, err, new(*customError.AccessDeniedError))
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+	_, err = service.UpdateComment(adminCtx, 1, 5, dto.CommentUpdateInput{Body: "Spam"})
+	assert.ErrorAs(t, err, new(*customError.AccessDeniedError))
+
+	// Comments are only found under their own greeting
+	_, err = service.UpdateComment(adminCtx, 2, 5, dto.CommentUpdateInput{Body: "Spam"})
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	mockRepo := new(customMock.MockCommentRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+	comment := domain.GreetingComment{ID: 5, GreetingID: 1, AuthorID: "2", Body: "Nice greeting!"}
+
+	mockRepo.On("FindByID", uint(5)).Return(util.Optional[domain.GreetingComment]{Value: &comment}, nil)
+	mockRepo.On("FindByID", uint(8)).Return(util.EmptyOptional[domain.GreetingComment](), nil)
+	mockRepo.On("DeleteThread", comment).Return(nil)
+
+	service := NewCommentService(mockRepo, mockHelloRepo, mapper.NewCommentMapper(), customMock.NewFakeClock(revisionTime))
+	authorCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+	otherCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "3", Authorities: []string{"ROLE_USER"}})
+
+	err := service.DeleteComment(otherCtx, 1, 5)
+	assert.ErrorAs(t, err, new(*customError.AccessDeniedError))
+	err = service.DeleteComment(authorCtx, 1, 8)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	assert.NoError(t, service.DeleteComment(authorCtx, 1, 5))
+	mockRepo.AssertNumberOfCalls(t, "DeleteThread", 1)
+}
+
+func TestCommentService_RemoveComment(t *testing.T) {
+	mockRepo := new(customMock.MockCommentRepository)
+	comment := domain.GreetingComment{ID: 7, GreetingID: 2, AuthorID: "3", Body: "Spam"}
+
+	mockRepo.On("FindByID", uint(7)).Return(util.Optional[domain.GreetingComment]{Value: &comment}, nil)
+	mockRepo.On("DeleteThread", comment).Return(nil)
+
+	// Admins remove comments of any user, even on greetings other users cannot see
+	service := NewCommentService(mockRepo, setUpCommentedGreetings(), mapper.NewCommentMapper(), customMock.NewFakeClock(revisionTime))
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{"ROLE_ADMIN"}})
+	assert.NoError(t, service.RemoveComment(adminCtx, 2, 7))
+
+	err := service.RemoveComment(adminCtx, 1, 7)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	// Greetings of other tenants are not found by the tenant-scoped repository
+	err = service.RemoveComment(adminCtx, 99, 7)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
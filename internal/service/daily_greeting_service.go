@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+)
+
+// DailyGreetingService manages the greeting of the day. Admins pin a greeting for a calendar date; on dates without
+// a pin, a greeting is chosen once, so every caller gets the same greeting all day. Only approved greetings which are
+// live when requested are returned, rendered for the user of the context.
+type DailyGreetingService interface {
+	GetDailyGreeting(ctx context.Context, query dto.GreetingRenderQuery) (dto.DailyGreetingResponse, error)
+	PinDailyGreeting(ctx context.Context, date string, input dto.GreetingPinInput) (dto.DailyGreetingResponse, error)
+	UnpinDailyGreeting(ctx context.Context, date string) error
+}
+
+type dailyGreetingServiceImpl struct {
+	repo        repository.GreetingPinRepository
+	helloRepo   repository.HelloRepository
+	userRepo    repository.UserRepository
+	helloMapper mapper.HelloMapper
+	mapper      mapper.DailyGreetingMapper
+	clock       util.Clock
+}
+
+// NewDailyGreetingService creates a new instance of dailyGreetingServiceImpl.
+// The greetings pinned and chosen are read from helloRepo, and rendered with the users of userRepo.
+func NewDailyGreetingService(repo repository.GreetingPinRepository,
+	helloRepo repository.HelloRepository,
+	userRepo repository.UserRepository,
+	helloMapper mapper.HelloMapper,
+	mapper mapper.DailyGreetingMapper,
+	clock util.Clock) DailyGreetingService {
+	return &dailyGreetingServiceImpl{
+		repo:        repo,
+		helloRepo:   helloRepo,
+		userRepo:    userRepo,
+		helloMapper: helloMapper,
+		mapper:      mapper,
+		clock:       clock,
+	}
+}
+
+// GetDailyGreeting returns the greeting of the day in the time zone of the query, rendered for the user of ctx.
+// The greeting pinned for the date comes first. Otherwise, the greeting chosen for the date by an earlier call is
+// returned, so every caller gets the same greeting all day. The first call of the day chooses the greeting,
+// see chooseDailyGreeting.
+func (s *dailyGreetingServiceImpl) GetDailyGreeting(ctx context.Context, query dto.GreetingRenderQuery) (dto.DailyGreetingResponse, error) {
+	s = s.scoped(ctx)
+	now, err := renderTimeIn(s.clock, query.TimeZone)
+	if err != nil {
+		return dto.DailyGreetingResponse{}, err
+	}
+	date := now.Format(time.DateOnly)
+
+	greeting, err := s.findPinnedGreeting(date, now)
+	if err != nil {
+		return dto.DailyGreetingResponse{}, err
+	}
+	pinned := greeting != nil
+
+	if !pinned {
+		if greeting, err = s.findDailyPick(date, now); err != nil {
+			return dto.DailyGreetingResponse{}, err
+		}
+	}
+	if greeting == nil {
+		if greeting, err = s.chooseDailyGreeting(date, now); err != nil {
+			return dto.DailyGreetingResponse{}, err
+		}
+	}
+	if greeting == nil {
+		return dto.DailyGreetingResponse{}, &customError.ResourceNotFoundError{
+			Resource: "Greeting of the day",
+			Criteria: "date",
+			Value:    date,
+		}
+	}
+
+	response, err := renderGreetingFor(ctx, s.userRepo, s.helloMapper, *greeting, now)
+	if err != nil {
+		return dto.DailyGreetingResponse{}, err
+	}
+	return dto.DailyGreetingResponse{GreetingResponse: response, Date: date, Pinned: pinned}, nil
+}
+
+// PinDailyGreeting pins a greeting as greeting of the day for a date, replacing the greeting pinned before.
+// A pinned greeting which is not live when requested is skipped, as if it was not pinned.
+func (s *dailyGreetingServiceImpl) PinDailyGreeting(ctx context.Context, date string, input dto.GreetingPinInput) (dto.DailyGreetingResponse, error) {
+	s = s.scoped(ctx)
+	optionalEntity, err := s.helloRepo.FindByID(input.GreetingID)
+	if err != nil {
+		return dto.DailyGreetingResponse{}, fmt.Errorf("failed to fetch greeting by ID: %w", err)
+	}
+	if optionalEntity.IsEmpty() {
+		return dto.DailyGreetingResponse{}, &customError.ResourceNotFoundError{
+			Resource: "Greeting",
+			Criteria: "id",
+			Value:    fmt.Sprintf("%d", input.GreetingID),
+		}
+	}
+
+	pin, err := s.repo.SavePin(domain.GreetingPin{Date: date, GreetingID: input.GreetingID})
+	if err != nil {
+		return dto.DailyGreetingResponse{}, fmt.Errorf("failed to pin greeting: %w", err)
+	}
+
+	pinResponse := s.mapper.ToGreetingPinResponse(pin)
+	return dto.DailyGreetingResponse{
+		GreetingResponse: s.helloMapper.ToGreetingResponse(*optionalEntity.Value),
+		Date:             date,
+		Pinned:           true,
+		Pin:              &pinResponse,
+	}, nil
+}
+
+// UnpinDailyGreeting removes the greeting pinned for a date, so the greeting of the day is chosen again
+func (s *dailyGreetingServiceImpl) UnpinDailyGreeting(ctx context.Context, date string) error {
+	s = s.scoped(ctx)
+	deleted, err := s.repo.DeletePinByDate(date)
+	if err != nil {
+		return fmt.Errorf("failed to unpin greeting: %w", err)
+	}
+	if !deleted {
+		return &customError.ResourceNotFoundError{
+			Resource: "Greeting pin",
+			Criteria: "date",
+			Value:    date,
+		}
+	}
+	return nil
+}
+
+// findPinnedGreeting returns the greeting pinned for the date, or nil when there is none or it is not approved
+// and live at now
+func (s *dailyGreetingServiceImpl) findPinnedGreeting(date string, now time.Time) (*domain.Greeting, error) {
+	optionalPin, err := s.repo.FindPinByDate(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch greeting pin: %w", err)
+	}
+	if optionalPin.IsEmpty() {
+		return nil, nil
+	}
+	return s.findLiveApprovedGreeting(optionalPin.Value.GreetingID, now)
+}
+
+// findDailyPick returns the greeting chosen for the date by an earlier call, or nil when there is none.
+// A chosen greeting which is no longer approved and live, e.g. because it was deleted, is discarded,
+// so that another greeting is chosen.
+func (s *dailyGreetingServiceImpl) findDailyPick(date string, now time.Time) (*domain.Greeting, error) {
+	optionalPick, err := s.repo.FindDailyPickByDate(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch greeting daily pick: %w", err)
+	}
+	if optionalPick.IsEmpty() {
+		return nil, nil
+	}
+
+	greeting, err := s.findLiveApprovedGreeting(optionalPick.Value.GreetingID, now)
+	if err != nil || greeting != nil {
+		return greeting, err
+	}
+	return nil, s.repo.DeleteDailyPick(*optionalPick.Value)
+}
+
+// chooseDailyGreeting chooses the greeting of the day and records the choice for the rest of the day.
+// The date seeds the choice among the live greetings created before the day began; greetings created during the day
+// are only chosen when there are no older ones. When a concurrent call chose first, its greeting is returned.
+func (s *dailyGreetingServiceImpl) chooseDailyGreeting(date string, now time.Time) (*domain.Greeting, error) {
+	index := func(n int64) int64 {
+		return dailyIndex(date, n)
+	}
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	approved := repository.StatusEquals(domain.GreetingStatusApproved)
+	greeting, err := pickGreetingOf(s.helloRepo, index, repository.LiveAt(now), approved, repository.CreatedBefore(startOfDay.UTC()))
+	if err != nil {
+		return nil, err
+	}
+	if greeting == nil {
+		if greeting, err = pickGreetingOf(s.helloRepo, index, repository.LiveAt(now), approved); err != nil {
+			return nil, err
+		}
+	}
+	if greeting == nil {
+		return nil, nil
+	}
+
+	pick, err := s.repo.SaveDailyPick(domain.GreetingDailyPick{Date: date, GreetingID: greeting.ID})
+	if err != nil {
+		return nil, err
+	}
+	if pick.GreetingID != greeting.ID {
+		chosen, err := s.findLiveApprovedGreeting(pick.GreetingID, now)
+		if err != nil || chosen != nil {
+			return chosen, err
+		}
+	}
+	return greeting, nil
+}
+
+// findLiveApprovedGreeting returns the greeting with the ID, or nil when there is none or it is not approved
+// and live at now
+func (s *dailyGreetingServiceImpl) findLiveApprovedGreeting(id uint, now time.Time) (*domain.Greeting, error) {
+	optionalEntity, err := s.helloRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch greeting by ID: %w", err)
+	}
+	if optionalEntity.IsEmpty() || optionalEntity.Value.Status != domain.GreetingStatusApproved ||
+		!optionalEntity.Value.IsLiveAt(now) {
+		return nil, nil
+	}
+	return optionalEntity.Value, nil
+}
+
+// scoped returns a copy of the service whose repositories are bound to ctx, so it only sees the greetings and pins
+// of the tenant of the authenticated user
+func (s *dailyGreetingServiceImpl) scoped(ctx context.Context) *dailyGreetingServiceImpl {
+	scoped := *s
+	scoped.repo = s.repo.WithContext(ctx)
+	scoped.helloRepo = s.helloRepo.WithContext(ctx)
+	return &scoped
+}
+
+// dailyIndex chooses a position among n greetings, seeded by the date so the choice is the same all day
+func dailyIndex(date string, n int64) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(date))
+	seed := hash.Sum64()
+	return rand.New(rand.NewPCG(seed, seed)).Int64N(n)
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestDailyGreetingService_GetDailyGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingPinRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	// It is already the next day in Tokyo
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 20, 0, 0, 0, time.UTC))
+
+	greetings := []domain.Greeting{
+		{ID: 1, Status: domain.GreetingStatusApproved, Message: "Hello, World!", Locale: "en"},
+		{ID: 2, Status: domain.GreetingStatusApproved, Message: "Hi there!", Locale: "en"},
+		{ID: 3, Status: domain.GreetingStatusApproved, Message: "Hey!", Locale: "en"},
+	}
+	index := int(dailyIndex("2025-01-06", int64(len(greetings))))
+	chosen := greetings[index]
+	pick := domain.GreetingDailyPick{Date: "2025-01-06", GreetingID: chosen.ID}
+
+	// The greetings are counted on the first page, ordered by ID, and the chosen page is fetched
+	mockHelloRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindPinByDate", "2025-01-06").Return(nil, nil)
+	mockRepo.On("FindDailyPickByDate", "2025-01-06").Return(nil, nil).Once()
+	for _, page := range []int{0, index} {
+		mockHelloRepo.On("FindAllPaged", repository.Pageable{Page: page, Size: 1, Sort: []repository.SortOrder{{Column: "id"}}},
+			mock.Anything).Return(repository.Page[domain.Greeting]{
+			Content:       greetings[page : page+1],
+			Page:          page,
+			Size:          1,
+			TotalElements: int64(len(greetings)),
+		}, nil).Once()
+	}
+	mockRepo.On("SaveDailyPick", pick).Return(pick, nil).Once()
+	mockMapper.On("ToGreetingResponse", chosen).Return(dto.GreetingResponse{ID: chosen.ID, Message: chosen.Message, Locale: "en"})
+
+	service := NewDailyGreetingService(mockRepo, mockHelloRepo, nil, mockMapper, mapper.NewDailyGreetingMapper(), mockClock)
+
+	query := dto.GreetingRenderQuery{TimeZone: "Asia/Tokyo"}
+	first, err := service.GetDailyGreeting(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-06", first.Date)
+	assert.Equal(t, chosen.ID, first.ID)
+	assert.False(t, first.Pinned)
+
+	// Every later call of the day gets the recorded greeting, whatever greetings changed in the meantime
+	mockRepo.On("FindDailyPickByDate", "2025-01-06").Return(util.Optional[domain.GreetingDailyPick]{Value: &pick}, nil)
+	mockHelloRepo.On("FindByID", chosen.ID).Return(util.Optional[domain.Greeting]{Value: &chosen}, nil)
+
+	second, err := service.GetDailyGreeting(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	mockHelloRepo.AssertNumberOfCalls(t, "FindAllPaged", 2)
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestDailyGreetingService_GetDailyGreeting_ChosenGreetingGone(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingPinRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC))
+
+	greeting := domain.Greeting{ID: 2, Status: domain.GreetingStatusApproved, Message: "Hi there!", Locale: "en"}
+	stalePick := domain.GreetingDailyPick{Date: "2025-01-05", GreetingID: 1}
+	concurrentPick := domain.GreetingDailyPick{Date: "2025-01-05", GreetingID: 3}
+	concurrent := domain.Greeting{ID: 3, Status: domain.GreetingStatusApproved, Message: "Hey!", Locale: "en"}
+
+	// The greeting chosen earlier was deleted, and a concurrent call chooses again first
+	mockHelloRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindPinByDate", "2025-01-05").Return(nil, nil)
+	mockRepo.On("FindDailyPickByDate", "2025-01-05").Return(util.Optional[domain.GreetingDailyPick]{Value: &stalePick}, nil)
+	mockHelloRepo.On("FindByID", uint(1)).Return(nil, nil)
+	mockRepo.On("DeleteDailyPick", stalePick).Return(nil)
+	mockHelloRepo.On("FindAllPaged", mock.Anything, mock.Anything).Return(repository.Page[domain.Greeting]{
+		Content: []domain.Greeting{greeting}, Size: 1, TotalElements: 1,
+	}, nil)
+	mockRepo.On("SaveDailyPick", domain.GreetingDailyPick{Date: "2025-01-05", GreetingID: 2}).Return(concurrentPick, nil)
+	mockHelloRepo.On("FindByID", uint(3)).Return(util.Optional[domain.Greeting]{Value: &concurrent}, nil)
+	mockMapper.On("ToGreetingResponse", concurrent).Return(dto.GreetingResponse{ID: 3, Message: concurrent.Message, Locale: "en"})
+
+	service := NewDailyGreetingService(mockRepo, mockHelloRepo, nil, mockMapper, mapper.NewDailyGreetingMapper(), mockClock)
+
+	actual, err := service.GetDailyGreeting(context.Background(), dto.GreetingRenderQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), actual.ID)
+	assert.False(t, actual.Pinned)
+
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestDailyGreetingService_GetDailyGreeting_Pinned(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingPinRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	now := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	mockClock.On("Now").Return(now)
+
+	pinned := domain.Greeting{ID: 5, Status: domain.GreetingStatusApproved, Message: "Happy Sunday!", Locale: "en"}
+	expired := now.Add(-time.Hour)
+
+	mockRepo.On("FindPinByDate", "2025-01-05").
+		Return(util.Optional[domain.GreetingPin]{Value: &domain.GreetingPin{Date: "2025-01-05", GreetingID: 5}}, nil)
+	mockHelloRepo.On("FindByID", uint(5)).Return(util.Optional[domain.Greeting]{Value: &pinned}, nil).Once()
+	mockMapper.On("ToGreetingResponse", pinned).Return(dto.GreetingResponse{ID: 5, Message: pinned.Message, Locale: "en"})
+
+	service := NewDailyGreetingService(mockRepo, mockHelloRepo, nil, mockMapper, mapper.NewDailyGreetingMapper(), mockClock)
+
+	actual, err := service.GetDailyGreeting(context.Background(), dto.GreetingRenderQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), actual.ID)
+	assert.True(t, actual.Pinned)
+
+	// A pinned greeting which expired is skipped
+	mockHelloRepo.On("FindByID", uint(5)).
+		Return(util.Optional[domain.Greeting]{Value: &domain.Greeting{ID: 5, Status: domain.GreetingStatusApproved, ExpireAt: &expired}}, nil)
+	mockRepo.On("FindDailyPickByDate", "2025-01-05").Return(nil, nil)
+	mockHelloRepo.On("Transaction").Return(nil)
+	mockHelloRepo.On("FindAllPaged", mock.Anything, mock.Anything).Return(repository.Page[domain.Greeting]{}, nil)
+
+	_, err = service.GetDailyGreeting(context.Background(), dto.GreetingRenderQuery{})
+	var notFoundErr *customError.ResourceNotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "2025-01-05", notFoundErr.Value)
+	}
+	// Greetings created before the day and then any greetings are considered
+	mockHelloRepo.AssertNumberOfCalls(t, "FindAllPaged", 2)
+
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestDailyGreetingService_PinDailyGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingPinRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+
+	greeting := domain.Greeting{ID: 1, Message: "Hello, World!", Locale: "en"}
+
+	mockHelloRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &greeting}, nil)
+	mockHelloRepo.On("FindByID", uint(2)).Return(nil, nil)
+	pin := domain.GreetingPin{Date: "2025-01-05", GreetingID: 1,
+		AuditingEntity: domain.AuditingEntity{CreatedBy: "1", UpdatedBy: "2"}}
+	mockRepo.On("SavePin", domain.GreetingPin{Date: "2025-01-05", GreetingID: 1}).Return(pin, nil)
+	mockRepo.On("DeletePinByDate", "2025-01-05").Return(true, nil)
+	mockRepo.On("DeletePinByDate", "2025-01-06").Return(false, nil)
+	mockMapper.On("ToGreetingResponse", greeting).Return(dto.GreetingResponse{ID: 1, Message: greeting.Message, Locale: "en"})
+
+	service := NewDailyGreetingService(mockRepo, mockHelloRepo, nil, mockMapper, mapper.NewDailyGreetingMapper(), nil)
+
+	actual, err := service.PinDailyGreeting(context.Background(), "2025-01-05", dto.GreetingPinInput{GreetingID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, dto.DailyGreetingResponse{
+		GreetingResponse: dto.GreetingResponse{ID: 1, Message: greeting.Message, Locale: "en"},
+		Date:             "2025-01-05",
+		Pinned:           true,
+		Pin:              &dto.GreetingPinResponse{CreatedBy: "1", UpdatedBy: "2"},
+	}, actual)
+
+	// The greeting does not exist
+	_, err = service.PinDailyGreeting(context.Background(), "2025-01-05", dto.GreetingPinInput{GreetingID: 2})
+	var notFoundErr *customError.ResourceNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+
+	// Unpinning
+	assert.NoError(t, service.UnpinDailyGreeting(context.Background(), "2025-01-05"))
+	err = service.UnpinDailyGreeting(context.Background(), "2025-01-06")
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "Greeting pin", notFoundErr.Resource)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "SavePin", 1)
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
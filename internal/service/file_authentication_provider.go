@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"gin-samples/internal/domain"
+	customError "gin-samples/internal/error"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"strings"
+)
+
+// fileUser represents a user defined in a credentials file
+type fileUser struct {
+	PasswordHash string
+	TenantID     string
+	Roles        []string
+	Enabled      bool
+}
+
+// fileAuthenticationProvider authenticates users loaded from a file with bcrypt hashed passwords
+type fileAuthenticationProvider struct {
+	name  string
+	users map[string]fileUser
+}
+
+// staticUsersFile represents the layout of the YAML users file
+type staticUsersFile struct {
+	Users []struct {
+		Username string   `yaml:"username"`
+		Password string   `yaml:"password"` // bcrypt hash
+		Tenant   string   `yaml:"tenant"`   // default tenant when omitted
+		Roles    []string `yaml:"roles"`
+		Enabled  *bool    `yaml:"enabled"`
+	} `yaml:"users"`
+}
+
+// NewHtpasswdAuthenticationProvider creates an AuthenticationProvider from an htpasswd file.
+// Only bcrypt entries are supported; every user is granted the given roles.
+func NewHtpasswdAuthenticationProvider(path string, roles []string) (AuthenticationProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read htpasswd file: %w", err)
+	}
+
+	users := make(map[string]fileUser)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		username, hash, found := strings.Cut(line, ":")
+		if !found || username == "" {
+			return nil, fmt.Errorf("invalid htpasswd entry on line %d", lineNumber)
+		}
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("unsupported htpasswd hash for user %q on line %d: only bcrypt is supported", username, lineNumber)
+		}
+
+		users[username] = fileUser{PasswordHash: hash, Roles: roles, Enabled: true}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read htpasswd file: %w", err)
+	}
+
+	return &fileAuthenticationProvider{name: "htpasswd", users: users}, nil
+}
+
+// NewStaticUsersAuthenticationProvider creates an AuthenticationProvider from a YAML users file
+func NewStaticUsersAuthenticationProvider(path string) (AuthenticationProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users file: %w", err)
+	}
+
+	var file staticUsersFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse users file: %w", err)
+	}
+
+	users := make(map[string]fileUser)
+	for _, u := range file.Users {
+		if u.Username == "" {
+			return nil, fmt.Errorf("users file contains a user without username")
+		}
+		if _, err := bcrypt.Cost([]byte(u.Password)); err != nil {
+			return nil, fmt.Errorf("unsupported password hash for user %q: only bcrypt is supported", u.Username)
+		}
+		users[u.Username] = fileUser{
+			PasswordHash: u.Password,
+			TenantID:     u.Tenant,
+			Roles:        u.Roles,
+			Enabled:      u.Enabled == nil || *u.Enabled,
+		}
+	}
+
+	return &fileAuthenticationProvider{name: "static", users: users}, nil
+}
+
+func (p *fileAuthenticationProvider) Name() string {
+	return p.name
+}
+
+// Authenticate validates the credentials against the users loaded from the file
+func (p *fileAuthenticationProvider) Authenticate(username, password string) (*AuthenticatedPrincipal, error) {
+	user, found := p.users[username]
+	if !found {
+		return nil, &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonUserNotFound}
+	}
+	if !user.Enabled {
+		return nil, &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonUserDisabled}
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, &customError.InvalidCredentialsError{Username: username, Reason: customError.ReasonBadCredentials}
+	}
+
+	return &AuthenticatedPrincipal{
+		UserID:      p.name + ":" + username,
+		Username:    username,
+		TenantID:    user.TenantID,
+		Authorities: user.Roles,
+		Provider:    p.name,
+	}, nil
+}
+
+// LoadAuthorities returns the roles of an enabled user of the tenant defined in the file
+func (p *fileAuthenticationProvider) LoadAuthorities(username, tenantID string) ([]string, error) {
+	user, found := p.users[username]
+	if !found || !user.Enabled || domain.TenantOrDefault(user.TenantID) != domain.TenantOrDefault(tenantID) {
+		return nil, nil
+	}
+	return user.Roles, nil
+}
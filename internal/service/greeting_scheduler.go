@@ -0,0 +1,166 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"log"
+	"sync"
+	"time"
+)
+
+// GreetingEventSink receives the publish and expire events of scheduled greetings
+type GreetingEventSink interface {
+	Publish(event domain.GreetingEvent) error
+}
+
+// logGreetingEventSink writes greeting events as JSON lines to the application log
+type logGreetingEventSink struct{}
+
+// NewLogGreetingEventSink creates a GreetingEventSink writing to the application log
+func NewLogGreetingEventSink() GreetingEventSink {
+	return &logGreetingEventSink{}
+}
+
+func (s *logGreetingEventSink) Publish(event domain.GreetingEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	log.Printf("greeting_event %s", payload)
+	return nil
+}
+
+// schedulerBatchSize is the number of greetings read from the database at once during a scheduler run
+const schedulerBatchSize = 500
+
+// GreetingScheduler publishes the events of scheduled greetings going live or expiring,
+// and purges the greetings which expired longer than the retention period ago
+type GreetingScheduler interface {
+	// Start runs the scheduler in the background, once per interval of its clock, until Stop is called
+	Start()
+	Stop()
+	// Run publishes the events of the greetings which went live or expired since the previous run,
+	// then purges the greetings which expired before the retention period
+	Run() error
+}
+
+type greetingSchedulerImpl struct {
+	repo      repository.HelloRepository
+	sink      GreetingEventSink
+	clock     util.Clock
+	interval  time.Duration
+	retention time.Duration
+
+	mu      sync.Mutex
+	lastRun time.Time
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewGreetingScheduler creates a new instance of GreetingScheduler. The first run covers the time since its creation,
+// so greetings which went live or expired before are not reported. A zero interval disables Start,
+// and a zero retention disables the purge. The scheduler acts on the greetings of all tenants.
+func NewGreetingScheduler(repo repository.HelloRepository,
+	sink GreetingEventSink,
+	clock util.Clock,
+	interval time.Duration,
+	retention time.Duration) GreetingScheduler {
+	return &greetingSchedulerImpl{
+		repo:      repo,
+		sink:      sink,
+		clock:     clock,
+		interval:  interval,
+		retention: retention,
+		lastRun:   clock.Now(),
+	}
+}
+
+func (s *greetingSchedulerImpl) Start() {
+	if s.interval <= 0 {
+		log.Printf("Greeting scheduler is disabled")
+		return
+	}
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	go s.loop(s.stop, s.done)
+}
+
+func (s *greetingSchedulerImpl) Stop() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop, s.done = nil, nil
+}
+
+// loop runs the scheduler every interval until stop is closed
+func (s *greetingSchedulerImpl) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-s.clock.After(s.interval):
+			// A failed run is retried with the next one, which covers the same period
+			if err := s.Run(); err != nil {
+				log.Printf("Greeting scheduler run failed: %v", err)
+			}
+		}
+	}
+}
+
+func (s *greetingSchedulerImpl) Run() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if err := s.publishEvents(domain.GreetingEventPublished, repository.PublishedBetween(s.lastRun, now),
+		func(g domain.Greeting) time.Time { return *g.PublishAt }); err != nil {
+		return err
+	}
+	if err := s.publishEvents(domain.GreetingEventExpired, repository.ExpiredBetween(s.lastRun, now),
+		func(g domain.Greeting) time.Time { return *g.ExpireAt }); err != nil {
+		return err
+	}
+	s.lastRun = now
+
+	if s.retention <= 0 {
+		return nil
+	}
+	purged, err := s.repo.WithContext(allTenantsContext).PurgeExpired(now.Add(-s.retention))
+	if err != nil {
+		return fmt.Errorf("failed to purge expired greetings: %w", err)
+	}
+	if purged > 0 {
+		log.Printf("Purged %d greetings expired for more than %s", purged, s.retention)
+	}
+	return nil
+}
+
+// publishEvents publishes an event of the type for every greeting matching the specification.
+// Failures of the sink are logged, so one event cannot hold back the others.
+func (s *greetingSchedulerImpl) publishEvents(eventType domain.GreetingEventType, spec repository.Specification,
+	occurredAt func(domain.Greeting) time.Time) error {
+	err := s.repo.WithContext(allTenantsContext).FindAllInBatches(schedulerBatchSize, func(greetings []domain.Greeting) error {
+		for _, greeting := range greetings {
+			event := domain.GreetingEvent{
+				Type:       eventType,
+				GreetingID: greeting.ID,
+				Message:    greeting.Message,
+				Locale:     greeting.Locale,
+				OccurredAt: occurredAt(greeting),
+			}
+			if err := s.sink.Publish(event); err != nil {
+				log.Printf("Failed to publish greeting event %s for greeting %d: %v", eventType, greeting.ID, err)
+			}
+		}
+		return nil
+	}, spec)
+	if err != nil {
+		return fmt.Errorf("failed to fetch greetings for %s events: %w", eventType, err)
+	}
+	return nil
+}
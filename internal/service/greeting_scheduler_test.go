@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"gin-samples/internal/domain"
+	customMock "gin-samples/internal/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// channelGreetingEventSink sends the published greeting events to a channel
+type channelGreetingEventSink chan domain.GreetingEvent
+
+func (s channelGreetingEventSink) Publish(event domain.GreetingEvent) error {
+	s <- event
+	return nil
+}
+
+func TestGreetingScheduler_Run(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	start := time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)
+	clock := customMock.NewFakeClock(start)
+	sink := make(channelGreetingEventSink, 10)
+
+	publishAt := start.Add(30 * time.Second)
+	expireAt := start.Add(45 * time.Second)
+	published := domain.Greeting{ID: 1, Message: "Hello, Soon!", Locale: "en", PublishAt: &publishAt}
+	expired := domain.Greeting{ID: 2, Message: "Hello, Gone!", Locale: "en", ExpireAt: &expireAt}
+
+	// The published greetings are fetched before the expired ones
+	mockRepo.On("FindAllInBatches", schedulerBatchSize, mock.Anything).Return([]domain.Greeting{published}, nil).Once()
+	mockRepo.On("FindAllInBatches", schedulerBatchSize, mock.Anything).Return([]domain.Greeting{expired}, nil).Once()
+	mockRepo.On("PurgeExpired", start.Add(time.Minute).Add(-time.Hour)).Return(int64(3), nil)
+
+	scheduler := NewGreetingScheduler(mockRepo, sink, clock, time.Minute, time.Hour)
+
+	clock.Advance(time.Minute)
+	assert.NoError(t, scheduler.Run())
+
+	if assert.Len(t, sink, 2) {
+		assert.Equal(t, domain.GreetingEvent{Type: domain.GreetingEventPublished, GreetingID: 1,
+			Message: "Hello, Soon!", Locale: "en", OccurredAt: publishAt}, <-sink)
+		assert.Equal(t, domain.GreetingEvent{Type: domain.GreetingEventExpired, GreetingID: 2,
+			Message: "Hello, Gone!", Locale: "en", OccurredAt: expireAt}, <-sink)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGreetingScheduler_Run_Error(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	clock := customMock.NewFakeClock(time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC))
+
+	mockRepo.On("FindAllInBatches", schedulerBatchSize, mock.Anything).Return(nil, errors.New("database error"))
+
+	scheduler := NewGreetingScheduler(mockRepo, make(channelGreetingEventSink, 10), clock, time.Minute, time.Hour)
+
+	// Nothing is purged when the events cannot be published
+	assert.ErrorContains(t, scheduler.Run(), "database error")
+	mockRepo.AssertNotCalled(t, "PurgeExpired", mock.Anything)
+}
+
+func TestGreetingScheduler_Start(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	start := time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)
+	clock := customMock.NewFakeClock(start)
+	sink := make(channelGreetingEventSink, 10)
+
+	publishAt := start.Add(30 * time.Second)
+	published := domain.Greeting{ID: 1, Message: "Hello, Soon!", Locale: "en", PublishAt: &publishAt}
+	mockRepo.On("FindAllInBatches", schedulerBatchSize, mock.Anything).Return([]domain.Greeting{published}, nil).Once()
+	mockRepo.On("FindAllInBatches", schedulerBatchSize, mock.Anything).Return([]domain.Greeting{}, nil)
+
+	// Without a retention, nothing is purged
+	scheduler := NewGreetingScheduler(mockRepo, sink, clock, time.Minute, 0)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	// The scheduler waits for its clock, so nothing happens before the interval has passed
+	assert.Eventually(t, func() bool { return clock.Timers() == 1 }, time.Second, time.Millisecond)
+	clock.Advance(30 * time.Second)
+	assert.Empty(t, sink)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case event := <-sink:
+		assert.Equal(t, domain.GreetingEventPublished, event.Type)
+		assert.Equal(t, uint(1), event.GreetingID)
+	case <-time.After(time.Second):
+		assert.Fail(t, "The scheduler should publish an event once the interval has passed")
+	}
+	mockRepo.AssertNotCalled(t, "PurgeExpired", mock.Anything)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// greetingTemplateVariables lists the fields of greetingTemplateData which templates may use, e.g. {{.FirstName}}
+var greetingTemplateVariables = []string{"FirstName", "LastName", "Username"}
+
+// greetingTemplateFunctions lists the functions without arguments which templates may use, e.g. {{timeOfDay}}
+var greetingTemplateFunctions = []string{"timeOfDay"}
+
+// greetingTemplateData holds the values of the template variables for the caller
+type greetingTemplateData struct {
+	FirstName string
+	LastName  string
+	Username  string
+}
+
+// isGreetingTemplate reports whether the message has placeholders
+func isGreetingTemplate(message string) bool {
+	return strings.Contains(message, "{{")
+}
+
+// checkGreetingTemplate returns a ConstraintViolationError when the message is not a valid template.
+// Only plain variables and functions are accepted, so templates cannot loop, call methods or fail at render time.
+func checkGreetingTemplate(message string) error {
+	if !isGreetingTemplate(message) {
+		return nil
+	}
+
+	// Unknown functions are reported like unknown variables instead of failing the parse
+	tree := parse.New("greeting")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(message, "", "", map[string]*parse.Tree{}); err != nil {
+		return customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "template_syntax",
+			Field:         "message",
+			RejectedValue: message,
+			Message:       fmt.Sprintf("message must be a valid template: %s", strings.TrimPrefix(err.Error(), "template: ")),
+		}}}
+	}
+
+	var violations []dto.Violation
+	for _, node := range tree.Root.Nodes {
+		if violation, ok := checkTemplateNode(node); !ok {
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		return customError.ConstraintViolationError{Violations: violations}
+	}
+	return nil
+}
+
+// checkTemplateNode returns the violation of a node which is neither text nor a single known variable or function
+func checkTemplateNode(node parse.Node) (dto.Violation, bool) {
+	switch node := node.(type) {
+	case *parse.TextNode, *parse.CommentNode:
+		return dto.Violation{}, true
+	case *parse.ActionNode:
+		if len(node.Pipe.Decl) == 0 && len(node.Pipe.Cmds) == 1 && len(node.Pipe.Cmds[0].Args) == 1 {
+			switch arg := node.Pipe.Cmds[0].Args[0].(type) {
+			case *parse.FieldNode:
+				if len(arg.Ident) == 1 && slices.Contains(greetingTemplateVariables, arg.Ident[0]) {
+					return dto.Violation{}, true
+				}
+				return unknownTemplateVariable(node, "."+strings.Join(arg.Ident, ".")), false
+			case *parse.IdentifierNode:
+				if slices.Contains(greetingTemplateFunctions, arg.Ident) {
+					return dto.Violation{}, true
+				}
+				return unknownTemplateVariable(node, arg.Ident), false
+			}
+		}
+	}
+	return dto.Violation{
+		Code:          "template_expression",
+		Field:         "message",
+		RejectedValue: node.String(),
+		Message:       "message may only use placeholders such as {{.FirstName}} or {{timeOfDay}}",
+	}, false
+}
+
+// unknownTemplateVariable reports a placeholder using an unknown variable or function
+func unknownTemplateVariable(node parse.Node, name string) dto.Violation {
+	known := make([]string, 0, len(greetingTemplateVariables)+len(greetingTemplateFunctions))
+	for _, variable := range greetingTemplateVariables {
+		known = append(known, "."+variable)
+	}
+	known = append(known, greetingTemplateFunctions...)
+	return dto.Violation{
+		Code:          "template_variable",
+		Field:         "message",
+		RejectedValue: node.String(),
+		Message:       fmt.Sprintf("%s is not a known variable, use one of %s", name, strings.Join(known, ", ")),
+	}
+}
+
+// renderGreetingTemplate renders the message for the user at the given time.
+// Messages without placeholders, and messages stored before templates were validated, are returned as they are.
+func renderGreetingTemplate(message string, user domain.User, now time.Time) string {
+	if checkGreetingTemplate(message) != nil {
+		return message
+	}
+
+	tmpl, err := template.New("greeting").
+		Funcs(template.FuncMap{"timeOfDay": func() string { return timeOfDay(now) }}).
+		Parse(message)
+	if err != nil {
+		return message
+	}
+
+	data := greetingTemplateData{FirstName: user.FirstName, LastName: user.LastName, Username: user.Username}
+	if data.FirstName == "" {
+		data.FirstName = user.Username
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return message
+	}
+	return rendered.String()
+}
+
+// timeOfDay names the part of the day of the given time: morning, afternoon, evening or night
+func timeOfDay(t time.Time) string {
+	switch hour := t.Hour(); {
+	case hour >= 5 && hour < 12:
+		return "morning"
+	case hour >= 12 && hour < 17:
+		return "afternoon"
+	case hour >= 17 && hour < 22:
+		return "evening"
+	default:
+		return "night"
+	}
+}
+
+// renderGreetingFor maps the greeting to a response whose message is rendered for the user of ctx at now.
+// The user is read from userRepo, see findCurrentUser.
+func renderGreetingFor(ctx context.Context, userRepo repository.UserRepository, helloMapper mapper.HelloMapper,
+	greeting domain.Greeting, now time.Time) (dto.GreetingResponse, error) {
+	user, err := findCurrentUser(ctx, userRepo)
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+	response := helloMapper.ToGreetingResponse(greeting)
+	response.Message = renderGreetingTemplate(response.Message, user, now)
+	return response, nil
+}
+
+// renderTimeIn returns the current time of the clock in the time zone, or in UTC when it is empty
+func renderTimeIn(clock util.Clock, timeZone string) (time.Time, error) {
+	location := time.UTC
+	if timeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(timeZone); err != nil {
+			return time.Time{}, customError.ConstraintViolationError{Violations: []dto.Violation{{
+				Code:          "timezone",
+				Field:         "timeZone",
+				RejectedValue: timeZone,
+				Message:       "timeZone must be a valid IANA time zone",
+			}}}
+		}
+	}
+	return clock.Now().In(location), nil
+}
+
+// findCurrentUser returns the authenticated user of ctx from userRepo. Users authenticated without an account
+// in the database, e.g. by a static provider, only have their ID as username; requests without a user get an empty user.
+func findCurrentUser(ctx context.Context, userRepo repository.UserRepository) (domain.User, error) {
+	claims, ok := security.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.User{}, nil
+	}
+
+	optionalUser, err := userRepo.WithContext(ctx).FindByID(claims.UserID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to fetch user by ID: %w", err)
+	}
+	if optionalUser.IsEmpty() {
+		return domain.User{ID: claims.UserID, Username: claims.UserID}, nil
+	}
+	return *optionalUser.Value, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/domain"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+	"time"
+)
+
+// findVisibleGreetingOf returns the greeting if it is visible to the user of ctx at the time of the clock,
+// see isVisibleAt, or a ResourceNotFoundError otherwise. Greetings are read from helloRepo bound to ctx. The greeting,
+// comment and reaction services look up greetings with it, so a greeting is found by all of them or by none.
+func findVisibleGreetingOf(ctx context.Context, helloRepo repository.HelloRepository, id uint,
+	clock util.Clock) (domain.Greeting, error) {
+	optionalEntity, err := helloRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		return domain.Greeting{}, fmt.Errorf("failed to fetch greeting by ID: %w", err)
+	}
+
+	if optionalEntity.IsEmpty() || !isVisibleAt(ctx, *optionalEntity.Value, clock.Now()) {
+		return domain.Greeting{}, &customError.ResourceNotFoundError{
+			Resource: "Greeting",
+			Criteria: "id",
+			Value:    fmt.Sprintf("%d", id),
+		}
+	}
+	return *optionalEntity.Value, nil
+}
+
+// visibilityFiltersOf returns the specifications hiding the greetings the user of ctx may not see at the time
+// of the clock
+func visibilityFiltersOf(ctx context.Context, clock util.Clock) []repository.Specification {
+	if security.HasAuthority(ctx, security.AuthorityAdmin) {
+		return nil
+	}
+	moderation := repository.StatusEquals(domain.GreetingStatusApproved)
+	if userID := currentUserID(ctx); userID != "" {
+		moderation = repository.ApprovedOrOwnedBy(userID)
+	}
+	return []repository.Specification{repository.LiveAt(clock.Now()), moderation}
+}
+
+// isVisibleAt reports whether the greeting may be shown to the user of ctx at the given time
+func isVisibleAt(ctx context.Context, greeting domain.Greeting, t time.Time) bool {
+	return security.HasAuthority(ctx, security.AuthorityAdmin) ||
+		greeting.IsLiveAt(t) && isApprovedOrOwned(ctx, greeting)
+}
+
+// isApprovedOrOwned reports whether the moderation state of the greeting lets the user of ctx see it.
+// Approved greetings are visible to everyone, others only to their owner and admins.
+func isApprovedOrOwned(ctx context.Context, greeting domain.Greeting) bool {
+	if greeting.Status == domain.GreetingStatusApproved || security.HasAuthority(ctx, security.AuthorityAdmin) {
+		return true
+	}
+	userID := currentUserID(ctx)
+	return userID != "" && greeting.OwnerID == userID
+}
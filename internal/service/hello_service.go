@@ -1,151 +1,1033 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"gin-samples/internal/domain"
 	"gin-samples/internal/dto"
 	customError "gin-samples/internal/error"
 	"gin-samples/internal/mapper"
 	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
 	"gin-samples/internal/util"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// GreetingPatch transforms the updatable fields of a greeting, e.g. by applying a JSON patch document
+type GreetingPatch func(dto.GreetingInput) (dto.GreetingInput, error)
+
+// HelloService manages greetings. Methods changing greetings take the context of the request,
+// whose authenticated user is recorded in the revision history and owns the greetings it creates. Methods reading
+// greetings take it as well, as unpublished and expired greetings are only visible to admins, greetings which are
+// not approved only to admins and their owner, and templated messages are rendered for its user.
 type HelloService interface {
-	GetGreeting() dto.GreetingResponse
-	CreateGreeting(input dto.GreetingInput) (dto.GreetingResponse, error)
-	GetAllGreetings() ([]dto.GreetingResponse, error)
-	GetGreetingByID(id uint) (dto.GreetingResponse, error)
-	UpdateGreeting(id uint, input dto.GreetingInput) (dto.GreetingResponse, error)
-	DeleteGreeting(id uint) error
+	GetGreeting(ctx context.Context, languages []string, query dto.GreetingRenderQuery) (dto.GreetingResponse, error)
+	ResolveLocale(ctx context.Context, languages []string) (string, error)
+	CreateGreeting(ctx context.Context, input dto.GreetingInput) (dto.GreetingResponse, error)
+	GetAllGreetings(ctx context.Context, query dto.GreetingQuery) (dto.PagedResponse[dto.GreetingResponse], error)
+	GetGreetingsByCursor(ctx context.Context, query dto.CursorQuery,
+		locale string) (dto.CursorPagedResponse[dto.GreetingResponse], error)
+	SearchGreetings(ctx context.Context, query dto.GreetingSearchQuery) (dto.PagedResponse[dto.GreetingSearchResponse], error)
+	GetGreetingByID(ctx context.Context, id uint) (dto.GreetingResponse, error)
+	RenderGreeting(ctx context.Context, id uint, query dto.GreetingRenderQuery) (dto.RenderedGreetingResponse, error)
+	GetRandomGreeting(ctx context.Context, query dto.RandomGreetingQuery) (dto.GreetingResponse, error)
+	UpdateGreeting(ctx context.Context, id uint, input dto.GreetingInput,
+		precondition *dto.VersionPrecondition) (dto.GreetingResponse, error)
+	PatchGreeting(ctx context.Context, id uint, patch GreetingPatch,
+		precondition *dto.VersionPrecondition) (dto.GreetingResponse, error)
+	DeleteGreeting(ctx context.Context, id uint, precondition *dto.VersionPrecondition) error
+	GetDeletedGreetings(ctx context.Context, query dto.GreetingTrashQuery) (dto.PagedResponse[dto.GreetingResponse], error)
+	RestoreGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error)
+	PurgeGreeting(ctx context.Context, id uint) error
+	BulkCreateGreetings(ctx context.Context, inputs []dto.GreetingInput, mode string,
+		validate func(dto.GreetingInput) error) ([]BulkResult[dto.GreetingResponse], error)
+	BulkUpdateGreetings(ctx context.Context, updates []dto.BulkGreetingUpdate, mode string,
+		validate func(dto.BulkGreetingUpdate) error) ([]BulkResult[dto.GreetingResponse], error)
+	BulkDeleteGreetings(ctx context.Context, deletes []dto.BulkGreetingDelete, mode string,
+		validate func(dto.BulkGreetingDelete) error) ([]BulkResult[struct{}], error)
+	ExportGreetings(ctx context.Context, query dto.GreetingExportQuery, write func([]dto.GreetingResponse) error) error
+	ImportGreetings(ctx context.Context, rows []GreetingImportRow, query dto.GreetingImportQuery,
+		validate func(dto.GreetingInput) error) ([]GreetingImportResult, error)
+}
+
+// GreetingImportRow is a row read from an import file; Err is set when the row could not be read
+type GreetingImportRow struct {
+	Line  int
+	Input dto.GreetingInput
+	Err   error
+}
+
+// GreetingImportResult is the outcome of an imported row
+type GreetingImportResult struct {
+	Line    int
+	Outcome string
+	ID      uint
+	Err     error
+}
+
+// greetingSortColumns maps the sortable greeting properties to their columns
+var greetingSortColumns = map[string]string{
+	"id":        "id",
+	"message":   "message",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// deletedGreetingSortColumns maps the sortable properties of deleted greetings to their columns
+var deletedGreetingSortColumns = map[string]string{
+	"id":        "id",
+	"message":   "message",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+	"deletedAt": "deleted_at",
 }
 
+// maxTagNameLength is the maximum number of characters of a tag name
+const maxTagNameLength = 50
+
+// defaultDeletedGreetingSort lists the most recently deleted greetings first
+var defaultDeletedGreetingSort = []string{"deletedAt,desc"}
+
+// exportBatchSize is the number of greetings read from the database at once during an export
+const exportBatchSize = 500
+
+// errDryRun rolls back a dry-run import
+var errDryRun = errors.New("dry run")
+
+// staticGreetings holds the static greeting message per locale
+var staticGreetings = map[string]string{
+	"en": "Hello, World!",
+	"de": "Hallo, Welt!",
+	"es": "¡Hola, Mundo!",
+	"fr": "Bonjour, le monde !",
+	"pt": "Olá, Mundo!",
+	"tr": "Merhaba, Dünya!",
+}
+
+// fallbackStaticLocale is used when neither the preferred nor the default locale has a static greeting
+const fallbackStaticLocale = "en"
+
 type helloServiceImpl struct {
-	repo   repository.HelloRepository
-	clock  util.Clock
-	mapper mapper.HelloMapper
+	repo          repository.HelloRepository
+	userRepo      repository.UserRepository
+	clock         util.Clock
+	mapper        mapper.HelloMapper
+	cursorCodec   security.CursorCodec
+	defaultLocale string
 }
 
-// NewHelloService creates a new instance of helloServiceImpl
+// NewHelloService creates a new instance of helloServiceImpl.
+// Greetings created without a locale, and requests without a matching locale, use defaultLocale.
+// Templated messages are rendered with the users of userRepo.
 func NewHelloService(repo repository.HelloRepository,
+	userRepo repository.UserRepository,
 	mapper mapper.HelloMapper,
-	clock util.Clock) HelloService {
+	clock util.Clock,
+	cursorCodec security.CursorCodec,
+	defaultLocale string) HelloService {
 	return &helloServiceImpl{
-		repo:   repo,
-		clock:  clock,
-		mapper: mapper,
+		repo:          repo,
+		userRepo:      userRepo,
+		clock:         clock,
+		mapper:        mapper,
+		cursorCodec:   cursorCodec,
+		defaultLocale: defaultLocale,
 	}
 }
 
-// GetGreeting returns a static greeting message
-func (s *helloServiceImpl) GetGreeting() dto.GreetingResponse {
-	now := s.clock.Now()
+// GetGreeting returns a greeting in the best matching locale having one: the most recently updated live template
+// of the locale rendered for the user of ctx, or else its static greeting message
+func (s *helloServiceImpl) GetGreeting(ctx context.Context, languages []string,
+	query dto.GreetingRenderQuery) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	now, err := renderTimeIn(s.clock, query.TimeZone)
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+
+	locale := fallbackStaticLocale
+	pageable := repository.Pageable{Size: 1, Sort: []repository.SortOrder{{Column: "updated_at", Desc: true}}}
+	for _, candidate := range util.LocaleChain(languages, s.defaultLocale) {
+		page, err := s.repo.FindAllPaged(pageable,
+			repository.LocaleEquals(candidate), repository.IsTemplate(), repository.LiveAt(now),
+			repository.StatusEquals(domain.GreetingStatusApproved))
+		if err != nil {
+			return dto.GreetingResponse{}, fmt.Errorf("failed to fetch greeting templates: %w", err)
+		}
+		if len(page.Content) > 0 {
+			return renderGreetingFor(ctx, s.userRepo, s.mapper, page.Content[0], now)
+		}
+		if _, found := staticGreetings[candidate]; found {
+			locale = candidate
+			break
+		}
+	}
+
 	return dto.GreetingResponse{
 		ID:        0,
-		Message:   "Hello, World!",
+		Message:   staticGreetings[locale],
+		Locale:    locale,
 		CreatedAt: now,
 		UpdatedAt: now,
+	}, nil
+}
+
+// ResolveLocale returns the best matching locale having greetings visible to the user of ctx, falling back
+// from e.g. pt-BR to pt and finally to the default locale
+func (s *helloServiceImpl) ResolveLocale(ctx context.Context, languages []string) (string, error) {
+	s = s.scoped(ctx)
+	locales, err := s.repo.FindLocales(s.visibilityFilters(ctx)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch greeting locales: %w", err)
+	}
+
+	available := make(map[string]bool, len(locales))
+	for _, locale := range locales {
+		available[locale] = true
+	}
+	for _, candidate := range util.LocaleChain(languages, "") {
+		if available[candidate] {
+			return candidate, nil
+		}
+	}
+	return s.defaultLocale, nil
+}
+
+// CreateGreeting creates a new greeting and records its first revision
+func (s *helloServiceImpl) CreateGreeting(ctx context.Context, input dto.GreetingInput) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	if err := checkGreetingTemplate(input.Message); err != nil {
+		return dto.GreetingResponse{}, err
+	}
+	if err := checkSchedule(input.PublishAt, input.ExpireAt); err != nil {
+		return dto.GreetingResponse{}, err
+	}
+	locale, err := s.normalizeLocale(input.Locale)
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+	input.Locale = locale
+	if input.Tags, err = normalizeTags(input.Tags); err != nil {
+		return dto.GreetingResponse{}, err
 	}
+
+	var response dto.GreetingResponse
+	err = s.transaction(func(tx *helloServiceImpl) error {
+		if err := tx.checkMessageIsUnique(input.Message, input.Locale); err != nil {
+			return err
+		}
+
+		entity := tx.mapper.ToGreetingEntity(input)
+		entity.Status = initialStatus(ctx, input.Draft)
+		entity.OwnerID = currentUserID(ctx)
+		savedEntity, err := tx.repo.Save(entity)
+		if err != nil {
+			return fmt.Errorf("failed to save greeting: %w", err)
+		}
+
+		if err := tx.recordRevision(ctx, domain.GreetingRevision{Action: domain.GreetingRevisionCreate}, nil, &savedEntity); err != nil {
+			return err
+		}
+
+		response = tx.mapper.ToGreetingResponse(savedEntity)
+		return nil
+	})
+	return response, err
 }
 
-// CreateGreeting creates a new greeting
-func (s *helloServiceImpl) CreateGreeting(input dto.GreetingInput) (dto.GreetingResponse, error) {
-	exists, err := s.repo.ExistsByMessage(input.Message)
+// GetAllGreetings retrieves a page of the visible greetings matching the query filters
+func (s *helloServiceImpl) GetAllGreetings(ctx context.Context,
+	query dto.GreetingQuery) (dto.PagedResponse[dto.GreetingResponse], error) {
+	s = s.scoped(ctx)
+	pageable, err := toPageable(query.Page, query.Size, query.Sort, greetingSortColumns)
 	if err != nil {
-		return dto.GreetingResponse{}, fmt.Errorf("failed to check existence: %w", err)
+		return dto.PagedResponse[dto.GreetingResponse]{}, err
 	}
-	if exists {
-		return dto.GreetingResponse{}, &customError.ResourceConflictError{
-			Resource: "Greeting",
-			Criteria: "message",
-			Value:    input.Message,
+
+	specs := greetingFilters(query.Locale, query.Message, query.CreatedBefore, query.CreatedAfter)
+	if len(query.Tags) > 0 {
+		tagSpec, err := tagFilter(query.Tags, query.TagMatch)
+		if err != nil {
+			return dto.PagedResponse[dto.GreetingResponse]{}, err
 		}
+		specs = append(specs, tagSpec)
+	}
+	specs = append(specs, s.visibilityFilters(ctx)...)
+	page, err := s.repo.FindAllPaged(pageable, specs...)
+	if err != nil {
+		return dto.PagedResponse[dto.GreetingResponse]{}, fmt.Errorf("failed to fetch greetings: %w", err)
 	}
 
-	entity := s.mapper.ToGreetingEntity(input)
-	savedEntity, err := s.repo.Save(entity)
+	return toPagedResponse(page, s.mapper.ToGreetingResponses(page.Content)), nil
+}
+
+// GetGreetingsByCursor retrieves the page of visible greetings the cursor points to, or the first page without a cursor.
+// When locale is not empty, only the greetings in that locale are listed.
+func (s *helloServiceImpl) GetGreetingsByCursor(ctx context.Context, query dto.CursorQuery,
+	locale string) (dto.CursorPagedResponse[dto.GreetingResponse], error) {
+	s = s.scoped(ctx)
+	pageable, err := toKeysetPageable(s.cursorCodec, query, greetingSortColumns)
+	if err != nil {
+		return dto.CursorPagedResponse[dto.GreetingResponse]{}, err
+	}
+
+	specs := s.visibilityFilters(ctx)
+	if locale != "" {
+		specs = append(specs, repository.LocaleEquals(locale))
+	}
+
+	page, err := s.repo.FindAllByKeyset(pageable, specs...)
 	if err != nil {
-		return dto.GreetingResponse{}, fmt.Errorf("failed to save greeting: %w", err)
+		return dto.CursorPagedResponse[dto.GreetingResponse]{}, fmt.Errorf("failed to fetch greetings: %w", err)
 	}
 
-	return s.mapper.ToGreetingResponse(savedEntity), nil
+	return toCursorPagedResponse(s.cursorCodec, page, query.Size, s.mapper.ToGreetingResponses(page.Content))
 }
 
-// GetAllGreetings retrieves all greetings
-func (s *helloServiceImpl) GetAllGreetings() ([]dto.GreetingResponse, error) {
-	entities, err := s.repo.FindAll()
+// SearchGreetings retrieves a page of visible greetings matching the search text, most relevant first
+func (s *helloServiceImpl) SearchGreetings(ctx context.Context,
+	query dto.GreetingSearchQuery) (dto.PagedResponse[dto.GreetingSearchResponse], error) {
+	s = s.scoped(ctx)
+	page, err := s.repo.Search(query.Q, repository.Pageable{Page: query.Page, Size: query.Size}, s.visibilityFilters(ctx)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch greetings: %w", err)
+		return dto.PagedResponse[dto.GreetingSearchResponse]{}, fmt.Errorf("failed to search greetings: %w", err)
 	}
 
-	return s.mapper.ToGreetingResponses(entities), nil
+	return toPagedResponse(page, s.mapper.ToGreetingSearchResponses(page.Content)), nil
 }
 
-// GetGreetingByID retrieves a greeting by its ID
-func (s *helloServiceImpl) GetGreetingByID(id uint) (dto.GreetingResponse, error) {
-	optionalEntity, err := s.repo.FindByID(id)
+// GetGreetingByID retrieves a greeting by its ID. Unpublished and expired greetings are only found by admins.
+func (s *helloServiceImpl) GetGreetingByID(ctx context.Context, id uint) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	greeting, err := s.findVisibleGreeting(ctx, id)
 	if err != nil {
-		return dto.GreetingResponse{}, fmt.Errorf("failed to fetch greeting by ID: %w", err)
+		return dto.GreetingResponse{}, err
 	}
+	return s.mapper.ToGreetingResponse(greeting), nil
+}
 
-	if optionalEntity.IsEmpty() {
+// findVisibleGreeting returns the greeting, or a ResourceNotFoundError when it does not exist
+// or is not visible to the user of ctx, see findVisibleGreetingOf
+func (s *helloServiceImpl) findVisibleGreeting(ctx context.Context, id uint) (domain.Greeting, error) {
+	return findVisibleGreetingOf(ctx, s.repo, id, s.clock)
+}
+
+// RenderGreeting renders the message of a visible greeting for the user of ctx at the request time
+func (s *helloServiceImpl) RenderGreeting(ctx context.Context, id uint,
+	query dto.GreetingRenderQuery) (dto.RenderedGreetingResponse, error) {
+	s = s.scoped(ctx)
+	now, err := renderTimeIn(s.clock, query.TimeZone)
+	if err != nil {
+		return dto.RenderedGreetingResponse{}, err
+	}
+	greeting, err := s.GetGreetingByID(ctx, id)
+	if err != nil {
+		return dto.RenderedGreetingResponse{}, err
+	}
+	user, err := findCurrentUser(ctx, s.userRepo)
+	if err != nil {
+		return dto.RenderedGreetingResponse{}, err
+	}
+
+	response := dto.RenderedGreetingResponse{GreetingResponse: greeting, Template: greeting.Message, RenderedAt: now}
+	response.Message = renderGreetingTemplate(greeting.Message, user, now)
+	return response, nil
+}
+
+// GetRandomGreeting picks a live greeting matching the query filters at random and renders it for the user of ctx
+func (s *helloServiceImpl) GetRandomGreeting(ctx context.Context, query dto.RandomGreetingQuery) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	now, err := renderTimeIn(s.clock, query.TimeZone)
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+
+	specs := []repository.Specification{repository.LiveAt(now), repository.StatusEquals(domain.GreetingStatusApproved)}
+	var filters []string
+	if query.Locale != "" {
+		locale, err := s.normalizeLocale(query.Locale)
+		if err != nil {
+			return dto.GreetingResponse{}, err
+		}
+		specs = append(specs, repository.LocaleEquals(locale))
+		filters = append(filters, "locale "+locale)
+	}
+	if len(query.Tags) > 0 {
+		tagSpec, err := tagFilter(query.Tags, query.TagMatch)
+		if err != nil {
+			return dto.GreetingResponse{}, err
+		}
+		specs = append(specs, tagSpec)
+		filters = append(filters, fmt.Sprintf("%s of the tags %s", query.TagMatch, strings.Join(query.Tags, ", ")))
+	}
+
+	greeting, err := pickGreetingOf(s.repo, rand.Int64N, specs...)
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+	if greeting == nil {
+		if len(filters) == 0 {
+			filters = append(filters, "no filters")
+		}
 		return dto.GreetingResponse{}, &customError.ResourceNotFoundError{
 			Resource: "Greeting",
-			Criteria: "id",
-			Value:    fmt.Sprintf("%d", id),
+			Criteria: "filters",
+			Value:    strings.Join(filters, " and "),
 		}
 	}
+	return renderGreetingFor(ctx, s.userRepo, s.mapper, *greeting, now)
+}
+
+// pickGreetingOf returns the greeting at the position chosen by index among the n greetings of helloRepo matching
+// the specs, ordered by ID, or nil when no greeting matches. Random greetings and the greeting of the day are picked
+// with it.
+func pickGreetingOf(helloRepo repository.HelloRepository, index func(n int64) int64,
+	specs ...repository.Specification) (*domain.Greeting, error) {
+	var greeting *domain.Greeting
+	err := helloRepo.Transaction(func(tx repository.HelloRepository) error {
+		pageable := repository.Pageable{Size: 1, Sort: []repository.SortOrder{{Column: "id"}}}
+		page, err := tx.FindAllPaged(pageable, specs...)
+		if err != nil {
+			return fmt.Errorf("failed to fetch greetings: %w", err)
+		}
+		if page.TotalElements == 0 {
+			return nil
+		}
 
-	return s.mapper.ToGreetingResponse(*optionalEntity.Value), nil
+		if pageable.Page = int(index(page.TotalElements)); pageable.Page > 0 {
+			if page, err = tx.FindAllPaged(pageable, specs...); err != nil {
+				return fmt.Errorf("failed to fetch greetings: %w", err)
+			}
+		}
+		if len(page.Content) > 0 {
+			greeting = &page.Content[0]
+		}
+		return nil
+	})
+	return greeting, err
 }
 
 // UpdateGreeting updates an existing greeting by ID
-func (s *helloServiceImpl) UpdateGreeting(id uint, input dto.GreetingInput) (dto.GreetingResponse, error) {
-	// Fetch the existing greeting
-	optionalEntity, err := s.repo.FindByID(id)
+func (s *helloServiceImpl) UpdateGreeting(ctx context.Context, id uint, input dto.GreetingInput,
+	precondition *dto.VersionPrecondition) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	var err error
+	if input.Locale != "" {
+		if input.Locale, err = s.normalizeLocale(input.Locale); err != nil {
+			return dto.GreetingResponse{}, err
+		}
+	}
+	if input.Tags, err = normalizeTags(input.Tags); err != nil {
+		return dto.GreetingResponse{}, err
+	}
+
+	update := domain.GreetingRevision{Action: domain.GreetingRevisionUpdate}
+	return updateGreetingOf(ctx, s.repo, s.mapper, s.clock, id, precondition, update, func(entity *domain.Greeting) error {
+		s.mapper.PartialUpdateGreeting(entity, input)
+		return nil
+	})
+}
+
+// PatchGreeting applies the patch to the updatable fields of a greeting and replaces the greeting with the result.
+// Unlike UpdateGreeting, every field is applied, so a removed locale falls back to the default locale.
+func (s *helloServiceImpl) PatchGreeting(ctx context.Context, id uint, patch GreetingPatch,
+	precondition *dto.VersionPrecondition) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	update := domain.GreetingRevision{Action: domain.GreetingRevisionUpdate}
+	return updateGreetingOf(ctx, s.repo, s.mapper, s.clock, id, precondition, update, func(entity *domain.Greeting) error {
+		input, err := patch(dto.GreetingInput{Message: entity.Message, Locale: entity.Locale,
+			PublishAt: entity.PublishAt, ExpireAt: entity.ExpireAt, Tags: entity.TagNames()})
+		if err != nil {
+			return err
+		}
+		if input.Locale, err = s.normalizeLocale(input.Locale); err != nil {
+			return err
+		}
+		if input.Tags, err = normalizeTags(input.Tags); err != nil {
+			return err
+		}
+		s.mapper.UpdateGreeting(entity, input)
+		return nil
+	})
+}
+
+// updateGreetingOf applies an update to the existing greeting of helloRepo and saves it along with the revision,
+// provided the user of ctx owns the greeting or is an admin, the greeting matches the precondition
+// and is not modified concurrently. Greetings are updated and rolled back to a revision with it.
+func updateGreetingOf(ctx context.Context, helloRepo repository.HelloRepository, helloMapper mapper.HelloMapper,
+	clock util.Clock, id uint, precondition *dto.VersionPrecondition, revision domain.GreetingRevision,
+	apply func(*domain.Greeting) error) (dto.GreetingResponse, error) {
+	var response dto.GreetingResponse
+	err := helloRepo.Transaction(func(tx repository.HelloRepository) error {
+		// Fetch the existing greeting
+		existingEntity, err := findVisibleGreetingOf(ctx, tx, id, clock)
+		if err != nil {
+			return err
+		}
+		if err := checkOwner(ctx, existingEntity); err != nil {
+			return err
+		}
+
+		if err := checkPrecondition(precondition, existingEntity); err != nil {
+			return err
+		}
+
+		// Apply the update to a copy, so the existing values are kept for the revision
+		updatedEntity := existingEntity
+		if err := apply(&updatedEntity); err != nil {
+			return err
+		}
+
+		// Changes by users other than admins are moderated again
+		contentChanged := updatedEntity.Message != existingEntity.Message || updatedEntity.Locale != existingEntity.Locale
+		moderated := existingEntity.Status == domain.GreetingStatusApproved || existingEntity.Status == domain.GreetingStatusRejected
+		if contentChanged && moderated && !security.HasAuthority(ctx, security.AuthorityAdmin) {
+			updatedEntity.Status = domain.GreetingStatusPending
+			updatedEntity.RejectionReason = nil
+		}
+		if updatedEntity.Message != existingEntity.Message {
+			if err := checkGreetingTemplate(updatedEntity.Message); err != nil {
+				return err
+			}
+		}
+		if err := checkSchedule(updatedEntity.PublishAt, updatedEntity.ExpireAt); err != nil {
+			return err
+		}
+
+		// The message must stay unique within its locale
+		if contentChanged {
+			if err := checkMessageIsUniqueIn(tx, updatedEntity.Message, updatedEntity.Locale); err != nil {
+				return err
+			}
+		}
+
+		// Save the updated entity
+		savedEntity, err := tx.Save(updatedEntity)
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return lostUpdateError(precondition, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update greeting: %w", err)
+		}
+
+		if err := recordRevisionOf(ctx, tx, clock, revision, &existingEntity, &savedEntity); err != nil {
+			return err
+		}
+
+		// Map the updated entity to response DTO
+		response = helloMapper.ToGreetingResponse(savedEntity)
+		return nil
+	})
+	return response, err
+}
+
+// DeleteGreeting moves a greeting to the trash, from where it can be restored or purged.
+// Only its owner and admins may delete it.
+func (s *helloServiceImpl) DeleteGreeting(ctx context.Context, id uint, precondition *dto.VersionPrecondition) error {
+	s = s.scoped(ctx)
+	return s.transaction(func(tx *helloServiceImpl) error {
+		// Check if the greeting exists
+		existingEntity, err := tx.findVisibleGreeting(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkOwner(ctx, existingEntity); err != nil {
+			return err
+		}
+
+		if err := checkPrecondition(precondition, existingEntity); err != nil {
+			return err
+		}
+
+		// Delete the entity, unless it was updated since it was read
+		err = tx.repo.Delete(existingEntity)
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return lostUpdateError(precondition, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete greeting: %w", err)
+		}
+
+		return tx.recordRevision(ctx, domain.GreetingRevision{Action: domain.GreetingRevisionDelete}, &existingEntity, nil)
+	})
+}
+
+// GetDeletedGreetings retrieves a page of the greetings in the trash
+func (s *helloServiceImpl) GetDeletedGreetings(ctx context.Context, query dto.GreetingTrashQuery) (dto.PagedResponse[dto.GreetingResponse], error) {
+	s = s.scoped(ctx)
+	sort := query.Sort
+	if len(sort) == 0 {
+		sort = defaultDeletedGreetingSort
+	}
+	pageable, err := toPageable(query.Page, query.Size, sort, deletedGreetingSortColumns)
 	if err != nil {
-		return dto.GreetingResponse{}, fmt.Errorf("failed to fetch greeting by ID: %w", err)
+		return dto.PagedResponse[dto.GreetingResponse]{}, err
+	}
+
+	page, err := s.repo.FindAllDeletedPaged(pageable)
+	if err != nil {
+		return dto.PagedResponse[dto.GreetingResponse]{}, fmt.Errorf("failed to fetch deleted greetings: %w", err)
+	}
+
+	return toPagedResponse(page, s.mapper.ToGreetingResponses(page.Content)), nil
+}
+
+// RestoreGreeting moves a greeting out of the trash, unless its message was reused in the meantime
+func (s *helloServiceImpl) RestoreGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	var response dto.GreetingResponse
+	err := s.transaction(func(tx *helloServiceImpl) error {
+		deletedEntity, err := tx.findDeletedGreeting(id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.checkMessageIsUnique(deletedEntity.Message, deletedEntity.Locale); err != nil {
+			return err
+		}
+
+		restoredEntity, err := tx.repo.Restore(deletedEntity)
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return lostUpdateError(nil, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore greeting: %w", err)
+		}
+
+		if err := tx.recordRevision(ctx, domain.GreetingRevision{Action: domain.GreetingRevisionRestore}, nil, &restoredEntity); err != nil {
+			return err
+		}
+
+		response = tx.mapper.ToGreetingResponse(restoredEntity)
+		return nil
+	})
+	return response, err
+}
+
+// PurgeGreeting permanently deletes a greeting in the trash along with its revisions
+func (s *helloServiceImpl) PurgeGreeting(ctx context.Context, id uint) error {
+	s = s.scoped(ctx)
+	deletedEntity, err := s.findDeletedGreeting(id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Purge(deletedEntity)
+	if errors.Is(err, repository.ErrOptimisticLock) {
+		return lostUpdateError(nil, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to purge greeting: %w", err)
+	}
+
+	return nil
+}
+
+// findDeletedGreeting returns the greeting in the trash, or a ResourceNotFoundError when it is not in the trash
+func (s *helloServiceImpl) findDeletedGreeting(id uint) (domain.Greeting, error) {
+	optionalEntity, err := s.repo.FindDeletedByID(id)
+	if err != nil {
+		return domain.Greeting{}, fmt.Errorf("failed to fetch deleted greeting by ID: %w", err)
 	}
 
 	if optionalEntity.IsEmpty() {
-		return dto.GreetingResponse{}, &customError.ResourceNotFoundError{
-			Resource: "Greeting",
+		return domain.Greeting{}, &customError.ResourceNotFoundError{
+			Resource: "deleted Greeting",
 			Criteria: "id",
 			Value:    fmt.Sprintf("%d", id),
 		}
 	}
+	return *optionalEntity.Value, nil
+}
+
+// BulkCreateGreetings validates and creates the greetings in one transaction, see runBulk for the modes
+func (s *helloServiceImpl) BulkCreateGreetings(ctx context.Context, inputs []dto.GreetingInput, mode string,
+	validate func(dto.GreetingInput) error) ([]BulkResult[dto.GreetingResponse], error) {
+	s = s.scoped(ctx)
+	return runBulk(s.repo, inputs, mode, validate,
+		func(tx repository.HelloRepository, input dto.GreetingInput) (dto.GreetingResponse, error) {
+			return s.withRepository(tx).CreateGreeting(ctx, input)
+		})
+}
 
-	// Apply partial update using the mapper
-	existingEntity := optionalEntity.Value
-	s.mapper.PartialUpdateGreeting(existingEntity, input)
+// BulkUpdateGreetings validates and updates the greetings in one transaction, see runBulk for the modes.
+// Updates carrying a version only apply to that version of the greeting.
+func (s *helloServiceImpl) BulkUpdateGreetings(ctx context.Context, updates []dto.BulkGreetingUpdate, mode string,
+	validate func(dto.BulkGreetingUpdate) error) ([]BulkResult[dto.GreetingResponse], error) {
+	s = s.scoped(ctx)
+	return runBulk(s.repo, updates, mode, validate,
+		func(tx repository.HelloRepository, update dto.BulkGreetingUpdate) (dto.GreetingResponse, error) {
+			return s.withRepository(tx).UpdateGreeting(ctx, update.ID, update.GreetingInput, versionPrecondition(update.Version))
+		})
+}
 
-	// Save the updated entity
-	updatedEntity, err := s.repo.Save(*existingEntity)
+// BulkDeleteGreetings validates and deletes the greetings in one transaction, see runBulk for the modes.
+// Deletes carrying a version only apply to that version of the greeting.
+func (s *helloServiceImpl) BulkDeleteGreetings(ctx context.Context, deletes []dto.BulkGreetingDelete, mode string,
+	validate func(dto.BulkGreetingDelete) error) ([]BulkResult[struct{}], error) {
+	s = s.scoped(ctx)
+	return runBulk(s.repo, deletes, mode, validate,
+		func(tx repository.HelloRepository, del dto.BulkGreetingDelete) (struct{}, error) {
+			return struct{}{}, s.withRepository(tx).DeleteGreeting(ctx, del.ID, versionPrecondition(del.Version))
+		})
+}
+
+// ExportGreetings passes all greetings matching the filters to write, in batches ordered by ID
+func (s *helloServiceImpl) ExportGreetings(ctx context.Context, query dto.GreetingExportQuery, write func([]dto.GreetingResponse) error) error {
+	s = s.scoped(ctx)
+	locale := query.Locale
+	if locale != "" {
+		var err error
+		if locale, err = s.normalizeLocale(locale); err != nil {
+			return err
+		}
+	}
+
+	specs := greetingFilters(locale, query.Message, query.CreatedBefore, query.CreatedAfter)
+	err := s.repo.FindAllInBatches(exportBatchSize, func(batch []domain.Greeting) error {
+		return write(s.mapper.ToGreetingResponses(batch))
+	}, specs...)
 	if err != nil {
-		return dto.GreetingResponse{}, fmt.Errorf("failed to update greeting: %w", err)
+		return fmt.Errorf("failed to export greetings: %w", err)
 	}
+	return nil
+}
 
-	// Map the updated entity to response DTO
-	return s.mapper.ToGreetingResponse(updatedEntity), nil
+// ImportGreetings validates and creates the greetings of the rows in one transaction, each row in its own savepoint,
+// so failed rows do not affect the others. Greetings whose message already exists in their locale are skipped,
+// overwritten or failed depending on query.OnDuplicate. A dry run reports the same outcomes, but rolls back everything.
+func (s *helloServiceImpl) ImportGreetings(ctx context.Context, rows []GreetingImportRow, query dto.GreetingImportQuery,
+	validate func(dto.GreetingInput) error) ([]GreetingImportResult, error) {
+	s = s.scoped(ctx)
+	results := make([]GreetingImportResult, len(rows))
+	err := s.repo.Transaction(func(tx repository.HelloRepository) error {
+		for i, row := range rows {
+			err := row.Err
+			if err == nil {
+				err = validate(row.Input)
+			}
+			if err == nil {
+				err = tx.Transaction(func(rowTx repository.HelloRepository) error {
+					var err error
+					results[i], err = s.withRepository(rowTx).importGreeting(ctx, row.Input, query.OnDuplicate)
+					return err
+				})
+			}
+			if err != nil {
+				results[i] = GreetingImportResult{Outcome: dto.ImportOutcomeFailed, Err: err}
+			}
+			results[i].Line = row.Line
+			// Rolled back greetings have no ID
+			if query.DryRun {
+				results[i].ID = 0
+			}
+		}
+		if query.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, fmt.Errorf("failed to import greetings: %w", err)
+	}
+	return results, nil
 }
 
-func (s *helloServiceImpl) DeleteGreeting(id uint) error {
-	// Check if the greeting exists
-	optionalEntity, err := s.repo.FindByID(id)
+// importGreeting creates a greeting, or handles the existing greeting with the same message and locale.
+// Overwriting applies the schedule and tags of the row like UpdateGreeting, keeping those the row omits.
+func (s *helloServiceImpl) importGreeting(ctx context.Context, input dto.GreetingInput,
+	onDuplicate string) (GreetingImportResult, error) {
+	if err := checkGreetingTemplate(input.Message); err != nil {
+		return GreetingImportResult{}, err
+	}
+	locale, err := s.normalizeLocale(input.Locale)
 	if err != nil {
-		return fmt.Errorf("failed to fetch greeting by ID: %w", err)
+		return GreetingImportResult{}, err
+	}
+	input.Locale = locale
+	if input.Tags, err = normalizeTags(input.Tags); err != nil {
+		return GreetingImportResult{}, err
 	}
 
-	if optionalEntity.IsEmpty() {
-		return &customError.ResourceNotFoundError{
-			Resource: "Greeting",
-			Criteria: "id",
-			Value:    fmt.Sprintf("%d", id),
+	exists, err := s.repo.ExistsByMessage(input.Message, input.Locale)
+	if err != nil {
+		return GreetingImportResult{}, fmt.Errorf("failed to check existence: %w", err)
+	}
+
+	if !exists {
+		if err := checkSchedule(input.PublishAt, input.ExpireAt); err != nil {
+			return GreetingImportResult{}, err
 		}
+		// Only admins import greetings, so they need no moderation
+		entity := s.mapper.ToGreetingEntity(input)
+		entity.Status = domain.GreetingStatusApproved
+		entity.OwnerID = currentUserID(ctx)
+		savedEntity, err := s.repo.Save(entity)
+		if err != nil {
+			return GreetingImportResult{}, fmt.Errorf("failed to save greeting: %w", err)
+		}
+		if err := s.recordRevision(ctx, domain.GreetingRevision{Action: domain.GreetingRevisionCreate}, nil, &savedEntity); err != nil {
+			return GreetingImportResult{}, err
+		}
+		return GreetingImportResult{Outcome: dto.ImportOutcomeCreated, ID: savedEntity.ID}, nil
 	}
 
-	// Delete the entity
-	if err := s.repo.DeleteByID(id); err != nil {
-		return fmt.Errorf("failed to delete greeting: %w", err)
+	switch onDuplicate {
+	case dto.OnDuplicateSkip:
+		return GreetingImportResult{Outcome: dto.ImportOutcomeSkipped}, nil
+	case dto.OnDuplicateOverwrite:
+		optionalEntity, err := s.repo.FindByMessage(input.Message, input.Locale)
+		if err != nil {
+			return GreetingImportResult{}, fmt.Errorf("failed to fetch greeting by message: %w", err)
+		}
+		// Deleted since its existence was checked
+		if optionalEntity.IsEmpty() {
+			return GreetingImportResult{}, &customError.ConcurrentModificationError{
+				Resource: "Greeting",
+				Criteria: "message and locale",
+				Value:    fmt.Sprintf("%s (%s)", input.Message, input.Locale),
+			}
+		}
+
+		existingEntity := *optionalEntity.Value
+		updatedEntity := existingEntity
+		s.mapper.PartialUpdateGreeting(&updatedEntity, input)
+		if err := checkSchedule(updatedEntity.PublishAt, updatedEntity.ExpireAt); err != nil {
+			return GreetingImportResult{}, err
+		}
+		savedEntity, err := s.repo.Save(updatedEntity)
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return GreetingImportResult{}, lostUpdateError(nil, existingEntity.ID)
+		}
+		if err != nil {
+			return GreetingImportResult{}, fmt.Errorf("failed to overwrite greeting: %w", err)
+		}
+		if err := s.recordRevision(ctx, domain.GreetingRevision{Action: domain.GreetingRevisionUpdate}, &existingEntity, &savedEntity); err != nil {
+			return GreetingImportResult{}, err
+		}
+		return GreetingImportResult{Outcome: dto.ImportOutcomeOverwritten, ID: savedEntity.ID}, nil
+	default:
+		return GreetingImportResult{}, messageConflictError(input.Message, input.Locale)
 	}
+}
 
+// checkOwner returns an AccessDeniedError unless the user of ctx owns the greeting or is an admin
+func checkOwner(ctx context.Context, greeting domain.Greeting) error {
+	userID := currentUserID(ctx)
+	if security.HasAuthority(ctx, security.AuthorityAdmin) || userID != "" && greeting.OwnerID == userID {
+		return nil
+	}
+	return &customError.AccessDeniedError{Message: "Only the owner of the greeting can change it"}
+}
+
+// recordRevision saves the revision of a change of a greeting, see recordRevisionOf
+func (s *helloServiceImpl) recordRevision(ctx context.Context, revision domain.GreetingRevision,
+	oldEntity, newEntity *domain.Greeting) error {
+	return recordRevisionOf(ctx, s.repo, s.clock, revision, oldEntity, newEntity)
+}
+
+// transaction runs fn with a copy of the service bound to a database transaction,
+// so that a change and its revision are committed together
+func (s *helloServiceImpl) transaction(fn func(tx *helloServiceImpl) error) error {
+	return s.repo.Transaction(func(repo repository.HelloRepository) error {
+		return fn(s.withRepository(repo))
+	})
+}
+
+// scoped returns a copy of the service whose repository is bound to ctx, so it only sees the greetings
+// of the tenant of the authenticated user
+func (s *helloServiceImpl) scoped(ctx context.Context) *helloServiceImpl {
+	return s.withRepository(s.repo.WithContext(ctx))
+}
+
+// withRepository returns a copy of the service using the given repository, e.g. one bound to a transaction
+func (s *helloServiceImpl) withRepository(repo repository.HelloRepository) *helloServiceImpl {
+	txService := *s
+	txService.repo = repo
+	return &txService
+}
+
+// visibilityFilters returns the specifications hiding unpublished and expired greetings from users other than admins,
+// as well as greetings which are not approved, unless the user owns them
+func (s *helloServiceImpl) visibilityFilters(ctx context.Context) []repository.Specification {
+	return visibilityFiltersOf(ctx, s.clock)
+}
+
+// initialStatus returns the moderation state of a greeting created by the user of ctx. Greetings of admins
+// are approved right away, and those of other users wait for moderation unless they are drafts.
+func initialStatus(ctx context.Context, draft bool) domain.GreetingStatus {
+	switch {
+	case draft:
+		return domain.GreetingStatusDraft
+	case security.HasAuthority(ctx, security.AuthorityAdmin):
+		return domain.GreetingStatusApproved
+	default:
+		return domain.GreetingStatusPending
+	}
+}
+
+// allTenantsContext binds the repositories used by system code, e.g. scheduled jobs, to the data of all tenants
+var allTenantsContext = security.ContextForAllTenants(context.Background())
+
+// currentUserID returns the ID of the authenticated user of ctx, or an empty string without one
+func currentUserID(ctx context.Context) string {
+	if claims, ok := security.ClaimsFromContext(ctx); ok {
+		return claims.UserID
+	}
+	return ""
+}
+
+// checkSchedule returns a ConstraintViolationError when a greeting would expire before it is published
+func checkSchedule(publishAt, expireAt *time.Time) error {
+	if publishAt == nil || expireAt == nil || expireAt.After(*publishAt) {
+		return nil
+	}
+	return customError.ConstraintViolationError{Violations: []dto.Violation{{
+		Code:          "gtfield",
+		Field:         "expireAt",
+		RejectedValue: expireAt.Format(time.RFC3339),
+		Message:       "expireAt must be after publishAt",
+	}}}
+}
+
+// tagFilter returns the specification matching greetings with any or all of the tags, depending on match
+func tagFilter(tags []string, match string) (repository.Specification, error) {
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if match == dto.TagMatchAll {
+		return repository.TaggedWithAll(normalized), nil
+	}
+	return repository.TaggedWithAny(normalized), nil
+}
+
+// normalizeTags returns the distinct tag names in lowercase, sorted by name. Nil stays nil,
+// so partial updates can tell omitted tags from removed ones.
+func normalizeTags(names []string) ([]string, error) {
+	if names == nil {
+		return nil, nil
+	}
+	normalized := make([]string, 0, len(names))
+	for i, name := range names {
+		tag, err := normalizeTagName(name, fmt.Sprintf("tags[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// normalizeTagName returns the tag name trimmed and in lowercase, or a ConstraintViolationError for the field
+// when it is blank or too long
+func normalizeTagName(name, field string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" || utf8.RuneCountInString(normalized) > maxTagNameLength {
+		return "", customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "tag",
+			Field:         field,
+			RejectedValue: name,
+			Message:       fmt.Sprintf("%s must not be blank and have at most %d characters", field, maxTagNameLength),
+		}}}
+	}
+	return normalized, nil
+}
+
+// versionPrecondition returns the precondition matching the version, or nil without a version
+func versionPrecondition(version *uint) *dto.VersionPrecondition {
+	if version == nil {
+		return nil
+	}
+	return &dto.VersionPrecondition{Versions: []uint{*version}}
+}
+
+// normalizeLocale returns the canonical form of the locale, or the default locale when it is empty
+func (s *helloServiceImpl) normalizeLocale(locale string) (string, error) {
+	if locale == "" {
+		return s.defaultLocale, nil
+	}
+	normalized, err := util.NormalizeLocale(locale)
+	if err != nil {
+		return "", customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "bcp47_language_tag",
+			Field:         "locale",
+			RejectedValue: locale,
+			Message:       "locale must be a valid BCP 47 language tag",
+		}}}
+	}
+	return normalized, nil
+}
+
+// checkMessageIsUnique returns a ResourceConflictError when the message already exists in the locale
+func (s *helloServiceImpl) checkMessageIsUnique(message, locale string) error {
+	return checkMessageIsUniqueIn(s.repo, message, locale)
+}
+
+// checkMessageIsUniqueIn returns a ResourceConflictError when the message already exists in the locale in helloRepo
+func checkMessageIsUniqueIn(helloRepo repository.HelloRepository, message, locale string) error {
+	exists, err := helloRepo.ExistsByMessage(message, locale)
+	if err != nil {
+		return fmt.Errorf("failed to check existence: %w", err)
+	}
+	if exists {
+		return messageConflictError(message, locale)
+	}
 	return nil
 }
+
+// messageConflictError reports a message which already exists in the locale
+func messageConflictError(message, locale string) error {
+	return &customError.ResourceConflictError{
+		Resource: "Greeting",
+		Criteria: "message and locale",
+		Value:    fmt.Sprintf("%s (%s)", message, locale),
+	}
+}
+
+// greetingFilters returns the specifications of the greeting filters which are set
+func greetingFilters(locale, message string, createdBefore, createdAfter *time.Time) []repository.Specification {
+	var specs []repository.Specification
+	if locale != "" {
+		specs = append(specs, repository.LocaleEquals(locale))
+	}
+	if message != "" {
+		specs = append(specs, repository.MessageContains(message))
+	}
+	if createdBefore != nil {
+		specs = append(specs, repository.CreatedBefore(*createdBefore))
+	}
+	if createdAfter != nil {
+		specs = append(specs, repository.CreatedAfter(*createdAfter))
+	}
+	return specs
+}
+
+// checkPrecondition verifies that the greeting has one of the versions accepted by the precondition
+func checkPrecondition(precondition *dto.VersionPrecondition, greeting domain.Greeting) error {
+	if precondition == nil || slices.Contains(precondition.Versions, greeting.Version) {
+		return nil
+	}
+	return &customError.PreconditionFailedError{
+		Resource: "Greeting",
+		Criteria: "id",
+		Value:    fmt.Sprintf("%d", greeting.ID),
+	}
+}
+
+// lostUpdateError reports a greeting modified between reading and writing it. For conditional requests,
+// the precondition no longer holds; otherwise the client is asked to retry.
+func lostUpdateError(precondition *dto.VersionPrecondition, id uint) error {
+	if precondition != nil {
+		return &customError.PreconditionFailedError{Resource: "Greeting", Criteria: "id", Value: fmt.Sprintf("%d", id)}
+	}
+	return &customError.ConcurrentModificationError{Resource: "Greeting", Criteria: "id", Value: fmt.Sprintf("%d", id)}
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"gin-samples/internal/domain"
 	"gin-samples/internal/dto"
 	customError "gin-samples/internal/error"
 	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
 	"gin-samples/internal/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,24 +17,183 @@ import (
 )
 
 func TestHelloService_GetGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
 	mockClock := new(customMock.MockClock)
 	fixedTime := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
 	mockClock.On("Now").Return(fixedTime)
 	mockMapper := new(customMock.MockHelloMapper)
 
-	service := NewHelloService(nil, mockMapper, mockClock) // No repo needed for this method
+	// Without templates, the static greeting is returned
+	mockRepo.On("FindAllPaged", mock.Anything, mock.Anything).Return(repository.Page[domain.Greeting]{}, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	expected := dto.GreetingResponse{
 		ID:        0,
 		Message:   "Hello, World!",
+		Locale:    "en",
 		CreatedAt: fixedTime,
 		UpdatedAt: fixedTime,
 	}
-	actual := service.GetGreeting()
+	actual, err := service.GetGreeting(context.Background(), nil, dto.GreetingRenderQuery{})
 
+	assert.NoError(t, err)
 	assert.Equal(t, expected, actual, "Greeting message should match the expected value")
 }
 
+func TestHelloService_GetGreeting_Localized(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC))
+	mockRepo.On("FindAllPaged", mock.Anything, mock.Anything).Return(repository.Page[domain.Greeting]{}, nil)
+
+	service := NewHelloService(mockRepo, nil, nil, mockClock, nil, "en")
+
+	// pt-BR has no static greeting of its own and falls back to pt
+	actual, err := service.GetGreeting(context.Background(), []string{"pt-BR", "en"}, dto.GreetingRenderQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, "pt", actual.Locale)
+	assert.Equal(t, staticGreetings["pt"], actual.Message)
+
+	// An unsupported language falls back to the default locale
+	actual, err = service.GetGreeting(context.Background(), []string{"ja"}, dto.GreetingRenderQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, "en", actual.Locale)
+}
+
+func TestHelloService_GetGreeting_Template(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockUserRepo := new(customMock.MockUserRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC))
+
+	template := domain.Greeting{ID: 3, Message: "Hallo, {{.FirstName}}!", Locale: "de"}
+	user := domain.User{ID: "2", Username: "user", FirstName: "Ayşe"}
+
+	// de-CH has neither a template nor a static greeting and falls back to the template of de
+	mockRepo.On("FindAllPaged", mock.Anything, mock.Anything).
+		Return(repository.Page[domain.Greeting]{}, nil).Once()
+	mockRepo.On("FindAllPaged", mock.Anything, mock.Anything).
+		Return(repository.Page[domain.Greeting]{Content: []domain.Greeting{template}, TotalElements: 1}, nil).Once()
+	mockUserRepo.On("FindByID", "2").Return(util.Optional[domain.User]{Value: &user}, nil)
+	mockMapper.On("ToGreetingResponse", template).Return(dto.GreetingResponse{ID: 3, Message: template.Message, Locale: "de"})
+
+	service := NewHelloService(mockRepo, mockUserRepo, mockMapper, mockClock, nil, "en")
+	ctx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2"})
+
+	actual, err := service.GetGreeting(ctx, []string{"de-CH"}, dto.GreetingRenderQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dto.GreetingResponse{ID: 3, Message: "Hallo, Ayşe!", Locale: "de"}, actual)
+	mockRepo.AssertNumberOfCalls(t, "FindAllPaged", 2)
+}
+
+func TestHelloService_GetGreeting_InvalidTimeZone(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockClock := new(customMock.MockClock)
+
+	service := NewHelloService(mockRepo, nil, nil, mockClock, nil, "en")
+
+	_, err := service.GetGreeting(context.Background(), nil, dto.GreetingRenderQuery{TimeZone: "Mars/Olympus"})
+
+	var violationErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &violationErr) {
+		assert.Equal(t, "timeZone", violationErr.Violations[0].Field)
+	}
+	mockRepo.AssertNotCalled(t, "FindAllPaged", mock.Anything, mock.Anything)
+}
+
+func TestHelloService_RenderGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockUserRepo := new(customMock.MockUserRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 20, 0, 0, 0, time.UTC))
+
+	entity := domain.Greeting{ID: 1, Message: "Good {{timeOfDay}}, {{.FirstName}} {{.LastName}}!", Locale: "en",
+		Status: domain.GreetingStatusApproved}
+	response := dto.GreetingResponse{ID: 1, Message: entity.Message, Locale: "en"}
+	user := domain.User{ID: "2", Username: "user", FirstName: "John", LastName: "Doe"}
+
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &entity}, nil)
+	mockMapper.On("ToGreetingResponse", entity).Return(response)
+	mockUserRepo.On("FindByID", "2").Return(util.Optional[domain.User]{Value: &user}, nil)
+	mockUserRepo.On("FindByID", "static-user").Return(util.Optional[domain.User]{}, nil)
+
+	service := NewHelloService(mockRepo, mockUserRepo, mockMapper, mockClock, nil, "en")
+
+	// Users in the database are greeted with their names
+	ctx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2"})
+	actual, err := service.RenderGreeting(ctx, 1, dto.GreetingRenderQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Good evening, John Doe!", actual.Message)
+	assert.Equal(t, entity.Message, actual.Template)
+	assert.Equal(t, time.Date(2025, 1, 5, 20, 0, 0, 0, time.UTC), actual.RenderedAt)
+
+	// Users of other providers are greeted with their ID; 20:00 UTC is 05:00 the next day in Tokyo
+	ctx = security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "static-user"})
+	actual, err = service.RenderGreeting(ctx, 1, dto.GreetingRenderQuery{TimeZone: "Asia/Tokyo"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Good morning, static-user !", actual.Message)
+	assert.Equal(t, "Asia/Tokyo", actual.RenderedAt.Location().String())
+}
+
+func TestHelloService_RenderGreeting_NotFound(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockUserRepo := new(customMock.MockUserRepository)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(revisionTime)
+
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{}, nil)
+
+	service := NewHelloService(mockRepo, mockUserRepo, nil, mockClock, nil, "en")
+
+	_, err := service.RenderGreeting(context.Background(), 1, dto.GreetingRenderQuery{})
+
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+	mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestHelloService_ResolveLocale(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	// Only the locales of greetings visible to the user are negotiated
+	mockRepo.On("FindLocales", mock.MatchedBy(func(specs []repository.Specification) bool {
+		return len(specs) == 2
+	})).Return([]string{"en", "pt"}, nil)
+
+	service := NewHelloService(mockRepo, nil, nil, customMock.NewFakeClock(revisionTime), nil, "en")
+
+	locale, err := service.ResolveLocale(context.Background(), []string{"pt-BR"})
+	assert.NoError(t, err)
+	assert.Equal(t, "pt", locale, "pt-BR should fall back to pt")
+
+	locale, err = service.ResolveLocale(context.Background(), []string{"de", "fr"})
+	assert.NoError(t, err)
+	assert.Equal(t, "en", locale, "Locales without greetings should fall back to the default locale")
+
+	mockRepo.AssertExpectations(t)
+}
+
+// revisionTime is the time the mocked clock returns when revisions are recorded
+var revisionTime = time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)
+
+// expectRevision mocks the transaction of a change and the revision recorded by the change, if it succeeds
+func expectRevision(mockRepo *customMock.MockHelloRepository, mockClock *customMock.MockClock) {
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("SaveRevision", mock.Anything).Return(domain.GreetingRevision{}, nil).Maybe()
+	mockClock.On("Now").Return(revisionTime).Maybe()
+}
+
+// greetingOwnerCtx is the context of the owner of the greetings changed by the tests, a user without admin rights
+var greetingOwnerCtx = security.ContextWithClaims(context.Background(),
+	&security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+// stringPtr returns a pointer to the string
+func stringPtr(s string) *string {
+	return &s
+}
+
 func TestHelloService_CreateGreeting_Success(t *testing.T) {
 	mockRepo := new(customMock.MockHelloRepository)
 	mockMapper := new(customMock.MockHelloMapper)
@@ -41,18 +203,31 @@ func TestHelloService_CreateGreeting_Success(t *testing.T) {
 	expectedEntity := domain.Greeting{ID: 1, Message: "Unique Greeting"}
 	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Unique Greeting"}
 
-	mockRepo.On("ExistsByMessage", input.Message).Return(false, nil)
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("ExistsByMessage", input.Message, "en").Return(false, nil)
 	mockRepo.On("Save", mock.AnythingOfType("domain.Greeting")).Return(expectedEntity, nil)
-	mockMapper.On("ToGreetingEntity", input).Return(expectedEntity, nil)
+	// The default locale is applied to greetings created without one
+	mockMapper.On("ToGreetingEntity", dto.GreetingInput{Message: "Unique Greeting", Locale: "en"}).Return(expectedEntity, nil)
 	mockMapper.On("ToGreetingResponse", expectedEntity).Return(expectedResponse, nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
-	actual, err := service.CreateGreeting(input)
+	ctx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1"})
+	actual, err := service.CreateGreeting(ctx, input)
 
 	assert.NoError(t, err, "There should be no error")
 	assert.Equal(t, expectedResponse, actual, "Created greeting should match the expected response")
 
+	// The creation is recorded as the first revision, authored by the user of the request
+	mockRepo.AssertCalled(t, "SaveRevision", domain.GreetingRevision{
+		GreetingID: 1,
+		Action:     domain.GreetingRevisionCreate,
+		NewMessage: stringPtr("Unique Greeting"),
+		NewLocale:  stringPtr(""),
+		ChangedBy:  "1",
+		ChangedAt:  revisionTime,
+	})
+
 	mockRepo.AssertExpectations(t)
 	mockMapper.AssertExpectations(t)
 }
@@ -64,11 +239,12 @@ func TestHelloService_CreateGreeting_Conflict(t *testing.T) {
 
 	input := dto.GreetingInput{Message: "Duplicate Greeting"}
 
-	mockRepo.On("ExistsByMessage", input.Message).Return(true, nil)
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("ExistsByMessage", input.Message, "en").Return(true, nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
-	_, err := service.CreateGreeting(input)
+	_, err := service.CreateGreeting(context.Background(), input)
 
 	assert.Error(t, err, "An error should be returned for duplicate message")
 	assert.IsType(t, &customError.ResourceConflictError{}, err, "Error should be of type ResourceConflictError")
@@ -76,10 +252,83 @@ func TestHelloService_CreateGreeting_Conflict(t *testing.T) {
 	var conflictErr *customError.ResourceConflictError
 	errors.As(err, &conflictErr)
 	assert.Equal(t, "Greeting", conflictErr.Resource, "Resource should be 'Greeting'")
-	assert.Equal(t, "message", conflictErr.Criteria, "Criteria should be 'message'")
-	assert.Equal(t, "Duplicate Greeting", conflictErr.Value, "Value should match the duplicate message")
+	assert.Equal(t, "message and locale", conflictErr.Criteria, "Criteria should be 'message and locale'")
+	assert.Equal(t, "Duplicate Greeting (en)", conflictErr.Value, "Value should match the duplicate message and locale")
 
 	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SaveRevision", mock.Anything)
+}
+
+func TestHelloService_CreateGreeting_InvalidSchedule(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	publishAt := revisionTime.Add(time.Hour)
+	expireAt := revisionTime
+	input := dto.GreetingInput{Message: "Hello, Never!", PublishAt: &publishAt, ExpireAt: &expireAt}
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	// A greeting cannot expire before it is published
+	_, err := service.CreateGreeting(context.Background(), input)
+
+	var violationErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &violationErr) {
+		assert.Equal(t, "expireAt", violationErr.Violations[0].Field)
+	}
+	mockRepo.AssertNotCalled(t, "Transaction")
+}
+
+func TestHelloService_CreateGreeting_InvalidTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		violations []dto.Violation
+	}{
+		{
+			name:    "unknown variables",
+			message: "Hello, {{.Nickname}}! Good {{weather}}",
+			violations: []dto.Violation{
+				{Code: "template_variable", Field: "message", RejectedValue: "{{.Nickname}}",
+					Message: ".Nickname is not a known variable, use one of .FirstName, .LastName, .Username, timeOfDay"},
+				{Code: "template_variable", Field: "message", RejectedValue: "{{weather}}",
+					Message: "weather is not a known variable, use one of .FirstName, .LastName, .Username, timeOfDay"},
+			},
+		},
+		{
+			name:    "syntax error",
+			message: "Hello, {{.FirstName}",
+			violations: []dto.Violation{
+				{Code: "template_syntax", Field: "message", RejectedValue: "Hello, {{.FirstName}",
+					Message: "message must be a valid template: greeting:1: bad character U+007D '}'"},
+			},
+		},
+		{
+			name:    "unsupported action",
+			message: "{{range .Username}}Hi{{end}}",
+			violations: []dto.Violation{
+				{Code: "template_expression", Field: "message", RejectedValue: "{{range .Username}}Hi{{end}}",
+					Message: "message may only use placeholders such as {{.FirstName}} or {{timeOfDay}}"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(customMock.MockHelloRepository)
+
+			service := NewHelloService(mockRepo, nil, nil, nil, nil, "en")
+
+			_, err := service.CreateGreeting(context.Background(), dto.GreetingInput{Message: tt.message})
+
+			var violationErr customError.ConstraintViolationError
+			if assert.ErrorAs(t, err, &violationErr) {
+				assert.Equal(t, tt.violations, violationErr.Violations)
+			}
+			mockRepo.AssertNotCalled(t, "Transaction")
+		})
+	}
 }
 
 func TestHelloService_GetAllGreetings(t *testing.T) {
@@ -96,15 +345,153 @@ func TestHelloService_GetAllGreetings(t *testing.T) {
 		{ID: 2, Message: "Hi there!"},
 	}
 
-	mockRepo.On("FindAll").Return(expectedEntities, nil)
+	createdAfter := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	expectedPageable := repository.Pageable{
+		Page: 1,
+		Size: 2,
+		Sort: []repository.SortOrder{{Column: "created_at", Desc: true}, {Column: "message"}},
+	}
+	// The message and creation time filters, and the filters hiding scheduled and unapproved greetings from users
+	mockRepo.On("FindAllPaged", expectedPageable, mock.MatchedBy(func(specs []repository.Specification) bool {
+		return len(specs) == 4
+	})).Return(repository.Page[domain.Greeting]{
+		Content:       expectedEntities,
+		Page:          1,
+		Size:          2,
+		TotalElements: 5,
+	}, nil)
 	mockMapper.On("ToGreetingResponses", expectedEntities).Return(expectedResponses, nil)
+	mockClock.On("Now").Return(revisionTime)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
-	actual, err := service.GetAllGreetings()
+	actual, err := service.GetAllGreetings(context.Background(), dto.GreetingQuery{
+		Page:         1,
+		Size:         2,
+		Sort:         []string{"createdAt,desc", "message"},
+		Message:      "hello",
+		CreatedAfter: &createdAfter,
+	})
 
 	assert.NoError(t, err, "There should be no error")
-	assert.Equal(t, expectedResponses, actual, "All greetings should match the expected DTO list")
+	assert.Equal(t, expectedResponses, actual.Content, "All greetings should match the expected DTO list")
+	assert.Equal(t, dto.PageMetadata{Number: 1, Size: 2, TotalElements: 5, TotalPages: 3}, actual.Page)
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_GetAllGreetings_InvalidSort(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	_, err := service.GetAllGreetings(context.Background(), dto.GreetingQuery{
+		Page: 0,
+		Size: 20,
+		Sort: []string{"password,desc", "message,sideways"},
+	})
+
+	var violationErr customError.ConstraintViolationError
+	assert.ErrorAs(t, err, &violationErr)
+	assert.Len(t, violationErr.Violations, 2)
+	assert.Equal(t, "password,desc", violationErr.Violations[0].RejectedValue)
+	assert.Equal(t, "message,sideways", violationErr.Violations[1].RejectedValue)
+
+	mockRepo.AssertNotCalled(t, "FindAllPaged", mock.Anything, mock.Anything)
+}
+
+func TestHelloService_GetGreetingsByCursor(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	codec, _ := security.NewCursorCodec([]byte("secret"))
+
+	firstPage := []domain.Greeting{{ID: 3, Message: "Hi there!"}}
+	secondPage := []domain.Greeting{{ID: 1, Message: "Hello, World!"}}
+	next := &repository.Keyset{Column: "message", Desc: true, Value: "Hi there!", ID: uint(3)}
+
+	mockRepo.On("FindAllByKeyset", repository.KeysetPageable{
+		Size: 1,
+		Sort: repository.SortOrder{Column: "message", Desc: true},
+	}, mock.Anything).Return(repository.KeysetPage[domain.Greeting]{Content: firstPage, Next: next}, nil)
+	mockRepo.On("FindAllByKeyset", mock.MatchedBy(func(pageable repository.KeysetPageable) bool {
+		// The keyset decoded from the cursor carries the sort order of the first page
+		return pageable.Keyset != nil && pageable.Keyset.Column == "message" && pageable.Keyset.Desc &&
+			pageable.Keyset.Value == "Hi there!"
+	}), mock.Anything).Return(repository.KeysetPage[domain.Greeting]{Content: secondPage}, nil)
+	mockMapper.On("ToGreetingResponses", firstPage).Return([]dto.GreetingResponse{{ID: 3, Message: "Hi there!"}})
+	mockMapper.On("ToGreetingResponses", secondPage).Return([]dto.GreetingResponse{{ID: 1, Message: "Hello, World!"}})
+	mockClock.On("Now").Return(revisionTime)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, codec, "en")
+
+	first, err := service.GetGreetingsByCursor(context.Background(), dto.CursorQuery{Size: 1, Sort: "message,desc"}, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.Next, "The first page should link to the next page")
+	assert.Empty(t, first.Prev)
+
+	second, err := service.GetGreetingsByCursor(context.Background(), dto.CursorQuery{Size: 1, Cursor: first.Next}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), second.Content[0].ID)
+	assert.Empty(t, second.Next)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestHelloService_GetGreetingsByCursor_TamperedCursor(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	codec, _ := security.NewCursorCodec([]byte("secret"))
+	otherCodec, _ := security.NewCursorCodec([]byte("other"))
+
+	forged, _ := otherCodec.Encode(repository.Keyset{Column: "message", Value: "x", ID: 1})
+	userCursor, _ := codec.Encode(repository.Keyset{Column: "username", Value: "admin", ID: "1"})
+
+	service := NewHelloService(mockRepo, nil, nil, nil, codec, "en")
+
+	for _, cursor := range []string{"garbage", forged, userCursor} {
+		_, err := service.GetGreetingsByCursor(context.Background(), dto.CursorQuery{Size: 1, Cursor: cursor}, "")
+
+		var violationErr customError.ConstraintViolationError
+		assert.ErrorAs(t, err, &violationErr)
+		assert.Equal(t, "cursor", violationErr.Violations[0].Field)
+	}
+
+	mockRepo.AssertNotCalled(t, "FindAllByKeyset", mock.Anything, mock.Anything)
+}
+
+func TestHelloService_SearchGreetings(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	results := []domain.GreetingSearchResult{{
+		Greeting: domain.Greeting{ID: 1, Message: "Good morning, World!"},
+		Snippet:  "\uE000Good\uE001 \uE000morning\uE001, World!",
+		Rank:     -1.5,
+	}}
+	expectedResponses := []dto.GreetingSearchResponse{{
+		GreetingResponse: dto.GreetingResponse{ID: 1, Message: "Good morning, World!"},
+		Snippet:          "<mark>Good</mark> <mark>morning</mark>, World!",
+		Score:            1.5,
+	}}
+
+	// Scheduled and unapproved greetings are hidden from users
+	mockRepo.On("Search", "good morning", repository.Pageable{Page: 0, Size: 10},
+		mock.MatchedBy(func(specs []repository.Specification) bool { return len(specs) == 2 })).
+		Return(repository.Page[domain.GreetingSearchResult]{Content: results, Page: 0, Size: 10, TotalElements: 1}, nil)
+	mockMapper.On("ToGreetingSearchResponses", results).Return(expectedResponses)
+	mockClock.On("Now").Return(revisionTime)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	actual, err := service.SearchGreetings(context.Background(), dto.GreetingSearchQuery{Q: "good morning", Page: 0, Size: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponses, actual.Content)
+	assert.Equal(t, dto.PageMetadata{Number: 0, Size: 10, TotalElements: 1, TotalPages: 1}, actual.Page)
 
 	mockRepo.AssertExpectations(t)
 	mockMapper.AssertExpectations(t)
@@ -115,15 +502,16 @@ func TestHelloService_GetGreetingByID_Success(t *testing.T) {
 	mockMapper := new(customMock.MockHelloMapper)
 	mockClock := new(customMock.MockClock)
 
-	expectedEntity := domain.Greeting{ID: 1, Message: "Hello, Mock!"}
+	expectedEntity := domain.Greeting{ID: 1, Status: domain.GreetingStatusApproved, Message: "Hello, Mock!"}
 	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Hello, Mock!"}
 
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &expectedEntity}, nil)
 	mockMapper.On("ToGreetingResponse", expectedEntity).Return(expectedResponse, nil)
+	mockClock.On("Now").Return(revisionTime)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
-	actual, err := service.GetGreetingByID(1)
+	actual, err := service.GetGreetingByID(context.Background(), 1)
 
 	assert.NoError(t, err, "There should be no error")
 	assert.Equal(t, expectedResponse, actual, "Greeting should match the expected response")
@@ -139,9 +527,9 @@ func TestHelloService_GetGreetingByID_NotFound(t *testing.T) {
 
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
-	_, err := service.GetGreetingByID(1)
+	_, err := service.GetGreetingByID(context.Background(), 1)
 
 	assert.Error(t, err, "An error should be returned when greeting is not found")
 	assert.IsType(t, &customError.ResourceNotFoundError{}, err, "Error should be of type ResourceNotFoundError")
@@ -156,6 +544,41 @@ func TestHelloService_GetGreetingByID_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestHelloService_GetGreetingByID_Scheduled(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := customMock.NewFakeClock(revisionTime)
+
+	publishAt := revisionTime.Add(time.Hour)
+	expireAt := revisionTime.Add(2 * time.Hour)
+	scheduledEntity := domain.Greeting{ID: 1, Status: domain.GreetingStatusApproved, Message: "Hello, Soon!", PublishAt: &publishAt, ExpireAt: &expireAt}
+	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Hello, Soon!", PublishAt: &publishAt, ExpireAt: &expireAt}
+
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &scheduledEntity}, nil)
+	mockMapper.On("ToGreetingResponse", scheduledEntity).Return(expectedResponse)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+
+	// Unpublished greetings are only found by admins
+	_, err := service.GetGreetingByID(userCtx, 1)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+	actual, err := service.GetGreetingByID(adminCtx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, actual)
+
+	// Published greetings are found by everyone
+	mockClock.Advance(time.Hour)
+	_, err = service.GetGreetingByID(userCtx, 1)
+	assert.NoError(t, err)
+
+	// Expired greetings are hidden again
+	mockClock.Advance(time.Hour)
+	_, err = service.GetGreetingByID(userCtx, 1)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+}
+
 func TestHelloService_GetGreetingByID_RepoError(t *testing.T) {
 	mockRepo := new(customMock.MockHelloRepository)
 	mockMapper := new(customMock.MockHelloMapper)
@@ -164,9 +587,9 @@ func TestHelloService_GetGreetingByID_RepoError(t *testing.T) {
 	expectedError := errors.New("database error")
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{}, expectedError)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
-	_, err := service.GetGreetingByID(1)
+	_, err := service.GetGreetingByID(context.Background(), 1)
 
 	assert.Error(t, err, "An error should be returned when repository fails")
 	assert.ErrorContains(t, err, "database error", "Error should contain the expected repository error")
@@ -180,31 +603,125 @@ func TestHelloService_UpdateGreeting_Success(t *testing.T) {
 	mockClock := new(customMock.MockClock)
 
 	// Mock data
-	existingEntity := domain.Greeting{ID: 1, Message: "Old Message"}
-	updatedEntity := domain.Greeting{ID: 1, Message: "Updated Message"}
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Old Message"}
+	updatedEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Updated Message"}
 	input := dto.GreetingInput{Message: "Updated Message"}
 	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Updated Message"}
 
 	// Mock expectations
+	expectRevision(mockRepo, mockClock)
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
 	mockMapper.On("PartialUpdateGreeting", &existingEntity, input)
 	mockRepo.On("Save", existingEntity).Return(updatedEntity, nil)
 	mockMapper.On("ToGreetingResponse", updatedEntity).Return(expectedResponse, nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	// Call the method under test
-	actual, err := service.UpdateGreeting(1, input)
+	actual, err := service.UpdateGreeting(greetingOwnerCtx, 1, input, nil)
 
 	// Assertions
 	assert.NoError(t, err, "There should be no error")
 	assert.Equal(t, expectedResponse, actual, "Updated greeting should match the expected response")
+	mockRepo.AssertCalled(t, "SaveRevision", domain.GreetingRevision{
+		GreetingID: 1,
+		Action:     domain.GreetingRevisionUpdate,
+		OldMessage: stringPtr("Old Message"),
+		OldLocale:  stringPtr(""),
+		NewMessage: stringPtr("Updated Message"),
+		NewLocale:  stringPtr(""),
+		ChangedBy:  "2",
+		ChangedAt:  revisionTime,
+	})
 
 	// Verify mock expectations
 	mockRepo.AssertExpectations(t)
 	mockMapper.AssertExpectations(t)
 }
 
+func TestHelloService_PatchGreeting_Success(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	expectRevision(mockRepo, mockClock)
+
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Olá", Locale: "pt"}
+	patchedEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Hello", Locale: "en"}
+	// The changed message of the approved greeting is moderated again
+	savedEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusPending, Message: "Hello", Locale: "en"}
+	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Hello", Locale: "en", Status: "PENDING"}
+
+	// The locale was removed by the patch, so the default locale is applied
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+	mockMapper.On("UpdateGreeting", &existingEntity, dto.GreetingInput{Message: "Hello", Locale: "en"}).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*domain.Greeting) = patchedEntity
+		})
+	mockRepo.On("ExistsByMessage", "Hello", "en").Return(false, nil)
+	mockRepo.On("Save", savedEntity).Return(savedEntity, nil)
+	mockMapper.On("ToGreetingResponse", savedEntity).Return(expectedResponse, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	// The patch receives the current fields and removes the locale
+	patch := func(document dto.GreetingInput) (dto.GreetingInput, error) {
+		assert.Equal(t, dto.GreetingInput{Message: "Olá", Locale: "pt"}, document)
+		return dto.GreetingInput{Message: "Hello"}, nil
+	}
+	actual, err := service.PatchGreeting(greetingOwnerCtx, 1, patch, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, actual)
+	assert.Equal(t, "pt", existingEntity.Locale, "The cached entity should not be modified")
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_UpdateGreeting_PreconditionFailed(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	expectRevision(mockRepo, mockClock)
+
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Old Message", VersionedEntity: domain.VersionedEntity{Version: 3}}
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	_, err := service.UpdateGreeting(greetingOwnerCtx, 1, dto.GreetingInput{Message: "Updated Message"},
+		&dto.VersionPrecondition{Versions: []uint{2}})
+
+	var preconditionErr *customError.PreconditionFailedError
+	assert.ErrorAs(t, err, &preconditionErr)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestHelloService_UpdateGreeting_LostUpdate(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	expectRevision(mockRepo, mockClock)
+
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Old Message", VersionedEntity: domain.VersionedEntity{Version: 3}}
+	input := dto.GreetingInput{Message: "Updated Message"}
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+	mockMapper.On("PartialUpdateGreeting", &existingEntity, input)
+	mockRepo.On("Save", existingEntity).Return(domain.Greeting{}, repository.ErrOptimisticLock)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	// A conditional request fails its precondition
+	_, err := service.UpdateGreeting(greetingOwnerCtx, 1, input, &dto.VersionPrecondition{Versions: []uint{3}})
+	var preconditionErr *customError.PreconditionFailedError
+	assert.ErrorAs(t, err, &preconditionErr)
+
+	// An unconditional request is asked to retry
+	_, err = service.UpdateGreeting(greetingOwnerCtx, 1, input, nil)
+	var concurrentModificationErr *customError.ConcurrentModificationError
+	assert.ErrorAs(t, err, &concurrentModificationErr)
+}
+
 func TestHelloService_UpdateGreeting_NotFound(t *testing.T) {
 	mockRepo := new(customMock.MockHelloRepository)
 	mockMapper := new(customMock.MockHelloMapper)
@@ -214,12 +731,13 @@ func TestHelloService_UpdateGreeting_NotFound(t *testing.T) {
 	input := dto.GreetingInput{Message: "Updated Message"}
 
 	// Mock expectations
+	expectRevision(mockRepo, mockClock)
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	// Call the method under test
-	_, err := service.UpdateGreeting(1, input)
+	_, err := service.UpdateGreeting(greetingOwnerCtx, 1, input, nil)
 
 	// Assertions
 	assert.Error(t, err, "An error should be returned when greeting is not found")
@@ -242,18 +760,19 @@ func TestHelloService_UpdateGreeting_RepoError(t *testing.T) {
 	mockClock := new(customMock.MockClock)
 
 	// Mock data
-	existingEntity := domain.Greeting{ID: 1, Message: "Old Message"}
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Old Message"}
 	input := dto.GreetingInput{Message: "Updated Message"}
 
 	// Mock expectations
+	expectRevision(mockRepo, mockClock)
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
 	mockMapper.On("PartialUpdateGreeting", &existingEntity, input)
 	mockRepo.On("Save", existingEntity).Return(domain.Greeting{}, errors.New("database error"))
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	// Call the method under test
-	_, err := service.UpdateGreeting(1, input)
+	_, err := service.UpdateGreeting(greetingOwnerCtx, 1, input, nil)
 
 	// Assertions
 	assert.Error(t, err, "An error should be returned when repository fails")
@@ -270,19 +789,28 @@ func TestHelloService_DeleteGreeting_Success(t *testing.T) {
 	mockClock := new(customMock.MockClock)
 
 	// Mock data
-	existingEntity := domain.Greeting{ID: 1, Message: "Hello, World!"}
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Hello, World!"}
 
 	// Mock expectations
+	expectRevision(mockRepo, mockClock)
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
-	mockRepo.On("DeleteByID", uint(1)).Return(nil)
+	mockRepo.On("Delete", existingEntity).Return(nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	// Call the method under test
-	err := service.DeleteGreeting(1)
+	err := service.DeleteGreeting(greetingOwnerCtx, 1, nil)
 
 	// Assertions
 	assert.NoError(t, err, "There should be no error when deleting a greeting")
+	mockRepo.AssertCalled(t, "SaveRevision", domain.GreetingRevision{
+		GreetingID: 1,
+		Action:     domain.GreetingRevisionDelete,
+		OldMessage: stringPtr("Hello, World!"),
+		OldLocale:  stringPtr(""),
+		ChangedBy:  "2",
+		ChangedAt:  revisionTime,
+	})
 
 	// Verify mock expectations
 	mockRepo.AssertExpectations(t)
@@ -294,12 +822,13 @@ func TestHelloService_DeleteGreeting_NotFound(t *testing.T) {
 	mockClock := new(customMock.MockClock)
 
 	// Mock expectations
+	expectRevision(mockRepo, mockClock)
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	// Call the method under test
-	err := service.DeleteGreeting(1)
+	err := service.DeleteGreeting(greetingOwnerCtx, 1, nil)
 
 	// Assertions
 	assert.Error(t, err, "An error should be returned when greeting is not found")
@@ -322,16 +851,17 @@ func TestHelloService_DeleteGreeting_RepoError(t *testing.T) {
 	mockClock := new(customMock.MockClock)
 
 	// Mock data
-	existingEntity := domain.Greeting{ID: 1, Message: "Hello, World!"}
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Hello, World!"}
 
 	// Mock expectations
+	expectRevision(mockRepo, mockClock)
 	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
-	mockRepo.On("DeleteByID", uint(1)).Return(errors.New("database error"))
+	mockRepo.On("Delete", existingEntity).Return(errors.New("database error"))
 
-	service := NewHelloService(mockRepo, mockMapper, mockClock)
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
 
 	// Call the method under test
-	err := service.DeleteGreeting(1)
+	err := service.DeleteGreeting(greetingOwnerCtx, 1, nil)
 
 	// Assertions
 	assert.Error(t, err, "An error should be returned when repository fails to delete")
@@ -340,3 +870,571 @@ func TestHelloService_DeleteGreeting_RepoError(t *testing.T) {
 	// Verify mock expectations
 	mockRepo.AssertExpectations(t)
 }
+
+func TestHelloService_ChangeGreeting_NotOwner(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock data
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Hello, World!"}
+	otherUserCtx := security.ContextWithClaims(context.Background(),
+		&security.TokenClaims{UserID: "3", Authorities: []string{"ROLE_USER"}})
+
+	// Mock expectations
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	// Other users can neither update nor delete the greeting
+	_, err := service.UpdateGreeting(otherUserCtx, 1, dto.GreetingInput{Message: "Updated Message"}, nil)
+	var accessDenied *customError.AccessDeniedError
+	assert.ErrorAs(t, err, &accessDenied)
+
+	err = service.DeleteGreeting(otherUserCtx, 1, nil)
+	assert.ErrorAs(t, err, &accessDenied)
+
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	mockRepo.AssertNotCalled(t, "SaveRevision", mock.Anything)
+}
+
+func TestHelloService_GetDeletedGreetings(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	expectedEntities := []domain.Greeting{{ID: 1, Message: "Hello, World!"}}
+	expectedResponses := []dto.GreetingResponse{{ID: 1, Message: "Hello, World!"}}
+
+	// Without sort criteria, the most recently deleted greetings come first
+	expectedPageable := repository.Pageable{
+		Page: 0,
+		Size: 20,
+		Sort: []repository.SortOrder{{Column: "deleted_at", Desc: true}},
+	}
+	mockRepo.On("FindAllDeletedPaged", expectedPageable).Return(repository.Page[domain.Greeting]{
+		Content:       expectedEntities,
+		Page:          0,
+		Size:          20,
+		TotalElements: 1,
+	}, nil)
+	mockMapper.On("ToGreetingResponses", expectedEntities).Return(expectedResponses, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	actual, err := service.GetDeletedGreetings(context.Background(), dto.GreetingTrashQuery{Page: 0, Size: 20})
+
+	assert.NoError(t, err, "There should be no error")
+	assert.Equal(t, expectedResponses, actual.Content, "Deleted greetings should match the expected DTO list")
+	assert.Equal(t, dto.PageMetadata{Number: 0, Size: 20, TotalElements: 1, TotalPages: 1}, actual.Page)
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_RestoreGreeting_Success(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock data
+	deletedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Locale: "en"}
+	restoredEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Locale: "en", VersionedEntity: domain.VersionedEntity{Version: 2}}
+	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Hello, World!", Locale: "en", Version: 2}
+
+	// Mock expectations
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("FindDeletedByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &deletedEntity}, nil)
+	mockRepo.On("ExistsByMessage", "Hello, World!", "en").Return(false, nil)
+	mockRepo.On("Restore", deletedEntity).Return(restoredEntity, nil)
+	mockMapper.On("ToGreetingResponse", restoredEntity).Return(expectedResponse)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	actual, err := service.RestoreGreeting(context.Background(), 1)
+
+	// Assertions
+	assert.NoError(t, err, "There should be no error when restoring a greeting")
+	assert.Equal(t, expectedResponse, actual, "Restored greeting should match the expected DTO")
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_RestoreGreeting_Conflict(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock data
+	deletedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Locale: "en"}
+
+	expectRevision(mockRepo, mockClock)
+
+	// Another greeting took over the message while this one was in the trash
+	mockRepo.On("FindDeletedByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &deletedEntity}, nil)
+	mockRepo.On("ExistsByMessage", "Hello, World!", "en").Return(true, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	_, err := service.RestoreGreeting(context.Background(), 1)
+
+	// Assertions
+	assert.ErrorAs(t, err, new(*customError.ResourceConflictError), "Error should be of type ResourceConflictError")
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Restore", mock.Anything)
+}
+
+func TestHelloService_RestoreGreeting_NotInTrash(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock expectations
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("FindDeletedByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	_, err := service.RestoreGreeting(context.Background(), 1)
+
+	// Assertions
+	var notFoundErr *customError.ResourceNotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr, "Error should be of type ResourceNotFoundError") {
+		assert.Equal(t, "1", notFoundErr.Value, "Value should match the missing ID")
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestHelloService_PurgeGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock data
+	deletedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Locale: "en"}
+
+	// Mock expectations
+	mockRepo.On("FindDeletedByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &deletedEntity}, nil)
+	mockRepo.On("Purge", deletedEntity).Return(nil)
+	mockRepo.On("FindDeletedByID", uint(2)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	// Greeting in the trash
+	assert.NoError(t, service.PurgeGreeting(context.Background(), 1), "There should be no error when purging a greeting")
+
+	// Greetings outside the trash cannot be purged
+	assert.ErrorAs(t, service.PurgeGreeting(context.Background(), 2), new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertExpectations(t)
+}
+
+// setUpBulkCreate mocks the creation of "Hello, Bulk!" and a conflict for "Hello, World!"
+func setUpBulkCreate(mockRepo *customMock.MockHelloRepository, mockMapper *customMock.MockHelloMapper) (domain.Greeting, dto.GreetingResponse) {
+	createdEntity := domain.Greeting{ID: 3, Message: "Hello, Bulk!", Locale: "en"}
+	createdResponse := dto.GreetingResponse{ID: 3, Message: "Hello, Bulk!", Locale: "en"}
+
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("ExistsByMessage", "Hello, Bulk!", "en").Return(false, nil)
+	mockRepo.On("ExistsByMessage", "Hello, World!", "en").Return(true, nil)
+	mockMapper.On("ToGreetingEntity", dto.GreetingInput{Message: "Hello, Bulk!", Locale: "en"}).Return(domain.Greeting{Message: "Hello, Bulk!", Locale: "en"})
+	// Greetings of users wait for moderation
+	mockRepo.On("Save", domain.Greeting{Message: "Hello, Bulk!", Locale: "en", Status: domain.GreetingStatusPending}).
+		Return(createdEntity, nil)
+	mockMapper.On("ToGreetingResponse", createdEntity).Return(createdResponse)
+
+	return createdEntity, createdResponse
+}
+
+func TestHelloService_BulkCreateGreetings_AllOrNothing(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	expectRevision(mockRepo, mockClock)
+	setUpBulkCreate(mockRepo, mockMapper)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	inputs := []dto.GreetingInput{{Message: "Hello, Bulk!"}, {Message: "Hello, World!"}}
+	results, err := service.BulkCreateGreetings(context.Background(), inputs, dto.BulkModeAllOrNothing, func(dto.GreetingInput) error { return nil })
+
+	// The conflict rolls back the created greeting
+	assert.NoError(t, err, "Failed items should be reported in the results")
+	if assert.Len(t, results, 2) {
+		assert.ErrorAs(t, results[0].Err, new(*customError.FailedDependencyError))
+		assert.Equal(t, dto.GreetingResponse{}, results[0].Value)
+		assert.ErrorAs(t, results[1].Err, new(*customError.ResourceConflictError))
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_BulkCreateGreetings_BestEffort(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	expectRevision(mockRepo, mockClock)
+	_, createdResponse := setUpBulkCreate(mockRepo, mockMapper)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	inputs := []dto.GreetingInput{{Message: "Hello, Bulk!"}, {Message: "Hello, World!"}}
+	results, err := service.BulkCreateGreetings(context.Background(), inputs, dto.BulkModeBestEffort, func(dto.GreetingInput) error { return nil })
+
+	// The created greeting is kept despite the conflict
+	assert.NoError(t, err, "Failed items should be reported in the results")
+	if assert.Len(t, results, 2) {
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, createdResponse, results[0].Value)
+		assert.ErrorAs(t, results[1].Err, new(*customError.ResourceConflictError))
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_BulkDeleteGreetings_InvalidItem(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	invalid := errors.New("invalid item")
+	deletes := []dto.BulkGreetingDelete{{ID: 1}, {ID: 0}}
+	results, err := service.BulkDeleteGreetings(context.Background(), deletes, dto.BulkModeAllOrNothing, func(del dto.BulkGreetingDelete) error {
+		if del.ID == 0 {
+			return invalid
+		}
+		return nil
+	})
+
+	// Nothing is applied when an item is invalid
+	assert.NoError(t, err, "Failed items should be reported in the results")
+	if assert.Len(t, results, 2) {
+		assert.ErrorAs(t, results[0].Err, new(*customError.FailedDependencyError))
+		assert.ErrorIs(t, results[1].Err, invalid)
+	}
+
+	mockRepo.AssertNotCalled(t, "Transaction")
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestHelloService_ExportGreetings(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	greetings := []domain.Greeting{{ID: 1, Message: "Hello, World!", Locale: "en"}}
+	responses := []dto.GreetingResponse{{ID: 1, Message: "Hello, World!", Locale: "en"}}
+	mockRepo.On("FindAllInBatches", exportBatchSize, mock.Anything).Return(greetings, nil)
+	mockMapper.On("ToGreetingResponses", greetings).Return(responses)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	var exported []dto.GreetingResponse
+	err := service.ExportGreetings(context.Background(), dto.GreetingExportQuery{Locale: "en", Message: "Hello"}, func(batch []dto.GreetingResponse) error {
+		exported = append(exported, batch...)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, responses, exported)
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_ExportGreetings_WriteError(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	mockRepo.On("FindAllInBatches", exportBatchSize, mock.Anything).Return([]domain.Greeting{{ID: 1}}, nil)
+	mockMapper.On("ToGreetingResponses", mock.Anything).Return([]dto.GreetingResponse{{ID: 1}})
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	writeErr := errors.New("connection reset")
+	err := service.ExportGreetings(context.Background(), dto.GreetingExportQuery{}, func([]dto.GreetingResponse) error {
+		return writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+}
+
+// setUpImport mocks a new greeting "Hello, Import!" and an existing greeting "Hello, World!", both in English
+// importExpireAt is the expiry time of the imported duplicate of setUpImport
+var importExpireAt = revisionTime.Add(time.Hour)
+
+func setUpImport(mockRepo *customMock.MockHelloRepository, mockMapper *customMock.MockHelloMapper) []GreetingImportRow {
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("ExistsByMessage", "Hello, Import!", "en").Return(false, nil)
+	mockRepo.On("ExistsByMessage", "Hello, World!", "en").Return(true, nil)
+	mockMapper.On("ToGreetingEntity", dto.GreetingInput{Message: "Hello, Import!", Locale: "en"}).Return(domain.Greeting{Message: "Hello, Import!", Locale: "en"})
+	mockRepo.On("Save", domain.Greeting{Message: "Hello, Import!", Locale: "en", Status: domain.GreetingStatusApproved}).
+		Return(domain.Greeting{ID: 3, Message: "Hello, Import!", Locale: "en", Status: domain.GreetingStatusApproved}, nil)
+
+	return []GreetingImportRow{
+		{Line: 2, Input: dto.GreetingInput{Message: "Hello, Import!", Locale: "en"}},
+		{Line: 3, Input: dto.GreetingInput{Message: "Hello, World!", Locale: "en", ExpireAt: &importExpireAt,
+			Tags: []string{"Campaign"}}},
+		{Line: 4, Err: &customError.MessageNotReadableError{Detail: "malformed row"}},
+	}
+}
+
+func TestHelloService_ImportGreetings_OnDuplicate(t *testing.T) {
+	tests := []struct {
+		onDuplicate     string
+		expectedOutcome string
+		expectedID      uint
+		expectedErr     any
+	}{
+		{onDuplicate: dto.OnDuplicateSkip, expectedOutcome: dto.ImportOutcomeSkipped},
+		{onDuplicate: dto.OnDuplicateOverwrite, expectedOutcome: dto.ImportOutcomeOverwritten, expectedID: 1},
+		{onDuplicate: dto.OnDuplicateFail, expectedOutcome: dto.ImportOutcomeFailed, expectedErr: new(*customError.ResourceConflictError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.onDuplicate, func(t *testing.T) {
+			mockRepo := new(customMock.MockHelloRepository)
+			mockMapper := new(customMock.MockHelloMapper)
+			mockClock := new(customMock.MockClock)
+			expectRevision(mockRepo, mockClock)
+			rows := setUpImport(mockRepo, mockMapper)
+
+			existingEntity := domain.Greeting{ID: 1, Status: domain.GreetingStatusApproved, Message: "Hello, World!", Locale: "en", VersionedEntity: domain.VersionedEntity{Version: 1}}
+			if tt.onDuplicate == dto.OnDuplicateOverwrite {
+				mockRepo.On("FindByMessage", "Hello, World!", "en").Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+				// The schedule and tags of the row are applied
+				mockMapper.On("PartialUpdateGreeting", &existingEntity, dto.GreetingInput{Message: "Hello, World!", Locale: "en",
+					ExpireAt: &importExpireAt, Tags: []string{"campaign"}}).
+					Run(func(args mock.Arguments) {
+						entity := args.Get(0).(*domain.Greeting)
+						entity.ExpireAt, entity.Tags = &importExpireAt, []domain.Tag{{Name: "campaign"}}
+					})
+				overwrittenEntity := existingEntity
+				overwrittenEntity.ExpireAt, overwrittenEntity.Tags = &importExpireAt, []domain.Tag{{Name: "campaign"}}
+				mockRepo.On("Save", overwrittenEntity).Return(domain.Greeting{ID: 1, Status: domain.GreetingStatusApproved, Message: "Hello, World!", Locale: "en", VersionedEntity: domain.VersionedEntity{Version: 2}}, nil)
+			}
+
+			service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+			results, err := service.ImportGreetings(context.Background(), rows, dto.GreetingImportQuery{OnDuplicate: tt.onDuplicate},
+				func(dto.GreetingInput) error { return nil })
+
+			assert.NoError(t, err, "Failed rows should be reported in the results")
+			if assert.Len(t, results, 3) {
+				assert.Equal(t, GreetingImportResult{Line: 2, Outcome: dto.ImportOutcomeCreated, ID: 3}, results[0])
+
+				assert.Equal(t, 3, results[1].Line)
+				assert.Equal(t, tt.expectedOutcome, results[1].Outcome)
+				assert.Equal(t, tt.expectedID, results[1].ID)
+				if tt.expectedErr != nil {
+					assert.ErrorAs(t, results[1].Err, tt.expectedErr)
+				} else {
+					assert.NoError(t, results[1].Err)
+				}
+
+				assert.Equal(t, 4, results[2].Line)
+				assert.Equal(t, dto.ImportOutcomeFailed, results[2].Outcome)
+				assert.ErrorAs(t, results[2].Err, new(*customError.MessageNotReadableError))
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockMapper.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHelloService_ImportGreetings_DryRun(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	expectRevision(mockRepo, mockClock)
+	rows := setUpImport(mockRepo, mockMapper)
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	invalid := errors.New("invalid row")
+	results, err := service.ImportGreetings(context.Background(), rows[:2], dto.GreetingImportQuery{DryRun: true, OnDuplicate: dto.OnDuplicateSkip},
+		func(input dto.GreetingInput) error {
+			if input.Message == "Hello, World!" {
+				return invalid
+			}
+			return nil
+		})
+
+	// The outcomes are reported, but the created greeting is rolled back and has no ID
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, GreetingImportResult{Line: 2, Outcome: dto.ImportOutcomeCreated}, results[0])
+		assert.Equal(t, dto.ImportOutcomeFailed, results[1].Outcome)
+		assert.ErrorIs(t, results[1].Err, invalid)
+	}
+
+	mockRepo.AssertNotCalled(t, "ExistsByMessage", "Hello, World!", "en")
+}
+
+func TestHelloService_CreateGreeting_Tags(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	entity := domain.Greeting{ID: 1, Message: "Merry Christmas", Locale: "en", Status: domain.GreetingStatusPending,
+		Tags: []domain.Tag{{Name: "christmas"}, {Name: "winter"}}}
+
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("ExistsByMessage", "Merry Christmas", "en").Return(false, nil)
+	mockRepo.On("Save", entity).Return(entity, nil)
+	// The tags are trimmed, lowercased, deduplicated and sorted
+	mockMapper.On("ToGreetingEntity", dto.GreetingInput{Message: "Merry Christmas", Locale: "en",
+		Tags: []string{"christmas", "winter"}}).Return(entity)
+	mockMapper.On("ToGreetingResponse", entity).Return(dto.GreetingResponse{ID: 1, Message: "Merry Christmas",
+		Locale: "en", Tags: []string{"christmas", "winter"}})
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	actual, err := service.CreateGreeting(context.Background(), dto.GreetingInput{Message: "Merry Christmas",
+		Tags: []string{"Winter", " Christmas ", "christmas"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"christmas", "winter"}, actual.Tags)
+
+	// A blank tag is a constraint violation
+	_, err = service.CreateGreeting(context.Background(), dto.GreetingInput{Message: "Merry Christmas",
+		Tags: []string{"christmas", " "}})
+	var constraintErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &constraintErr) {
+		assert.Equal(t, "tags[1]", constraintErr.Violations[0].Field)
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_GetAllGreetings_Tags(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+
+	// The tag filter; admins also see scheduled greetings
+	mockRepo.On("FindAllPaged", mock.Anything, mock.MatchedBy(func(specs []repository.Specification) bool {
+		return len(specs) == 1
+	})).Return(repository.Page[domain.Greeting]{}, nil)
+	mockMapper.On("ToGreetingResponses", []domain.Greeting(nil)).Return([]dto.GreetingResponse{})
+
+	service := NewHelloService(mockRepo, nil, mockMapper, nil, nil, "en")
+
+	ctx := security.ContextWithClaims(context.Background(),
+		&security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+	_, err := service.GetAllGreetings(ctx, dto.GreetingQuery{
+		Size:     20,
+		Tags:     []string{"Christmas", "winter"},
+		TagMatch: dto.TagMatchAll,
+	})
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_GetRandomGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC))
+
+	greeting := domain.Greeting{ID: 2, Message: "Good {{timeOfDay}}!", Locale: "en", Status: domain.GreetingStatusApproved}
+
+	// The live filter, the approved filter, the locale filter and the tag filter
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindAllPaged", mock.Anything, mock.MatchedBy(func(specs []repository.Specification) bool {
+		return len(specs) == 4
+	})).Return(repository.Page[domain.Greeting]{Content: []domain.Greeting{greeting}, TotalElements: 1}, nil)
+	mockRepo.On("FindAllPaged", mock.Anything, mock.Anything).Return(repository.Page[domain.Greeting]{}, nil)
+	mockMapper.On("ToGreetingResponse", greeting).Return(dto.GreetingResponse{ID: 2, Message: greeting.Message, Locale: "en"})
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+
+	// The only matching greeting is picked and rendered
+	actual, err := service.GetRandomGreeting(context.Background(), dto.RandomGreetingQuery{
+		Locale:   "EN",
+		Tags:     []string{"christmas"},
+		TagMatch: dto.TagMatchAny,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), actual.ID)
+	assert.Equal(t, "Good morning!", actual.Message)
+
+	// No greeting matches
+	_, err = service.GetRandomGreeting(context.Background(), dto.RandomGreetingQuery{Locale: "tr"})
+	var notFoundErr *customError.ResourceNotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "locale tr", notFoundErr.Value)
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestHelloService_CreateGreeting_Moderation(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	expectRevision(mockRepo, mockClock)
+	mockRepo.On("ExistsByMessage", "Hello, World!", "en").Return(false, nil)
+	mockMapper.On("ToGreetingEntity", mock.Anything).Return(domain.Greeting{Message: "Hello, World!", Locale: "en"})
+	mockRepo.On("Save", mock.AnythingOfType("domain.Greeting")).Return(domain.Greeting{ID: 1}, nil)
+	mockMapper.On("ToGreetingResponse", mock.Anything).Return(dto.GreetingResponse{ID: 1})
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+
+	// Greetings of users wait for moderation, drafts until they are submitted
+	_, err := service.CreateGreeting(userCtx, dto.GreetingInput{Message: "Hello, World!"})
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Save", domain.Greeting{Message: "Hello, World!", Locale: "en",
+		Status: domain.GreetingStatusPending, OwnerID: "2"})
+
+	_, err = service.CreateGreeting(userCtx, dto.GreetingInput{Message: "Hello, World!", Draft: true})
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Save", domain.Greeting{Message: "Hello, World!", Locale: "en",
+		Status: domain.GreetingStatusDraft, OwnerID: "2"})
+
+	// Greetings of admins are approved right away
+	_, err = service.CreateGreeting(adminCtx, dto.GreetingInput{Message: "Hello, World!"})
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "Save", domain.Greeting{Message: "Hello, World!", Locale: "en",
+		Status: domain.GreetingStatusApproved, OwnerID: "1"})
+}
+
+func TestHelloService_GetGreetingByID_Moderation(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := customMock.NewFakeClock(revisionTime)
+
+	pendingEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusPending, OwnerID: "2"}
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &pendingEntity}, nil)
+	mockMapper.On("ToGreetingResponse", pendingEntity).Return(dto.GreetingResponse{ID: 1, Status: "PENDING"})
+
+	service := NewHelloService(mockRepo, nil, mockMapper, mockClock, nil, "en")
+	ownerCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+	otherCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "3", Authorities: []string{"ROLE_USER"}})
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+
+	// Greetings which are not approved are only found by their owner and admins
+	_, err := service.GetGreetingByID(ownerCtx, 1)
+	assert.NoError(t, err)
+	_, err = service.GetGreetingByID(adminCtx, 1)
+	assert.NoError(t, err)
+	_, err = service.GetGreetingByID(otherCtx, 1)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+	_, err = service.GetGreetingByID(context.Background(), 1)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"strings"
+)
+
+// ModerationService moves greetings through their moderation states. Owners submit their greetings for moderation
+// and archive them, while admins list the greetings waiting for moderation and approve or reject them.
+// Greetings are only moderated when visible to the user of the context, see HelloService.
+type ModerationService interface {
+	GetModerationQueue(ctx context.Context, query dto.ModerationQueueQuery) (dto.PagedResponse[dto.GreetingResponse], error)
+	SubmitGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error)
+	ArchiveGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error)
+	ApproveGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error)
+	RejectGreeting(ctx context.Context, id uint, input dto.GreetingRejectionInput) (dto.GreetingResponse, error)
+}
+
+// defaultModerationQueueSort lists the greetings waiting the longest for moderation first
+var defaultModerationQueueSort = []string{"createdAt"}
+
+type moderationServiceImpl struct {
+	repo   repository.HelloRepository
+	mapper mapper.HelloMapper
+	clock  util.Clock
+}
+
+// NewModerationService creates a new instance of moderationServiceImpl.
+// Moderation times are taken from clock.
+func NewModerationService(repo repository.HelloRepository,
+	mapper mapper.HelloMapper,
+	clock util.Clock) ModerationService {
+	return &moderationServiceImpl{
+		repo:   repo,
+		mapper: mapper,
+		clock:  clock,
+	}
+}
+
+// GetModerationQueue retrieves a page of the greetings waiting for moderation, the longest waiting first by default
+func (s *moderationServiceImpl) GetModerationQueue(ctx context.Context, query dto.ModerationQueueQuery) (dto.PagedResponse[dto.GreetingResponse], error) {
+	s = s.scoped(ctx)
+	sort := query.Sort
+	if len(sort) == 0 {
+		sort = defaultModerationQueueSort
+	}
+	pageable, err := toPageable(query.Page, query.Size, sort, greetingSortColumns)
+	if err != nil {
+		return dto.PagedResponse[dto.GreetingResponse]{}, err
+	}
+
+	page, err := s.repo.FindAllPaged(pageable, repository.StatusEquals(domain.GreetingStatusPending))
+	if err != nil {
+		return dto.PagedResponse[dto.GreetingResponse]{}, fmt.Errorf("failed to fetch pending greetings: %w", err)
+	}
+
+	return toPagedResponse(page, s.mapper.ToGreetingResponses(page.Content)), nil
+}
+
+// SubmitGreeting submits a draft or rejected greeting for moderation. Only its owner and admins may submit it.
+func (s *moderationServiceImpl) SubmitGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	return s.moderateGreeting(ctx, id, domain.GreetingStatusPending, func(entity *domain.Greeting) error {
+		if err := checkOwner(ctx, *entity); err != nil {
+			return err
+		}
+		entity.RejectionReason = nil
+		return nil
+	})
+}
+
+// ArchiveGreeting archives a greeting, which hides it from everyone but its owner and admins for good.
+// Only its owner and admins may archive it.
+func (s *moderationServiceImpl) ArchiveGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	return s.moderateGreeting(ctx, id, domain.GreetingStatusArchived, func(entity *domain.Greeting) error {
+		return checkOwner(ctx, *entity)
+	})
+}
+
+// ApproveGreeting approves a pending greeting, which makes it visible to everyone
+func (s *moderationServiceImpl) ApproveGreeting(ctx context.Context, id uint) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	return s.moderateGreeting(ctx, id, domain.GreetingStatusApproved, func(entity *domain.Greeting) error {
+		s.recordModeration(ctx, entity, nil)
+		return nil
+	})
+}
+
+// RejectGreeting rejects a pending greeting with a reason for its owner, who may change and submit it again
+func (s *moderationServiceImpl) RejectGreeting(ctx context.Context, id uint,
+	input dto.GreetingRejectionInput) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return dto.GreetingResponse{}, customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "required",
+			Field:         "reason",
+			RejectedValue: input.Reason,
+			Message:       "reason must not be blank",
+		}}}
+	}
+	return s.moderateGreeting(ctx, id, domain.GreetingStatusRejected, func(entity *domain.Greeting) error {
+		s.recordModeration(ctx, entity, &reason)
+		return nil
+	})
+}
+
+// moderateGreeting moves a greeting visible to the user of ctx to the target status after applying the update,
+// provided the status allows it and the greeting is not modified concurrently
+func (s *moderationServiceImpl) moderateGreeting(ctx context.Context, id uint, target domain.GreetingStatus,
+	apply func(*domain.Greeting) error) (dto.GreetingResponse, error) {
+	var response dto.GreetingResponse
+	err := s.repo.Transaction(func(tx repository.HelloRepository) error {
+		entity, err := findVisibleGreetingOf(ctx, tx, id, s.clock)
+		if err != nil {
+			return err
+		}
+
+		if !entity.Status.CanTransitionTo(target) {
+			return &customError.InvalidStateTransitionError{
+				Resource: "Greeting",
+				Value:    fmt.Sprintf("%d", id),
+				From:     string(entity.Status),
+				To:       string(target),
+			}
+		}
+		if err := apply(&entity); err != nil {
+			return err
+		}
+		entity.Status = target
+
+		savedEntity, err := tx.Save(entity)
+		if errors.Is(err, repository.ErrOptimisticLock) {
+			return lostUpdateError(nil, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to moderate greeting: %w", err)
+		}
+
+		response = s.mapper.ToGreetingResponse(savedEntity)
+		return nil
+	})
+	return response, err
+}
+
+// recordModeration records the admin of ctx approving or rejecting the greeting with the reason
+func (s *moderationServiceImpl) recordModeration(ctx context.Context, entity *domain.Greeting, reason *string) {
+	moderatedAt := s.clock.Now()
+	entity.ModeratedBy = currentUserID(ctx)
+	entity.ModeratedAt = &moderatedAt
+	entity.RejectionReason = reason
+}
+
+// scoped returns a copy of the service whose repository is bound to ctx, so it only sees the greetings
+// of the tenant of the authenticated user
+func (s *moderationServiceImpl) scoped(ctx context.Context) *moderationServiceImpl {
+	scoped := *s
+	scoped.repo = s.repo.WithContext(ctx)
+	return &scoped
+}
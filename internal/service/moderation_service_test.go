@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestModerationService_GetModerationQueue(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+
+	greetings := []domain.Greeting{{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusPending}}
+	expectedResponses := []dto.GreetingResponse{{ID: 1, Message: "Hello, World!", Status: "PENDING"}}
+
+	// The longest waiting greetings come first unless another order is requested
+	mockRepo.On("FindAllPaged", repository.Pageable{
+		Size: 20,
+		Sort: []repository.SortOrder{{Column: "created_at"}},
+	}, mock.MatchedBy(func(specs []repository.Specification) bool {
+		return len(specs) == 1
+	})).Return(repository.Page[domain.Greeting]{Content: greetings, Size: 20, TotalElements: 1}, nil)
+	mockMapper.On("ToGreetingResponses", greetings).Return(expectedResponses)
+
+	service := NewModerationService(mockRepo, mockMapper, nil)
+
+	actual, err := service.GetModerationQueue(context.Background(), dto.ModerationQueueQuery{Size: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponses, actual.Content)
+	assert.Equal(t, dto.PageMetadata{Number: 0, Size: 20, TotalElements: 1, TotalPages: 1}, actual.Page)
+
+	mockRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestModerationService_SubmitGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+
+	reason := "Contains a link"
+	rejectedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusRejected,
+		RejectionReason: &reason, OwnerID: "2", VersionedEntity: domain.VersionedEntity{Version: 2}}
+	approvedEntity := domain.Greeting{ID: 2, Message: "Hello, Moon!", Status: domain.GreetingStatusApproved, OwnerID: "2"}
+	archivedEntity := domain.Greeting{ID: 3, Message: "Hello, Sun!", Status: domain.GreetingStatusArchived, OwnerID: "2"}
+	expiredAt := revisionTime.Add(-time.Hour)
+	expiredEntity := domain.Greeting{ID: 4, Message: "Hello, Stars!", Status: domain.GreetingStatusApproved, OwnerID: "2",
+		ExpireAt: &expiredAt}
+	submittedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusPending,
+		OwnerID: "2", VersionedEntity: domain.VersionedEntity{Version: 2}}
+
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &rejectedEntity}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(util.Optional[domain.Greeting]{Value: &approvedEntity}, nil)
+	mockRepo.On("FindByID", uint(3)).Return(util.Optional[domain.Greeting]{Value: &archivedEntity}, nil)
+	mockRepo.On("FindByID", uint(4)).Return(util.Optional[domain.Greeting]{Value: &expiredEntity}, nil)
+	// The rejection reason is cleared on submission
+	mockRepo.On("Save", submittedEntity).Return(submittedEntity, nil)
+	mockMapper.On("ToGreetingResponse", submittedEntity).Return(dto.GreetingResponse{ID: 1, Status: "PENDING"})
+
+	service := NewModerationService(mockRepo, mockMapper, customMock.NewFakeClock(revisionTime))
+	ownerCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+	otherCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "3", Authorities: []string{"ROLE_USER"}})
+
+	actual, err := service.SubmitGreeting(ownerCtx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "PENDING", actual.Status)
+
+	// Greetings of others are hidden unless approved, and then they may not be changed
+	_, err = service.SubmitGreeting(otherCtx, 1)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+	_, err = service.ArchiveGreeting(otherCtx, 2)
+	assert.ErrorAs(t, err, new(*customError.AccessDeniedError))
+
+	// Archived greetings stay archived
+	_, err = service.SubmitGreeting(ownerCtx, 3)
+	var transitionErr *customError.InvalidStateTransitionError
+	if assert.ErrorAs(t, err, &transitionErr) {
+		assert.Equal(t, "ARCHIVED", transitionErr.From)
+		assert.Equal(t, "PENDING", transitionErr.To)
+	}
+
+	// Greetings which are not live are hidden from their owner as well
+	_, err = service.ArchiveGreeting(ownerCtx, 4)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestModerationService_ApproveGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := customMock.NewFakeClock(revisionTime)
+
+	pendingEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusPending, OwnerID: "2"}
+	approvedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusApproved, OwnerID: "2",
+		ModeratedBy: "1", ModeratedAt: &revisionTime}
+
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &pendingEntity}, nil).Once()
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &approvedEntity}, nil)
+	mockRepo.On("Save", approvedEntity).Return(approvedEntity, nil)
+	mockMapper.On("ToGreetingResponse", approvedEntity).Return(dto.GreetingResponse{ID: 1, Status: "APPROVED"})
+
+	service := NewModerationService(mockRepo, mockMapper, mockClock)
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+
+	// The moderator and the moderation time are recorded
+	actual, err := service.ApproveGreeting(adminCtx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "APPROVED", actual.Status)
+
+	// An approved greeting cannot be approved again
+	_, err = service.ApproveGreeting(adminCtx, 1)
+	var transitionErr *customError.InvalidStateTransitionError
+	if assert.ErrorAs(t, err, &transitionErr) {
+		assert.Equal(t, "Greeting", transitionErr.Resource)
+		assert.Equal(t, "APPROVED", transitionErr.From)
+		assert.Equal(t, "APPROVED", transitionErr.To)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestModerationService_RejectGreeting(t *testing.T) {
+	mockRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := customMock.NewFakeClock(revisionTime)
+
+	reason := "Contains a link"
+	pendingEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusPending, OwnerID: "2"}
+	rejectedEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusRejected, OwnerID: "2",
+		RejectionReason: &reason, ModeratedBy: "1", ModeratedAt: &revisionTime}
+
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &pendingEntity}, nil)
+	mockRepo.On("Save", rejectedEntity).Return(rejectedEntity, nil)
+	mockMapper.On("ToGreetingResponse", rejectedEntity).
+		Return(dto.GreetingResponse{ID: 1, Status: "REJECTED", RejectionReason: &reason})
+
+	service := NewModerationService(mockRepo, mockMapper, mockClock)
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+
+	// The reason is trimmed
+	actual, err := service.RejectGreeting(adminCtx, 1, dto.GreetingRejectionInput{Reason: " Contains a link "})
+	assert.NoError(t, err)
+	assert.Equal(t, &reason, actual.RejectionReason)
+
+	// A blank reason is a constraint violation
+	_, err = service.RejectGreeting(adminCtx, 1, dto.GreetingRejectionInput{Reason: " "})
+	var constraintErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &constraintErr) {
+		assert.Equal(t, "reason", constraintErr.Violations[0].Field)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"sort"
+	"strings"
+)
+
+// toPageable converts the page parameters into a Pageable.
+// Each sort criterion has the format property[,asc|desc]; properties are translated with sortColumns,
+// so only whitelisted columns ever reach the query.
+func toPageable(page, size int, sortParams []string, sortColumns map[string]string) (repository.Pageable, error) {
+	pageable := repository.Pageable{Page: page, Size: size}
+
+	var violations []dto.Violation
+	for _, param := range sortParams {
+		order, violation := parseSortOrder(param, sortColumns)
+		if violation != nil {
+			violations = append(violations, *violation)
+			continue
+		}
+		pageable.Sort = append(pageable.Sort, order)
+	}
+
+	if len(violations) > 0 {
+		return repository.Pageable{}, customError.ConstraintViolationError{Violations: violations}
+	}
+	return pageable, nil
+}
+
+// toKeysetPageable converts the cursor parameters into a KeysetPageable.
+// A cursor carries the sort order of the listing it was issued for, so the sort parameter only applies to the first page.
+func toKeysetPageable(codec security.CursorCodec, query dto.CursorQuery,
+	sortColumns map[string]string) (repository.KeysetPageable, error) {
+	pageable := repository.KeysetPageable{Size: query.Size, Sort: repository.SortOrder{Column: "id"}}
+
+	if query.Cursor != "" {
+		var keyset repository.Keyset
+		if err := codec.Decode(query.Cursor, &keyset); err != nil || !isSortColumn(keyset.Column, sortColumns) {
+			return repository.KeysetPageable{}, customError.ConstraintViolationError{Violations: []dto.Violation{{
+				Code:          "cursor",
+				Field:         "cursor",
+				RejectedValue: query.Cursor,
+				Message:       "cursor is invalid or has been tampered with",
+			}}}
+		}
+		pageable.Keyset = &keyset
+		return pageable, nil
+	}
+
+	if query.Sort != "" {
+		order, violation := parseSortOrder(query.Sort, sortColumns)
+		if violation != nil {
+			return repository.KeysetPageable{}, customError.ConstraintViolationError{Violations: []dto.Violation{*violation}}
+		}
+		pageable.Sort = order
+	}
+	return pageable, nil
+}
+
+// parseSortOrder parses a sort criterion in the format property[,asc|desc]
+func parseSortOrder(param string, sortColumns map[string]string) (repository.SortOrder, *dto.Violation) {
+	property, direction, _ := strings.Cut(param, ",")
+	property = strings.TrimSpace(property)
+	direction = strings.ToLower(strings.TrimSpace(direction))
+
+	column, ok := sortColumns[property]
+	if !ok {
+		return repository.SortOrder{}, &dto.Violation{
+			Code:          "sort",
+			Field:         "sort",
+			RejectedValue: param,
+			Message:       fmt.Sprintf("sort property must be one of [%s]", strings.Join(sortProperties(sortColumns), " ")),
+		}
+	}
+	if direction != "" && direction != "asc" && direction != "desc" {
+		return repository.SortOrder{}, &dto.Violation{
+			Code:          "sort",
+			Field:         "sort",
+			RejectedValue: param,
+			Message:       "sort direction must be one of [asc desc]",
+		}
+	}
+	return repository.SortOrder{Column: column, Desc: direction == "desc"}, nil
+}
+
+// sortProperties returns the allowed sort properties in a stable order
+func sortProperties(sortColumns map[string]string) []string {
+	properties := make([]string, 0, len(sortColumns))
+	for property := range sortColumns {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
+// isSortColumn reports whether the column is one of the allowed sort columns
+func isSortColumn(column string, sortColumns map[string]string) bool {
+	for _, allowed := range sortColumns {
+		if allowed == column {
+			return true
+		}
+	}
+	return false
+}
+
+// toPagedResponse wraps the mapped content of a page with its pagination metadata
+func toPagedResponse[E any, R any](page repository.Page[E], content []R) dto.PagedResponse[R] {
+	return dto.PagedResponse[R]{
+		Content: content,
+		Page: dto.PageMetadata{
+			Number:        page.Page,
+			Size:          page.Size,
+			TotalElements: page.TotalElements,
+			TotalPages:    page.TotalPages(),
+		},
+	}
+}
+
+// toCursorPagedResponse wraps the mapped content of a keyset page with the encoded cursors of the neighbouring pages
+func toCursorPagedResponse[E any, R any](codec security.CursorCodec, page repository.KeysetPage[E],
+	size int, content []R) (dto.CursorPagedResponse[R], error) {
+	response := dto.CursorPagedResponse[R]{Content: content, Size: size}
+
+	var err error
+	if page.Next != nil {
+		if response.Next, err = codec.Encode(page.Next); err != nil {
+			return dto.CursorPagedResponse[R]{}, fmt.Errorf("failed to encode cursor: %w", err)
+		}
+	}
+	if page.Prev != nil {
+		if response.Prev, err = codec.Encode(page.Prev); err != nil {
+			return dto.CursorPagedResponse[R]{}, fmt.Errorf("failed to encode cursor: %w", err)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"strings"
+	"time"
+)
+
+// ReactionService manages the reactions of users to greetings and ranks greetings by them. Reactions are only
+// read and changed on greetings visible to the user of the context, see HelloService, and only ranked among them.
+type ReactionService interface {
+	GetReactions(ctx context.Context, greetingID uint) (dto.ReactionSummaryResponse, error)
+	AddReaction(ctx context.Context, greetingID uint, reactionType string) (dto.ReactionSummaryResponse, error)
+	RemoveReaction(ctx context.Context, greetingID uint, reactionType string) (dto.ReactionSummaryResponse, error)
+	GetTopGreetings(ctx context.Context, query dto.TopGreetingQuery) ([]dto.TopGreetingResponse, error)
+}
+
+// topPeriods maps the ranking periods of the top greetings to their length; all time has none
+var topPeriods = map[string]time.Duration{
+	dto.TopPeriodDay:   24 * time.Hour,
+	dto.TopPeriodWeek:  7 * 24 * time.Hour,
+	dto.TopPeriodMonth: 30 * 24 * time.Hour,
+	dto.TopPeriodYear:  365 * 24 * time.Hour,
+}
+
+type reactionServiceImpl struct {
+	repo      repository.ReactionRepository
+	helloRepo repository.HelloRepository
+	mapper    mapper.ReactionMapper
+	clock     util.Clock
+}
+
+// NewReactionService creates a new instance of reactionServiceImpl.
+// The greetings reacted to are read from helloRepo.
+func NewReactionService(repo repository.ReactionRepository,
+	helloRepo repository.HelloRepository,
+	mapper mapper.ReactionMapper,
+	clock util.Clock) ReactionService {
+	return &reactionServiceImpl{
+		repo:      repo,
+		helloRepo: helloRepo,
+		mapper:    mapper,
+		clock:     clock,
+	}
+}
+
+// GetReactions retrieves the number of reactions per type to a visible greeting and the reactions of the user of ctx
+func (s *reactionServiceImpl) GetReactions(ctx context.Context, greetingID uint) (dto.ReactionSummaryResponse, error) {
+	if _, err := findVisibleGreetingOf(ctx, s.helloRepo, greetingID, s.clock); err != nil {
+		return dto.ReactionSummaryResponse{}, err
+	}
+	return s.reactionSummary(greetingID, currentUserID(ctx))
+}
+
+// AddReaction adds a reaction of the user of ctx to a visible greeting. Reacting again with the same type
+// has no effect, so a user reacts at most once per type.
+func (s *reactionServiceImpl) AddReaction(ctx context.Context, greetingID uint,
+	reactionType string) (dto.ReactionSummaryResponse, error) {
+	return s.changeReaction(ctx, greetingID, reactionType, func(reaction domain.GreetingReaction) error {
+		reaction.CreatedAt = s.clock.Now().UTC()
+		_, err := s.repo.Save(reaction)
+		return err
+	})
+}
+
+// RemoveReaction removes a reaction of the user of ctx from a visible greeting. Removing a missing reaction
+// has no effect.
+func (s *reactionServiceImpl) RemoveReaction(ctx context.Context, greetingID uint,
+	reactionType string) (dto.ReactionSummaryResponse, error) {
+	return s.changeReaction(ctx, greetingID, reactionType, func(reaction domain.GreetingReaction) error {
+		_, err := s.repo.Delete(reaction)
+		return err
+	})
+}
+
+// changeReaction applies a change to the reaction of the user of ctx to a visible greeting and returns the
+// resulting reactions. Counts are aggregated from the stored reactions, so concurrent changes never skew them.
+func (s *reactionServiceImpl) changeReaction(ctx context.Context, greetingID uint, reactionType string,
+	apply func(domain.GreetingReaction) error) (dto.ReactionSummaryResponse, error) {
+	parsedType, err := parseReactionType(reactionType, "type")
+	if err != nil {
+		return dto.ReactionSummaryResponse{}, err
+	}
+	userID := currentUserID(ctx)
+	if userID == "" {
+		return dto.ReactionSummaryResponse{}, &customError.AccessDeniedError{
+			Message: "Only authenticated users can react to greetings",
+		}
+	}
+	if _, err := findVisibleGreetingOf(ctx, s.helloRepo, greetingID, s.clock); err != nil {
+		return dto.ReactionSummaryResponse{}, err
+	}
+
+	if err := apply(domain.GreetingReaction{GreetingID: greetingID, UserID: userID, Type: parsedType}); err != nil {
+		return dto.ReactionSummaryResponse{}, err
+	}
+	return s.reactionSummary(greetingID, userID)
+}
+
+// reactionSummary returns the number of reactions per type to the greeting and the reactions of the user
+func (s *reactionServiceImpl) reactionSummary(greetingID uint, userID string) (dto.ReactionSummaryResponse, error) {
+	counts, err := s.repo.CountByGreeting(greetingID)
+	if err != nil {
+		return dto.ReactionSummaryResponse{}, err
+	}
+	var mine []domain.ReactionType
+	if userID != "" {
+		if mine, err = s.repo.FindTypesByUser(greetingID, userID); err != nil {
+			return dto.ReactionSummaryResponse{}, err
+		}
+	}
+	return s.mapper.ToReactionSummaryResponse(greetingID, counts, mine), nil
+}
+
+// GetTopGreetings ranks the visible greetings by the number of reactions they received in the period,
+// optionally only counting reactions of one type. Greetings without reactions in the period are not ranked.
+func (s *reactionServiceImpl) GetTopGreetings(ctx context.Context, query dto.TopGreetingQuery) ([]dto.TopGreetingResponse, error) {
+	var reactionType domain.ReactionType
+	if query.Type != "" {
+		var err error
+		if reactionType, err = parseReactionType(query.Type, "type"); err != nil {
+			return nil, err
+		}
+	}
+	var since time.Time
+	if period, ok := topPeriods[query.Period]; ok {
+		since = s.clock.Now().Add(-period)
+	}
+
+	scores, err := s.repo.WithContext(ctx).FindTopScored(since, reactionType, query.Size, visibilityFiltersOf(ctx, s.clock)...)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapper.ToTopGreetingResponses(scores), nil
+}
+
+// parseReactionType returns the reaction type in lowercase, or a ConstraintViolationError for the field
+// when it is not supported
+func parseReactionType(value, field string) (domain.ReactionType, error) {
+	reactionType := domain.ReactionType(strings.ToLower(strings.TrimSpace(value)))
+	if !reactionType.IsValid() {
+		names := make([]string, len(domain.ReactionTypes))
+		for i, t := range domain.ReactionTypes {
+			names[i] = string(t)
+		}
+		return "", customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "oneof",
+			Field:         field,
+			RejectedValue: value,
+			Message:       fmt.Sprintf("%s must be one of %s", field, strings.Join(names, ", ")),
+		}}}
+	}
+	return reactionType, nil
+}
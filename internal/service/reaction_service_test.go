@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestReactionService_AddReaction(t *testing.T) {
+	mockRepo := new(customMock.MockReactionRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+
+	mockRepo.On("Save", domain.GreetingReaction{GreetingID: 1, UserID: "2", Type: domain.ReactionLike,
+		CreatedAt: revisionTime}).Return(true, nil)
+	mockRepo.On("CountByGreeting", uint(1)).Return(map[domain.ReactionType]int64{domain.ReactionLike: 2}, nil)
+	mockRepo.On("FindTypesByUser", uint(1), "2").Return([]domain.ReactionType{domain.ReactionLike}, nil)
+
+	service := NewReactionService(mockRepo, mockHelloRepo, mapper.NewReactionMapper(mapper.NewHelloMapper()),
+		customMock.NewFakeClock(revisionTime))
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	// The reaction type is matched case-insensitively
+	actual, err := service.AddReaction(userCtx, 1, "Like")
+	assert.NoError(t, err)
+	assert.Equal(t, dto.ReactionSummaryResponse{GreetingID: 1, Reactions: map[string]int64{"like": 2},
+		Mine: []string{"like"}}, actual)
+
+	// Unknown reaction types are constraint violations
+	_, err = service.AddReaction(userCtx, 1, "angry")
+	var constraintErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &constraintErr) {
+		assert.Equal(t, "type", constraintErr.Violations[0].Field)
+	}
+
+	// Only authenticated users react, and only to greetings they can see
+	_, err = service.AddReaction(context.Background(), 1, "like")
+	assert.ErrorAs(t, err, new(*customError.AccessDeniedError))
+	_, err = service.AddReaction(userCtx, 2, "like")
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReactionService_RemoveReaction(t *testing.T) {
+	mockRepo := new(customMock.MockReactionRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+
+	// Removing a missing reaction is no error
+	mockRepo.On("Delete", domain.GreetingReaction{GreetingID: 1, UserID: "2", Type: domain.ReactionLove}).
+		Return(false, nil)
+	mockRepo.On("CountByGreeting", uint(1)).Return(map[domain.ReactionType]int64{}, nil)
+	mockRepo.On("FindTypesByUser", uint(1), "2").Return(nil, nil)
+
+	service := NewReactionService(mockRepo, mockHelloRepo, mapper.NewReactionMapper(mapper.NewHelloMapper()),
+		customMock.NewFakeClock(revisionTime))
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	actual, err := service.RemoveReaction(userCtx, 1, "love")
+	assert.NoError(t, err)
+	assert.Equal(t, dto.ReactionSummaryResponse{GreetingID: 1, Reactions: map[string]int64{}, Mine: []string{}}, actual)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReactionService_GetReactions(t *testing.T) {
+	mockRepo := new(customMock.MockReactionRepository)
+	mockHelloRepo := setUpCommentedGreetings()
+
+	mockRepo.On("CountByGreeting", uint(1)).Return(map[domain.ReactionType]int64{domain.ReactionLaugh: 1}, nil)
+
+	service := NewReactionService(mockRepo, mockHelloRepo, mapper.NewReactionMapper(mapper.NewHelloMapper()),
+		customMock.NewFakeClock(revisionTime))
+
+	// Anonymous callers have no reactions of their own
+	actual, err := service.GetReactions(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.ReactionSummaryResponse{GreetingID: 1, Reactions: map[string]int64{"laugh": 1}, Mine: []string{}}, actual)
+
+	// Greetings pending for moderation are hidden from other users
+	_, err = service.GetReactions(context.Background(), 2)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReactionService_GetTopGreetings(t *testing.T) {
+	mockRepo := new(customMock.MockReactionRepository)
+
+	scores := []domain.GreetingScore{{Greeting: domain.Greeting{ID: 1, Message: "Hello, World!"}, Score: 3}}
+
+	// The reactions of the last week are counted among the visible greetings
+	mockRepo.On("FindTopScored", revisionTime.Add(-7*24*time.Hour), domain.ReactionLike, 10,
+		mock.MatchedBy(func(specs []repository.Specification) bool {
+			return len(specs) == 2
+		})).Return(scores, nil)
+	// All reactions are counted for all time
+	mockRepo.On("FindTopScored", time.Time{}, domain.ReactionType(""), 5, mock.Anything).
+		Return([]domain.GreetingScore{}, nil)
+
+	service := NewReactionService(mockRepo, nil, mapper.NewReactionMapper(mapper.NewHelloMapper()),
+		customMock.NewFakeClock(revisionTime))
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	actual, err := service.GetTopGreetings(userCtx, dto.TopGreetingQuery{Period: dto.TopPeriodWeek, Type: "like", Size: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, actual, 1) {
+		assert.Equal(t, uint(1), actual[0].ID)
+		assert.Equal(t, "Hello, World!", actual[0].Message)
+		assert.Equal(t, int64(3), actual[0].Score)
+	}
+
+	actual, err = service.GetTopGreetings(userCtx, dto.TopGreetingQuery{Period: dto.TopPeriodAll, Size: 5})
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	// Unknown reaction types are constraint violations
+	_, err = service.GetTopGreetings(userCtx, dto.TopGreetingQuery{Period: dto.TopPeriodDay, Type: "angry", Size: 10})
+	assert.ErrorAs(t, err, new(customError.ConstraintViolationError))
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+)
+
+// RevisionService reads the revision history of greetings and rolls greetings back to their revisions. Revisions
+// are recorded along with the changes of HelloService, see recordRevisionOf. The revisions of a greeting are only
+// read when the greeting is visible to the user of the context; admins read those of greetings in the trash as well.
+type RevisionService interface {
+	GetGreetingRevisions(ctx context.Context, id uint, query dto.GreetingRevisionQuery) (dto.PagedResponse[dto.GreetingRevisionResponse], error)
+	DiffGreetingRevisions(ctx context.Context, id uint, query dto.GreetingRevisionDiffQuery) (dto.GreetingRevisionDiffResponse, error)
+	RollbackGreeting(ctx context.Context, id, revision uint,
+		precondition *dto.VersionPrecondition) (dto.GreetingResponse, error)
+}
+
+type revisionServiceImpl struct {
+	repo        repository.GreetingRevisionRepository
+	helloRepo   repository.HelloRepository
+	helloMapper mapper.HelloMapper
+	mapper      mapper.RevisionMapper
+	clock       util.Clock
+}
+
+// NewRevisionService creates a new instance of revisionServiceImpl.
+// The greetings revised are read from and rolled back with helloRepo.
+func NewRevisionService(repo repository.GreetingRevisionRepository,
+	helloRepo repository.HelloRepository,
+	helloMapper mapper.HelloMapper,
+	mapper mapper.RevisionMapper,
+	clock util.Clock) RevisionService {
+	return &revisionServiceImpl{
+		repo:        repo,
+		helloRepo:   helloRepo,
+		helloMapper: helloMapper,
+		mapper:      mapper,
+		clock:       clock,
+	}
+}
+
+// GetGreetingRevisions retrieves a page of the revisions of a greeting visible to the user of ctx, most recent first.
+// Admins list the revisions of greetings in the trash as well.
+func (s *revisionServiceImpl) GetGreetingRevisions(ctx context.Context, id uint,
+	query dto.GreetingRevisionQuery) (dto.PagedResponse[dto.GreetingRevisionResponse], error) {
+	s = s.scoped(ctx)
+	if err := s.checkRevisedGreeting(ctx, id); err != nil {
+		return dto.PagedResponse[dto.GreetingRevisionResponse]{}, err
+	}
+
+	pageable := repository.Pageable{
+		Page: query.Page,
+		Size: query.Size,
+		Sort: []repository.SortOrder{{Column: "revision", Desc: true}},
+	}
+	page, err := s.repo.FindPagedByGreeting(id, pageable)
+	if err != nil {
+		return dto.PagedResponse[dto.GreetingRevisionResponse]{}, fmt.Errorf("failed to fetch greeting revisions: %w", err)
+	}
+
+	return toPagedResponse(page, s.mapper.ToGreetingRevisionResponses(page.Content)), nil
+}
+
+// DiffGreetingRevisions compares the values of a greeting visible to the user of ctx after two of its revisions.
+// Admins compare the revisions of greetings in the trash as well.
+func (s *revisionServiceImpl) DiffGreetingRevisions(ctx context.Context, id uint,
+	query dto.GreetingRevisionDiffQuery) (dto.GreetingRevisionDiffResponse, error) {
+	s = s.scoped(ctx)
+	if err := s.checkRevisedGreeting(ctx, id); err != nil {
+		return dto.GreetingRevisionDiffResponse{}, err
+	}
+	from, err := s.findRevision(id, query.From)
+	if err != nil {
+		return dto.GreetingRevisionDiffResponse{}, err
+	}
+	to, err := s.findRevision(id, query.To)
+	if err != nil {
+		return dto.GreetingRevisionDiffResponse{}, err
+	}
+
+	changes := []dto.GreetingFieldChange{}
+	for _, field := range []struct {
+		name     string
+		from, to *string
+	}{
+		{name: "message", from: from.NewMessage, to: to.NewMessage},
+		{name: "locale", from: from.NewLocale, to: to.NewLocale},
+	} {
+		if (field.from == nil) != (field.to == nil) || (field.from != nil && *field.from != *field.to) {
+			changes = append(changes, dto.GreetingFieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	return dto.GreetingRevisionDiffResponse{
+		From:    s.mapper.ToGreetingRevisionResponse(from),
+		To:      s.mapper.ToGreetingRevisionResponse(to),
+		Changes: changes,
+	}, nil
+}
+
+// RollbackGreeting sets the message and locale of a greeting back to their values after the given revision.
+// The rollback is recorded as a new revision, so it can be rolled back as well.
+func (s *revisionServiceImpl) RollbackGreeting(ctx context.Context, id, revision uint,
+	precondition *dto.VersionPrecondition) (dto.GreetingResponse, error) {
+	s = s.scoped(ctx)
+	target, err := s.findRevision(id, revision)
+	if err != nil {
+		return dto.GreetingResponse{}, err
+	}
+
+	// A delete leaves no values to roll back to; deleted greetings are restored from the trash instead
+	if target.NewMessage == nil {
+		return dto.GreetingResponse{}, customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "rollback_target",
+			Field:         "revision",
+			RejectedValue: fmt.Sprintf("%d", revision),
+			Message:       "revision must not be a delete",
+		}}}
+	}
+
+	// Revisions do not record the schedule and tags, so the current ones are kept
+	rollback := domain.GreetingRevision{Action: domain.GreetingRevisionRollback, RolledBackTo: &target.Revision}
+	return updateGreetingOf(ctx, s.helloRepo, s.helloMapper, s.clock, id, precondition, rollback,
+		func(entity *domain.Greeting) error {
+			s.helloMapper.UpdateGreeting(entity, dto.GreetingInput{Message: *target.NewMessage, Locale: *target.NewLocale,
+				PublishAt: entity.PublishAt, ExpireAt: entity.ExpireAt, Tags: entity.TagNames()})
+			return nil
+		})
+}
+
+// checkRevisedGreeting returns a ResourceNotFoundError unless the greeting is visible to the user of ctx,
+// see findVisibleGreetingOf, or the user is an admin and the greeting is in the trash
+func (s *revisionServiceImpl) checkRevisedGreeting(ctx context.Context, id uint) error {
+	_, err := findVisibleGreetingOf(ctx, s.helloRepo, id, s.clock)
+	var notFoundErr *customError.ResourceNotFoundError
+	if !errors.As(err, &notFoundErr) || !security.HasAuthority(ctx, security.AuthorityAdmin) {
+		return err
+	}
+	optionalEntity, err := s.helloRepo.FindDeletedByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deleted greeting by ID: %w", err)
+	}
+	if optionalEntity.IsEmpty() {
+		return notFoundErr
+	}
+	return nil
+}
+
+// findRevision returns the revision of a greeting, or a ResourceNotFoundError when it does not exist
+func (s *revisionServiceImpl) findRevision(id, revision uint) (domain.GreetingRevision, error) {
+	optionalRevision, err := s.repo.FindByGreetingAndNumber(id, revision)
+	if err != nil {
+		return domain.GreetingRevision{}, fmt.Errorf("failed to fetch greeting revision: %w", err)
+	}
+
+	if optionalRevision.IsEmpty() {
+		return domain.GreetingRevision{}, &customError.ResourceNotFoundError{
+			Resource: "Greeting revision",
+			Criteria: "revision",
+			Value:    fmt.Sprintf("%d", revision),
+		}
+	}
+	return *optionalRevision.Value, nil
+}
+
+// scoped returns a copy of the service whose repositories are bound to ctx, so it only sees the greetings
+// and revisions of the tenant of the authenticated user
+func (s *revisionServiceImpl) scoped(ctx context.Context) *revisionServiceImpl {
+	scoped := *s
+	scoped.repo = s.repo.WithContext(ctx)
+	scoped.helloRepo = s.helloRepo.WithContext(ctx)
+	return &scoped
+}
+
+// recordRevisionOf completes the revision with the change of a greeting from oldEntity to newEntity, either of which
+// is nil when the greeting did not exist before or after the change, and saves it with helloRepo, which must be bound
+// to the transaction of the change. The user of ctx is its author.
+func recordRevisionOf(ctx context.Context, helloRepo repository.HelloRepository, clock util.Clock,
+	revision domain.GreetingRevision, oldEntity, newEntity *domain.Greeting) error {
+	revision.ChangedAt = clock.Now()
+	if oldEntity != nil {
+		oldMessage, oldLocale := oldEntity.Message, oldEntity.Locale
+		revision.GreetingID, revision.Version = oldEntity.ID, oldEntity.Version
+		revision.OldMessage, revision.OldLocale = &oldMessage, &oldLocale
+	}
+	if newEntity != nil {
+		newMessage, newLocale := newEntity.Message, newEntity.Locale
+		revision.GreetingID, revision.Version = newEntity.ID, newEntity.Version
+		revision.NewMessage, revision.NewLocale = &newMessage, &newLocale
+	}
+	revision.ChangedBy = currentUserID(ctx)
+
+	if _, err := helloRepo.SaveRevision(revision); err != nil {
+		return fmt.Errorf("failed to record greeting revision: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestRevisionService_GetGreetingRevisions(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingRevisionRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+
+	// Mock data
+	deletedEntity := domain.Greeting{ID: 2, Message: "Deleted"}
+	pendingEntity := domain.Greeting{ID: 4, Message: "Pending", Status: domain.GreetingStatusPending, OwnerID: "3"}
+	revisions := []domain.GreetingRevision{{GreetingID: 2, Revision: 2, Action: domain.GreetingRevisionDelete}}
+	responses := []dto.GreetingRevisionResponse{{Revision: 2, Action: string(domain.GreetingRevisionDelete)}}
+	pageable := repository.Pageable{Page: 0, Size: 20, Sort: []repository.SortOrder{{Column: "revision", Desc: true}}}
+
+	// Mock expectations
+	mockHelloRepo.On("FindByID", uint(2)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
+	mockHelloRepo.On("FindDeletedByID", uint(2)).Return(util.Optional[domain.Greeting]{Value: &deletedEntity}, nil)
+	mockRepo.On("FindPagedByGreeting", uint(2), pageable).
+		Return(repository.Page[domain.GreetingRevision]{Content: revisions, Page: 0, Size: 20, TotalElements: 1}, nil)
+	mockHelloRepo.On("FindByID", uint(3)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
+	mockHelloRepo.On("FindDeletedByID", uint(3)).Return(util.Optional[domain.Greeting]{Value: nil}, nil)
+	mockHelloRepo.On("FindByID", uint(4)).Return(util.Optional[domain.Greeting]{Value: &pendingEntity}, nil)
+
+	service := NewRevisionService(mockRepo, mockHelloRepo, nil, mapper.NewRevisionMapper(), customMock.NewFakeClock(revisionTime))
+	adminCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "1", Authorities: []string{security.AuthorityAdmin}})
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	// Admins list the revisions of a greeting in the trash as well
+	actual, err := service.GetGreetingRevisions(adminCtx, 2, dto.GreetingRevisionQuery{Page: 0, Size: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, responses, actual.Content)
+	assert.Equal(t, int64(1), actual.Page.TotalElements)
+
+	// Unknown greetings have no revisions, and other users do not see the trash nor the pending greetings of others
+	var notFoundErr *customError.ResourceNotFoundError
+	for _, tc := range []struct {
+		ctx context.Context
+		id  uint
+	}{{adminCtx, 3}, {userCtx, 2}, {userCtx, 4}} {
+		_, err = service.GetGreetingRevisions(tc.ctx, tc.id, dto.GreetingRevisionQuery{Page: 0, Size: 20})
+		if assert.ErrorAs(t, err, &notFoundErr) {
+			assert.Equal(t, "Greeting", notFoundErr.Resource)
+		}
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "FindPagedByGreeting", 1)
+	mockHelloRepo.AssertNotCalled(t, "FindDeletedByID", uint(4))
+}
+
+func TestRevisionService_DiffGreetingRevisions(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingRevisionRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+
+	// Mock data
+	existingEntity := domain.Greeting{ID: 1, Message: "Hello, World!", Status: domain.GreetingStatusApproved}
+	draftEntity := domain.Greeting{ID: 2, Message: "Draft", Status: domain.GreetingStatusDraft, OwnerID: "3"}
+	created := domain.GreetingRevision{GreetingID: 1, Revision: 1, Action: domain.GreetingRevisionCreate,
+		NewMessage: stringPtr("Hello"), NewLocale: stringPtr("en")}
+	updated := domain.GreetingRevision{GreetingID: 1, Revision: 2, Action: domain.GreetingRevisionUpdate,
+		NewMessage: stringPtr("Hello, World!"), NewLocale: stringPtr("en")}
+
+	// Mock expectations
+	mockHelloRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+	mockHelloRepo.On("FindByID", uint(2)).Return(util.Optional[domain.Greeting]{Value: &draftEntity}, nil)
+	mockRepo.On("FindByGreetingAndNumber", uint(1), uint(1)).Return(util.Optional[domain.GreetingRevision]{Value: &created}, nil)
+	mockRepo.On("FindByGreetingAndNumber", uint(1), uint(2)).Return(util.Optional[domain.GreetingRevision]{Value: &updated}, nil)
+	mockRepo.On("FindByGreetingAndNumber", uint(1), uint(9)).Return(util.Optional[domain.GreetingRevision]{Value: nil}, nil)
+
+	service := NewRevisionService(mockRepo, mockHelloRepo, nil, mapper.NewRevisionMapper(), customMock.NewFakeClock(revisionTime))
+	userCtx := security.ContextWithClaims(context.Background(), &security.TokenClaims{UserID: "2", Authorities: []string{"ROLE_USER"}})
+
+	// Only the changed fields are listed
+	actual, err := service.DiffGreetingRevisions(userCtx, 1, dto.GreetingRevisionDiffQuery{From: 1, To: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), actual.From.Revision)
+	assert.Equal(t, uint(2), actual.To.Revision)
+	assert.Equal(t, []dto.GreetingFieldChange{{Field: "message", From: stringPtr("Hello"), To: stringPtr("Hello, World!")}},
+		actual.Changes)
+
+	// Unknown revisions cannot be compared
+	_, err = service.DiffGreetingRevisions(userCtx, 1, dto.GreetingRevisionDiffQuery{From: 1, To: 9})
+	var notFoundErr *customError.ResourceNotFoundError
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "Greeting revision", notFoundErr.Resource)
+	}
+
+	// Nor can the revisions of the drafts of other users
+	_, err = service.DiffGreetingRevisions(userCtx, 2, dto.GreetingRevisionDiffQuery{From: 1, To: 2})
+	if assert.ErrorAs(t, err, &notFoundErr) {
+		assert.Equal(t, "Greeting", notFoundErr.Resource)
+	}
+
+	mockRepo.AssertNotCalled(t, "FindByGreetingAndNumber", uint(2), mock.Anything)
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertExpectations(t)
+}
+
+func TestRevisionService_RollbackGreeting_Success(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingRevisionRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock data
+	target := domain.GreetingRevision{GreetingID: 1, Revision: 1, Action: domain.GreetingRevisionCreate,
+		NewMessage: stringPtr("Hello"), NewLocale: stringPtr("en")}
+	existingEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Hello, World!", Locale: "en",
+		VersionedEntity: domain.VersionedEntity{Version: 1}}
+	rolledBackEntity := domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusApproved, Message: "Hello", Locale: "en",
+		VersionedEntity: domain.VersionedEntity{Version: 2}}
+	expectedResponse := dto.GreetingResponse{ID: 1, Message: "Hello", Locale: "en", Version: 2}
+
+	// Mock expectations
+	expectRevision(mockHelloRepo, mockClock)
+	mockRepo.On("FindByGreetingAndNumber", uint(1), uint(1)).Return(util.Optional[domain.GreetingRevision]{Value: &target}, nil)
+	mockHelloRepo.On("FindByID", uint(1)).Return(util.Optional[domain.Greeting]{Value: &existingEntity}, nil)
+	mockMapper.On("UpdateGreeting", mock.Anything, dto.GreetingInput{Message: "Hello", Locale: "en"}).
+		Run(func(args mock.Arguments) {
+			entity := args.Get(0).(*domain.Greeting)
+			entity.Message, entity.Locale = "Hello", "en"
+		})
+	mockHelloRepo.On("ExistsByMessage", "Hello", "en").Return(false, nil)
+	// The content changed, so it waits for moderation again
+	mockHelloRepo.On("Save", domain.Greeting{ID: 1, OwnerID: "2", Status: domain.GreetingStatusPending, Message: "Hello", Locale: "en",
+		VersionedEntity: domain.VersionedEntity{Version: 1}}).Return(rolledBackEntity, nil)
+	mockMapper.On("ToGreetingResponse", rolledBackEntity).Return(expectedResponse)
+
+	service := NewRevisionService(mockRepo, mockHelloRepo, mockMapper, mapper.NewRevisionMapper(), mockClock)
+
+	actual, err := service.RollbackGreeting(greetingOwnerCtx, 1, 1, nil)
+
+	// The rollback is recorded as a new revision referring to its target
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, actual)
+	rolledBackTo := uint(1)
+	mockHelloRepo.AssertCalled(t, "SaveRevision", domain.GreetingRevision{
+		GreetingID:   1,
+		Action:       domain.GreetingRevisionRollback,
+		OldMessage:   stringPtr("Hello, World!"),
+		OldLocale:    stringPtr("en"),
+		NewMessage:   stringPtr("Hello"),
+		NewLocale:    stringPtr("en"),
+		Version:      2,
+		RolledBackTo: &rolledBackTo,
+		ChangedBy:    "2",
+		ChangedAt:    revisionTime,
+	})
+
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertExpectations(t)
+	mockMapper.AssertExpectations(t)
+}
+
+func TestRevisionService_RollbackGreeting_InvalidTarget(t *testing.T) {
+	mockRepo := new(customMock.MockGreetingRevisionRepository)
+	mockHelloRepo := new(customMock.MockHelloRepository)
+	mockMapper := new(customMock.MockHelloMapper)
+	mockClock := new(customMock.MockClock)
+
+	// Mock data
+	deleted := domain.GreetingRevision{GreetingID: 1, Revision: 2, Action: domain.GreetingRevisionDelete,
+		OldMessage: stringPtr("Hello"), OldLocale: stringPtr("en")}
+
+	// Mock expectations
+	mockRepo.On("FindByGreetingAndNumber", uint(1), uint(2)).Return(util.Optional[domain.GreetingRevision]{Value: &deleted}, nil)
+	mockRepo.On("FindByGreetingAndNumber", uint(1), uint(9)).Return(util.Optional[domain.GreetingRevision]{Value: nil}, nil)
+
+	service := NewRevisionService(mockRepo, mockHelloRepo, mockMapper, mapper.NewRevisionMapper(), mockClock)
+
+	// A delete leaves no values to roll back to
+	_, err := service.RollbackGreeting(context.Background(), 1, 2, nil)
+	var violationErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &violationErr) {
+		assert.Equal(t, "rollback_target", violationErr.Violations[0].Code)
+	}
+
+	// Unknown revisions cannot be rolled back to
+	_, err = service.RollbackGreeting(context.Background(), 1, 9, nil)
+	assert.ErrorAs(t, err, new(*customError.ResourceNotFoundError))
+
+	mockRepo.AssertExpectations(t)
+	mockHelloRepo.AssertNotCalled(t, "Transaction")
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"gin-samples/internal/util"
+	"log"
+)
+
+// SecurityEventSink receives the recorded security events
+type SecurityEventSink interface {
+	Publish(event domain.SecurityEvent) error
+}
+
+// dbSecurityEventSink stores security events in the security_event table
+type dbSecurityEventSink struct {
+	repo repository.SecurityEventRepository
+}
+
+// NewDBSecurityEventSink creates a SecurityEventSink backed by the SecurityEventRepository
+func NewDBSecurityEventSink(repo repository.SecurityEventRepository) SecurityEventSink {
+	return &dbSecurityEventSink{repo: repo}
+}
+
+func (s *dbSecurityEventSink) Publish(event domain.SecurityEvent) error {
+	_, err := s.repo.Save(event)
+	return err
+}
+
+// logSecurityEventSink writes security events as JSON lines to the application log
+type logSecurityEventSink struct{}
+
+// NewLogSecurityEventSink creates a SecurityEventSink writing to the application log
+func NewLogSecurityEventSink() SecurityEventSink {
+	return &logSecurityEventSink{}
+}
+
+func (s *logSecurityEventSink) Publish(event domain.SecurityEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	log.Printf("security_event %s", payload)
+	return nil
+}
+
+// SecurityAuditService records and queries security events
+type SecurityAuditService interface {
+	Record(event domain.SecurityEvent)
+	FindEvents(ctx context.Context, query dto.SecurityEventQuery) ([]dto.SecurityEventResponse, error)
+}
+
+type securityAuditServiceImpl struct {
+	sink   SecurityEventSink
+	repo   repository.SecurityEventRepository
+	mapper mapper.SecurityEventMapper
+	clock  util.Clock
+}
+
+// NewSecurityAuditService creates a new instance of SecurityAuditService.
+// Events are published to the sink; queries always read the security_event table.
+func NewSecurityAuditService(sink SecurityEventSink,
+	repo repository.SecurityEventRepository,
+	mapper mapper.SecurityEventMapper,
+	clock util.Clock) SecurityAuditService {
+	return &securityAuditServiceImpl{
+		sink:   sink,
+		repo:   repo,
+		mapper: mapper,
+		clock:  clock,
+	}
+}
+
+// Record publishes a security event. Failures are logged, so auditing never breaks the request.
+func (s *securityAuditServiceImpl) Record(event domain.SecurityEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = s.clock.Now()
+	}
+	if err := s.sink.Publish(event); err != nil {
+		log.Printf("Failed to record security event %s: %v", event.Type, err)
+	}
+}
+
+// FindEvents retrieves the security events matching the query, newest first. Admins only see the events
+// of the users of their tenant; super-admins see all events, including those of unknown users.
+func (s *securityAuditServiceImpl) FindEvents(ctx context.Context, query dto.SecurityEventQuery) ([]dto.SecurityEventResponse, error) {
+	criteria := repository.SecurityEventCriteria{
+		Principal: query.User,
+		Type:      domain.SecurityEventType(query.Type),
+		Limit:     query.Limit,
+	}
+	criteria.TenantID, criteria.AllTenants = security.TenantFromContext(ctx)
+	if query.From != nil {
+		criteria.From = *query.From
+	}
+	if query.To != nil {
+		criteria.To = *query.To
+	}
+
+	events, err := s.repo.FindByCriteria(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch security events: %w", err)
+	}
+	return s.mapper.ToSecurityEventResponses(events), nil
+}
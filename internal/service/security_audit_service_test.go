@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	"gin-samples/internal/mapper"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestSecurityAuditService_Record_SetsOccurredAt(t *testing.T) {
+	mockRepo := new(customMock.MockSecurityEventRepository)
+	mockClock := new(customMock.MockClock)
+	fixedTime := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	mockClock.On("Now").Return(fixedTime)
+
+	expected := domain.SecurityEvent{
+		Type:       domain.SecurityEventLoginFailure,
+		Principal:  "admin",
+		Reason:     "bad credentials",
+		OccurredAt: fixedTime,
+	}
+	mockRepo.On("Save", expected).Return(expected, nil)
+
+	service := NewSecurityAuditService(NewDBSecurityEventSink(mockRepo), mockRepo, mapper.NewSecurityEventMapper(), mockClock)
+	service.Record(domain.SecurityEvent{
+		Type:      domain.SecurityEventLoginFailure,
+		Principal: "admin",
+		Reason:    "bad credentials",
+	})
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSecurityAuditService_Record_SinkFailureIsIgnored(t *testing.T) {
+	mockRepo := new(customMock.MockSecurityEventRepository)
+	mockClock := new(customMock.MockClock)
+	mockClock.On("Now").Return(time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC))
+	mockRepo.On("Save", mock.AnythingOfType("domain.SecurityEvent")).Return(nil, errors.New("db down"))
+
+	service := NewSecurityAuditService(NewDBSecurityEventSink(mockRepo), mockRepo, mapper.NewSecurityEventMapper(), mockClock)
+
+	assert.NotPanics(t, func() {
+		service.Record(domain.SecurityEvent{Type: domain.SecurityEventAdminAction})
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSecurityAuditService_FindEvents(t *testing.T) {
+	mockRepo := new(customMock.MockSecurityEventRepository)
+	from := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	occurredAt := from.Add(time.Hour)
+
+	// Admins only query the events of their tenant
+	mockRepo.On("FindByCriteria", repository.SecurityEventCriteria{
+		Principal: "admin",
+		TenantID:  "acme",
+		Type:      domain.SecurityEventAccessDenied,
+		From:      from,
+		To:        to,
+		Limit:     10,
+	}).Return([]domain.SecurityEvent{{
+		ID:         1,
+		Type:       domain.SecurityEventAccessDenied,
+		Principal:  "admin",
+		TenantID:   "acme",
+		Authority:  "ROLE_ADMIN",
+		Status:     403,
+		OccurredAt: occurredAt,
+	}}, nil)
+	// Super-admins query the events of all tenants
+	mockRepo.On("FindByCriteria", repository.SecurityEventCriteria{TenantID: "default", AllTenants: true, Limit: 100}).Return([]domain.SecurityEvent{}, nil)
+
+	service := NewSecurityAuditService(NewLogSecurityEventSink(), mockRepo, mapper.NewSecurityEventMapper(), nil)
+
+	adminCtx := security.ContextWithClaims(context.Background(),
+		&security.TokenClaims{UserID: "1", TenantID: "acme", Authorities: []string{security.AuthorityAdmin}})
+	superAdminCtx := security.ContextWithClaims(context.Background(),
+		&security.TokenClaims{UserID: "2", Authorities: []string{security.AuthorityAdmin, security.AuthoritySuperAdmin}})
+
+	events, err := service.FindEvents(adminCtx, dto.SecurityEventQuery{
+		User:  "admin",
+		Type:  "ACCESS_DENIED",
+		From:  &from,
+		To:    &to,
+		Limit: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.SecurityEventResponse{{
+		ID:         1,
+		Type:       "ACCESS_DENIED",
+		Principal:  "admin",
+		TenantID:   "acme",
+		Authority:  "ROLE_ADMIN",
+		Status:     403,
+		OccurredAt: occurredAt,
+	}}, events)
+
+	events, err = service.FindEvents(superAdminCtx, dto.SecurityEventQuery{Limit: 100})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"slices"
+)
+
+// TagService manages the tags of greetings. Tags are created and assigned along with the greetings, see HelloService;
+// they are listed per tenant, and renamed or merged for all tenants at once.
+type TagService interface {
+	GetTags(ctx context.Context, query dto.TagQuery) (dto.PagedResponse[dto.TagResponse], error)
+	RenameTag(ctx context.Context, id uint, input dto.TagInput) (dto.TagResponse, error)
+	MergeTags(ctx context.Context, input dto.TagMergeInput) (dto.TagResponse, error)
+}
+
+// tagSortColumns maps the sortable tag properties to their columns
+var tagSortColumns = map[string]string{
+	"name":      "tag.name",
+	"count":     "count",
+	"createdAt": "tag.created_at",
+}
+
+// defaultTagSort lists tags by name
+var defaultTagSort = []string{"name"}
+
+type tagServiceImpl struct {
+	repo   repository.TagRepository
+	mapper mapper.TagMapper
+}
+
+// NewTagService creates a new instance of tagServiceImpl
+func NewTagService(repo repository.TagRepository, mapper mapper.TagMapper) TagService {
+	return &tagServiceImpl{
+		repo:   repo,
+		mapper: mapper,
+	}
+}
+
+// GetTags retrieves a page of the tags with the number of greetings tagged with them
+func (s *tagServiceImpl) GetTags(ctx context.Context, query dto.TagQuery) (dto.PagedResponse[dto.TagResponse], error) {
+	sort := query.Sort
+	if len(sort) == 0 {
+		sort = defaultTagSort
+	}
+	pageable, err := toPageable(query.Page, query.Size, sort, tagSortColumns)
+	if err != nil {
+		return dto.PagedResponse[dto.TagResponse]{}, err
+	}
+
+	page, err := s.repo.WithContext(ctx).FindUsagePaged(pageable)
+	if err != nil {
+		return dto.PagedResponse[dto.TagResponse]{}, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	return toPagedResponse(page, s.mapper.ToTagResponses(page.Content)), nil
+}
+
+// RenameTag renames a tag. A name already used by another tag is a conflict; such tags are merged instead.
+func (s *tagServiceImpl) RenameTag(ctx context.Context, id uint, input dto.TagInput) (dto.TagResponse, error) {
+	name, err := normalizeTagName(input.Name, "name")
+	if err != nil {
+		return dto.TagResponse{}, err
+	}
+
+	var response dto.TagResponse
+	err = s.repo.WithContext(ctx).Transaction(func(tx repository.TagRepository) error {
+		tag, err := findTag(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if tag.Name != name {
+			optionalTag, err := tx.FindByName(name)
+			if err != nil {
+				return fmt.Errorf("failed to fetch tag by name: %w", err)
+			}
+			if optionalTag.IsPresent() {
+				return &customError.ResourceConflictError{Resource: "Tag", Criteria: "name", Value: name}
+			}
+			if err := tx.Rename(id, name); err != nil {
+				return fmt.Errorf("failed to rename tag: %w", err)
+			}
+			// Read the tag again for its new modifier
+			if tag, err = findTag(tx, id); err != nil {
+				return err
+			}
+		}
+
+		response = s.mapper.ToTagResponse(tag)
+		return nil
+	})
+	return response, err
+}
+
+// MergeTags tags the greetings tagged with the source tags with the target tag instead and deletes the source tags
+func (s *tagServiceImpl) MergeTags(ctx context.Context, input dto.TagMergeInput) (dto.TagResponse, error) {
+	if slices.Contains(input.SourceIDs, input.TargetID) {
+		return dto.TagResponse{}, customError.ConstraintViolationError{Violations: []dto.Violation{{
+			Code:          "excluded_with",
+			Field:         "targetId",
+			RejectedValue: fmt.Sprintf("%d", input.TargetID),
+			Message:       "targetId must not be one of sourceIds",
+		}}}
+	}
+	sourceIDs := slices.Clone(input.SourceIDs)
+	slices.Sort(sourceIDs)
+	sourceIDs = slices.Compact(sourceIDs)
+
+	var response dto.TagResponse
+	err := s.repo.WithContext(ctx).Transaction(func(tx repository.TagRepository) error {
+		for _, id := range append(sourceIDs, input.TargetID) {
+			if _, err := findTag(tx, id); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Merge(sourceIDs, input.TargetID); err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		target, err := findTag(tx, input.TargetID)
+		if err != nil {
+			return err
+		}
+		response = s.mapper.ToTagResponse(target)
+		return nil
+	})
+	return response, err
+}
+
+// findTag returns a tag with its usage, or a ResourceNotFoundError when it does not exist
+func findTag(repo repository.TagRepository, id uint) (domain.TagUsage, error) {
+	optionalTag, err := repo.FindUsageByID(id)
+	if err != nil {
+		return domain.TagUsage{}, fmt.Errorf("failed to fetch tag by ID: %w", err)
+	}
+
+	if optionalTag.IsEmpty() {
+		return domain.TagUsage{}, &customError.ResourceNotFoundError{
+			Resource: "Tag",
+			Criteria: "id",
+			Value:    fmt.Sprintf("%d", id),
+		}
+	}
+	return *optionalTag.Value, nil
+}
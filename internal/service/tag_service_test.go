@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"gin-samples/internal/domain"
+	"gin-samples/internal/dto"
+	customError "gin-samples/internal/error"
+	"gin-samples/internal/mapper"
+	customMock "gin-samples/internal/mock"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestTagService_GetTags(t *testing.T) {
+	mockRepo := new(customMock.MockTagRepository)
+
+	tags := []domain.TagUsage{{Tag: domain.Tag{ID: 1, Name: "christmas"}, Count: 2}}
+	expectedResponses := []dto.TagResponse{{ID: 1, Name: "christmas", Count: 2}}
+
+	// Tags are sorted by name unless another order is requested
+	mockRepo.On("FindUsagePaged", repository.Pageable{
+		Size: 20,
+		Sort: []repository.SortOrder{{Column: "tag.name"}},
+	}).Return(repository.Page[domain.TagUsage]{Content: tags, Size: 20, TotalElements: 1}, nil)
+
+	service := NewTagService(mockRepo, mapper.NewTagMapper())
+
+	actual, err := service.GetTags(context.Background(), dto.TagQuery{Size: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponses, actual.Content)
+	assert.Equal(t, dto.PageMetadata{Number: 0, Size: 20, TotalElements: 1, TotalPages: 1}, actual.Page)
+
+	// An unknown sort property is rejected
+	_, err = service.GetTags(context.Background(), dto.TagQuery{Size: 20, Sort: []string{"message"}})
+	assert.Error(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	mockRepo := new(customMock.MockTagRepository)
+
+	tag := domain.TagUsage{Tag: domain.Tag{ID: 1, Name: "christmas",
+		AuditingEntity: domain.AuditingEntity{CreatedBy: "2", UpdatedBy: "2"}}, Count: 2}
+	renamed := domain.TagUsage{Tag: domain.Tag{ID: 1, Name: "xmas",
+		AuditingEntity: domain.AuditingEntity{CreatedBy: "2", UpdatedBy: "9"}}, Count: 2}
+
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindUsageByID", uint(1)).Return(util.Optional[domain.TagUsage]{Value: &tag}, nil).Once()
+	// The renamed tag is read again with its new modifier
+	mockRepo.On("FindUsageByID", uint(1)).Return(util.Optional[domain.TagUsage]{Value: &renamed}, nil).Once()
+	mockRepo.On("FindUsageByID", uint(1)).Return(util.Optional[domain.TagUsage]{Value: &tag}, nil)
+	mockRepo.On("FindUsageByID", uint(2)).Return(nil, nil)
+	mockRepo.On("FindByName", "xmas").Return(nil, nil).Once()
+	mockRepo.On("FindByName", "winter").Return(util.Optional[domain.Tag]{Value: &domain.Tag{ID: 3, Name: "winter"}}, nil)
+	mockRepo.On("Rename", uint(1), "xmas").Return(nil)
+
+	service := NewTagService(mockRepo, mapper.NewTagMapper())
+	ctx := context.Background()
+
+	// The name is normalized like the tags of greetings
+	actual, err := service.RenameTag(ctx, 1, dto.TagInput{Name: " XMAS "})
+	assert.NoError(t, err)
+	assert.Equal(t, dto.TagResponse{ID: 1, Name: "xmas", Count: 2, CreatedBy: "2", UpdatedBy: "9"}, actual)
+
+	// Another tag has the name
+	_, err = service.RenameTag(ctx, 1, dto.TagInput{Name: "Winter"})
+	var conflictErr *customError.ResourceConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Equal(t, "Tag", conflictErr.Resource)
+		assert.Equal(t, "winter", conflictErr.Value)
+	}
+
+	// The tag does not exist
+	_, err = service.RenameTag(ctx, 2, dto.TagInput{Name: "xmas"})
+	var notFoundErr *customError.ResourceNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+
+	mockRepo.AssertNumberOfCalls(t, "Rename", 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	mockRepo := new(customMock.MockTagRepository)
+
+	target := domain.TagUsage{Tag: domain.Tag{ID: 1, Name: "christmas"}, Count: 1}
+	merged := domain.TagUsage{Tag: domain.Tag{ID: 1, Name: "christmas"}, Count: 3}
+
+	mockRepo.On("Transaction").Return(nil)
+	mockRepo.On("FindUsageByID", uint(1)).Return(util.Optional[domain.TagUsage]{Value: &target}, nil).Once()
+	mockRepo.On("FindUsageByID", uint(1)).Return(util.Optional[domain.TagUsage]{Value: &merged}, nil).Once()
+	mockRepo.On("FindUsageByID", mock.Anything).Return(util.Optional[domain.TagUsage]{Value: &domain.TagUsage{}}, nil)
+	// Duplicate source tags are merged once
+	mockRepo.On("Merge", []uint{2, 3}, uint(1)).Return(nil)
+
+	service := NewTagService(mockRepo, mapper.NewTagMapper())
+
+	actual, err := service.MergeTags(context.Background(), dto.TagMergeInput{SourceIDs: []uint{3, 2, 3}, TargetID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), actual.Count)
+
+	// A tag cannot be merged into itself
+	_, err = service.MergeTags(context.Background(), dto.TagMergeInput{SourceIDs: []uint{1, 2}, TargetID: 1})
+	var constraintErr customError.ConstraintViolationError
+	if assert.ErrorAs(t, err, &constraintErr) {
+		assert.Equal(t, "targetId", constraintErr.Violations[0].Field)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "Merge", 1)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"gin-samples/internal/dto"
+	"gin-samples/internal/mapper"
+	"gin-samples/internal/repository"
+	"gin-samples/internal/security"
+)
+
+// UserService defines the user management operations. Admins only see the users of their tenant.
+type UserService interface {
+	GetUsersByCursor(ctx context.Context, query dto.CursorQuery) (dto.CursorPagedResponse[dto.UserResponse], error)
+}
+
+// userSortColumns maps the sortable user properties to their columns
+var userSortColumns = map[string]string{
+	"id":        "id",
+	"username":  "username",
+	"email":     "email",
+	"createdAt": "created_at",
+}
+
+type userServiceImpl struct {
+	repo        repository.UserRepository
+	mapper      mapper.UserMapper
+	cursorCodec security.CursorCodec
+}
+
+// NewUserService creates a new instance of UserService
+func NewUserService(repo repository.UserRepository,
+	mapper mapper.UserMapper,
+	cursorCodec security.CursorCodec) UserService {
+	return &userServiceImpl{
+		repo:        repo,
+		mapper:      mapper,
+		cursorCodec: cursorCodec,
+	}
+}
+
+// GetUsersByCursor retrieves the page of the users of the tenant of ctx the cursor points to,
+// or the first page without a cursor
+func (s *userServiceImpl) GetUsersByCursor(ctx context.Context, query dto.CursorQuery) (dto.CursorPagedResponse[dto.UserResponse], error) {
+	pageable, err := toKeysetPageable(s.cursorCodec, query, userSortColumns)
+	if err != nil {
+		return dto.CursorPagedResponse[dto.UserResponse]{}, err
+	}
+
+	page, err := s.repo.WithContext(ctx).FindAllByKeyset(pageable, repository.WithRoles())
+	if err != nil {
+		return dto.CursorPagedResponse[dto.UserResponse]{}, fmt.Errorf("failed to fetch users: %w", err)
+	}
+
+	return toCursorPagedResponse(s.cursorCodec, page, query.Size, s.mapper.ToUserResponses(page.Content))
+}
@@ -5,6 +5,8 @@ import "time"
 // Clock interface for time-related operations
 type Clock interface {
 	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
 }
 
 // RealClock uses the actual time
@@ -14,3 +16,8 @@ type RealClock struct{}
 func (r *RealClock) Now() time.Time {
 	return time.Now()
 }
+
+// After waits for the duration to elapse on the actual time
+func (r *RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// NormalizeLocale returns the canonical form of a BCP 47 language tag, e.g. "pt-br" becomes "pt-BR"
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", err
+	}
+	return tag.String(), nil
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header, most preferred first.
+// Invalid tags, the "*" wildcard and tags with q=0 are ignored.
+func ParseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		// The wildcard is parsed as "mul" (multiple languages)
+		if tag == language.Und || tag == language.MustParse("mul") {
+			continue
+		}
+		locales = append(locales, tag.String())
+	}
+	return locales
+}
+
+// LocaleChain returns the locales to try for the preferences, in order: every preferred locale followed by
+// its parents, then the default locale. For example, "pt-BR" with the default "en" gives pt-BR, pt, en.
+func LocaleChain(preferences []string, defaultLocale string) []string {
+	seen := make(map[string]bool)
+	var chain []string
+	add := func(locale string) {
+		if locale != "" && !seen[locale] {
+			seen[locale] = true
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, preference := range preferences {
+		for locale := preference; locale != ""; {
+			add(locale)
+			index := strings.LastIndex(locale, "-")
+			if index < 0 {
+				break
+			}
+			locale = locale[:index]
+		}
+	}
+	add(defaultLocale)
+	return chain
+}
@@ -2,10 +2,13 @@ package util
 
 import (
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
-	"os"
+	"sync/atomic"
+	"time"
 )
 
 // RSAKeyPair represents a pair of RSA keys (private and public).
@@ -14,32 +17,109 @@ type RSAKeyPair struct {
 	PublicKey  *rsa.PublicKey
 }
 
-// LoadRSAKeyPair loads the RSA private and public keys from given file paths.
-func LoadRSAKeyPair(privateKeyPath, publicKeyPath string) (*RSAKeyPair, error) {
-	privateKey, err := loadRSAPrivateKey(privateKeyPath)
+// RSAKeyPairHolder holds an RSA key pair that can be replaced at runtime, e.g. after key rotation.
+// Rotated key pairs are retained for a while, so that tokens issued with them can still be read.
+type RSAKeyPairHolder struct {
+	keyPairs atomic.Pointer[rsaKeyPairs]
+}
+
+// rsaKeyPairs holds the current key pair of an RSAKeyPairHolder and the retired key pairs it still retains
+type rsaKeyPairs struct {
+	current *RSAKeyPair
+	retired []retiredRSAKeyPair
+}
+
+// retiredRSAKeyPair is a rotated key pair and the time until which it is retained
+type retiredRSAKeyPair struct {
+	keyPair     *RSAKeyPair
+	retainUntil time.Time
+}
+
+// NewRSAKeyPairHolder creates a new holder initialized with the given key pair.
+func NewRSAKeyPairHolder(keyPair *RSAKeyPair) *RSAKeyPairHolder {
+	holder := &RSAKeyPairHolder{}
+	holder.Set(keyPair)
+	return holder
+}
+
+// Get returns the current key pair.
+func (h *RSAKeyPairHolder) Get() *RSAKeyPair {
+	return h.keyPairs.Load().current
+}
+
+// Set replaces the current key pair, dropping the retired key pairs.
+func (h *RSAKeyPairHolder) Set(keyPair *RSAKeyPair) {
+	h.keyPairs.Store(&rsaKeyPairs{current: keyPair})
+}
+
+// Rotate replaces the current key pair and retains the replaced one for the retention after now.
+// Retired key pairs whose retention has passed are dropped.
+func (h *RSAKeyPairHolder) Rotate(keyPair *RSAKeyPair, now time.Time, retention time.Duration) {
+	for {
+		old := h.keyPairs.Load()
+		next := &rsaKeyPairs{
+			current: keyPair,
+			retired: []retiredRSAKeyPair{{keyPair: old.current, retainUntil: now.Add(retention)}},
+		}
+		for _, retired := range old.retired {
+			if retired.retainUntil.After(now) {
+				next.retired = append(next.retired, retired)
+			}
+		}
+		if h.keyPairs.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
+
+// KeyPairs returns the current key pair followed by the retired key pairs still retained at now,
+// most recently retired first.
+func (h *RSAKeyPairHolder) KeyPairs(now time.Time) []*RSAKeyPair {
+	keyPairs := h.keyPairs.Load()
+	result := []*RSAKeyPair{keyPairs.current}
+	for _, retired := range keyPairs.retired {
+		if retired.retainUntil.After(now) {
+			result = append(result, retired.keyPair)
+		}
+	}
+	return result
+}
+
+// ParseRSAKeyPair parses the RSA private and public keys from PEM encoded data.
+func ParseRSAKeyPair(privateKeyPEM, publicKeyPEM []byte) (*RSAKeyPair, error) {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey, err := loadRSAPublicKey(publicKeyPath)
+	publicKey, err := parseRSAPublicKey(publicKeyPEM)
 	if err != nil {
 		return nil, err
 	}
 
+	if !privateKey.PublicKey.Equal(publicKey) {
+		return nil, errors.New("public key does not match private key")
+	}
+
 	return &RSAKeyPair{
 		PrivateKey: privateKey,
 		PublicKey:  publicKey,
 	}, nil
 }
 
-// loadRSAPrivateKey loads the RSA private key from a PEM file.
-func loadRSAPrivateKey(filePath string) (*rsa.PrivateKey, error) {
-	fileContent, err := os.ReadFile(filePath)
+// PublicKeyFingerprint returns the hex encoded SHA-256 digest of the DER encoded public key.
+func PublicKeyFingerprint(publicKey *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	block, _ := pem.Decode(fileContent)
+// parseRSAPrivateKey parses the RSA private key from PEM data.
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
 	if block == nil || block.Type != "PRIVATE KEY" {
 		return nil, errors.New("failed to decode PEM block containing private key")
 	}
@@ -57,14 +137,9 @@ func loadRSAPrivateKey(filePath string) (*rsa.PrivateKey, error) {
 	return privateKey, nil
 }
 
-// loadRSAPublicKey loads the RSA public key from a PEM file.
-func loadRSAPublicKey(filePath string) (*rsa.PublicKey, error) {
-	fileContent, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
-	block, _ := pem.Decode(fileContent)
+// parseRSAPublicKey parses the RSA public key from PEM data.
+func parseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
 	if block == nil || block.Type != "PUBLIC KEY" {
 		return nil, errors.New("failed to decode PEM block containing public key")
 	}
@@ -0,0 +1,18 @@
+-- Create security_event table for the security audit log
+CREATE TABLE IF NOT EXISTS security_event (
+    id INTEGER PRIMARY KEY AUTOINCREMENT, -- Unique identifier for the event
+    type TEXT NOT NULL, -- Event type, e.g. LOGIN_FAILURE
+    principal TEXT, -- User id or attempted username
+    reason TEXT, -- Reason of a failure or rejection
+    authority TEXT, -- Required authority of a denied request
+    method TEXT, -- HTTP method of the request
+    path TEXT, -- Path of the request
+    client_ip TEXT, -- Client IP address
+    status INTEGER, -- HTTP response status
+    occurred_at DATETIME NOT NULL -- Time the event occurred
+);
+
+-- Create indexes for security_event
+CREATE INDEX IF NOT EXISTS idx_security_event_principal ON security_event (principal); -- Fast search by user
+CREATE INDEX IF NOT EXISTS idx_security_event_type_occurred_at ON security_event (type, occurred_at); -- Fast search by type and time range
+CREATE INDEX IF NOT EXISTS idx_security_event_occurred_at ON security_event (occurred_at); -- Fast search by time range
@@ -0,0 +1,24 @@
+-- Full-text index over greeting messages (requires SQLite built with FTS5, e.g. the sqlite_fts5 build tag)
+CREATE VIRTUAL TABLE IF NOT EXISTS greeting_fts USING fts5(
+    message, -- Indexed greeting message
+    content = 'greeting', -- Rows are read from the greeting table
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+-- Keep the index in sync with the greeting table
+CREATE TRIGGER IF NOT EXISTS greeting_fts_after_insert AFTER INSERT ON greeting BEGIN
+    INSERT INTO greeting_fts (rowid, message) VALUES (new.id, new.message);
+END;
+
+CREATE TRIGGER IF NOT EXISTS greeting_fts_after_delete AFTER DELETE ON greeting BEGIN
+    INSERT INTO greeting_fts (greeting_fts, rowid, message) VALUES ('delete', old.id, old.message);
+END;
+
+CREATE TRIGGER IF NOT EXISTS greeting_fts_after_update AFTER UPDATE OF message ON greeting BEGIN
+    INSERT INTO greeting_fts (greeting_fts, rowid, message) VALUES ('delete', old.id, old.message);
+    INSERT INTO greeting_fts (rowid, message) VALUES (new.id, new.message);
+END;
+
+-- Index the existing greetings
+INSERT INTO greeting_fts (greeting_fts) VALUES ('rebuild');
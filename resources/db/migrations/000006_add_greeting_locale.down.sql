@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ux_greeting_message_locale;
+ALTER TABLE greeting DROP COLUMN locale;
@@ -0,0 +1,5 @@
+-- Existing greetings are assigned to the default locale
+ALTER TABLE greeting ADD COLUMN locale TEXT NOT NULL DEFAULT 'en'; -- BCP 47 language tag
+
+-- A message may exist once per locale
+CREATE UNIQUE INDEX IF NOT EXISTS ux_greeting_message_locale ON greeting (message, locale);
@@ -0,0 +1,2 @@
+-- Drop the version column
+ALTER TABLE greeting DROP COLUMN version;
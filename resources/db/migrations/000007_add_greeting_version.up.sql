@@ -0,0 +1,2 @@
+-- Add the version column used for optimistic locking
+ALTER TABLE greeting ADD COLUMN version INTEGER NOT NULL DEFAULT 1; -- Incremented by every update
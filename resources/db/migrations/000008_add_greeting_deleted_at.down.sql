@@ -0,0 +1,8 @@
+-- Drop the deleted greetings along with the column, as they could break the uniqueness of the messages
+DELETE FROM greeting WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS ux_greeting_message_locale;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_greeting_message_locale ON greeting (message, locale);
+
+DROP INDEX IF EXISTS idx_greeting_deleted_at;
+ALTER TABLE greeting DROP COLUMN deleted_at;
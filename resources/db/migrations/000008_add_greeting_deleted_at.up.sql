@@ -0,0 +1,8 @@
+-- Add the deletion time used for soft delete
+ALTER TABLE greeting ADD COLUMN deleted_at DATETIME; -- Set when the greeting is moved to the trash
+
+CREATE INDEX IF NOT EXISTS idx_greeting_deleted_at ON greeting (deleted_at);
+
+-- Deleted greetings no longer reserve their message, so the uniqueness only applies to the others
+DROP INDEX IF EXISTS ux_greeting_message_locale;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_greeting_message_locale ON greeting (message, locale) WHERE deleted_at IS NULL;
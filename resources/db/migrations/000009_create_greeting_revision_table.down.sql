@@ -0,0 +1 @@
+DROP TABLE IF EXISTS greeting_revision;
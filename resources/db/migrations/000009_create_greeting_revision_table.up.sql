@@ -0,0 +1,19 @@
+-- Create greeting_revision table for the revision history of greetings
+CREATE TABLE IF NOT EXISTS greeting_revision (
+    id INTEGER PRIMARY KEY AUTOINCREMENT, -- Unique identifier for the revision
+    greeting_id INTEGER NOT NULL, -- Revised greeting
+    revision INTEGER NOT NULL, -- Sequence number of the revision within the greeting, starting at 1
+    action TEXT NOT NULL, -- Kind of change, e.g. UPDATE
+    old_message TEXT, -- Message before the change, NULL if the greeting did not exist
+    old_locale TEXT, -- Locale before the change, NULL if the greeting did not exist
+    new_message TEXT, -- Message after the change, NULL if the greeting was deleted
+    new_locale TEXT, -- Locale after the change, NULL if the greeting was deleted
+    version INTEGER NOT NULL, -- Version of the greeting after the change
+    rolled_back_to INTEGER, -- Revision restored by a rollback
+    changed_by TEXT, -- User id of the user who made the change
+    changed_at DATETIME NOT NULL, -- Time of the change
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE -- Revisions are purged with their greeting
+);
+
+-- Create indexes for greeting_revision
+CREATE UNIQUE INDEX IF NOT EXISTS ux_greeting_revision_greeting_id_revision ON greeting_revision (greeting_id, revision); -- Fast lookup and unique numbering of revisions
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_greeting_expire_at;
+DROP INDEX IF EXISTS idx_greeting_publish_at;
+ALTER TABLE greeting DROP COLUMN expire_at;
+ALTER TABLE greeting DROP COLUMN publish_at;
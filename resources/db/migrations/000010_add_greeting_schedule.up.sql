@@ -0,0 +1,6 @@
+-- Add the publishing schedule; greetings without one are live from creation and never expire
+ALTER TABLE greeting ADD COLUMN publish_at DATETIME; -- Time the greeting goes live
+ALTER TABLE greeting ADD COLUMN expire_at DATETIME; -- Time the greeting disappears
+
+CREATE INDEX IF NOT EXISTS idx_greeting_publish_at ON greeting (publish_at); -- Fast search of published greetings
+CREATE INDEX IF NOT EXISTS idx_greeting_expire_at ON greeting (expire_at); -- Fast search of expired greetings
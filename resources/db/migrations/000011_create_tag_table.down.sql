@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_greeting_tag_tag_id;
+DROP TABLE IF EXISTS greeting_tag;
+DROP TABLE IF EXISTS tag;
@@ -0,0 +1,19 @@
+-- Create tag table for grouping greetings, e.g. by campaign or occasion
+CREATE TABLE IF NOT EXISTS tag (
+    id INTEGER PRIMARY KEY AUTOINCREMENT, -- Unique identifier for the tag
+    name TEXT NOT NULL UNIQUE, -- Name of the tag in lowercase
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Creation timestamp
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP -- Last update timestamp
+);
+
+-- Create greeting_tag table for the tags of greetings
+CREATE TABLE IF NOT EXISTS greeting_tag (
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    tag_id INTEGER NOT NULL, -- Foreign key to tag
+    PRIMARY KEY (greeting_id, tag_id), -- Composite primary key
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE, -- Tags are removed with their greeting
+    FOREIGN KEY (tag_id) REFERENCES tag (id) ON DELETE CASCADE -- Greetings are untagged with their tag
+);
+
+-- Create indexes for greeting_tag
+CREATE INDEX IF NOT EXISTS idx_greeting_tag_tag_id ON greeting_tag (tag_id); -- Fast search of tagged greetings
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_greeting_pin_greeting_id;
+DROP TABLE IF EXISTS greeting_pin;
@@ -0,0 +1,11 @@
+-- Create greeting_pin table for the greetings pinned as greeting of the day
+CREATE TABLE IF NOT EXISTS greeting_pin (
+    date TEXT PRIMARY KEY, -- Calendar date in the format 2006-01-02
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Creation timestamp
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Last update timestamp
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE -- Pins are removed with their greeting
+);
+
+-- Create indexes for greeting_pin
+CREATE INDEX IF NOT EXISTS idx_greeting_pin_greeting_id ON greeting_pin (greeting_id); -- Fast removal of the pins of a greeting
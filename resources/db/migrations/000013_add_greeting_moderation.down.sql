@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_greeting_owner_id;
+DROP INDEX IF EXISTS idx_greeting_status;
+ALTER TABLE greeting DROP COLUMN moderated_at;
+ALTER TABLE greeting DROP COLUMN moderated_by;
+ALTER TABLE greeting DROP COLUMN owner_id;
+ALTER TABLE greeting DROP COLUMN rejection_reason;
+ALTER TABLE greeting DROP COLUMN status;
@@ -0,0 +1,9 @@
+-- Add the moderation state; existing greetings were visible to everyone, so they are approved
+ALTER TABLE greeting ADD COLUMN status TEXT NOT NULL DEFAULT 'APPROVED'; -- Moderation state, e.g. PENDING
+ALTER TABLE greeting ADD COLUMN rejection_reason TEXT; -- Reason given by the moderator who rejected the greeting
+ALTER TABLE greeting ADD COLUMN owner_id TEXT; -- User id of the user who created the greeting
+ALTER TABLE greeting ADD COLUMN moderated_by TEXT; -- User id of the admin who approved or rejected the greeting
+ALTER TABLE greeting ADD COLUMN moderated_at DATETIME; -- Time the greeting was approved or rejected
+
+CREATE INDEX IF NOT EXISTS idx_greeting_status ON greeting (status); -- Fast search of approved and pending greetings
+CREATE INDEX IF NOT EXISTS idx_greeting_owner_id ON greeting (owner_id); -- Fast search of the greetings of a user
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_greeting_reaction_created_at;
+DROP TABLE IF EXISTS greeting_reaction;
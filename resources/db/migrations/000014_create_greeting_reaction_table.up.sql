@@ -0,0 +1,12 @@
+-- Create greeting_reaction table for the reactions of users to greetings
+CREATE TABLE IF NOT EXISTS greeting_reaction (
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    user_id TEXT NOT NULL, -- User id of the user who reacted
+    type TEXT NOT NULL, -- Kind of the reaction, e.g. like
+    created_at DATETIME NOT NULL, -- Time of the reaction
+    PRIMARY KEY (greeting_id, user_id, type), -- One reaction per user and type
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE -- Reactions are removed with their greeting
+);
+
+-- Create indexes for greeting_reaction
+CREATE INDEX IF NOT EXISTS idx_greeting_reaction_created_at ON greeting_reaction (created_at); -- Fast ranking over a time window
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_greeting_comment_parent_id;
+DROP INDEX IF EXISTS idx_greeting_comment_greeting_id;
+DROP TABLE IF EXISTS greeting_comment;
@@ -0,0 +1,16 @@
+-- Create greeting_comment table for the threaded comments on greetings
+CREATE TABLE IF NOT EXISTS greeting_comment (
+    id INTEGER PRIMARY KEY AUTOINCREMENT, -- Primary key
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    parent_id INTEGER, -- Comment replied to, NULL for top-level comments
+    author_id TEXT NOT NULL, -- User id of the user who wrote the comment
+    body TEXT NOT NULL, -- Text of the comment
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Record creation timestamp
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Record update timestamp
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE, -- Comments are removed with their greeting
+    FOREIGN KEY (parent_id) REFERENCES greeting_comment (id) ON DELETE CASCADE -- Replies are removed with their comment
+);
+
+-- Create indexes for greeting_comment
+CREATE INDEX IF NOT EXISTS idx_greeting_comment_greeting_id ON greeting_comment (greeting_id); -- Fast listing and counting per greeting
+CREATE INDEX IF NOT EXISTS idx_greeting_comment_parent_id ON greeting_comment (parent_id); -- Fast lookup of replies
@@ -0,0 +1,27 @@
+DELETE FROM user_role_mapping WHERE user_id = '9b2c4e1a-3f5d-4c7e-8a6b-2d1f0e9c8b7a';
+DELETE FROM user_role_mapping WHERE role_id = 'e4a85e96-98d2-4d2e-9c2f-1ed65e2e6a68';
+DELETE FROM role WHERE id = 'e4a85e96-98d2-4d2e-9c2f-1ed65e2e6a68';
+DELETE FROM user_identity WHERE id = '9b2c4e1a-3f5d-4c7e-8a6b-2d1f0e9c8b7a';
+
+-- Only the pins of the default tenant are kept, as the date alone identifies a pin again
+CREATE TABLE IF NOT EXISTS greeting_pin_default (
+    date TEXT PRIMARY KEY, -- Calendar date in the format 2006-01-02
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Creation timestamp
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Last update timestamp
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE -- Pins are removed with their greeting
+);
+INSERT INTO greeting_pin_default (date, greeting_id, created_at, updated_at)
+SELECT date, greeting_id, created_at, updated_at FROM greeting_pin WHERE tenant_id = 'default';
+DROP INDEX IF EXISTS idx_greeting_pin_greeting_id;
+DROP TABLE greeting_pin;
+ALTER TABLE greeting_pin_default RENAME TO greeting_pin;
+CREATE INDEX IF NOT EXISTS idx_greeting_pin_greeting_id ON greeting_pin (greeting_id);
+
+DROP INDEX IF EXISTS ux_greeting_message_locale;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_greeting_message_locale ON greeting (message, locale) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_greeting_tenant_id;
+DROP INDEX IF EXISTS idx_user_identity_tenant_id;
+ALTER TABLE greeting DROP COLUMN tenant_id;
+ALTER TABLE user_identity DROP COLUMN tenant_id;
@@ -0,0 +1,41 @@
+-- Add the tenant of users and greetings; existing rows belong to the default tenant
+ALTER TABLE user_identity ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default'; -- Organisation of the user
+ALTER TABLE greeting ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default'; -- Organisation owning the greeting
+
+CREATE INDEX IF NOT EXISTS idx_user_identity_tenant_id ON user_identity (tenant_id); -- Fast search of the users of a tenant
+CREATE INDEX IF NOT EXISTS idx_greeting_tenant_id ON greeting (tenant_id); -- Fast search of the greetings of a tenant
+
+-- A message may exist once per locale within a tenant
+DROP INDEX IF EXISTS ux_greeting_message_locale;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_greeting_message_locale ON greeting (tenant_id, message, locale) WHERE deleted_at IS NULL;
+
+-- Every tenant pins its own greeting of the day
+CREATE TABLE IF NOT EXISTS greeting_pin_tenant (
+    tenant_id TEXT NOT NULL DEFAULT 'default', -- Tenant whose greeting of the day is pinned
+    date TEXT NOT NULL, -- Calendar date in the format 2006-01-02
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Creation timestamp
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Last update timestamp
+    PRIMARY KEY (tenant_id, date), -- One pin per tenant and date
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE -- Pins are removed with their greeting
+);
+INSERT INTO greeting_pin_tenant (date, greeting_id, created_at, updated_at)
+SELECT date, greeting_id, created_at, updated_at FROM greeting_pin;
+DROP INDEX IF EXISTS idx_greeting_pin_greeting_id;
+DROP TABLE greeting_pin;
+ALTER TABLE greeting_pin_tenant RENAME TO greeting_pin;
+CREATE INDEX IF NOT EXISTS idx_greeting_pin_greeting_id ON greeting_pin (greeting_id); -- Fast removal of the pins of a greeting
+
+-- Super-admins act across tenants
+INSERT OR IGNORE INTO role (id, name, description, created_at, created_by, updated_at, updated_by)
+VALUES
+  ('e4a85e96-98d2-4d2e-9c2f-1ed65e2e6a68', 'ROLE_SUPER_ADMIN', 'Super-administrator role acting across tenants', '2023-07-13 10:00:00.533433', 'system', NULL, NULL);
+
+INSERT OR IGNORE INTO user_identity (id, username, password, email, first_name, last_name, enabled, tenant_id, created_at, created_by, updated_at, updated_by)
+VALUES
+  ('9b2c4e1a-3f5d-4c7e-8a6b-2d1f0e9c8b7a', 'superadmin', '$2a$10$45h4TdLTwTCtLIRThucXLuPOMtALeRErlNU5Ch2GkwZIWojh7mTOe', 'superadmin@example.com', 'Super', 'Admin', 1, 'default', '2023-07-13 10:00:00.533433', 'system', NULL, NULL);
+
+INSERT OR IGNORE INTO user_role_mapping (user_id, role_id, created_at, created_by, updated_at, updated_by)
+VALUES
+  ('9b2c4e1a-3f5d-4c7e-8a6b-2d1f0e9c8b7a', 'c2e7d07a-896e-41a7-bb47-8ccedb9c9fc3', '2023-07-13 10:00:00.533433', 'system', NULL, NULL),
+  ('9b2c4e1a-3f5d-4c7e-8a6b-2d1f0e9c8b7a', 'e4a85e96-98d2-4d2e-9c2f-1ed65e2e6a68', '2023-07-13 10:00:00.533433', 'system', NULL, NULL);
@@ -0,0 +1,8 @@
+ALTER TABLE greeting_comment DROP COLUMN updated_by;
+ALTER TABLE greeting_comment DROP COLUMN created_by;
+ALTER TABLE greeting_pin DROP COLUMN updated_by;
+ALTER TABLE greeting_pin DROP COLUMN created_by;
+ALTER TABLE tag DROP COLUMN updated_by;
+ALTER TABLE tag DROP COLUMN created_by;
+ALTER TABLE greeting DROP COLUMN updated_by;
+ALTER TABLE greeting DROP COLUMN created_by;
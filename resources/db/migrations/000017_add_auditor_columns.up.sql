@@ -0,0 +1,9 @@
+-- Record the users who created and last modified greetings and their related records
+ALTER TABLE greeting ADD COLUMN created_by TEXT; -- Creator of the greeting
+ALTER TABLE greeting ADD COLUMN updated_by TEXT; -- Last modifier of the greeting
+ALTER TABLE tag ADD COLUMN created_by TEXT; -- Creator of the tag
+ALTER TABLE tag ADD COLUMN updated_by TEXT; -- Last modifier of the tag
+ALTER TABLE greeting_pin ADD COLUMN created_by TEXT; -- Admin who pinned the greeting
+ALTER TABLE greeting_pin ADD COLUMN updated_by TEXT; -- Admin who last changed the pin
+ALTER TABLE greeting_comment ADD COLUMN created_by TEXT; -- Creator of the comment
+ALTER TABLE greeting_comment ADD COLUMN updated_by TEXT; -- Last modifier of the comment
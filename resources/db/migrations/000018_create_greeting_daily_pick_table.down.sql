@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_greeting_daily_pick_greeting_id;
+DROP TABLE IF EXISTS greeting_daily_pick;
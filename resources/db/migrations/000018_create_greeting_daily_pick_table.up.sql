@@ -0,0 +1,12 @@
+-- Create greeting_daily_pick table for the greetings chosen as greeting of the day, so the choice holds all day
+CREATE TABLE IF NOT EXISTS greeting_daily_pick (
+    tenant_id TEXT NOT NULL DEFAULT 'default', -- Tenant whose greeting of the day was chosen
+    date TEXT NOT NULL, -- Calendar date in the format 2006-01-02
+    greeting_id INTEGER NOT NULL, -- Foreign key to greeting
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP, -- Time of the choice
+    PRIMARY KEY (tenant_id, date), -- One choice per tenant and date
+    FOREIGN KEY (greeting_id) REFERENCES greeting (id) ON DELETE CASCADE -- Choices are removed with their greeting
+);
+
+-- Create indexes for greeting_daily_pick
+CREATE INDEX IF NOT EXISTS idx_greeting_daily_pick_greeting_id ON greeting_daily_pick (greeting_id); -- Fast removal of the choices of a greeting
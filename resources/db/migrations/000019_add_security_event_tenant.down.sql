@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_security_event_tenant_id_occurred_at;
+ALTER TABLE security_event DROP COLUMN tenant_id;
@@ -0,0 +1,9 @@
+-- Record the tenant of the user of security events, so tenant admins only query the events of their tenant
+ALTER TABLE security_event ADD COLUMN tenant_id TEXT NOT NULL DEFAULT ''; -- Tenant of the user, empty when the user is unknown
+
+-- Existing events belong to the tenant of their principal, a user id or an attempted username
+UPDATE security_event SET tenant_id = COALESCE((
+    SELECT u.tenant_id FROM user_identity u WHERE u.id = security_event.principal OR u.username = security_event.principal
+), '');
+
+CREATE INDEX IF NOT EXISTS idx_security_event_tenant_id_occurred_at ON security_event (tenant_id, occurred_at); -- Fast search of the events of a tenant